@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"os/signal"
+	"route256/cart/internal/pkg/loadgen"
+	"route256/cart/internal/pkg/service/product/catalog"
+	"route256/cart/pkg/logger"
+	"sort"
+	"syscall"
+	"time"
+)
+
+func main() {
+	ctx, cancel := signal.NotifyContext(context.Background(), syscall.SIGTERM, syscall.SIGINT)
+	defer cancel()
+
+	cartUrl := flag.String("cart-url", "http://localhost:8082", "cart http address")
+	lomsUrl := flag.String("loms-url", "http://localhost:8097", "loms http gateway address")
+	rate := flag.Float64("rate", 50, "requests per second")
+	duration := flag.Duration("duration", 30*time.Second, "test duration, 0 - until replay ends")
+	weights := flag.String("weights", "add=5,get=10,remove=2,checkout=1,pay=1,cancel=1", "scenario weights")
+	users := flag.Int("users", 100, "number of users")
+	fixture := flag.String("fixture", "test/testdata/products.json", "products fixture (.json or .csv) with skus")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	maxInFlight := flag.Int("max-inflight", 1000, "max concurrent requests, others are dropped")
+	timeout := flag.Duration("timeout", 5*time.Second, "request timeout")
+	record := flag.String("record", "", "write request log to jsonl file")
+	replay := flag.String("replay", "", "replay requests from jsonl file instead of generating")
+	speed := flag.Float64("speed", 1, "replay speed multiplier")
+	flag.Parse()
+
+	logger.Set(logger.With("service", "loadgen"))
+
+	opts := []loadgen.Option{
+		loadgen.WithMaxInFlight(*maxInFlight),
+		loadgen.WithTimeout(*timeout),
+	}
+
+	if *record != "" {
+		f, err := os.Create(*record)
+		if err != nil {
+			logger.Panicw(ctx, "os.Create", "err", err)
+		}
+		defer f.Close()
+		opts = append(opts, loadgen.WithRecorder(loadgen.NewRecorder(f)))
+	}
+
+	var source loadgen.Source
+	if *replay != "" {
+		f, err := os.Open(*replay)
+		if err != nil {
+			logger.Panicw(ctx, "os.Open", "err", err)
+		}
+		defer f.Close()
+		source = loadgen.NewReplay(f, *speed)
+	} else {
+		parsedWeights, err := loadgen.ParseWeights(*weights)
+		if err != nil {
+			logger.Panicw(ctx, "loadgen.ParseWeights", "err", err)
+		}
+		products, err := catalog.LoadCatalog(*fixture)
+		if err != nil {
+			logger.Panicw(ctx, "catalog.LoadCatalog", "err", err)
+		}
+		skus := make([]int64, 0, len(products))
+		for sku := range products {
+			skus = append(skus, int64(sku))
+		}
+		sort.Slice(skus, func(i, j int) bool { return skus[i] < skus[j] })
+
+		gen, err := loadgen.NewGenerator(parsedWeights, *rate, *users, skus, *seed)
+		if err != nil {
+			logger.Panicw(ctx, "loadgen.NewGenerator", "err", err)
+		}
+		opts = append(opts, loadgen.WithOrderCallback(gen.OrderCreated))
+		source = gen
+	}
+
+	logger.Infow(ctx, "loadgen started", "cart", *cartUrl, "loms", *lomsUrl, "rate", *rate, "duration", *duration)
+	report, err := loadgen.NewRunner(*cartUrl, *lomsUrl, opts...).Run(ctx, source, *duration)
+	if err != nil {
+		logger.Errorw(ctx, "runner.Run", "err", err)
+	}
+	if err := report.Print(os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
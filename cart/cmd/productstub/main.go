@@ -0,0 +1,65 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"net/http"
+	"os"
+	"os/signal"
+	"route256/cart/internal/pkg/productstub"
+	"route256/cart/internal/pkg/service/product/catalog"
+	"route256/cart/pkg/logger"
+	"syscall"
+	"time"
+)
+
+func main() {
+	ctx := context.Background()
+
+	addr := flag.String("addr", ":8080", "listen address")
+	fixture := flag.String("fixture", "test/testdata/products.json", "products fixture (.json or .csv)")
+	token := flag.String("token", "testtoken", "expected token")
+	latency := flag.Duration("latency", 0, "delay before each response")
+	errorRate := flag.Float64("error-rate", 0, "share of requests answered with 500")
+	rateLimitRate := flag.Float64("rate-limit-rate", 0, "share of requests answered with rate-limit-status")
+	rateLimitFirst := flag.Int("rate-limit-first", 0, "answer first n requests with rate-limit-status")
+	rateLimitStatus := flag.Int("rate-limit-status", http.StatusTooManyRequests, "rate limit status code (420 or 429)")
+	seed := flag.Int64("seed", time.Now().UnixNano(), "random seed")
+	flag.Parse()
+
+	logger.Set(logger.With("service", "productstub"))
+
+	products, err := catalog.LoadCatalog(*fixture)
+	if err != nil {
+		logger.Panicw(ctx, "catalog.LoadCatalog", "err", err)
+	}
+
+	handler := productstub.NewHandler(products,
+		productstub.WithToken(*token),
+		productstub.WithLatency(*latency),
+		productstub.WithErrorRate(*errorRate),
+		productstub.WithRateLimitRate(*rateLimitRate, *rateLimitStatus),
+		productstub.WithRateLimitFirst(*rateLimitFirst, *rateLimitStatus),
+		productstub.WithSeed(*seed),
+	)
+	srv := &http.Server{
+		Addr:    *addr,
+		Handler: handler,
+	}
+	go func() {
+		logger.Infow(ctx, "productstub started", "addr", *addr, "products", len(products))
+		if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Panicw(ctx, "srv.ListenAndServe", "err", err)
+		}
+	}()
+
+	quit := make(chan os.Signal, 1)
+	signal.Notify(quit, syscall.SIGTERM, syscall.SIGINT)
+	<-quit
+
+	logger.Infow(ctx, "productstub shutting down")
+	if err := srv.Shutdown(ctx); err != nil {
+		logger.Errorw(ctx, "srv.Shutdown", "err", err)
+	}
+}
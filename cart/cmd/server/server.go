@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"route256/cart/internal/app/server"
@@ -16,8 +18,27 @@ import (
 
 func main() {
 	ctx := context.Background()
-	config := config.NewConfig()
 
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to yaml config")
+	printConfig := flag.Bool("print-config", false, "print config with secrets redacted and exit")
+	flag.Parse()
+
+	config, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *printConfig {
+		if err := config.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := logger.SetLevel(config.LogLevel); err != nil {
+		logger.Panicw(ctx, "logger.SetLevel", "err", err)
+	}
 	logger.Set(logger.With("service", config.ServiceName))
 
 	tp, err := tracing.NewTracerProvider(ctx, config.ServiceName, config.TracerUrl)
@@ -5,15 +5,21 @@ import (
 	"net/http"
 	"net/http/pprof"
 	"route256/cart/internal/pkg/cache"
+	"route256/cart/internal/pkg/cache/codec"
 	"route256/cart/internal/pkg/config"
+	"route256/cart/internal/pkg/health"
 	"route256/cart/internal/pkg/middleware"
+	"route256/cart/internal/pkg/model"
 	"route256/cart/internal/pkg/repository"
 	"route256/cart/internal/pkg/service/cart"
+	"route256/cart/internal/pkg/service/hold"
 	"route256/cart/internal/pkg/service/loms"
 	"route256/cart/internal/pkg/service/product"
 	"route256/cart/internal/pkg/service/product/product_cache"
+	"route256/cart/internal/pkg/service/product/product_file"
 	lomsapi "route256/cart/pkg/api/loms/v1"
 	"route256/cart/pkg/logger"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus/promhttp"
 	"github.com/redis/go-redis/v9"
@@ -22,10 +28,23 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+const (
+	healthCheckTimeout   = 2 * time.Second
+	historyCleanupPeriod = time.Minute
+)
+
 type App struct {
 	http.Server
-	config     config.Config
-	grpcClient *grpc.ClientConn
+	config       config.Config
+	grpcClient   *grpc.ClientConn
+	warmupCancel context.CancelFunc
+	reloadCancel context.CancelFunc
+	configCancel context.CancelFunc
+
+	cartRepository *repository.CartMemoryRepository
+	wal            *repository.WAL
+	snapshotCancel context.CancelFunc
+	historyCancel  context.CancelFunc
 }
 
 func NewApp(ctx context.Context, config config.Config) *App {
@@ -40,8 +59,32 @@ func NewApp(ctx context.Context, config config.Config) *App {
 	lomsClient := lomsapi.NewLomsClient(grpcClient)
 	lomsService := loms.NewLomsService(lomsClient)
 
-	cartRepository := repository.NewCartMemoryRepository()
-	productService := product.NewProductService(config)
+	var (
+		repositoryOpts []repository.Option
+		wal            *repository.WAL
+	)
+	if config.SnapshotWAL {
+		wal, err = repository.OpenWAL(config.SnapshotFile + ".wal")
+		if err != nil {
+			logger.Panicw(ctx, "repository.OpenWAL", "err", err)
+		}
+		repositoryOpts = append(repositoryOpts, repository.WithWAL(wal))
+	}
+	cartRepository := repository.NewCartMemoryRepository(repositoryOpts...)
+	if config.SnapshotFile != "" {
+		if err := cartRepository.Restore(config.SnapshotFile); err != nil {
+			logger.Panicw(ctx, "cartRepository.Restore", "err", err)
+		}
+	}
+	var productService product_cache.ProductService = product.NewProductService(config)
+	var productFileService *product_file.ProductFileService
+	if config.ProductServiceFile != "" {
+		productFileService, err = product_file.NewProductFileService(config.ProductServiceFile)
+		if err != nil {
+			logger.Panicw(ctx, "product_file.NewProductFileService", "err", err)
+		}
+		productService = productFileService
+	}
 
 	redisClient := redis.NewClient(&redis.Options{
 		Addr:     config.RedisUrl,
@@ -52,13 +95,39 @@ func NewApp(ctx context.Context, config config.Config) *App {
 		logger.Panicw(ctx, "cache.Ping", "err", err)
 	}
 	cache := cache.NewRedisLRUCache(redisClient, config.CacheSize)
-	productCacheService := product_cache.NewProductCacheService(productService, cache, config.CacheDefaultTTL)
+	productCodec, err := codec.NewProductCodec(config.CacheCodec)
+	if err != nil {
+		logger.Panicw(ctx, "codec.NewProductCodec", "err", err)
+	}
+	productCacheService := product_cache.NewProductCacheService(productService, cache, productCodec, config.CacheDefaultTTL)
 
-	cartService := cart.NewCartService(cartRepository, productCacheService, lomsService)
+	cartHistory := repository.NewCartHistoryMemoryRepository(config.HistoryRetention)
+	cartOpts := []cart.Option{
+		cart.WithHistory(cartHistory),
+		cart.WithProductRps(config.ProductServiceRps),
+		cart.WithLimits(model.CartLimits{
+			MaxLineCount: config.CartMaxLineCount,
+			MaxSkus:      config.CartMaxSkus,
+			MaxValue:     config.CartMaxValue,
+		}),
+	}
+	if config.StockHoldsEnabled {
+		cartOpts = append(cartOpts, cart.WithHoldService(hold.NewHoldService(lomsService, config.StockHoldTTL)))
+	}
+	cartService := cart.NewCartService(cartRepository, productCacheService, lomsService, cartOpts...)
 	cartServer := NewServer(cartService)
+	cacheAdminServer := NewCacheAdminServer(productCacheService, config.ProductServiceRps)
+
+	checker := health.NewChecker(healthCheckTimeout)
+	checker.Add("redis", func(ctx context.Context) error {
+		return redisClient.Ping(ctx).Err()
+	})
+	checker.Add("loms", health.GrpcCheck(grpcClient))
 
 	mux := http.NewServeMux()
 	mux.Handle("GET /metrics", promhttp.Handler())
+	mux.HandleFunc("GET /healthz", checker.Liveness)
+	mux.HandleFunc("GET /readyz", checker.Readiness)
 
 	mux.HandleFunc("/debug/pprof/", pprof.Index)
 	mux.HandleFunc("/debug/pprof/cmdline", pprof.Cmdline)
@@ -72,16 +141,63 @@ func NewApp(ctx context.Context, config config.Config) *App {
 	muxMetricsWrapper.Handle("DELETE /user/{user_id}/cart/{sku_id}", middleware.ErrorWrapper(cartServer.RemoveProduct))
 	muxMetricsWrapper.Handle("DELETE /user/{user_id}/cart", middleware.ErrorWrapper(cartServer.ClearCart))
 	muxMetricsWrapper.Handle("GET /user/{user_id}/cart/list", middleware.ErrorWrapper(cartServer.GetCart))
+	muxMetricsWrapper.Handle("GET /user/{user_id}/cart/history", middleware.ErrorWrapper(cartServer.GetCartHistory))
 	muxMetricsWrapper.Handle("POST /cart/checkout", middleware.ErrorWrapper(cartServer.Checkout))
 
-	return &App{
+	// админские ручки доступны только с токеном, без токена не регистрируются
+	if config.AdminToken != "" {
+		admin := func(h middleware.ErrorWrapper) http.Handler {
+			return middleware.AdminTokenWrapper{Token: config.AdminToken, Wrap: h}
+		}
+		muxMetricsWrapper.Handle("GET /admin/cache/product/{sku_id}", admin(cacheAdminServer.LookupProduct))
+		muxMetricsWrapper.Handle("DELETE /admin/cache/product/{sku_id}", admin(cacheAdminServer.InvalidateProduct))
+		muxMetricsWrapper.Handle("POST /admin/cache/product/{sku_id}/refresh", admin(cacheAdminServer.RefreshProduct))
+		muxMetricsWrapper.Handle("DELETE /admin/cache/product", admin(cacheAdminServer.InvalidatePrefix))
+		muxMetricsWrapper.Handle("POST /admin/cache/product/refresh", admin(cacheAdminServer.RefreshPrefix))
+	}
+
+	app := &App{
 		Server: http.Server{
 			Addr:    config.CartServiceUrl,
 			Handler: mux,
 		},
-		config:     config,
-		grpcClient: grpcClient,
+		config:         config,
+		grpcClient:     grpcClient,
+		cartRepository: cartRepository,
+		wal:            wal,
 	}
+
+	historyCtx, historyCancel := context.WithCancel(context.Background())
+	app.historyCancel = historyCancel
+	go cartHistory.RunCleanup(historyCtx, historyCleanupPeriod)
+
+	if config.SnapshotFile != "" {
+		snapshotCtx, cancel := context.WithCancel(context.Background())
+		app.snapshotCancel = cancel
+		go cartRepository.RunSnapshots(snapshotCtx, config.SnapshotFile, config.SnapshotPeriod)
+	}
+
+	if productFileService != nil {
+		reloadCtx, cancel := context.WithCancel(context.Background())
+		app.reloadCancel = cancel
+		go productFileService.Watch(reloadCtx, config.ProductReloadPeriod)
+	}
+
+	if config.File != "" {
+		configCtx, cancel := context.WithCancel(context.Background())
+		app.configCancel = cancel
+		go watchConfig(configCtx, config, productCacheService, cartService, cacheAdminServer)
+	}
+
+	// прогрев по корзинам запускается после cartRepository.Restore выше,
+	// иначе в репозитории еще нет корзин
+	if config.WarmupFile != "" || config.WarmupFromCarts {
+		warmupCtx, cancel := context.WithCancel(context.Background())
+		app.warmupCancel = cancel
+		go warmupProductCache(warmupCtx, config, cartRepository, productCacheService)
+	}
+
+	return app
 }
 
 func (app *App) ListenAndServe(ctx context.Context) error {
@@ -91,8 +207,31 @@ func (app *App) ListenAndServe(ctx context.Context) error {
 
 func (app *App) Shutdown(ctx context.Context) error {
 	logger.Infow(ctx, "shutting down server app")
+	if app.warmupCancel != nil {
+		app.warmupCancel()
+	}
+	if app.reloadCancel != nil {
+		app.reloadCancel()
+	}
+	if app.configCancel != nil {
+		app.configCancel()
+	}
+	app.historyCancel()
 	if err := app.grpcClient.Close(); err != nil {
 		logger.Errorw(ctx, "failed to close grpc client", "err", err)
 	}
-	return app.Server.Shutdown(ctx)
+	err := app.Server.Shutdown(ctx)
+	// снапшот после остановки сервера, чтобы в него попали все изменения
+	if app.snapshotCancel != nil {
+		app.snapshotCancel()
+		if err := app.cartRepository.Snapshot(app.config.SnapshotFile); err != nil {
+			logger.Errorw(ctx, "cartRepository.Snapshot", "err", err)
+		}
+	}
+	if app.wal != nil {
+		if err := app.wal.Close(); err != nil {
+			logger.Errorw(ctx, "wal.Close", "err", err)
+		}
+	}
+	return err
 }
@@ -0,0 +1,182 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"route256/cart/internal/pkg/customerror"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/service/product/product_cache"
+	"route256/cart/internal/pkg/utils"
+	"route256/cart/pkg/tracing"
+	"sync/atomic"
+)
+
+type productCache interface {
+	LookupProduct(ctx context.Context, ProductSku model.ProductSku) (*model.Product, error)
+	InvalidateProduct(ctx context.Context, ProductSku model.ProductSku) error
+	RefreshProduct(ctx context.Context, ProductSku model.ProductSku) (*model.Product, error)
+	InvalidatePrefix(ctx context.Context, prefix string) (int, error)
+	RefreshPrefix(ctx context.Context, prefix string, rps int) (int, error)
+}
+
+type CacheAdminServer struct {
+	productCache productCache
+	rps          atomic.Int64
+}
+
+func NewCacheAdminServer(productCache productCache, rps int) *CacheAdminServer {
+	s := &CacheAdminServer{
+		productCache: productCache,
+	}
+	s.SetRps(rps)
+	return s
+}
+
+// SetRps меняет ограничение запросов к ProductService при обновлении кэша.
+func (s *CacheAdminServer) SetRps(rps int) {
+	s.rps.Store(int64(rps))
+}
+
+type CacheProductResponse struct {
+	SkuId int64  `json:"sku_id"`
+	Name  string `json:"name"`
+	Price uint32 `json:"price"`
+}
+
+type CachePrefixResponse struct {
+	Count int `json:"count"`
+}
+
+func (s *CacheAdminServer) LookupProduct(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.LookupProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	skuId, err := utils.GetIntPahtValue(r, "sku_id")
+	if err != nil {
+		return fmt.Errorf("utils.GetIntPahtValue: %w", err)
+	}
+
+	product, err := s.productCache.LookupProduct(ctx, model.ProductSku(skuId))
+	if errors.Is(err, product_cache.ErrNotCached) {
+		return customerror.NewErrStatusCode(err.Error(), http.StatusNotFound)
+	}
+	if err != nil {
+		return fmt.Errorf("s.productCache.LookupProduct: %w", err)
+	}
+	return writeCacheProduct(w, product)
+}
+
+func (s *CacheAdminServer) InvalidateProduct(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.InvalidateProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	skuId, err := utils.GetIntPahtValue(r, "sku_id")
+	if err != nil {
+		return fmt.Errorf("utils.GetIntPahtValue: %w", err)
+	}
+
+	if err := s.productCache.InvalidateProduct(ctx, model.ProductSku(skuId)); err != nil {
+		return fmt.Errorf("s.productCache.InvalidateProduct: %w", err)
+	}
+
+	w.WriteHeader(http.StatusNoContent)
+	return nil
+}
+
+func (s *CacheAdminServer) RefreshProduct(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.RefreshProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	skuId, err := utils.GetIntPahtValue(r, "sku_id")
+	if err != nil {
+		return fmt.Errorf("utils.GetIntPahtValue: %w", err)
+	}
+
+	product, err := s.productCache.RefreshProduct(ctx, model.ProductSku(skuId))
+	if err != nil {
+		return fmt.Errorf("s.productCache.RefreshProduct: %w", err)
+	}
+	return writeCacheProduct(w, product)
+}
+
+func (s *CacheAdminServer) InvalidatePrefix(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.InvalidatePrefix")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	prefix, err := getSkuPrefix(r)
+	if err != nil {
+		return fmt.Errorf("getSkuPrefix: %w", err)
+	}
+
+	count, err := s.productCache.InvalidatePrefix(ctx, prefix)
+	if err != nil {
+		return fmt.Errorf("s.productCache.InvalidatePrefix: %w", err)
+	}
+	return writeCachePrefix(w, count)
+}
+
+func (s *CacheAdminServer) RefreshPrefix(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.RefreshPrefix")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	prefix, err := getSkuPrefix(r)
+	if err != nil {
+		return fmt.Errorf("getSkuPrefix: %w", err)
+	}
+
+	count, err := s.productCache.RefreshPrefix(ctx, prefix, int(s.rps.Load()))
+	if err != nil {
+		return fmt.Errorf("s.productCache.RefreshPrefix: %w", err)
+	}
+	return writeCachePrefix(w, count)
+}
+
+// getSkuPrefix возвращает префикс sku из query-параметра prefix.
+// Пустой префикс не допускается, чтобы случайно не сбросить весь кэш.
+func getSkuPrefix(r *http.Request) (string, error) {
+	prefix := r.URL.Query().Get("prefix")
+	if prefix == "" {
+		return "", errors.New("empty prefix")
+	}
+	for _, c := range prefix {
+		if c < '0' || c > '9' {
+			return "", fmt.Errorf("invalid prefix: %s", prefix)
+		}
+	}
+	return prefix, nil
+}
+
+func writeCacheProduct(w http.ResponseWriter, product *model.Product) error {
+	data, err := json.Marshal(CacheProductResponse{
+		SkuId: int64(product.Sku),
+		Name:  product.Name,
+		Price: product.Price,
+	})
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	w.Write(data)
+	return nil
+}
+
+func writeCachePrefix(w http.ResponseWriter, count int) error {
+	data, err := json.Marshal(CachePrefixResponse{Count: count})
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	w.Write(data)
+	return nil
+}
@@ -11,6 +11,8 @@ import (
 
 type CheckoutRequest struct {
 	UserId int64 `json:"user" validate:"required"`
+	// RequestId - ключ идемпотентности: повтор checkout с тем же ключом вернет уже созданный заказ
+	RequestId string `json:"request_id"`
 }
 
 type CheckoutResponse struct {
@@ -35,7 +37,7 @@ func (s *Server) Checkout(w http.ResponseWriter, r *http.Request) (err error) {
 		return fmt.Errorf("json.Unmarshal: %w", err)
 	}
 
-	orderId, err := s.cartService.Checkout(ctx, model.UserId(checkoutRequest.UserId))
+	orderId, err := s.cartService.Checkout(ctx, model.UserId(checkoutRequest.UserId), checkoutRequest.RequestId)
 	if err != nil {
 		return fmt.Errorf("s.cartService.Checkout: %w", err)
 	}
@@ -0,0 +1,33 @@
+package server
+
+import (
+	"context"
+	"route256/cart/internal/pkg/config"
+	"route256/cart/pkg/logger"
+	"time"
+)
+
+const configReloadPeriod = 5 * time.Second
+
+type productCacheTTLSetter interface {
+	SetDefaultTTL(ttl time.Duration)
+}
+
+type productRpsSetter interface {
+	SetProductRps(rps int)
+}
+
+// watchConfig применяет на лету TTL кэша, rps ProductService (в корзине и в админке кэша)
+// и уровень логирования из файла конфига.
+func watchConfig(ctx context.Context, conf config.Config, productCache productCacheTTLSetter, cartService productRpsSetter, cacheAdminServer *CacheAdminServer) {
+	watcher := config.NewWatcher(conf.File, conf)
+	watcher.OnReload(func(conf config.Config) {
+		productCache.SetDefaultTTL(conf.CacheDefaultTTL)
+		cartService.SetProductRps(conf.ProductServiceRps)
+		cacheAdminServer.SetRps(conf.ProductServiceRps)
+		if err := logger.SetLevel(conf.LogLevel); err != nil {
+			logger.Errorw(ctx, "logger.SetLevel", "err", err)
+		}
+	})
+	watcher.Watch(ctx, configReloadPeriod)
+}
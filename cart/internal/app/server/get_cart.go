@@ -11,17 +11,35 @@ import (
 )
 
 type GetCartResponseProduct struct {
-	SkuId int64  `json:"sku_id"`
-	Name  string `json:"name"`
-	Count uint16 `json:"count"`
-	Price uint32 `json:"price"`
+	SkuId    int64                    `json:"sku_id"`
+	Name     string                   `json:"name"`
+	Count    uint16                   `json:"count"`
+	Price    uint32                   `json:"price"`
+	Warnings []GetCartResponseWarning `json:"warnings,omitempty"`
+}
+
+type GetCartResponseWarning struct {
+	Type string `json:"type"`
+	Old  uint64 `json:"old"`
+	New  uint64 `json:"new"`
+}
+
+type GetCartResponseUnavailable struct {
+	SkuId  int64  `json:"sku_id"`
+	Count  uint16 `json:"count"`
+	Reason string `json:"reason"`
 }
 
 type GetCartResponse struct {
-	Items      []GetCartResponseProduct `json:"items"`
-	TotalPrice uint32                   `json:"total_price"`
+	Items       []GetCartResponseProduct     `json:"items"`
+	TotalPrice  uint64                       `json:"total_price"`
+	Unavailable []GetCartResponseUnavailable `json:"unavailable,omitempty"`
+	Degraded    bool                         `json:"degraded,omitempty"`
 }
 
+// DegradedHeader выставляется, если часть товаров корзины не удалось загрузить.
+const DegradedHeader = "X-Cart-Degraded"
+
 func (s *Server) GetCart(w http.ResponseWriter, r *http.Request) (err error) {
 	ctx, span := tracing.Start(r.Context(), "server.GetCart")
 	defer tracing.EndWithCheckError(span, &err)
@@ -33,27 +51,35 @@ func (s *Server) GetCart(w http.ResponseWriter, r *http.Request) (err error) {
 		return fmt.Errorf("utils.GetIntPahtValue: %w", err)
 	}
 
-	cartFull, err := s.cartService.GetCart(ctx, model.UserId(userId))
+	cartView, err := s.cartService.GetCart(ctx, model.UserId(userId))
 	if err != nil {
-		return fmt.Errorf("s.cartService.ClearCart: %w", err)
+		return fmt.Errorf("s.cartService.GetCart: %w", err)
 	}
 
-	if len(cartFull) == 0 {
+	if len(cartView.Items) == 0 && !cartView.Degraded() {
 		w.WriteHeader(http.StatusNotFound)
 		w.Write([]byte("{}"))
 		return nil
 	}
 
-	items := make([]GetCartResponseProduct, 0, len(cartFull))
-	totalPrice := uint32(0)
-	for product, count := range cartFull {
-		items = append(items, GetCartResponseProduct{
+	items := make([]GetCartResponseProduct, 0, len(cartView.Items))
+	totalPrice := uint64(0)
+	for product, count := range cartView.Items {
+		item := GetCartResponseProduct{
 			SkuId: int64(product.Sku),
 			Name:  product.Name,
 			Count: count,
 			Price: product.Price,
-		})
-		totalPrice += product.Price * uint32(count)
+		}
+		for _, warning := range cartView.Warnings[product.Sku] {
+			item.Warnings = append(item.Warnings, GetCartResponseWarning{
+				Type: string(warning.Type),
+				Old:  warning.Old,
+				New:  warning.New,
+			})
+		}
+		items = append(items, item)
+		totalPrice += uint64(product.Price) * uint64(count)
 	}
 	sort.Slice(items, func(i, j int) bool {
 		return items[i].SkuId < items[j].SkuId
@@ -61,7 +87,21 @@ func (s *Server) GetCart(w http.ResponseWriter, r *http.Request) (err error) {
 
 	getCartResponse := GetCartResponse{
 		Items:      items,
-		TotalPrice: uint32(totalPrice),
+		TotalPrice: totalPrice,
+		Degraded:   cartView.Degraded(),
+	}
+	for sku, item := range cartView.Unavailable {
+		getCartResponse.Unavailable = append(getCartResponse.Unavailable, GetCartResponseUnavailable{
+			SkuId:  int64(sku),
+			Count:  item.Count,
+			Reason: string(item.Reason),
+		})
+	}
+	sort.Slice(getCartResponse.Unavailable, func(i, j int) bool {
+		return getCartResponse.Unavailable[i].SkuId < getCartResponse.Unavailable[j].SkuId
+	})
+	if getCartResponse.Degraded {
+		w.Header().Set(DegradedHeader, "true")
 	}
 
 	data, err := json.Marshal(getCartResponse)
@@ -0,0 +1,109 @@
+package server
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/utils"
+	"route256/cart/pkg/tracing"
+	"strconv"
+	"time"
+)
+
+const (
+	historyDefaultLimit = 50
+	historyMaxLimit     = 500
+)
+
+type GetCartHistoryResponseEvent struct {
+	ID      int64     `json:"id"`
+	Time    time.Time `json:"time"`
+	SkuId   int64     `json:"sku_id"`
+	Delta   int32     `json:"delta"`
+	Action  string    `json:"action"`
+	Source  string    `json:"source"`
+	TraceID string    `json:"trace_id,omitempty"`
+}
+
+type GetCartHistoryResponse struct {
+	Events []GetCartHistoryResponseEvent `json:"events"`
+	// NextCursor передается в cursor для получения следующей страницы
+	NextCursor int64 `json:"next_cursor,omitempty"`
+}
+
+func (s *Server) GetCartHistory(w http.ResponseWriter, r *http.Request) (err error) {
+	ctx, span := tracing.Start(r.Context(), "server.GetCartHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	w.Header().Add("Content-Type", "application/json")
+
+	userId, err := utils.GetIntPahtValue(r, "user_id")
+	if err != nil {
+		return fmt.Errorf("utils.GetIntPahtValue: %w", err)
+	}
+	filter, err := getHistoryFilter(r)
+	if err != nil {
+		return fmt.Errorf("getHistoryFilter: %w", err)
+	}
+
+	events, err := s.cartService.GetHistory(ctx, model.UserId(userId), filter)
+	if err != nil {
+		return fmt.Errorf("s.cartService.GetHistory: %w", err)
+	}
+
+	response := GetCartHistoryResponse{
+		Events: make([]GetCartHistoryResponseEvent, 0, len(events)),
+	}
+	for _, event := range events {
+		response.Events = append(response.Events, GetCartHistoryResponseEvent{
+			ID:      event.ID,
+			Time:    event.Time,
+			SkuId:   int64(event.Sku),
+			Delta:   event.Delta,
+			Action:  string(event.Action),
+			Source:  string(event.Source),
+			TraceID: event.TraceID,
+		})
+	}
+	if len(events) == filter.Limit {
+		response.NextCursor = events[len(events)-1].ID
+	}
+
+	data, err := json.Marshal(response)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	w.Write(data)
+	return nil
+}
+
+// getHistoryFilter разбирает query-параметры from и to (RFC3339), cursor и limit.
+func getHistoryFilter(r *http.Request) (model.CartHistoryFilter, error) {
+	query := r.URL.Query()
+	filter := model.CartHistoryFilter{
+		Limit: historyDefaultLimit,
+	}
+	var err error
+	if from := query.Get("from"); from != "" {
+		if filter.From, err = time.Parse(time.RFC3339, from); err != nil {
+			return filter, fmt.Errorf("invalid from: %w", err)
+		}
+	}
+	if to := query.Get("to"); to != "" {
+		if filter.To, err = time.Parse(time.RFC3339, to); err != nil {
+			return filter, fmt.Errorf("invalid to: %w", err)
+		}
+	}
+	if cursor := query.Get("cursor"); cursor != "" {
+		if filter.AfterID, err = strconv.ParseInt(cursor, 10, 64); err != nil || filter.AfterID < 0 {
+			return filter, fmt.Errorf("invalid cursor: %s", cursor)
+		}
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit < 1 || filter.Limit > historyMaxLimit {
+			return filter, fmt.Errorf("invalid limit: %s, must be from 1 to %d", limit, historyMaxLimit)
+		}
+	}
+	return filter, nil
+}
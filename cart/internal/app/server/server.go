@@ -9,8 +9,9 @@ type cartService interface {
 	AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) error
 	RemoveProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) error
 	ClearCart(ctx context.Context, userId model.UserId) error
-	GetCart(ctx context.Context, userId model.UserId) (model.CartFull, error)
-	Checkout(ctx context.Context, userId model.UserId) (model.OrderId, error)
+	GetCart(ctx context.Context, userId model.UserId) (model.CartView, error)
+	Checkout(ctx context.Context, userId model.UserId, requestId string) (model.OrderId, error)
+	GetHistory(ctx context.Context, userId model.UserId, filter model.CartHistoryFilter) ([]model.CartEvent, error)
 }
 
 type Server struct {
@@ -0,0 +1,57 @@
+package server
+
+import (
+	"context"
+	"route256/cart/internal/pkg/config"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/service/product/product_cache"
+	"route256/cart/pkg/logger"
+)
+
+type topProductsProvider interface {
+	GetTopProducts(ctx context.Context, limit int) ([]model.ProductSku, error)
+}
+
+type productCacheWarmer interface {
+	Warmup(ctx context.Context, skus []model.ProductSku, rps int) (int, error)
+}
+
+func warmupProductCache(ctx context.Context, config config.Config, topProducts topProductsProvider, warmer productCacheWarmer) {
+	skus := make([]model.ProductSku, 0, config.WarmupLimit)
+	seen := make(map[model.ProductSku]struct{}, config.WarmupLimit)
+	add := func(list []model.ProductSku) {
+		for _, sku := range list {
+			if len(skus) >= config.WarmupLimit {
+				return
+			}
+			if _, ok := seen[sku]; ok {
+				continue
+			}
+			seen[sku] = struct{}{}
+			skus = append(skus, sku)
+		}
+	}
+
+	if config.WarmupFile != "" {
+		fileSkus, err := product_cache.ReadWarmupSkus(config.WarmupFile)
+		if err != nil {
+			logger.Errorw(ctx, "cache warmup: product_cache.ReadWarmupSkus", "file", config.WarmupFile, "err", err)
+		}
+		add(fileSkus)
+	}
+	if config.WarmupFromCarts {
+		cartSkus, err := topProducts.GetTopProducts(ctx, config.WarmupLimit)
+		if err != nil {
+			logger.Errorw(ctx, "cache warmup: topProducts.GetTopProducts", "err", err)
+		}
+		add(cartSkus)
+	}
+
+	logger.Infow(ctx, "cache warmup started", "skus", len(skus))
+	loaded, err := warmer.Warmup(ctx, skus, config.ProductServiceRps)
+	if err != nil {
+		logger.Errorw(ctx, "cache warmup: warmer.Warmup", "loaded", loaded, "err", err)
+		return
+	}
+	logger.Infow(ctx, "cache warmup finished", "loaded", loaded, "skus", len(skus))
+}
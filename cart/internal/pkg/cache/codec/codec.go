@@ -0,0 +1,71 @@
+package codec
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	ErrEmptyData      = errors.New("empty cache value")
+	ErrUnknownVersion = errors.New("unknown codec version")
+	ErrUnknownCodec   = errors.New("unknown codec")
+)
+
+const (
+	legacyJSONFirstByte byte = '{'
+
+	VersionJSON     byte = 1
+	VersionProtobuf byte = 2
+	VersionMsgpack  byte = 3
+)
+
+type Codec[T any] interface {
+	Version() byte
+	Encode(T) ([]byte, error)
+	Decode([]byte) (T, error)
+}
+
+// VersionedCodec пишет значения текущим кодеком, добавляя перед ними байт версии,
+// а читает любым из известных кодеков по этому байту. Значения без байта версии,
+// записанные до появления кодеков, читаются как JSON. Так формат можно сменить
+// без очистки Redis: старые записи дочитываются до истечения TTL.
+type VersionedCodec[T any] struct {
+	write  Codec[T]
+	read   map[byte]Codec[T]
+	legacy Codec[T]
+}
+
+func NewVersionedCodec[T any](write Codec[T], read ...Codec[T]) *VersionedCodec[T] {
+	c := &VersionedCodec[T]{
+		write:  write,
+		read:   make(map[byte]Codec[T], len(read)+1),
+		legacy: JSON[T]{},
+	}
+	c.read[write.Version()] = write
+	for _, r := range read {
+		c.read[r.Version()] = r
+	}
+	return c
+}
+
+func (c *VersionedCodec[T]) Encode(value T) ([]byte, error) {
+	data, err := c.write.Encode(value)
+	if err != nil {
+		return nil, fmt.Errorf("c.write.Encode: %w", err)
+	}
+	return append([]byte{c.write.Version()}, data...), nil
+}
+
+func (c *VersionedCodec[T]) Decode(data []byte) (value T, err error) {
+	if len(data) == 0 {
+		return value, ErrEmptyData
+	}
+	if data[0] == legacyJSONFirstByte {
+		return c.legacy.Decode(data)
+	}
+	codec, ok := c.read[data[0]]
+	if !ok {
+		return value, fmt.Errorf("%w: %d", ErrUnknownVersion, data[0])
+	}
+	return codec.Decode(data[1:])
+}
@@ -0,0 +1,64 @@
+package codec
+
+import (
+	"route256/cart/internal/pkg/model"
+	"testing"
+)
+
+func benchmarkCodecs() map[string]Codec[model.Product] {
+	return map[string]Codec[model.Product]{
+		"json":     JSON[model.Product]{},
+		"protobuf": ProductProtobuf{},
+		"msgpack":  Msgpack[model.Product]{},
+	}
+}
+
+func BenchmarkEncode(b *testing.B) {
+	for name, c := range benchmarkCodecs() {
+		b.Run(name, func(b *testing.B) {
+			size := 0
+			for _, product := range testProducts {
+				data, err := c.Encode(product)
+				if err != nil {
+					b.Fatal(err)
+				}
+				size += len(data)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Encode(testProducts[i%len(testProducts)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(size)/float64(len(testProducts)), "encoded-bytes")
+		})
+	}
+}
+
+func BenchmarkDecode(b *testing.B) {
+	for name, c := range benchmarkCodecs() {
+		b.Run(name, func(b *testing.B) {
+			encoded := make([][]byte, 0, len(testProducts))
+			size := 0
+			for _, product := range testProducts {
+				data, err := c.Encode(product)
+				if err != nil {
+					b.Fatal(err)
+				}
+				encoded = append(encoded, data)
+				size += len(data)
+			}
+			b.ReportAllocs()
+			b.ResetTimer()
+
+			for i := 0; i < b.N; i++ {
+				if _, err := c.Decode(encoded[i%len(encoded)]); err != nil {
+					b.Fatal(err)
+				}
+			}
+			b.ReportMetric(float64(size)/float64(len(testProducts)), "encoded-bytes")
+		})
+	}
+}
@@ -0,0 +1,90 @@
+package codec
+
+import (
+	"encoding/json"
+	"route256/cart/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// Товары близкие к ответам ProductService по размеру и содержимому
+var testProducts = []model.Product{
+	{Sku: 1076963, Name: "Теория нравственных чувств | Смит Адам", Price: 3379},
+	{Sku: 1148162, Name: "Кулинар Гуру | Друзья Ее Величества | Поварская книга", Price: 2956},
+	{Sku: 1625903, Name: "Unknown Book", Price: 2620},
+	{Sku: 4288068, Name: "Ключи к королевству. Приключения | Твен М.", Price: 4018},
+	{Sku: 2618151, Name: "", Price: 0},
+}
+
+func TestCodecsRoundTrip(t *testing.T) {
+	codecs := []Codec[model.Product]{
+		JSON[model.Product]{},
+		ProductProtobuf{},
+		Msgpack[model.Product]{},
+	}
+	for _, c := range codecs {
+		for _, product := range testProducts {
+			data, err := c.Encode(product)
+			require.NoError(t, err)
+
+			decoded, err := c.Decode(data)
+			require.NoError(t, err)
+			assert.Equal(t, product, decoded, "codec version %d", c.Version())
+		}
+	}
+}
+
+func TestVersionedCodecRollout(t *testing.T) {
+	product := testProducts[0]
+
+	oldCodec, err := NewProductCodec("json")
+	require.NoError(t, err)
+	newCodec, err := NewProductCodec("msgpack")
+	require.NoError(t, err)
+
+	oldData, err := oldCodec.Encode(product)
+	require.NoError(t, err)
+	assert.Equal(t, VersionJSON, oldData[0])
+
+	newData, err := newCodec.Encode(product)
+	require.NoError(t, err)
+	assert.Equal(t, VersionMsgpack, newData[0])
+
+	// во время раскатки старые и новые инстансы читают записи друг друга
+	decoded, err := newCodec.Decode(oldData)
+	require.NoError(t, err)
+	assert.Equal(t, product, decoded)
+
+	decoded, err = oldCodec.Decode(newData)
+	require.NoError(t, err)
+	assert.Equal(t, product, decoded)
+}
+
+func TestVersionedCodecLegacyJSON(t *testing.T) {
+	product := testProducts[1]
+	legacyData, err := json.Marshal(product)
+	require.NoError(t, err)
+
+	c, err := NewProductCodec("protobuf")
+	require.NoError(t, err)
+
+	decoded, err := c.Decode(legacyData)
+	require.NoError(t, err)
+	assert.Equal(t, product, decoded)
+}
+
+func TestVersionedCodecErrors(t *testing.T) {
+	c, err := NewProductCodec("json")
+	require.NoError(t, err)
+
+	_, err = c.Decode(nil)
+	assert.ErrorIs(t, err, ErrEmptyData)
+
+	_, err = c.Decode([]byte{42, 1, 2})
+	assert.ErrorIs(t, err, ErrUnknownVersion)
+
+	_, err = NewProductCodec("xml")
+	assert.ErrorIs(t, err, ErrUnknownCodec)
+}
@@ -0,0 +1,18 @@
+package codec
+
+import "encoding/json"
+
+type JSON[T any] struct{}
+
+func (JSON[T]) Version() byte {
+	return VersionJSON
+}
+
+func (JSON[T]) Encode(value T) ([]byte, error) {
+	return json.Marshal(value)
+}
+
+func (JSON[T]) Decode(data []byte) (value T, err error) {
+	err = json.Unmarshal(data, &value)
+	return value, err
+}
@@ -0,0 +1,18 @@
+package codec
+
+import "github.com/vmihailenco/msgpack/v5"
+
+type Msgpack[T any] struct{}
+
+func (Msgpack[T]) Version() byte {
+	return VersionMsgpack
+}
+
+func (Msgpack[T]) Encode(value T) ([]byte, error) {
+	return msgpack.Marshal(value)
+}
+
+func (Msgpack[T]) Decode(data []byte) (value T, err error) {
+	err = msgpack.Unmarshal(data, &value)
+	return value, err
+}
@@ -0,0 +1,25 @@
+package codec
+
+import (
+	"fmt"
+	"route256/cart/internal/pkg/model"
+)
+
+// NewProductCodec возвращает кодек товаров, который пишет форматом name
+// и читает все известные форматы.
+func NewProductCodec(name string) (*VersionedCodec[model.Product], error) {
+	codecs := map[string]Codec[model.Product]{
+		"json":     JSON[model.Product]{},
+		"protobuf": ProductProtobuf{},
+		"msgpack":  Msgpack[model.Product]{},
+	}
+	write, ok := codecs[name]
+	if !ok {
+		return nil, fmt.Errorf("%w: %s", ErrUnknownCodec, name)
+	}
+	read := make([]Codec[model.Product], 0, len(codecs))
+	for _, c := range codecs {
+		read = append(read, c)
+	}
+	return NewVersionedCodec(write, read...), nil
+}
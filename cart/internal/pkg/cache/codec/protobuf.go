@@ -0,0 +1,87 @@
+package codec
+
+import (
+	"fmt"
+	"route256/cart/internal/pkg/model"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// ProductProtobuf кодирует товар в protobuf wire format сообщения
+//
+//	message Product {
+//	    int64 sku = 1;
+//	    string name = 2;
+//	    uint32 price = 3;
+//	}
+//
+// без генерации кода: сообщение состоит из трех полей, а protowire не требует рефлексии.
+type ProductProtobuf struct{}
+
+const (
+	productSkuField   protowire.Number = 1
+	productNameField  protowire.Number = 2
+	productPriceField protowire.Number = 3
+)
+
+func (ProductProtobuf) Version() byte {
+	return VersionProtobuf
+}
+
+func (ProductProtobuf) Encode(product model.Product) ([]byte, error) {
+	data := make([]byte, 0, 16+len(product.Name))
+	if product.Sku != 0 {
+		data = protowire.AppendTag(data, productSkuField, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(product.Sku))
+	}
+	if product.Name != "" {
+		data = protowire.AppendTag(data, productNameField, protowire.BytesType)
+		data = protowire.AppendString(data, product.Name)
+	}
+	if product.Price != 0 {
+		data = protowire.AppendTag(data, productPriceField, protowire.VarintType)
+		data = protowire.AppendVarint(data, uint64(product.Price))
+	}
+	return data, nil
+}
+
+func (ProductProtobuf) Decode(data []byte) (product model.Product, err error) {
+	for len(data) > 0 {
+		num, typ, n := protowire.ConsumeTag(data)
+		if n < 0 {
+			return model.Product{}, fmt.Errorf("protowire.ConsumeTag: %w", protowire.ParseError(n))
+		}
+		data = data[n:]
+
+		switch {
+		case num == productSkuField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return model.Product{}, fmt.Errorf("protowire.ConsumeVarint sku: %w", protowire.ParseError(n))
+			}
+			product.Sku = model.ProductSku(v)
+			data = data[n:]
+		case num == productNameField && typ == protowire.BytesType:
+			v, n := protowire.ConsumeString(data)
+			if n < 0 {
+				return model.Product{}, fmt.Errorf("protowire.ConsumeString name: %w", protowire.ParseError(n))
+			}
+			product.Name = v
+			data = data[n:]
+		case num == productPriceField && typ == protowire.VarintType:
+			v, n := protowire.ConsumeVarint(data)
+			if n < 0 {
+				return model.Product{}, fmt.Errorf("protowire.ConsumeVarint price: %w", protowire.ParseError(n))
+			}
+			product.Price = uint32(v)
+			data = data[n:]
+		default:
+			n := protowire.ConsumeFieldValue(num, typ, data)
+			if n < 0 {
+				return model.Product{}, fmt.Errorf("protowire.ConsumeFieldValue: %w", protowire.ParseError(n))
+			}
+			data = data[n:]
+		}
+	}
+	return product, nil
+}
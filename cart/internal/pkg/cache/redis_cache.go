@@ -45,6 +45,15 @@ func (c *RedisLRUCache) Set(ctx context.Context, key string, value any, ttl time
 	c.mx.Lock()
 	defer c.mx.Unlock()
 
+	// обновление ключа из кеша ничего не вытесняет, только поднимает его в начало списка
+	if el, ok := c.listElements[key]; ok {
+		if err := c.cache.Set(ctx, key, value, ttl).Err(); err != nil {
+			return fmt.Errorf("c.cache.Set: %w", err)
+		}
+		c.list.MoveToFront(el)
+		return nil
+	}
+
 	if len(c.listElements) == c.size {
 		lastKey := c.list.Back().Value.(string)
 		if err := c.cache.Del(ctx, lastKey).Err(); err != nil {
@@ -74,3 +83,15 @@ func (c *RedisLRUCache) Del(ctx context.Context, key string) error {
 	delete(c.listElements, key)
 	return nil
 }
+
+func (c *RedisLRUCache) Keys(ctx context.Context, prefix string) ([]string, error) {
+	keys := make([]string, 0)
+	iter := c.cache.Scan(ctx, 0, prefix+"*", 0).Iterator()
+	for iter.Next(ctx) {
+		keys = append(keys, iter.Val())
+	}
+	if err := iter.Err(); err != nil {
+		return nil, fmt.Errorf("iter.Err: %w", err)
+	}
+	return keys, nil
+}
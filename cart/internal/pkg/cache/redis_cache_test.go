@@ -12,13 +12,14 @@ import (
 )
 
 func getCache(t *testing.T) *RedisLRUCache {
-	config := config.NewConfig()
+	config, err := config.NewConfig()
+	require.NoError(t, err)
 	redisCache := redis.NewClient(&redis.Options{
 		Addr:     config.RedisUrl,
 		Password: config.RedisPassword,
 		DB:       config.RedisDB,
 	})
-	_, err := redisCache.Ping(context.Background()).Result()
+	_, err = redisCache.Ping(context.Background()).Result()
 	require.NoError(t, err)
 	return NewRedisLRUCache(redisCache, 3)
 }
@@ -74,3 +75,31 @@ func TestCacheSize(t *testing.T) {
 	_, err = cache.Get(ctx, "4")
 	assert.Error(t, err)
 }
+
+func TestCacheRefreshFull(t *testing.T) {
+	ctx := context.Background()
+	cache := getCache(t)
+
+	require.NoError(t, cache.Set(ctx, "r1", "toy1", 0))
+	require.NoError(t, cache.Set(ctx, "r2", "toy2", 0))
+	require.NoError(t, cache.Set(ctx, "r3", "toy3", 0))
+
+	// обновление ключей полного кеша ничего не вытесняет
+	require.NoError(t, cache.Set(ctx, "r1", "toy1-new", 0))
+	require.NoError(t, cache.Set(ctx, "r2", "toy2-new", 0))
+	assert.Equal(t, 3, cache.list.Len())
+	for _, kv := range [][2]string{{"r1", "toy1-new"}, {"r2", "toy2-new"}, {"r3", "toy3"}} {
+		got, err := cache.Get(ctx, kv[0])
+		assert.NoError(t, err)
+		assert.Equal(t, kv[1], got)
+	}
+
+	// новый ключ вытесняет самый давний по обращениям r2, а не только что обновленный r1
+	require.NoError(t, cache.Set(ctx, "r1", "toy1", 0))
+	require.NoError(t, cache.Set(ctx, "r4", "toy4", 0))
+	assert.Equal(t, 3, cache.list.Len())
+	_, err := cache.Get(ctx, "r2")
+	assert.Error(t, err)
+	_, err = cache.Get(ctx, "r1")
+	assert.NoError(t, err)
+}
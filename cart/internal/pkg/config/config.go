@@ -1,85 +1,182 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
+	"math"
 	"os"
-	"strconv"
 	"strings"
 	"time"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
 )
 
+// Config собирается слоями: значения по умолчанию, yaml-файл, переменные окружения.
 type Config struct {
-	ServiceName         string
-	CartServiceUrl      string
-	LomsServiceUrl      string
-	ProductServiceUrl   string
-	ProductServiceToken string
-	TracerUrl           string
-	RedisUrl            string
-	RedisPassword       string
-	RedisDB             int
-	CacheSize           int
-	CacheDefaultTTL     time.Duration
+	ServiceName         string        `yaml:"service_name"`
+	CartServiceUrl      string        `yaml:"cart_service_url"`
+	LomsServiceUrl      string        `yaml:"loms_service_url"`
+	ProductServiceUrl   string        `yaml:"product_service_url"`
+	ProductServiceToken string        `yaml:"product_service_token" secret:"true"`
+	ProductServiceFile  string        `yaml:"product_service_file"`
+	ProductReloadPeriod time.Duration `yaml:"product_service_file_reload"`
+	TracerUrl           string        `yaml:"tracer_url"`
+	RedisUrl            string        `yaml:"redis_url"`
+	RedisPassword       string        `yaml:"redis_password" secret:"true"`
+	RedisDB             int           `yaml:"redis_db"`
+	CacheSize           int           `yaml:"cache_size"`
+	CacheDefaultTTL     time.Duration `yaml:"cache_default_ttl" reload:"true"`
+	CacheCodec          string        `yaml:"cache_codec"`
+	ProductServiceRps   int           `yaml:"product_service_rps" reload:"true"`
+	WarmupFile          string        `yaml:"cache_warmup_file"`
+	WarmupFromCarts     bool          `yaml:"cache_warmup_from_carts"`
+	WarmupLimit         int           `yaml:"cache_warmup_limit"`
+	StockHoldsEnabled   bool          `yaml:"stock_holds_enabled"`
+	StockHoldTTL        time.Duration `yaml:"stock_hold_ttl"`
+	CartMaxLineCount    uint16        `yaml:"cart_max_line_count"`
+	CartMaxSkus         int           `yaml:"cart_max_skus"`
+	CartMaxValue        uint64        `yaml:"cart_max_value"`
+	LogLevel            string        `yaml:"log_level" reload:"true"`
+	SnapshotFile        string        `yaml:"cart_snapshot_file"`
+	SnapshotPeriod      time.Duration `yaml:"cart_snapshot_period"`
+	SnapshotWAL         bool          `yaml:"cart_snapshot_wal"`
+	HistoryRetention    time.Duration `yaml:"cart_history_retention"`
+	AdminToken          string        `yaml:"admin_token" secret:"true"`
+	// File - путь к файлу, из которого прочитан конфиг
+	File string `yaml:"-"`
 }
 
-func NewConfig() Config {
-	serviceName := os.Getenv("SERVICE_NAME")
-	if serviceName == "" {
-		serviceName = "cart"
-	}
-	cartServiceUrl := os.Getenv("CART_SERVICE_URL")
-	if cartServiceUrl == "" {
-		cartServiceUrl = "localhost:8082"
-	}
-	lomsServiceUrl := os.Getenv("LOMS_SERVICE_URL")
-	if lomsServiceUrl == "" {
-		lomsServiceUrl = "localhost:50777"
-	}
-	productServiceUrl := os.Getenv("PRODUCT_SERVICE_URL")
-	if productServiceUrl == "" {
-		productServiceUrl = "http://route256.pavl.uk:8080"
-	}
-	productServiceToken := os.Getenv("PRODUCT_SERVICE_TOKEN")
-	if productServiceToken == "" {
-		productServiceToken = "testtoken"
-	}
-	tracerUrl := os.Getenv("TRACER_URL")
-	if tracerUrl == "" {
-		tracerUrl = "http://localhost:4318"
-	}
-	if !strings.HasPrefix(tracerUrl, "http") {
-		tracerUrl = "http://" + tracerUrl
+func Default() Config {
+	return Config{
+		ServiceName:         "cart",
+		CartServiceUrl:      "localhost:8082",
+		LomsServiceUrl:      "localhost:50777",
+		ProductServiceUrl:   "http://route256.pavl.uk:8080",
+		ProductServiceToken: "testtoken",
+		ProductReloadPeriod: 5 * time.Second,
+		TracerUrl:           "http://localhost:4318",
+		RedisUrl:            "localhost:6379",
+		RedisPassword:       "passwd",
+		CacheSize:           1000,
+		CacheDefaultTTL:     60 * time.Second,
+		CacheCodec:          "json",
+		ProductServiceRps:   10,
+		WarmupLimit:         100,
+		StockHoldTTL:        15 * time.Minute,
+		CartMaxLineCount:    math.MaxUint16,
+		LogLevel:            "info",
+		SnapshotPeriod:      time.Minute,
+		HistoryRetention:    30 * 24 * time.Hour,
 	}
-	redisUrl := os.Getenv("REDIS_URL")
-	if redisUrl == "" {
-		redisUrl = "localhost:6379"
+}
+
+// NewConfig читает конфиг из файла CONFIG_FILE (если задан) и переменных окружения.
+func NewConfig() (Config, error) {
+	return Load(os.Getenv("CONFIG_FILE"))
+}
+
+// Load читает конфиг из файла path и переменных окружения и проверяет его.
+// Переменные окружения имеют приоритет над файлом.
+func Load(path string) (Config, error) {
+	config := Default()
+	config.File = path
+	if path != "" {
+		if err := config.readFile(path); err != nil {
+			return Config{}, err
+		}
 	}
-	redisPassword := os.Getenv("REDIS_PASSWORD")
-	if redisPassword == "" {
-		redisPassword = "passwd"
+	if err := config.readEnv(); err != nil {
+		return Config{}, err
 	}
-	redisDB, err := strconv.Atoi(os.Getenv("REDIS_DB"))
-	if err != nil {
-		redisDB = 0
+	if !strings.HasPrefix(config.TracerUrl, "http") {
+		config.TracerUrl = "http://" + config.TracerUrl
 	}
-	cacheSize, err := strconv.Atoi(os.Getenv("CACHE_SIZE"))
-	if err != nil {
-		cacheSize = 1000
+	if err := config.Validate(); err != nil {
+		return Config{}, err
 	}
-	cacheDefaultTTL, err := strconv.Atoi(os.Getenv("CACHE_DEFAULT_TTL"))
+	return config, nil
+}
+
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
 	if err != nil {
-		cacheDefaultTTL = 60
+		return fmt.Errorf("config: %w", err)
 	}
-	return Config{
-		ServiceName:         serviceName,
-		CartServiceUrl:      cartServiceUrl,
-		LomsServiceUrl:      lomsServiceUrl,
-		ProductServiceUrl:   productServiceUrl,
-		ProductServiceToken: productServiceToken,
-		TracerUrl:           tracerUrl,
-		RedisUrl:            redisUrl,
-		RedisPassword:       redisPassword,
-		RedisDB:             redisDB,
-		CacheSize:           cacheSize,
-		CacheDefaultTTL:     time.Duration(cacheDefaultTTL) * time.Second,
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
 	}
+	return nil
+}
+
+func (c *Config) readEnv() error {
+	env := envReader{}
+	env.String("SERVICE_NAME", &c.ServiceName)
+	env.String("CART_SERVICE_URL", &c.CartServiceUrl)
+	env.String("LOMS_SERVICE_URL", &c.LomsServiceUrl)
+	env.String("PRODUCT_SERVICE_URL", &c.ProductServiceUrl)
+	env.String("PRODUCT_SERVICE_TOKEN", &c.ProductServiceToken)
+	// если задан файл каталога, товары берутся из него вместо ProductService
+	env.String("PRODUCT_SERVICE_FILE", &c.ProductServiceFile)
+	env.Duration("PRODUCT_SERVICE_FILE_RELOAD", &c.ProductReloadPeriod)
+	env.String("TRACER_URL", &c.TracerUrl)
+	env.String("REDIS_URL", &c.RedisUrl)
+	env.String("REDIS_PASSWORD", &c.RedisPassword)
+	env.Int("REDIS_DB", &c.RedisDB)
+	env.Int("CACHE_SIZE", &c.CacheSize)
+	env.Duration("CACHE_DEFAULT_TTL", &c.CacheDefaultTTL)
+	env.String("CACHE_CODEC", &c.CacheCodec)
+	env.Int("PRODUCT_SERVICE_RPS", &c.ProductServiceRps)
+	env.String("CACHE_WARMUP_FILE", &c.WarmupFile)
+	env.Bool("CACHE_WARMUP_FROM_CARTS", &c.WarmupFromCarts)
+	env.Int("CACHE_WARMUP_LIMIT", &c.WarmupLimit)
+	env.Bool("STOCK_HOLDS_ENABLED", &c.StockHoldsEnabled)
+	env.Duration("STOCK_HOLD_TTL", &c.StockHoldTTL)
+	env.Uint16("CART_MAX_LINE_COUNT", &c.CartMaxLineCount)
+	// 0 - без ограничения
+	env.Int("CART_MAX_SKUS", &c.CartMaxSkus)
+	env.Uint64("CART_MAX_VALUE", &c.CartMaxValue)
+	env.String("LOG_LEVEL", &c.LogLevel)
+	// если задан файл снапшота, корзины сохраняются в него и восстанавливаются при старте
+	env.String("CART_SNAPSHOT_FILE", &c.SnapshotFile)
+	env.Duration("CART_SNAPSHOT_PERIOD", &c.SnapshotPeriod)
+	env.Bool("CART_SNAPSHOT_WAL", &c.SnapshotWAL)
+	env.Duration("CART_HISTORY_RETENTION", &c.HistoryRetention)
+	// если не задан, админские ручки кэша не регистрируются
+	env.String("ADMIN_TOKEN", &c.AdminToken)
+	return env.Err()
+}
+
+// Validate возвращает все найденные ошибки конфига разом.
+func (c Config) Validate() error {
+	v := validator{}
+	v.Check(c.ServiceName != "", "service_name must not be empty")
+	v.Check(c.CartServiceUrl != "", "cart_service_url must not be empty")
+	v.Check(c.LomsServiceUrl != "", "loms_service_url must not be empty")
+	v.Check(c.ProductServiceUrl != "" || c.ProductServiceFile != "", "product_service_url or product_service_file must be set")
+	v.Check(c.ProductReloadPeriod > 0, "product_service_file_reload must be positive, got %s", c.ProductReloadPeriod)
+	v.Check(c.RedisUrl != "", "redis_url must not be empty")
+	v.Check(c.RedisDB >= 0, "redis_db must not be negative, got %d", c.RedisDB)
+	v.Check(c.CacheSize > 0, "cache_size must be positive, got %d", c.CacheSize)
+	v.Check(c.CacheDefaultTTL >= time.Second, "cache_default_ttl must be at least 1s, got %s", c.CacheDefaultTTL)
+	v.Check(c.CacheCodec == "json" || c.CacheCodec == "protobuf" || c.CacheCodec == "msgpack",
+		"cache_codec must be one of json, protobuf, msgpack, got %q", c.CacheCodec)
+	v.Check(c.ProductServiceRps > 0, "product_service_rps must be positive, got %d", c.ProductServiceRps)
+	v.Check(c.WarmupLimit >= 0, "cache_warmup_limit must not be negative, got %d", c.WarmupLimit)
+	// без снапшота корзины на старте пустые и прогревать по ним нечего
+	v.Check(!c.WarmupFromCarts || c.SnapshotFile != "", "cache_warmup_from_carts requires cart_snapshot_file")
+	v.Check(c.StockHoldTTL >= time.Second, "stock_hold_ttl must be at least 1s, got %s", c.StockHoldTTL)
+	v.Check(c.CartMaxLineCount > 0, "cart_max_line_count must be positive")
+	v.Check(c.CartMaxSkus >= 0, "cart_max_skus must not be negative, got %d", c.CartMaxSkus)
+	v.Check(c.SnapshotPeriod > 0, "cart_snapshot_period must be positive, got %s", c.SnapshotPeriod)
+	v.Check(!c.SnapshotWAL || c.SnapshotFile != "", "cart_snapshot_wal requires cart_snapshot_file")
+	v.Check(c.HistoryRetention > 0, "cart_history_retention must be positive, got %s", c.HistoryRetention)
+	_, err := zapcore.ParseLevel(c.LogLevel)
+	v.Check(err == nil, "log_level must be one of debug, info, warn, error, got %q", c.LogLevel)
+	return v.Err()
 }
@@ -0,0 +1,142 @@
+package config
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfig(t *testing.T, path, data string) {
+	t.Helper()
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+}
+
+func TestLoad(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, `
+redis_db: 2
+cache_size: 500
+cache_default_ttl: 2m
+log_level: debug
+`)
+	t.Setenv("CACHE_SIZE", "700")
+
+	config, err := Load(path)
+	require.NoError(t, err)
+	assert.Equal(t, 2, config.RedisDB)
+	assert.Equal(t, 700, config.CacheSize)
+	assert.Equal(t, 2*time.Minute, config.CacheDefaultTTL)
+	assert.Equal(t, "debug", config.LogLevel)
+	assert.Equal(t, "cart", config.ServiceName)
+	assert.Equal(t, path, config.File)
+}
+
+func TestLoadErrors(t *testing.T) {
+	testData := []struct {
+		name string
+		file string
+		env  map[string]string
+		errs []string
+	}{
+		{
+			name: "unknown field",
+			file: "cache_sise: 10\n",
+			errs: []string{"field cache_sise not found"},
+		},
+		{
+			name: "invalid env",
+			env:  map[string]string{"CACHE_SIZE": "many", "REDIS_DB": "one"},
+			errs: []string{`invalid CACHE_SIZE="many"`, `invalid REDIS_DB="one"`},
+		},
+		{
+			name: "validation",
+			file: "cache_size: 0\ncache_codec: xml\nlog_level: loud\n",
+			errs: []string{"cache_size must be positive", `cache_codec must be one of json, protobuf, msgpack, got "xml"`, "log_level must be one of"},
+		},
+		{
+			name: "warmup from carts without snapshot",
+			file: "cache_warmup_from_carts: true\n",
+			errs: []string{"cache_warmup_from_carts requires cart_snapshot_file"},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "config.yaml")
+			writeConfig(t, path, tt.file)
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+
+			_, err := Load(path)
+			require.Error(t, err)
+			for _, msg := range tt.errs {
+				assert.ErrorContains(t, err, msg)
+			}
+		})
+	}
+}
+
+func TestDurationEnvSeconds(t *testing.T) {
+	t.Setenv("CACHE_DEFAULT_TTL", "90")
+
+	config, err := Load("")
+	require.NoError(t, err)
+	assert.Equal(t, 90*time.Second, config.CacheDefaultTTL)
+}
+
+func TestPrintRedacted(t *testing.T) {
+	config := Default()
+	config.RedisPassword = "secret-password"
+
+	var buf bytes.Buffer
+	require.NoError(t, config.Print(&buf))
+	assert.NotContains(t, buf.String(), "secret-password")
+	assert.NotContains(t, buf.String(), "testtoken")
+	assert.Contains(t, buf.String(), "redis_password: '***'")
+	assert.Contains(t, buf.String(), "cache_default_ttl: 1m0s")
+	assert.Equal(t, "secret-password", config.RedisPassword)
+}
+
+func TestWatcherReload(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	writeConfig(t, path, "cache_default_ttl: 1m\nredis_db: 1\n")
+	config, err := Load(path)
+	require.NoError(t, err)
+
+	watcher := NewWatcher(path, config)
+	var applied Config
+	watcher.OnReload(func(config Config) {
+		applied = config
+	})
+
+	reloaded, ignored, err := watcher.Reload()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+	assert.Empty(t, ignored)
+
+	writeConfig(t, path, "cache_default_ttl: 5m\nredis_db: 3\nproduct_service_rps: 20\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(time.Second)))
+
+	reloaded, ignored, err = watcher.Reload()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, []string{"redis_db"}, ignored)
+	assert.Equal(t, 5*time.Minute, applied.CacheDefaultTTL)
+	assert.Equal(t, 20, applied.ProductServiceRps)
+	assert.Equal(t, 1, applied.RedisDB)
+	assert.Equal(t, applied, watcher.Current())
+
+	// невалидный файл не применяется
+	writeConfig(t, path, "cache_default_ttl: 0s\n")
+	require.NoError(t, os.Chtimes(path, time.Now(), time.Now().Add(2*time.Second)))
+
+	_, _, err = watcher.Reload()
+	assert.Error(t, err)
+	assert.Equal(t, 5*time.Minute, watcher.Current().CacheDefaultTTL)
+}
@@ -0,0 +1,121 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"time"
+)
+
+// envReader переопределяет значения конфига переменными окружения и копит ошибки разбора,
+// чтобы при старте показать их все сразу.
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(name string) (string, bool) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func (e *envReader) fail(name, value string, err error) {
+	e.errs = append(e.errs, fmt.Errorf("config: invalid %s=%q: %w", name, value, err))
+}
+
+func (e *envReader) String(name string, dst *string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = value
+	}
+}
+
+func (e *envReader) Int(name string, dst *int) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	v, err := strconv.Atoi(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*dst = v
+}
+
+func (e *envReader) Uint16(name string, dst *uint16) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	v, err := strconv.ParseUint(value, 10, 16)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*dst = uint16(v)
+}
+
+func (e *envReader) Uint64(name string, dst *uint64) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	v, err := strconv.ParseUint(value, 10, 64)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*dst = v
+}
+
+func (e *envReader) Bool(name string, dst *bool) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	v, err := strconv.ParseBool(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*dst = v
+}
+
+// Duration принимает как строку вида 1m30s, так и целое число секунд.
+func (e *envReader) Duration(name string, dst *time.Duration) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		*dst = time.Duration(seconds) * time.Second
+		return
+	}
+	v, err := time.ParseDuration(value)
+	if err != nil {
+		e.fail(name, value, err)
+		return
+	}
+	*dst = v
+}
+
+func (e *envReader) Err() error {
+	return errors.Join(e.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) Check(ok bool, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("config: "+format, args...))
+	}
+}
+
+func (v *validator) Err() error {
+	return errors.Join(v.errs...)
+}
@@ -0,0 +1,34 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "***"
+
+// Redacted возвращает копию конфига со скрытыми секретами (поля с тегом secret).
+func (c Config) Redacted() Config {
+	v := reflect.ValueOf(&c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if t.Field(i).Tag.Get("secret") == "true" && field.Kind() == reflect.String && field.String() != "" {
+			field.SetString(redacted)
+		}
+	}
+	return c
+}
+
+// Print выводит итоговый конфиг в yaml без секретов.
+func (c Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
@@ -3,6 +3,8 @@ package customerror
 type ErrStatusCode struct {
 	msg    string
 	Status int
+	// Code - машиночитаемый код ошибки, отдается клиенту в теле ответа
+	Code string
 }
 
 func (e ErrStatusCode) Error() string {
@@ -10,5 +12,9 @@ func (e ErrStatusCode) Error() string {
 }
 
 func NewErrStatusCode(msg string, status int) ErrStatusCode {
-	return ErrStatusCode{msg, status}
+	return ErrStatusCode{msg: msg, Status: status}
+}
+
+func NewErrStatusCodeWithCode(msg string, status int, code string) ErrStatusCode {
+	return ErrStatusCode{msg: msg, Status: status, Code: code}
 }
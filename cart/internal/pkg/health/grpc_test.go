@@ -0,0 +1,40 @@
+package health
+
+import (
+	"context"
+	"net"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+func TestGrpcCheck(t *testing.T) {
+	listener := bufconn.Listen(1024 * 1024)
+	healthServer := health.NewServer()
+	server := grpc.NewServer()
+	healthpb.RegisterHealthServer(server, healthServer)
+	go server.Serve(listener)
+	defer server.Stop()
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	require.NoError(t, err)
+	defer conn.Close()
+	check := GrpcCheck(conn)
+
+	assert.NoError(t, check(context.Background()))
+
+	// сервис отвечает, но сам не готов
+	healthServer.SetServingStatus("", healthpb.HealthCheckResponse_NOT_SERVING)
+	assert.ErrorContains(t, check(context.Background()), "NOT_SERVING")
+}
@@ -0,0 +1,85 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestLiveness(t *testing.T) {
+	checker := NewChecker(time.Second)
+	checker.Add("redis", func(ctx context.Context) error {
+		return errors.New("connection refused")
+	})
+
+	w := httptest.NewRecorder()
+	checker.Liveness(w, httptest.NewRequest(http.MethodGet, "/healthz", nil))
+
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, `{"status":"ok"}`, w.Body.String())
+}
+
+func TestReadiness(t *testing.T) {
+	testData := []struct {
+		name     string
+		redisErr error
+		status   int
+		response Response
+	}{
+		{
+			name:   "all ok",
+			status: http.StatusOK,
+			response: Response{
+				Status: StatusOk,
+				Checks: map[string]string{"redis": StatusOk, "loms": StatusOk},
+			},
+		},
+		{
+			name:     "redis down",
+			redisErr: errors.New("connection refused"),
+			status:   http.StatusServiceUnavailable,
+			response: Response{
+				Status: StatusUnavailable,
+				Checks: map[string]string{"redis": "connection refused", "loms": StatusOk},
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			checker := NewChecker(time.Second)
+			checker.Add("redis", func(ctx context.Context) error {
+				return tt.redisErr
+			})
+			checker.Add("loms", func(ctx context.Context) error {
+				return nil
+			})
+
+			w := httptest.NewRecorder()
+			checker.Readiness(w, httptest.NewRequest(http.MethodGet, "/readyz", nil))
+
+			assert.Equal(t, tt.status, w.Code)
+			var response Response
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+			assert.Equal(t, tt.response, response)
+		})
+	}
+}
+
+func TestCheckTimeout(t *testing.T) {
+	checker := NewChecker(10 * time.Millisecond)
+	checker.Add("slow", func(ctx context.Context) error {
+		<-ctx.Done()
+		return ctx.Err()
+	})
+
+	result := checker.Check(context.Background())
+	assert.ErrorIs(t, result["slow"], context.DeadlineExceeded)
+}
@@ -0,0 +1,173 @@
+package loadgen
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// эндпоинты сценариев
+const (
+	EndpointAdd      = "add"
+	EndpointGet      = "get"
+	EndpointRemove   = "remove"
+	EndpointCheckout = "checkout"
+	EndpointPay      = "pay"
+	EndpointCancel   = "cancel"
+)
+
+var ErrInvalidWeights = errors.New("invalid weights")
+
+// Weights - доля каждого эндпоинта в нагрузке.
+type Weights map[string]int
+
+// ParseWeights разбирает строку вида add=5,get=10,checkout=1.
+func ParseWeights(raw string) (Weights, error) {
+	known := map[string]struct{}{
+		EndpointAdd: {}, EndpointGet: {}, EndpointRemove: {},
+		EndpointCheckout: {}, EndpointPay: {}, EndpointCancel: {},
+	}
+	weights := make(Weights)
+	for _, part := range strings.Split(raw, ",") {
+		name, value, ok := strings.Cut(strings.TrimSpace(part), "=")
+		if !ok {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWeights, part)
+		}
+		if _, ok := known[name]; !ok {
+			return nil, fmt.Errorf("%w: unknown endpoint %q", ErrInvalidWeights, name)
+		}
+		weight, err := strconv.Atoi(value)
+		if err != nil || weight < 0 {
+			return nil, fmt.Errorf("%w: %q", ErrInvalidWeights, part)
+		}
+		weights[name] = weight
+	}
+	return weights, nil
+}
+
+// orderPool хранит заказы, созданные checkout, для оплаты и отмены.
+type orderPool struct {
+	mx     sync.Mutex
+	orders []int64
+}
+
+func (p *orderPool) Put(orderId int64) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	p.orders = append(p.orders, orderId)
+}
+
+func (p *orderPool) Take(rnd *rand.Rand) (int64, bool) {
+	p.mx.Lock()
+	defer p.mx.Unlock()
+	if len(p.orders) == 0 {
+		return 0, false
+	}
+	i := rnd.Intn(len(p.orders))
+	orderId := p.orders[i]
+	p.orders[i] = p.orders[len(p.orders)-1]
+	p.orders = p.orders[:len(p.orders)-1]
+	return orderId, true
+}
+
+// Generator выдает запросы с постоянной частотой rate в секунду.
+type Generator struct {
+	rnd       *rand.Rand
+	endpoints []string
+	cumWeight []int
+	total     int
+	users     int
+	skus      []int64
+	interval  time.Duration
+	orders    *orderPool
+	seq       int64
+}
+
+func NewGenerator(weights Weights, rate float64, users int, skus []int64, seed int64) (*Generator, error) {
+	if rate <= 0 || users < 1 || len(skus) == 0 {
+		return nil, fmt.Errorf("rate, users and skus must be positive")
+	}
+	g := &Generator{
+		rnd:      rand.New(rand.NewSource(seed)),
+		users:    users,
+		skus:     skus,
+		interval: time.Duration(float64(time.Second) / rate),
+		orders:   &orderPool{},
+	}
+	endpoints := make([]string, 0, len(weights))
+	for name := range weights {
+		endpoints = append(endpoints, name)
+	}
+	// порядок важен для воспроизводимости с одним seed
+	sort.Strings(endpoints)
+	for _, name := range endpoints {
+		if weights[name] == 0 {
+			continue
+		}
+		g.total += weights[name]
+		g.endpoints = append(g.endpoints, name)
+		g.cumWeight = append(g.cumWeight, g.total)
+	}
+	if g.total == 0 {
+		return nil, fmt.Errorf("%w: all weights are zero", ErrInvalidWeights)
+	}
+	return g, nil
+}
+
+// Next возвращает следующий запрос; At растет на 1/rate независимо от ответов (open-loop).
+func (g *Generator) Next() (Request, error) {
+	at := time.Duration(g.seq) * g.interval
+	g.seq++
+
+	n := g.rnd.Intn(g.total)
+	endpoint := g.endpoints[sort.SearchInts(g.cumWeight, n+1)]
+	user := g.rnd.Intn(g.users) + 1
+	sku := g.skus[g.rnd.Intn(len(g.skus))]
+
+	if endpoint == EndpointPay || endpoint == EndpointCancel {
+		orderId, ok := g.orders.Take(g.rnd)
+		if !ok {
+			// оплачивать нечего - сначала создаем заказ
+			endpoint = EndpointCheckout
+		} else {
+			return g.request(at, endpoint, TargetLoms, http.MethodPost, "/v1/order_"+endpoint, map[string]int64{"order_id": orderId}), nil
+		}
+	}
+
+	switch endpoint {
+	case EndpointAdd:
+		return g.request(at, endpoint, TargetCart, http.MethodPost, fmt.Sprintf("/user/%d/cart/%d", user, sku), map[string]int{"count": g.rnd.Intn(3) + 1}), nil
+	case EndpointGet:
+		return g.request(at, endpoint, TargetCart, http.MethodGet, fmt.Sprintf("/user/%d/cart/list", user), nil), nil
+	case EndpointRemove:
+		return g.request(at, endpoint, TargetCart, http.MethodDelete, fmt.Sprintf("/user/%d/cart/%d", user, sku), nil), nil
+	default:
+		return g.request(at, EndpointCheckout, TargetCart, http.MethodPost, "/cart/checkout", map[string]int{"user": user}), nil
+	}
+}
+
+func (g *Generator) request(at time.Duration, endpoint string, target Target, method, path string, body any) Request {
+	req := Request{
+		At:       at,
+		Endpoint: endpoint,
+		Target:   target,
+		Method:   method,
+		Path:     path,
+	}
+	if body != nil {
+		req.Body, _ = json.Marshal(body)
+	}
+	return req
+}
+
+// OrderCreated добавляет заказ из ответа checkout в пул для pay и cancel.
+func (g *Generator) OrderCreated(orderId int64) {
+	g.orders.Put(orderId)
+}
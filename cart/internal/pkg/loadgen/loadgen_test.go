@@ -0,0 +1,244 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseWeights(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		raw     string
+		want    Weights
+		wantErr bool
+	}{
+		{
+			name: "valid",
+			raw:  "add=5, get=10,checkout=0",
+			want: Weights{EndpointAdd: 5, EndpointGet: 10, EndpointCheckout: 0},
+		},
+		{
+			name:    "unknown endpoint",
+			raw:     "add=1,buy=2",
+			wantErr: true,
+		},
+		{
+			name:    "negative",
+			raw:     "add=-1",
+			wantErr: true,
+		},
+		{
+			name:    "no value",
+			raw:     "add",
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := ParseWeights(tt.raw)
+			if tt.wantErr {
+				assert.ErrorIs(t, err, ErrInvalidWeights)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
+
+func TestGenerator(t *testing.T) {
+	t.Parallel()
+
+	weights := Weights{EndpointAdd: 3, EndpointGet: 1, EndpointPay: 1}
+	gen, err := NewGenerator(weights, 100, 10, []int64{1, 2, 3}, 42)
+	require.NoError(t, err)
+
+	counts := map[string]int{}
+	for i := 0; i < 4000; i++ {
+		req, err := gen.Next()
+		require.NoError(t, err)
+		assert.Equal(t, time.Duration(i)*10*time.Millisecond, req.At)
+		counts[req.Endpoint]++
+	}
+	// без созданных заказов pay превращается в checkout
+	assert.Zero(t, counts[EndpointPay])
+	assert.InDelta(t, 2400, counts[EndpointAdd], 150)
+	assert.InDelta(t, 800, counts[EndpointGet], 100)
+	assert.InDelta(t, 800, counts[EndpointCheckout], 100)
+
+	gen.OrderCreated(77)
+	var pay *Request
+	for i := 0; i < 100 && pay == nil; i++ {
+		req, err := gen.Next()
+		require.NoError(t, err)
+		if req.Endpoint == EndpointPay {
+			pay = &req
+		}
+	}
+	require.NotNil(t, pay)
+	assert.Equal(t, TargetLoms, pay.Target)
+	assert.Equal(t, "/v1/order_pay", pay.Path)
+	assert.JSONEq(t, `{"order_id":77}`, string(pay.Body))
+
+	// одинаковый seed - одинаковая последовательность
+	a, _ := NewGenerator(weights, 100, 10, []int64{1, 2, 3}, 7)
+	b, _ := NewGenerator(weights, 100, 10, []int64{1, 2, 3}, 7)
+	for i := 0; i < 100; i++ {
+		reqA, _ := a.Next()
+		reqB, _ := b.Next()
+		assert.Equal(t, reqA, reqB)
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+
+	latencies := make([]time.Duration, 100)
+	for i := range latencies {
+		latencies[i] = time.Duration(i+1) * time.Millisecond
+	}
+	assert.Equal(t, 50*time.Millisecond, percentile(latencies, 50))
+	assert.Equal(t, 99*time.Millisecond, percentile(latencies, 99))
+	assert.Equal(t, 100*time.Millisecond, percentile(latencies, 100))
+	assert.Equal(t, time.Millisecond, percentile(latencies[:1], 99))
+	assert.Zero(t, percentile(nil, 50))
+}
+
+func TestRecordReplay(t *testing.T) {
+	t.Parallel()
+
+	gen, err := NewGenerator(Weights{EndpointAdd: 1, EndpointGet: 1, EndpointRemove: 1}, 10, 5, []int64{1, 2}, 1)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	recorder := NewRecorder(&buf)
+	var want []Request
+	for i := 0; i < 20; i++ {
+		req, err := gen.Next()
+		require.NoError(t, err)
+		require.NoError(t, recorder.Record(req))
+		want = append(want, req)
+	}
+
+	replay := NewReplay(&buf, 2)
+	for _, w := range want {
+		got, err := replay.Next()
+		require.NoError(t, err)
+		w.At /= 2
+		assert.Equal(t, w, got)
+	}
+	_, err = replay.Next()
+	assert.ErrorIs(t, err, io.EOF)
+
+	_, err = NewReplay(bytes.NewBufferString(`{"endpoint":"add"}`), 1).Next()
+	assert.Error(t, err)
+}
+
+func TestRunner(t *testing.T) {
+	t.Parallel()
+
+	var (
+		mx    sync.Mutex
+		paths []string
+	)
+	cart := http.NewServeMux()
+	cart.HandleFunc("POST /cart/checkout", func(w http.ResponseWriter, r *http.Request) {
+		_ = json.NewEncoder(w).Encode(map[string]int64{"order_id": 5})
+	})
+	cart.HandleFunc("GET /user/{user_id}/cart/list", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusNotFound)
+	})
+	loms := http.NewServeMux()
+	loms.HandleFunc("POST /v1/order_pay", func(w http.ResponseWriter, r *http.Request) {
+		mx.Lock()
+		defer mx.Unlock()
+		body, _ := io.ReadAll(r.Body)
+		paths = append(paths, r.URL.Path+" "+string(body))
+	})
+	cartSrv := httptest.NewServer(cart)
+	defer cartSrv.Close()
+	lomsSrv := httptest.NewServer(loms)
+	defer lomsSrv.Close()
+
+	gen, err := NewGenerator(Weights{EndpointGet: 1, EndpointPay: 1}, 500, 3, []int64{1}, 3)
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	runner := NewRunner(cartSrv.URL, lomsSrv.URL, WithRecorder(NewRecorder(&buf)), WithOrderCallback(gen.OrderCreated))
+	report, err := runner.Run(context.Background(), gen, 200*time.Millisecond)
+	require.NoError(t, err)
+
+	byEndpoint := map[string]EndpointReport{}
+	total := 0
+	for _, e := range report.Endpoints {
+		byEndpoint[e.Endpoint] = e
+		total += e.Count
+	}
+	assert.Equal(t, 100, total)
+	assert.Equal(t, byEndpoint[EndpointGet].Count, byEndpoint[EndpointGet].Errors["status 404"])
+	assert.Empty(t, byEndpoint[EndpointCheckout].Errors)
+	assert.Positive(t, byEndpoint[EndpointPay].Count)
+	assert.LessOrEqual(t, byEndpoint[EndpointGet].P50, byEndpoint[EndpointGet].Max)
+
+	mx.Lock()
+	assert.Contains(t, paths, `/v1/order_pay {"order_id":5}`)
+	mx.Unlock()
+
+	replayed, err := NewRunner(cartSrv.URL, lomsSrv.URL).Run(context.Background(), NewReplay(&buf, 10), 0)
+	require.NoError(t, err)
+	replayedTotal := 0
+	for _, e := range replayed.Endpoints {
+		replayedTotal += e.Count
+	}
+	assert.Equal(t, 100, replayedTotal)
+}
+
+type sliceSource []Request
+
+func (s *sliceSource) Next() (Request, error) {
+	if len(*s) == 0 {
+		return Request{}, io.EOF
+	}
+	req := (*s)[0]
+	*s = (*s)[1:]
+	return req, nil
+}
+
+func TestRunnerCancel(t *testing.T) {
+	t.Parallel()
+
+	release := make(chan struct{})
+	defer close(release)
+	cart := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		select {
+		case <-release:
+		case <-r.Context().Done():
+		}
+	}))
+	defer cart.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	source := &sliceSource{{Endpoint: EndpointGet, Target: TargetCart, Method: http.MethodGet, Path: "/user/1/cart/list"}}
+	time.AfterFunc(100*time.Millisecond, cancel)
+
+	// отмена прерывает запрос в полете, не дожидаясь таймаута клиента
+	start := time.Now()
+	report, err := NewRunner(cart.URL, cart.URL, WithTimeout(time.Minute)).Run(ctx, source, 0)
+	require.NoError(t, err)
+	assert.Less(t, time.Since(start), 10*time.Second)
+	require.Len(t, report.Endpoints, 1)
+	assert.Equal(t, 1, report.Endpoints[0].Errors["canceled"])
+}
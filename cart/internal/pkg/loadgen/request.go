@@ -0,0 +1,88 @@
+package loadgen
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+)
+
+type Target string
+
+const (
+	TargetCart Target = "cart"
+	TargetLoms Target = "loms"
+)
+
+// Request - один запрос нагрузки. At - смещение от начала запуска, когда запрос должен уйти.
+type Request struct {
+	At       time.Duration   `json:"at"`
+	Endpoint string          `json:"endpoint"`
+	Target   Target          `json:"target"`
+	Method   string          `json:"method"`
+	Path     string          `json:"path"`
+	Body     json.RawMessage `json:"body,omitempty"`
+}
+
+// Recorder пишет запросы в JSONL, по одному на строку.
+type Recorder struct {
+	mx      sync.Mutex
+	encoder *json.Encoder
+}
+
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{encoder: json.NewEncoder(w)}
+}
+
+func (r *Recorder) Record(req Request) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	if err := r.encoder.Encode(req); err != nil {
+		return fmt.Errorf("r.encoder.Encode: %w", err)
+	}
+	return nil
+}
+
+// Replay читает записанные запросы. speed > 1 ускоряет воспроизведение.
+type Replay struct {
+	scanner *bufio.Scanner
+	speed   float64
+	line    int
+}
+
+func NewReplay(r io.Reader, speed float64) *Replay {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	if speed <= 0 {
+		speed = 1
+	}
+	return &Replay{scanner: scanner, speed: speed}
+}
+
+// Next возвращает io.EOF, когда запросы закончились.
+func (r *Replay) Next() (Request, error) {
+	for r.scanner.Scan() {
+		r.line++
+		if len(r.scanner.Bytes()) == 0 {
+			continue
+		}
+		var req Request
+		if err := json.Unmarshal(r.scanner.Bytes(), &req); err != nil {
+			return Request{}, fmt.Errorf("line %d: json.Unmarshal: %w", r.line, err)
+		}
+		if req.Endpoint == "" || req.Method == "" || req.Path == "" {
+			return Request{}, fmt.Errorf("line %d: %w", r.line, errInvalidRequest)
+		}
+		req.At = time.Duration(float64(req.At) / r.speed)
+		return req, nil
+	}
+	if err := r.scanner.Err(); err != nil {
+		return Request{}, fmt.Errorf("r.scanner.Err: %w", err)
+	}
+	return Request{}, io.EOF
+}
+
+var errInvalidRequest = errors.New("request must have endpoint, method and path")
@@ -0,0 +1,199 @@
+package loadgen
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Source - источник запросов: генератор или запись. io.EOF завершает запуск.
+type Source interface {
+	Next() (Request, error)
+}
+
+type Runner struct {
+	client      *http.Client
+	urls        map[Target]string
+	stats       *Stats
+	recorder    *Recorder
+	onOrder     func(orderId int64)
+	maxInFlight int
+}
+
+type Option interface {
+	apply(*Runner)
+}
+
+type optionFn func(*Runner)
+
+func (fn optionFn) apply(r *Runner) {
+	fn(r)
+}
+
+func WithRecorder(recorder *Recorder) Option {
+	return optionFn(func(r *Runner) {
+		r.recorder = recorder
+	})
+}
+
+// WithOrderCallback вызывается с order_id из успешного ответа checkout.
+func WithOrderCallback(fn func(orderId int64)) Option {
+	return optionFn(func(r *Runner) {
+		r.onOrder = fn
+	})
+}
+
+func WithMaxInFlight(n int) Option {
+	return optionFn(func(r *Runner) {
+		if n > 0 {
+			r.maxInFlight = n
+		}
+	})
+}
+
+func WithTimeout(timeout time.Duration) Option {
+	return optionFn(func(r *Runner) {
+		r.client.Timeout = timeout
+	})
+}
+
+func NewRunner(cartUrl, lomsUrl string, opts ...Option) *Runner {
+	r := &Runner{
+		client: &http.Client{Timeout: 5 * time.Second},
+		urls: map[Target]string{
+			TargetCart: strings.TrimRight(cartUrl, "/"),
+			TargetLoms: strings.TrimRight(lomsUrl, "/"),
+		},
+		stats:       NewStats(),
+		maxInFlight: 1000,
+	}
+	for _, opt := range opts {
+		opt.apply(r)
+	}
+	return r
+}
+
+// Run отправляет запросы в момент start+At, не дожидаясь ответов на предыдущие.
+// Задержка считается от запланированного времени, чтобы не скрывать очередь на стороне клиента.
+func (r *Runner) Run(ctx context.Context, source Source, duration time.Duration) (Report, error) {
+	var (
+		wg       sync.WaitGroup
+		inFlight = make(chan struct{}, r.maxInFlight)
+		start    = time.Now()
+		runErr   error
+	)
+
+	timer := time.NewTimer(0)
+	defer timer.Stop()
+	<-timer.C
+
+loop:
+	for {
+		req, err := source.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			runErr = fmt.Errorf("source.Next: %w", err)
+			break
+		}
+		if duration > 0 && req.At >= duration {
+			break
+		}
+
+		timer.Reset(time.Until(start.Add(req.At)))
+		select {
+		case <-ctx.Done():
+			break loop
+		case <-timer.C:
+		}
+
+		if r.recorder != nil {
+			if err := r.recorder.Record(req); err != nil {
+				runErr = fmt.Errorf("r.recorder.Record: %w", err)
+				break
+			}
+		}
+
+		select {
+		case inFlight <- struct{}{}:
+		default:
+			r.stats.Drop()
+			continue
+		}
+		wg.Add(1)
+		go func(req Request, scheduled time.Time) {
+			defer func() {
+				<-inFlight
+				wg.Done()
+			}()
+			r.do(ctx, req, scheduled)
+		}(req, start.Add(req.At))
+	}
+
+	wg.Wait()
+	return r.stats.Report(time.Since(start)), runErr
+}
+
+// do отправляет запрос с контекстом запуска: отмена Run прерывает запросы в полете.
+func (r *Runner) do(ctx context.Context, req Request, scheduled time.Time) {
+	httpReq, err := http.NewRequestWithContext(ctx, req.Method, r.urls[req.Target]+req.Path, bytes.NewReader(req.Body))
+	if err != nil {
+		r.stats.Observe(req.Endpoint, 0, "bad request")
+		return
+	}
+	if len(req.Body) > 0 {
+		httpReq.Header.Set("Content-Type", "application/json")
+	}
+
+	res, err := r.client.Do(httpReq)
+	if err != nil {
+		r.stats.Observe(req.Endpoint, time.Since(scheduled), errorKind(err))
+		return
+	}
+	defer res.Body.Close()
+	body, err := io.ReadAll(res.Body)
+	latency := time.Since(scheduled)
+	if err != nil {
+		r.stats.Observe(req.Endpoint, latency, errorKind(err))
+		return
+	}
+	if res.StatusCode >= http.StatusBadRequest {
+		r.stats.Observe(req.Endpoint, latency, fmt.Sprintf("status %d", res.StatusCode))
+		return
+	}
+	r.stats.Observe(req.Endpoint, latency, "")
+
+	if req.Endpoint == EndpointCheckout && r.onOrder != nil {
+		var checkout struct {
+			OrderId int64 `json:"order_id"`
+		}
+		if err := json.Unmarshal(body, &checkout); err == nil && checkout.OrderId > 0 {
+			r.onOrder(checkout.OrderId)
+		}
+	}
+}
+
+func errorKind(err error) string {
+	var netErr net.Error
+	switch {
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return "timeout"
+	case errors.Is(err, context.Canceled):
+		return "canceled"
+	default:
+		var opErr *net.OpError
+		if errors.As(err, &opErr) {
+			return "network: " + opErr.Op
+		}
+		return "transport"
+	}
+}
@@ -0,0 +1,147 @@
+package loadgen
+
+import (
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+type endpointStats struct {
+	latencies []time.Duration
+	errors    map[string]int
+}
+
+// Stats собирает задержки и ошибки по эндпоинтам.
+type Stats struct {
+	mx        sync.Mutex
+	endpoints map[string]*endpointStats
+	dropped   int
+}
+
+func NewStats() *Stats {
+	return &Stats{endpoints: make(map[string]*endpointStats)}
+}
+
+// Observe учитывает ответ; errKind пустой для успешного запроса.
+func (s *Stats) Observe(endpoint string, latency time.Duration, errKind string) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	es, ok := s.endpoints[endpoint]
+	if !ok {
+		es = &endpointStats{errors: make(map[string]int)}
+		s.endpoints[endpoint] = es
+	}
+	es.latencies = append(es.latencies, latency)
+	if errKind != "" {
+		es.errors[errKind]++
+	}
+}
+
+// Drop учитывает запрос, который не ушел из-за лимита одновременных запросов.
+func (s *Stats) Drop() {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	s.dropped++
+}
+
+type EndpointReport struct {
+	Endpoint string
+	Count    int
+	Errors   map[string]int
+	P50      time.Duration
+	P90      time.Duration
+	P99      time.Duration
+	Max      time.Duration
+}
+
+type Report struct {
+	Endpoints []EndpointReport
+	Dropped   int
+	Elapsed   time.Duration
+}
+
+func (s *Stats) Report(elapsed time.Duration) Report {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	report := Report{
+		Endpoints: make([]EndpointReport, 0, len(s.endpoints)),
+		Dropped:   s.dropped,
+		Elapsed:   elapsed,
+	}
+	for endpoint, es := range s.endpoints {
+		latencies := make([]time.Duration, len(es.latencies))
+		copy(latencies, es.latencies)
+		sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+		errors := make(map[string]int, len(es.errors))
+		for kind, count := range es.errors {
+			errors[kind] = count
+		}
+		report.Endpoints = append(report.Endpoints, EndpointReport{
+			Endpoint: endpoint,
+			Count:    len(latencies),
+			Errors:   errors,
+			P50:      percentile(latencies, 50),
+			P90:      percentile(latencies, 90),
+			P99:      percentile(latencies, 99),
+			Max:      percentile(latencies, 100),
+		})
+	}
+	sort.Slice(report.Endpoints, func(i, j int) bool {
+		return report.Endpoints[i].Endpoint < report.Endpoints[j].Endpoint
+	})
+	return report
+}
+
+// percentile по отсортированному срезу, метод nearest-rank.
+func percentile(sorted []time.Duration, p int) time.Duration {
+	if len(sorted) == 0 {
+		return 0
+	}
+	rank := (p*len(sorted) + 99) / 100
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+func (r Report) Print(w io.Writer) error {
+	tw := tabwriter.NewWriter(w, 0, 0, 2, ' ', tabwriter.AlignRight)
+	fmt.Fprintln(tw, "endpoint\tcount\terrors\trps\tp50\tp90\tp99\tmax\t")
+	for _, e := range r.Endpoints {
+		errCount := 0
+		for _, count := range e.Errors {
+			errCount += count
+		}
+		rps := 0.0
+		if r.Elapsed > 0 {
+			rps = float64(e.Count) / r.Elapsed.Seconds()
+		}
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%.1f\t%s\t%s\t%s\t%s\t\n",
+			e.Endpoint, e.Count, errCount, rps,
+			e.P50.Round(time.Microsecond), e.P90.Round(time.Microsecond),
+			e.P99.Round(time.Microsecond), e.Max.Round(time.Microsecond))
+	}
+	if err := tw.Flush(); err != nil {
+		return fmt.Errorf("tw.Flush: %w", err)
+	}
+
+	fmt.Fprintf(w, "\ndropped: %d\n", r.Dropped)
+	for _, e := range r.Endpoints {
+		if len(e.Errors) == 0 {
+			continue
+		}
+		kinds := make([]string, 0, len(e.Errors))
+		for kind := range e.Errors {
+			kinds = append(kinds, kind)
+		}
+		sort.Strings(kinds)
+		fmt.Fprintf(w, "%s errors:\n", e.Endpoint)
+		for _, kind := range kinds {
+			fmt.Fprintf(w, "  %s: %d\n", kind, e.Errors[kind])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package middleware
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+)
+
+// AdminTokenWrapper пропускает запрос, только если в заголовке Authorization
+// передан токен "Bearer <token>".
+type AdminTokenWrapper struct {
+	Token string
+	Wrap  http.Handler
+}
+
+func (h AdminTokenWrapper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+	if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(h.Token)) != 1 {
+		w.Header().Add("Content-Type", "application/json")
+		w.WriteHeader(http.StatusUnauthorized)
+		w.Write([]byte("{}"))
+		return
+	}
+	h.Wrap.ServeHTTP(w, r)
+}
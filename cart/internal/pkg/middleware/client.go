@@ -1,13 +1,13 @@
 package middleware
 
 import (
+	"bytes"
 	"context"
+	"fmt"
 	"io"
 	"net/http"
 	"route256/cart/pkg/tracing"
 	"strconv"
-
-	"go.opentelemetry.io/otel/codes"
 )
 
 type RetryClient struct {
@@ -19,28 +19,42 @@ func NewRetryClient() *RetryClient {
 }
 
 func (rc *RetryClient) Post(ctx context.Context, url string, contentType string, body io.Reader) (resp *http.Response, err error) {
-	_, span := tracing.Start(ctx, "RetryClient.Post")
-	defer span.End()
-	defer func() {
-		span.AddEvent("StatusCode: " + strconv.Itoa(resp.StatusCode))
-	}()
+	ctx, span := tracing.Start(ctx, "RetryClient.Post")
+	defer tracing.EndWithCheckError(span, &err)
+
+	// тело читаем один раз, чтобы отправлять его заново при повторах
+	var data []byte
+	if body != nil {
+		data, err = io.ReadAll(body)
+		if err != nil {
+			return nil, fmt.Errorf("io.ReadAll: %w", err)
+		}
+	}
 
 	attempt := 0
 	for {
-		if attempt > 3 {
-			return
-		}
 		attempt++
-		span.AddEvent("Attempt: " + strconv.Itoa(attempt))
-		resp, err = rc.Client.Post(url, contentType, body)
+		if span != nil {
+			span.AddEvent("Attempt: " + strconv.Itoa(attempt))
+		}
+
+		req, err := http.NewRequestWithContext(ctx, http.MethodPost, url, bytes.NewReader(data))
+		if err != nil {
+			return nil, fmt.Errorf("http.NewRequestWithContext: %w", err)
+		}
+		req.Header.Set("Content-Type", contentType)
+
+		resp, err = rc.Client.Do(req)
 		if err != nil {
-			span.SetStatus(codes.Error, err.Error())
-			span.RecordError(err)
-			return
+			return nil, fmt.Errorf("rc.Client.Do: %w", err)
+		}
+		if span != nil {
+			span.AddEvent("StatusCode: " + strconv.Itoa(resp.StatusCode))
 		}
-		if resp.StatusCode == http.StatusTooManyRequests || resp.StatusCode == 420 {
-			continue
+		if attempt > 3 || (resp.StatusCode != http.StatusTooManyRequests && resp.StatusCode != 420) {
+			return resp, nil
 		}
-		return
+		io.Copy(io.Discard, resp.Body)
+		resp.Body.Close()
 	}
 }
@@ -1,12 +1,18 @@
 package middleware
 
 import (
+	"encoding/json"
 	"errors"
 	"net/http"
 	"route256/cart/internal/pkg/customerror"
 	"route256/cart/pkg/logger"
 )
 
+type ErrorResponse struct {
+	Code    string `json:"code"`
+	Message string `json:"message"`
+}
+
 type ErrorWrapper func(w http.ResponseWriter, r *http.Request) error
 
 func (h ErrorWrapper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
@@ -14,11 +20,20 @@ func (h ErrorWrapper) ServeHTTP(w http.ResponseWriter, r *http.Request) {
 		logger.Errorw(r.Context(), "Handle error", "method", r.Method, "url", r.URL.Path, "err", err)
 
 		var errStatusCode customerror.ErrStatusCode
-		if errors.As(err, &errStatusCode) {
-			w.WriteHeader(errStatusCode.Status)
-		} else {
+		if !errors.As(err, &errStatusCode) {
 			w.WriteHeader(http.StatusBadRequest)
+			w.Write([]byte("{}"))
+			return
+		}
+		w.WriteHeader(errStatusCode.Status)
+		if errStatusCode.Code == "" {
+			w.Write([]byte("{}"))
+			return
 		}
-		w.Write([]byte("{}"))
+		data, _ := json.Marshal(ErrorResponse{
+			Code:    errStatusCode.Code,
+			Message: errStatusCode.Error(),
+		})
+		w.Write(data)
 	}
 }
@@ -4,6 +4,53 @@ import "sync"
 
 type Cart map[ProductSku]uint16
 
+// CartItem - строка корзины с ценой, которую пользователь видел при добавлении товара.
+type CartItem struct {
+	Count uint16
+	Price uint32
+}
+
+type CartItems map[ProductSku]CartItem
+
+type CartWarningType string
+
+const (
+	CartWarningPriceChanged   CartWarningType = "price_changed"
+	CartWarningNotEnoughStock CartWarningType = "not_enough_stock"
+)
+
+// CartWarning для price_changed содержит старую и новую цену,
+// для not_enough_stock - количество в корзине и доступный остаток.
+type CartWarning struct {
+	Type CartWarningType
+	Old  uint64
+	New  uint64
+}
+
+type UnavailableReason string
+
+const (
+	UnavailableReasonNotFound      UnavailableReason = "not_found"
+	UnavailableReasonTimeout       UnavailableReason = "timeout"
+	UnavailableReasonUpstreamError UnavailableReason = "upstream_error"
+)
+
+type CartUnavailableItem struct {
+	Count  uint16
+	Reason UnavailableReason
+}
+
+type CartView struct {
+	Items       CartFull
+	Warnings    map[ProductSku][]CartWarning
+	Unavailable map[ProductSku]CartUnavailableItem
+}
+
+// Degraded - не все товары корзины удалось загрузить.
+func (c CartView) Degraded() bool {
+	return len(c.Unavailable) > 0
+}
+
 type CartFull map[Product]uint16
 
 type CartFullMx struct {
@@ -0,0 +1,40 @@
+package model
+
+import "time"
+
+type CartEventAction string
+
+const (
+	CartEventAdd    CartEventAction = "add"
+	CartEventRemove CartEventAction = "remove"
+	CartEventClear  CartEventAction = "clear"
+)
+
+// CartEventSource - откуда пришло изменение корзины.
+type CartEventSource string
+
+const (
+	CartEventSourceUser     CartEventSource = "user"
+	CartEventSourceCheckout CartEventSource = "checkout"
+)
+
+// CartEvent - запись журнала изменений корзины. Delta отрицательная при удалении товара.
+type CartEvent struct {
+	ID      int64
+	Time    time.Time
+	User    UserId
+	Sku     ProductSku
+	Delta   int32
+	Action  CartEventAction
+	Source  CartEventSource
+	TraceID string
+}
+
+// CartHistoryFilter выбирает события в полуинтервале [From, To) после события AfterID.
+// Нулевые From и To не ограничивают выборку.
+type CartHistoryFilter struct {
+	From    time.Time
+	To      time.Time
+	AfterID int64
+	Limit   int
+}
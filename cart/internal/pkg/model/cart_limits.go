@@ -0,0 +1,19 @@
+package model
+
+import "math"
+
+// CartLimits - ограничения корзины. Нулевые MaxSkus и MaxValue означают отсутствие ограничения.
+type CartLimits struct {
+	// MaxLineCount - максимальное количество единиц одного товара
+	MaxLineCount uint16
+	// MaxSkus - максимальное количество разных товаров в корзине
+	MaxSkus int
+	// MaxValue - максимальная стоимость корзины
+	MaxValue uint64
+}
+
+func DefaultCartLimits() CartLimits {
+	return CartLimits{
+		MaxLineCount: math.MaxUint16,
+	}
+}
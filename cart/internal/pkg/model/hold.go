@@ -0,0 +1,3 @@
+package model
+
+type HoldId int64
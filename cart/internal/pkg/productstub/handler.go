@@ -0,0 +1,132 @@
+package productstub
+
+import (
+	"encoding/json"
+	"math/rand"
+	"net/http"
+	"route256/cart/internal/pkg/service/product/catalog"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+type GetProductRequest struct {
+	Token string `json:"token"`
+	Sku   uint32 `json:"sku"`
+}
+
+type GetProductResponse struct {
+	Name  string `json:"name"`
+	Price uint32 `json:"price"`
+}
+
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+// Handler имитирует ProductService: отвечает на POST /get_product по каталогу товаров,
+// проверяет токен и по настройкам добавляет задержку, 500-е ошибки и 420/429 ответы.
+type Handler struct {
+	catalog         catalog.Catalog
+	token           string
+	latency         time.Duration
+	errorRate       float64
+	rateLimitRate   float64
+	rateLimitFirst  int
+	rateLimitStatus int
+	seed            int64
+
+	rnd      *rand.Rand
+	rndMx    sync.Mutex
+	requests atomic.Int64
+	mux      *http.ServeMux
+}
+
+func NewHandler(products catalog.Catalog, opts ...Option) *Handler {
+	h := &Handler{
+		catalog:         products,
+		token:           "testtoken",
+		rateLimitStatus: http.StatusTooManyRequests,
+		seed:            time.Now().UnixNano(),
+	}
+	for _, opt := range opts {
+		opt.Apply(h)
+	}
+	h.rnd = rand.New(rand.NewSource(h.seed))
+
+	h.mux = http.NewServeMux()
+	h.mux.HandleFunc("POST /get_product", h.GetProduct)
+	return h
+}
+
+func (h *Handler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	h.mux.ServeHTTP(w, r)
+}
+
+// Requests возвращает количество запросов к /get_product, включая неуспешные.
+func (h *Handler) Requests() int64 {
+	return h.requests.Load()
+}
+
+func (h *Handler) GetProduct(w http.ResponseWriter, r *http.Request) {
+	n := h.requests.Add(1)
+	w.Header().Add("Content-Type", "application/json")
+
+	if h.latency > 0 {
+		select {
+		case <-time.After(h.latency):
+		case <-r.Context().Done():
+			return
+		}
+	}
+
+	if n <= int64(h.rateLimitFirst) || h.roll(h.rateLimitRate) {
+		writeError(w, h.rateLimitStatus, 8, "too many requests")
+		return
+	}
+	if h.roll(h.errorRate) {
+		writeError(w, http.StatusInternalServerError, 13, "internal error")
+		return
+	}
+
+	var req GetProductRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		writeError(w, http.StatusBadRequest, 3, "invalid request: "+err.Error())
+		return
+	}
+	if req.Token != h.token {
+		writeError(w, http.StatusUnauthorized, 16, "invalid token")
+		return
+	}
+	product, ok := h.catalog[req.Sku]
+	if !ok {
+		writeError(w, http.StatusNotFound, 5, "sku not found")
+		return
+	}
+
+	data, err := json.Marshal(GetProductResponse{
+		Name:  product.Name,
+		Price: product.Price,
+	})
+	if err != nil {
+		writeError(w, http.StatusInternalServerError, 13, err.Error())
+		return
+	}
+	w.Write(data)
+}
+
+func (h *Handler) roll(rate float64) bool {
+	if rate <= 0 {
+		return false
+	}
+	h.rndMx.Lock()
+	defer h.rndMx.Unlock()
+	return h.rnd.Float64() < rate
+}
+
+func writeError(w http.ResponseWriter, status int, code int, message string) {
+	data, _ := json.Marshal(ErrorResponse{Code: code, Message: message})
+	w.WriteHeader(status)
+	w.Write(data)
+}
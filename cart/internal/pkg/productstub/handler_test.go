@@ -0,0 +1,150 @@
+package productstub_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"route256/cart/internal/pkg/config"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/productstub"
+	"route256/cart/internal/pkg/service/product"
+	"route256/cart/internal/pkg/service/product/catalog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testCatalog = catalog.Catalog{
+	1076963: {Sku: 1076963, Name: "Теория нравственных чувств | Смит Адам", Price: 3379},
+	1148162: {Sku: 1148162, Name: "Кулинар Гуров", Price: 2567},
+}
+
+func newProductService(t *testing.T, handler http.Handler, token string) *product.ProductService {
+	srv := httptest.NewServer(handler)
+	t.Cleanup(srv.Close)
+	return product.NewProductService(config.Config{
+		ProductServiceUrl:   srv.URL,
+		ProductServiceToken: token,
+	})
+}
+
+func TestHandler(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+
+	testData := []struct {
+		name     string
+		opts     []productstub.Option
+		token    string
+		sku      model.ProductSku
+		product  *model.Product
+		err      bool
+		requests int64
+	}{
+		{
+			name:     "ok",
+			token:    "testtoken",
+			sku:      1076963,
+			product:  &model.Product{Sku: 1076963, Name: "Теория нравственных чувств | Смит Адам", Price: 3379},
+			requests: 1,
+		},
+		{
+			name:     "unknown sku",
+			token:    "testtoken",
+			sku:      1,
+			err:      true,
+			requests: 1,
+		},
+		{
+			name:     "invalid token",
+			token:    "wrong",
+			sku:      1076963,
+			err:      true,
+			requests: 1,
+		},
+		{
+			name:     "custom token",
+			opts:     []productstub.Option{productstub.WithToken("secret")},
+			token:    "secret",
+			sku:      1148162,
+			product:  &model.Product{Sku: 1148162, Name: "Кулинар Гуров", Price: 2567},
+			requests: 1,
+		},
+		{
+			name:     "retry after 420",
+			opts:     []productstub.Option{productstub.WithRateLimitFirst(2, 420)},
+			token:    "testtoken",
+			sku:      1148162,
+			product:  &model.Product{Sku: 1148162, Name: "Кулинар Гуров", Price: 2567},
+			requests: 3,
+		},
+		{
+			name:     "retry after 429",
+			opts:     []productstub.Option{productstub.WithRateLimitFirst(3, http.StatusTooManyRequests)},
+			token:    "testtoken",
+			sku:      1148162,
+			product:  &model.Product{Sku: 1148162, Name: "Кулинар Гуров", Price: 2567},
+			requests: 4,
+		},
+		{
+			name:     "retries exhausted",
+			opts:     []productstub.Option{productstub.WithRateLimitRate(1, http.StatusTooManyRequests)},
+			token:    "testtoken",
+			sku:      1148162,
+			err:      true,
+			requests: 4,
+		},
+		{
+			name:     "internal error",
+			opts:     []productstub.Option{productstub.WithErrorRate(1)},
+			token:    "testtoken",
+			sku:      1148162,
+			err:      true,
+			requests: 1,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			handler := productstub.NewHandler(testCatalog, tt.opts...)
+			productService := newProductService(t, handler, tt.token)
+
+			product, err := productService.GetProduct(ctx, tt.sku)
+			if tt.err {
+				require.Error(t, err)
+			} else {
+				require.NoError(t, err)
+				require.Equal(t, tt.product, product)
+			}
+			require.Equal(t, tt.requests, handler.Requests())
+		})
+	}
+}
+
+func TestHandlerSeed(t *testing.T) {
+	t.Parallel()
+
+	statuses := func() []int {
+		handler := productstub.NewHandler(testCatalog,
+			productstub.WithErrorRate(0.5),
+			productstub.WithSeed(42),
+		)
+		res := make([]int, 0, 20)
+		for range 20 {
+			w := httptest.NewRecorder()
+			r := httptest.NewRequest(http.MethodPost, "/get_product", strings.NewReader(`{"token":"testtoken","sku":1076963}`))
+			handler.ServeHTTP(w, r)
+			res = append(res, w.Code)
+		}
+		return res
+	}
+
+	first := statuses()
+	require.Equal(t, first, statuses())
+	require.Contains(t, first, http.StatusOK)
+	require.Contains(t, first, http.StatusInternalServerError)
+}
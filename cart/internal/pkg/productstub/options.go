@@ -0,0 +1,55 @@
+package productstub
+
+import "time"
+
+type Option interface {
+	Apply(*Handler)
+}
+
+type optionFn func(*Handler)
+
+func (fn optionFn) Apply(h *Handler) {
+	fn(h)
+}
+
+func WithToken(token string) Option {
+	return optionFn(func(h *Handler) {
+		h.token = token
+	})
+}
+
+func WithLatency(latency time.Duration) Option {
+	return optionFn(func(h *Handler) {
+		h.latency = latency
+	})
+}
+
+// WithErrorRate задает долю запросов (от 0 до 1), на которые отвечаем 500.
+func WithErrorRate(rate float64) Option {
+	return optionFn(func(h *Handler) {
+		h.errorRate = rate
+	})
+}
+
+// WithRateLimitRate задает долю запросов (от 0 до 1), на которые отвечаем status (420 или 429).
+func WithRateLimitRate(rate float64, status int) Option {
+	return optionFn(func(h *Handler) {
+		h.rateLimitRate = rate
+		h.rateLimitStatus = status
+	})
+}
+
+// WithRateLimitFirst отвечает status на первые n запросов, независимо от rate.
+func WithRateLimitFirst(n int, status int) Option {
+	return optionFn(func(h *Handler) {
+		h.rateLimitFirst = n
+		h.rateLimitStatus = status
+	})
+}
+
+// WithSeed фиксирует генератор случайных чисел, чтобы ошибки приходили в одном и том же порядке.
+func WithSeed(seed int64) Option {
+	return optionFn(func(h *Handler) {
+		h.seed = seed
+	})
+}
@@ -2,35 +2,84 @@ package repository
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
 	"route256/cart/internal/pkg/model"
 	"route256/cart/internal/pkg/utils/metrics"
 	"route256/cart/pkg/tracing"
+	"sort"
 	"sync"
+	"sync/atomic"
 )
 
-type Storage map[model.UserId]model.Cart
+const defaultShardsCount = 64
+
+var ErrCountOverflow = errors.New("product count overflow")
+
+type Storage map[model.UserId]model.CartItems
+
+// shard хранит корзины части пользователей под своей блокировкой,
+// чтобы запросы разных пользователей не ждали друг друга.
+type shard struct {
+	mx    sync.RWMutex
+	carts Storage
+}
 
 type CartMemoryRepository struct {
-	mx      sync.RWMutex
-	storage Storage
+	shardsCount int
+	shards      []*shard
+	// amount - количество товаров во всех корзинах, поддерживается при каждом изменении
+	amount atomic.Int64
+	// seq - номер последнего изменения, по нему снапшот сопоставляется с журналом
+	seq atomic.Uint64
+	wal *WAL
+}
+
+func NewCartMemoryRepository(opts ...Option) *CartMemoryRepository {
+	r := &CartMemoryRepository{
+		shardsCount: defaultShardsCount,
+	}
+	for _, opt := range opts {
+		opt.Apply(r)
+	}
+	r.shards = make([]*shard, r.shardsCount)
+	for i := range r.shards {
+		r.shards[i] = &shard{carts: make(Storage)}
+	}
+	return r
+}
+
+func (r *CartMemoryRepository) shard(userId model.UserId) *shard {
+	return r.shards[uint64(userId)%uint64(len(r.shards))]
+}
+
+// lockAll блокирует все шарды для операций над хранилищем целиком.
+func (r *CartMemoryRepository) lockAll() {
+	for _, sh := range r.shards {
+		sh.mx.Lock()
+	}
 }
 
-func NewCartMemoryRepository() *CartMemoryRepository {
-	return &CartMemoryRepository{
-		storage: make(Storage),
+func (r *CartMemoryRepository) unlockAll() {
+	for _, sh := range r.shards {
+		sh.mx.Unlock()
 	}
 }
 
-func (r *CartMemoryRepository) AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) error {
+func (r *CartMemoryRepository) AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) error {
 	_, span := tracing.Start(ctx, "CartMemoryRepository.AddProduct")
 	defer span.End()
 
-	r.mx.Lock()
-	defer r.mx.Unlock()
-	if _, ok := r.storage[userId]; !ok {
-		r.storage[userId] = make(model.Cart)
+	sh := r.shard(userId)
+	sh.mx.Lock()
+	defer sh.mx.Unlock()
+	if uint32(sh.carts[userId][ProductSku].Count)+uint32(count) > math.MaxUint16 {
+		return ErrCountOverflow
+	}
+	if err := r.commit(sh, walRecord{Op: walOpAdd, User: userId, Sku: ProductSku, Count: count, Price: price}); err != nil {
+		return err
 	}
-	r.storage[userId][ProductSku] += count
 	r.SendMetrics()
 	return nil
 }
@@ -39,12 +88,15 @@ func (r *CartMemoryRepository) RemoveProduct(ctx context.Context, userId model.U
 	_, span := tracing.Start(ctx, "CartMemoryRepository.RemoveProduct")
 	defer span.End()
 
-	r.mx.Lock()
-	defer r.mx.Unlock()
-	if _, ok := r.storage[userId]; !ok {
+	sh := r.shard(userId)
+	sh.mx.Lock()
+	defer sh.mx.Unlock()
+	if _, ok := sh.carts[userId][ProductSku]; !ok {
 		return nil
 	}
-	delete(r.storage[userId], ProductSku)
+	if err := r.commit(sh, walRecord{Op: walOpRemove, User: userId, Sku: ProductSku}); err != nil {
+		return err
+	}
 	r.SendMetrics()
 	return nil
 }
@@ -53,45 +105,124 @@ func (r *CartMemoryRepository) ClearCart(ctx context.Context, userId model.UserI
 	_, span := tracing.Start(ctx, "CartMemoryRepository.ClearCart")
 	defer span.End()
 
-	r.mx.Lock()
-	defer r.mx.Unlock()
-	delete(r.storage, userId)
+	sh := r.shard(userId)
+	sh.mx.Lock()
+	defer sh.mx.Unlock()
+	if _, ok := sh.carts[userId]; !ok {
+		return nil
+	}
+	if err := r.commit(sh, walRecord{Op: walOpClear, User: userId}); err != nil {
+		return err
+	}
 	r.SendMetrics()
 	return nil
 }
 
+// commit записывает изменение в журнал (если он включен) и применяет его.
+// Вызывается под блокировкой шарда sh.
+func (r *CartMemoryRepository) commit(sh *shard, record walRecord) error {
+	record.Seq = r.seq.Add(1)
+	if r.wal != nil {
+		if err := r.wal.Append(record); err != nil {
+			return fmt.Errorf("r.wal.Append: %w", err)
+		}
+	}
+	r.apply(sh, record)
+	return nil
+}
+
+func (r *CartMemoryRepository) apply(sh *shard, record walRecord) {
+	switch record.Op {
+	case walOpAdd:
+		if _, ok := sh.carts[record.User]; !ok {
+			sh.carts[record.User] = make(model.CartItems)
+		}
+		item := sh.carts[record.User][record.Sku]
+		item.Count += record.Count
+		item.Price = record.Price
+		sh.carts[record.User][record.Sku] = item
+		r.amount.Add(int64(record.Count))
+	case walOpRemove:
+		r.amount.Add(-int64(sh.carts[record.User][record.Sku].Count))
+		delete(sh.carts[record.User], record.Sku)
+	case walOpClear:
+		for _, item := range sh.carts[record.User] {
+			r.amount.Add(-int64(item.Count))
+		}
+		delete(sh.carts, record.User)
+	}
+}
+
 func (r *CartMemoryRepository) GetCart(ctx context.Context, userId model.UserId) (model.Cart, error) {
 	_, span := tracing.Start(ctx, "CartMemoryRepository.GetCart")
 	defer span.End()
 
-	r.mx.Lock()
-	defer r.mx.Unlock()
-	if _, ok := r.storage[userId]; !ok {
-		r.storage[userId] = make(model.Cart)
+	sh := r.shard(userId)
+	sh.mx.RLock()
+	defer sh.mx.RUnlock()
+	cart := make(model.Cart, len(sh.carts[userId]))
+	for sku, item := range sh.carts[userId] {
+		cart[sku] = item.Count
 	}
-	r.SendMetrics()
-	return r.storage[userId], nil
+	return cart, nil
+}
+
+func (r *CartMemoryRepository) GetCartItems(ctx context.Context, userId model.UserId) (model.CartItems, error) {
+	_, span := tracing.Start(ctx, "CartMemoryRepository.GetCartItems")
+	defer span.End()
+
+	sh := r.shard(userId)
+	sh.mx.RLock()
+	defer sh.mx.RUnlock()
+	items := make(model.CartItems, len(sh.carts[userId]))
+	for sku, item := range sh.carts[userId] {
+		items[sku] = item
+	}
+	return items, nil
 }
 
 func (r *CartMemoryRepository) GetProductCount(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) (uint16, error) {
 	_, span := tracing.Start(ctx, "CartMemoryRepository.GetProductCount")
 	defer span.End()
 
-	r.mx.RLock()
-	defer r.mx.RUnlock()
-	if _, ok := r.storage[userId]; !ok {
-		return 0, nil
-	}
-	r.SendMetrics()
-	return r.storage[userId][ProductSku], nil
+	sh := r.shard(userId)
+	sh.mx.RLock()
+	defer sh.mx.RUnlock()
+	return sh.carts[userId][ProductSku].Count, nil
 }
 
-func (r *CartMemoryRepository) SendMetrics() {
-	var amount float64
-	for _, cart := range r.storage {
-		for _, count := range cart {
-			amount += float64(count)
+// GetTopProducts возвращает не более limit sku, которые встречаются в наибольшем количестве корзин.
+func (r *CartMemoryRepository) GetTopProducts(ctx context.Context, limit int) ([]model.ProductSku, error) {
+	_, span := tracing.Start(ctx, "CartMemoryRepository.GetTopProducts")
+	defer span.End()
+
+	frequency := make(map[model.ProductSku]int)
+	for _, sh := range r.shards {
+		sh.mx.RLock()
+		for _, cart := range sh.carts {
+			for sku := range cart {
+				frequency[sku]++
+			}
 		}
+		sh.mx.RUnlock()
+	}
+
+	skus := make([]model.ProductSku, 0, len(frequency))
+	for sku := range frequency {
+		skus = append(skus, sku)
 	}
-	metrics.CartRepositoryAmounter(amount)
+	sort.Slice(skus, func(i, j int) bool {
+		if frequency[skus[i]] != frequency[skus[j]] {
+			return frequency[skus[i]] > frequency[skus[j]]
+		}
+		return skus[i] < skus[j]
+	})
+	if limit > 0 && len(skus) > limit {
+		skus = skus[:limit]
+	}
+	return skus, nil
+}
+
+func (r *CartMemoryRepository) SendMetrics() {
+	metrics.CartRepositoryAmounter(float64(r.amount.Load()))
 }
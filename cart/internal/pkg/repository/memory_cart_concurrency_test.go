@@ -2,8 +2,13 @@ package repository
 
 import (
 	"context"
+	"route256/cart/internal/pkg/model"
+	"strconv"
 	"sync"
+	"sync/atomic"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
 )
 
 const count = 100000
@@ -17,7 +22,7 @@ func TestRaceAddProduct(t *testing.T) {
 	for range count {
 		wg.Add(1)
 		go func() {
-			repo.AddProduct(context.Background(), 1, 1, 1)
+			repo.AddProduct(context.Background(), 1, 1, 1, 100)
 			wg.Done()
 		}()
 	}
@@ -87,3 +92,111 @@ func TestRaceGetProductCount(t *testing.T) {
 	}
 	wg.Wait()
 }
+
+func TestRaceMixed(t *testing.T) {
+	t.Parallel()
+
+	repo := NewCartMemoryRepository()
+
+	const users = 100
+	wg := &sync.WaitGroup{}
+	for i := range count {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ctx := context.Background()
+			userId := model.UserId(i%users + 1)
+			sku := model.ProductSku(i%7 + 1)
+			switch i % 5 {
+			case 0, 1:
+				repo.AddProduct(ctx, userId, sku, 1, 100)
+			case 2:
+				repo.GetCart(ctx, userId)
+			case 3:
+				repo.RemoveProduct(ctx, userId, sku)
+			case 4:
+				repo.GetTopProducts(ctx, 3)
+			}
+		}()
+	}
+	wg.Wait()
+
+	// счетчик для метрики должен совпасть с содержимым корзин
+	var amount int64
+	for _, cart := range repo.carts() {
+		for _, item := range cart {
+			amount += int64(item.Count)
+		}
+	}
+	assert.Equal(t, amount, repo.amount.Load())
+}
+
+func TestRaceGetCartWhileWriting(t *testing.T) {
+	t.Parallel()
+
+	repo := NewCartMemoryRepository()
+
+	wg := &sync.WaitGroup{}
+	for i := range count / 10 {
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			repo.AddProduct(context.Background(), 1, model.ProductSku(i%10+1), 1, 100)
+		}()
+		go func() {
+			defer wg.Done()
+			cart, _ := repo.GetCart(context.Background(), 1)
+			// возвращается копия, ее можно читать без блокировок
+			for range cart {
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// benchmarkParallelAddProduct пишет в корзины разных пользователей из нескольких горутин.
+// Сравнение с одним шардом показывает выигрыш от разделения блокировок.
+func benchmarkParallelAddProduct(b *testing.B, shards int) {
+	repo := NewCartMemoryRepository(WithShards(shards))
+	var userSeq atomic.Int64
+
+	b.ResetTimer()
+	b.RunParallel(func(pb *testing.PB) {
+		userId := model.UserId(userSeq.Add(1))
+		ctx := context.Background()
+		i := 0
+		for pb.Next() {
+			repo.AddProduct(ctx, userId, model.ProductSku(i%100+1), 1, 100)
+			repo.GetCart(ctx, userId)
+			i++
+		}
+	})
+}
+
+func BenchmarkParallelAddProductOneShard(b *testing.B) {
+	benchmarkParallelAddProduct(b, 1)
+}
+
+func BenchmarkParallelAddProductShards(b *testing.B) {
+	benchmarkParallelAddProduct(b, defaultShardsCount)
+}
+
+// BenchmarkAddProductLargeStorage - стоимость записи не зависит от количества товаров в хранилище.
+func BenchmarkAddProductLargeStorage(b *testing.B) {
+	for _, users := range []int{10, 10000} {
+		b.Run(strconv.Itoa(users), func(b *testing.B) {
+			ctx := context.Background()
+			repo := NewCartMemoryRepository()
+			for i := range users {
+				for sku := range 10 {
+					repo.AddProduct(ctx, model.UserId(i+1), model.ProductSku(sku+1), 1, 100)
+				}
+			}
+
+			b.ResetTimer()
+			for i := range b.N {
+				repo.AddProduct(ctx, model.UserId(i%users+1), 1, 1, 100)
+			}
+		})
+	}
+}
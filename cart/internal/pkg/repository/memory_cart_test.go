@@ -2,56 +2,82 @@ package repository
 
 import (
 	"context"
+	"math"
 	"route256/cart/internal/pkg/model"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 )
 
+// carts собирает корзины всех шардов.
+func (r *CartMemoryRepository) carts() Storage {
+	r.lockAll()
+	defer r.unlockAll()
+	storage := make(Storage)
+	for _, sh := range r.shards {
+		for userId, cart := range sh.carts {
+			storage[userId] = cart
+		}
+	}
+	return storage
+}
+
 func TestAddProduct(t *testing.T) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 
-	err := repo.AddProduct(ctx, 1, 1, 1)
+	err := repo.AddProduct(ctx, 1, 1, 1, 100)
+	assert.NoError(t, err)
+	assert.Len(t, repo.carts(), 1)
+
+	err = repo.AddProduct(ctx, 2, 1, 1, 100)
 	assert.NoError(t, err)
-	assert.Len(t, repo.storage, 1)
+	assert.Len(t, repo.carts(), 2)
+}
+
+func TestAddProductOverflow(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartMemoryRepository()
 
-	err = repo.AddProduct(ctx, 2, 1, 1)
+	err := repo.AddProduct(ctx, 1, 1, math.MaxUint16, 100)
 	assert.NoError(t, err)
-	assert.Len(t, repo.storage, 2)
+
+	err = repo.AddProduct(ctx, 1, 1, 1, 100)
+	assert.ErrorIs(t, err, ErrCountOverflow)
+	assert.Equal(t, uint16(math.MaxUint16), repo.carts()[1][1].Count)
 }
 
 func TestRemoveProduct(t *testing.T) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 
-	repo.AddProduct(ctx, 1, 1, 1)
-	assert.Len(t, repo.storage[1], 1)
+	repo.AddProduct(ctx, 1, 1, 1, 100)
+	assert.Len(t, repo.carts()[1], 1)
 
 	err := repo.RemoveProduct(ctx, 1, 1)
 	assert.NoError(t, err)
-	assert.Len(t, repo.storage[1], 0)
+	assert.Len(t, repo.carts()[1], 0)
 }
 
 func TestClearCart(t *testing.T) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 
-	repo.AddProduct(ctx, 1, 1, 1)
-	repo.AddProduct(ctx, 2, 1, 1)
-	assert.Len(t, repo.storage, 2)
+	repo.AddProduct(ctx, 1, 1, 1, 100)
+	repo.AddProduct(ctx, 2, 1, 1, 100)
+	assert.Len(t, repo.carts(), 2)
 
 	err := repo.ClearCart(ctx, 1)
 	assert.NoError(t, err)
-	assert.Len(t, repo.storage[1], 0)
+	assert.Len(t, repo.carts()[1], 0)
 }
 
 func TestGetCart(t *testing.T) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 
-	repo.AddProduct(ctx, 1, 1, 5)
-	repo.AddProduct(ctx, 1, 2, 7)
+	repo.AddProduct(ctx, 1, 1, 5, 100)
+	repo.AddProduct(ctx, 1, 2, 7, 100)
 
 	cart, err := repo.GetCart(ctx, 1)
 	assert.NoError(t, err)
@@ -65,8 +91,8 @@ func TestGetProductCount(t *testing.T) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 
-	repo.AddProduct(ctx, 1, 1, 5)
-	repo.AddProduct(ctx, 1, 1, 3)
+	repo.AddProduct(ctx, 1, 1, 5, 100)
+	repo.AddProduct(ctx, 1, 1, 3, 100)
 
 	count, err := repo.GetProductCount(ctx, 1, 1)
 	assert.NoError(t, err)
@@ -77,6 +103,56 @@ func BenchmarkAddProduct(b *testing.B) {
 	ctx := context.Background()
 	repo := NewCartMemoryRepository()
 	for i := 0; i < b.N; i++ {
-		repo.AddProduct(ctx, 1, 1, 1)
+		repo.AddProduct(ctx, 1, 1, 1, 100)
 	}
 }
+
+func TestGetCartItems(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartMemoryRepository()
+
+	repo.AddProduct(ctx, 1, 1, 5, 100)
+	repo.AddProduct(ctx, 1, 1, 2, 120)
+	repo.AddProduct(ctx, 1, 2, 7, 300)
+
+	items, err := repo.GetCartItems(ctx, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, model.CartItems{
+		1: {Count: 7, Price: 120},
+		2: {Count: 7, Price: 300},
+	}, items)
+}
+
+func TestAmount(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartMemoryRepository()
+
+	repo.AddProduct(ctx, 1, 1, 5, 100)
+	repo.AddProduct(ctx, 1, 2, 7, 100)
+	repo.AddProduct(ctx, 2, 1, 3, 100)
+	assert.Equal(t, int64(15), repo.amount.Load())
+
+	repo.RemoveProduct(ctx, 1, 2)
+	assert.Equal(t, int64(8), repo.amount.Load())
+
+	repo.ClearCart(ctx, 1)
+	assert.Equal(t, int64(3), repo.amount.Load())
+}
+
+func TestGetCartReturnsCopy(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartMemoryRepository()
+
+	repo.AddProduct(ctx, 1, 1, 5, 100)
+
+	cart, err := repo.GetCart(ctx, 1)
+	assert.NoError(t, err)
+	cart[1] = 100
+	items, err := repo.GetCartItems(ctx, 1)
+	assert.NoError(t, err)
+	items[1] = model.CartItem{Count: 100}
+
+	count, err := repo.GetProductCount(ctx, 1, 1)
+	assert.NoError(t, err)
+	assert.Equal(t, uint16(5), count)
+}
@@ -0,0 +1,112 @@
+package repository
+
+import (
+	"context"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/pkg/tracing"
+	"sort"
+	"sync"
+	"time"
+)
+
+// CartHistoryMemoryRepository - журнал изменений корзин только на добавление.
+// События старше retention удаляются Cleanup.
+type CartHistoryMemoryRepository struct {
+	mx        sync.RWMutex
+	lastID    int64
+	events    map[model.UserId][]model.CartEvent
+	retention time.Duration
+}
+
+func NewCartHistoryMemoryRepository(retention time.Duration) *CartHistoryMemoryRepository {
+	return &CartHistoryMemoryRepository{
+		events:    make(map[model.UserId][]model.CartEvent),
+		retention: retention,
+	}
+}
+
+// Append присваивает событиям возрастающие ID и сохраняет их. Время события
+// без Time проставляется под блокировкой, чтобы оно росло вместе с ID.
+func (r *CartHistoryMemoryRepository) Append(ctx context.Context, events ...model.CartEvent) error {
+	_, span := tracing.Start(ctx, "CartHistoryMemoryRepository.Append")
+	defer span.End()
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	for _, event := range events {
+		r.lastID++
+		event.ID = r.lastID
+		if event.Time.IsZero() {
+			event.Time = time.Now()
+		}
+		r.events[event.User] = append(r.events[event.User], event)
+	}
+	return nil
+}
+
+// List возвращает события пользователя по возрастанию ID, не больше filter.Limit.
+func (r *CartHistoryMemoryRepository) List(ctx context.Context, userId model.UserId, filter model.CartHistoryFilter) ([]model.CartEvent, error) {
+	_, span := tracing.Start(ctx, "CartHistoryMemoryRepository.List")
+	defer span.End()
+
+	r.mx.RLock()
+	defer r.mx.RUnlock()
+	userEvents := r.events[userId]
+	start := sort.Search(len(userEvents), func(i int) bool {
+		return userEvents[i].ID > filter.AfterID
+	})
+	events := make([]model.CartEvent, 0, min(filter.Limit, len(userEvents)-start))
+	for _, event := range userEvents[start:] {
+		if len(events) >= filter.Limit {
+			break
+		}
+		if !filter.From.IsZero() && event.Time.Before(filter.From) {
+			continue
+		}
+		if !filter.To.IsZero() && !event.Time.Before(filter.To) {
+			continue
+		}
+		events = append(events, event)
+	}
+	return events, nil
+}
+
+// Cleanup удаляет события старше retention и возвращает их количество.
+func (r *CartHistoryMemoryRepository) Cleanup(now time.Time) int {
+	threshold := now.Add(-r.retention)
+
+	r.mx.Lock()
+	defer r.mx.Unlock()
+	removed := 0
+	for userId, userEvents := range r.events {
+		// события пользователя добавляются по возрастанию времени
+		i := sort.Search(len(userEvents), func(i int) bool {
+			return !userEvents[i].Time.Before(threshold)
+		})
+		if i == 0 {
+			continue
+		}
+		removed += i
+		if i == len(userEvents) {
+			delete(r.events, userId)
+			continue
+		}
+		r.events[userId] = append([]model.CartEvent(nil), userEvents[i:]...)
+	}
+	return removed
+}
+
+// RunCleanup удаляет устаревшие события каждые period, пока не отменен ctx.
+func (r *CartHistoryMemoryRepository) RunCleanup(ctx context.Context, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case now := <-ticker.C:
+			r.Cleanup(now)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package repository
+
+import (
+	"context"
+	"route256/cart/internal/pkg/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestHistoryList(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartHistoryMemoryRepository(time.Hour)
+	start := time.Date(2024, 8, 1, 12, 0, 0, 0, time.UTC)
+
+	for i := range 5 {
+		require.NoError(t, repo.Append(ctx, model.CartEvent{
+			Time:   start.Add(time.Duration(i) * time.Minute),
+			User:   1,
+			Sku:    model.ProductSku(i + 1),
+			Delta:  1,
+			Action: model.CartEventAdd,
+		}))
+	}
+	require.NoError(t, repo.Append(ctx, model.CartEvent{Time: start, User: 2, Sku: 1, Delta: 1}))
+
+	ids := func(events []model.CartEvent) []int64 {
+		result := make([]int64, 0, len(events))
+		for _, event := range events {
+			result = append(result, event.ID)
+		}
+		return result
+	}
+
+	testData := []struct {
+		name   string
+		filter model.CartHistoryFilter
+		ids    []int64
+	}{
+		{
+			name:   "all",
+			filter: model.CartHistoryFilter{Limit: 10},
+			ids:    []int64{1, 2, 3, 4, 5},
+		},
+		{
+			name:   "first page",
+			filter: model.CartHistoryFilter{Limit: 2},
+			ids:    []int64{1, 2},
+		},
+		{
+			name:   "next page",
+			filter: model.CartHistoryFilter{AfterID: 2, Limit: 2},
+			ids:    []int64{3, 4},
+		},
+		{
+			name:   "time range",
+			filter: model.CartHistoryFilter{From: start.Add(time.Minute), To: start.Add(3 * time.Minute), Limit: 10},
+			ids:    []int64{2, 3},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			events, err := repo.List(ctx, 1, tt.filter)
+			require.NoError(t, err)
+			assert.Equal(t, tt.ids, ids(events))
+		})
+	}
+}
+
+func TestHistoryCleanup(t *testing.T) {
+	ctx := context.Background()
+	repo := NewCartHistoryMemoryRepository(time.Hour)
+	now := time.Now()
+
+	require.NoError(t, repo.Append(ctx,
+		model.CartEvent{Time: now.Add(-2 * time.Hour), User: 1, Sku: 1},
+		model.CartEvent{Time: now.Add(-time.Minute), User: 1, Sku: 2},
+		model.CartEvent{Time: now.Add(-3 * time.Hour), User: 2, Sku: 1},
+	))
+
+	assert.Equal(t, 2, repo.Cleanup(now))
+
+	events, err := repo.List(ctx, 1, model.CartHistoryFilter{Limit: 10})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, model.ProductSku(2), events[0].Sku)
+	events, err = repo.List(ctx, 2, model.CartHistoryFilter{Limit: 10})
+	require.NoError(t, err)
+	assert.Empty(t, events)
+}
@@ -0,0 +1,27 @@
+package repository
+
+type Option interface {
+	Apply(*CartMemoryRepository)
+}
+
+type optionFn func(*CartMemoryRepository)
+
+func (fn optionFn) Apply(r *CartMemoryRepository) {
+	fn(r)
+}
+
+// WithWAL включает журнал изменений между снапшотами.
+func WithWAL(wal *WAL) Option {
+	return optionFn(func(r *CartMemoryRepository) {
+		r.wal = wal
+	})
+}
+
+// WithShards задает количество шардов хранилища.
+func WithShards(count int) Option {
+	return optionFn(func(r *CartMemoryRepository) {
+		if count > 0 {
+			r.shardsCount = count
+		}
+	})
+}
@@ -0,0 +1,171 @@
+package repository
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/pkg/logger"
+	"time"
+)
+
+// snapshotVersion увеличивается при несовместимом изменении формата снапшота.
+const snapshotVersion = 1
+
+var ErrUnknownSnapshotVersion = errors.New("unknown snapshot version")
+
+type snapshotItem struct {
+	Count uint16 `json:"count"`
+	Price uint32 `json:"price"`
+}
+
+type snapshot struct {
+	Version   int                                                `json:"version"`
+	Seq       uint64                                             `json:"seq"`
+	CreatedAt time.Time                                          `json:"created_at"`
+	Carts     map[model.UserId]map[model.ProductSku]snapshotItem `json:"carts"`
+}
+
+// Snapshot атомарно сохраняет все корзины в файл path.
+func (r *CartMemoryRepository) Snapshot(path string) error {
+	r.lockAll()
+	data := snapshot{
+		Version:   snapshotVersion,
+		Seq:       r.seq.Load(),
+		CreatedAt: time.Now().UTC(),
+		Carts:     make(map[model.UserId]map[model.ProductSku]snapshotItem),
+	}
+	for _, sh := range r.shards {
+		for userId, cart := range sh.carts {
+			if len(cart) == 0 {
+				continue
+			}
+			items := make(map[model.ProductSku]snapshotItem, len(cart))
+			for sku, item := range cart {
+				items[sku] = snapshotItem{Count: item.Count, Price: item.Price}
+			}
+			data.Carts[userId] = items
+		}
+	}
+	if r.wal != nil {
+		if err := r.wal.rotate(); err != nil {
+			r.unlockAll()
+			return fmt.Errorf("r.wal.rotate: %w", err)
+		}
+	}
+	r.unlockAll()
+
+	if err := writeFileAtomic(path, data); err != nil {
+		return fmt.Errorf("writeFileAtomic: %w", err)
+	}
+	if r.wal != nil {
+		if err := r.wal.dropPrev(); err != nil {
+			return fmt.Errorf("r.wal.dropPrev: %w", err)
+		}
+	}
+	return nil
+}
+
+// Restore загружает корзины из снапшота path и доигрывает журнал, если он включен.
+// Отсутствие файла снапшота не считается ошибкой.
+func (r *CartMemoryRepository) Restore(path string) error {
+	var data snapshot
+	raw, err := os.ReadFile(path)
+	switch {
+	case errors.Is(err, os.ErrNotExist):
+	case err != nil:
+		return fmt.Errorf("os.ReadFile: %w", err)
+	default:
+		if err := json.Unmarshal(raw, &data); err != nil {
+			return fmt.Errorf("json.Unmarshal: %w", err)
+		}
+		if data.Version != snapshotVersion {
+			return fmt.Errorf("%w: %d", ErrUnknownSnapshotVersion, data.Version)
+		}
+	}
+
+	r.lockAll()
+	defer r.unlockAll()
+	for _, sh := range r.shards {
+		sh.carts = make(Storage)
+	}
+	var amount int64
+	for userId, items := range data.Carts {
+		cart := make(model.CartItems, len(items))
+		for sku, item := range items {
+			cart[sku] = model.CartItem{Count: item.Count, Price: item.Price}
+			amount += int64(item.Count)
+		}
+		r.shard(userId).carts[userId] = cart
+	}
+	r.amount.Store(amount)
+	r.seq.Store(data.Seq)
+	if r.wal != nil {
+		// записи разных шардов могут лежать в журнале не по порядку seq,
+		// поэтому сравниваем с seq снапшота, а не с последней примененной
+		err := r.wal.replay(func(record walRecord) {
+			if record.Seq <= data.Seq {
+				return
+			}
+			r.apply(r.shard(record.User), record)
+			if record.Seq > r.seq.Load() {
+				r.seq.Store(record.Seq)
+			}
+		})
+		if err != nil {
+			return fmt.Errorf("r.wal.replay: %w", err)
+		}
+	}
+	r.SendMetrics()
+	return nil
+}
+
+// RunSnapshots сохраняет снапшот каждые period, пока не отменен ctx.
+func (r *CartMemoryRepository) RunSnapshots(ctx context.Context, path string, period time.Duration) {
+	ticker := time.NewTicker(period)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			if err := r.Snapshot(path); err != nil {
+				logger.Errorw(ctx, "cart snapshot failed", "path", path, "err", err)
+			}
+		}
+	}
+}
+
+// writeFileAtomic пишет во временный файл рядом с path и переименовывает его,
+// чтобы при падении на диске остался либо старый, либо новый снапшот целиком.
+func writeFileAtomic(path string, data snapshot) error {
+	tmp, err := os.CreateTemp(filepath.Dir(path), filepath.Base(path)+".tmp-*")
+	if err != nil {
+		return fmt.Errorf("os.CreateTemp: %w", err)
+	}
+	defer os.Remove(tmp.Name())
+
+	if err := json.NewEncoder(tmp).Encode(data); err != nil {
+		tmp.Close()
+		return fmt.Errorf("json.Encode: %w", err)
+	}
+	if err := tmp.Sync(); err != nil {
+		tmp.Close()
+		return fmt.Errorf("tmp.Sync: %w", err)
+	}
+	if err := tmp.Close(); err != nil {
+		return fmt.Errorf("tmp.Close: %w", err)
+	}
+	if err := os.Rename(tmp.Name(), path); err != nil {
+		return fmt.Errorf("os.Rename: %w", err)
+	}
+	if dir, err := os.Open(filepath.Dir(path)); err == nil {
+		dir.Sync()
+		dir.Close()
+	}
+	return nil
+}
@@ -0,0 +1,126 @@
+package repository
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"route256/cart/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSnapshotRestore(t *testing.T) {
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "carts.json")
+
+	repo := NewCartMemoryRepository()
+	require.NoError(t, repo.AddProduct(ctx, 1, 1, 5, 100))
+	require.NoError(t, repo.AddProduct(ctx, 1, 2, 1, 200))
+	require.NoError(t, repo.AddProduct(ctx, 2, 1, 3, 100))
+	require.NoError(t, repo.Snapshot(path))
+
+	restored := NewCartMemoryRepository()
+	require.NoError(t, restored.Restore(path))
+	items, err := restored.GetCartItems(ctx, 1)
+	require.NoError(t, err)
+	assert.Equal(t, model.CartItems{1: {Count: 5, Price: 100}, 2: {Count: 1, Price: 200}}, items)
+	count, err := restored.GetProductCount(ctx, 2, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint16(3), count)
+}
+
+func TestRestoreMissingSnapshot(t *testing.T) {
+	repo := NewCartMemoryRepository()
+	assert.NoError(t, repo.Restore(filepath.Join(t.TempDir(), "carts.json")))
+	assert.Empty(t, repo.carts())
+}
+
+func TestRestoreUnknownVersion(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "carts.json")
+	require.NoError(t, os.WriteFile(path, []byte(`{"version":99,"carts":{}}`), 0o644))
+
+	repo := NewCartMemoryRepository()
+	assert.ErrorIs(t, repo.Restore(path), ErrUnknownSnapshotVersion)
+}
+
+func TestRestoreWAL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "carts.json")
+	walPath := filepath.Join(dir, "carts.json.wal")
+
+	wal, err := OpenWAL(walPath)
+	require.NoError(t, err)
+	repo := NewCartMemoryRepository(WithWAL(wal))
+	require.NoError(t, repo.AddProduct(ctx, 1, 1, 5, 100))
+	require.NoError(t, repo.AddProduct(ctx, 2, 1, 1, 100))
+	require.NoError(t, repo.Snapshot(path))
+
+	// изменения после снапшота есть только в журнале
+	require.NoError(t, repo.AddProduct(ctx, 1, 1, 2, 120))
+	require.NoError(t, repo.RemoveProduct(ctx, 2, 1))
+	require.NoError(t, repo.AddProduct(ctx, 3, 7, 1, 300))
+	require.NoError(t, repo.ClearCart(ctx, 3))
+	require.NoError(t, wal.Close())
+
+	// процесс упал посреди записи
+	f, err := os.OpenFile(walPath, os.O_WRONLY|os.O_APPEND, 0o644)
+	require.NoError(t, err)
+	_, err = f.WriteString(`{"seq":7,"op":"ad`)
+	require.NoError(t, err)
+	require.NoError(t, f.Close())
+
+	wal, err = OpenWAL(walPath)
+	require.NoError(t, err)
+	defer wal.Close()
+	restored := NewCartMemoryRepository(WithWAL(wal))
+	require.NoError(t, restored.Restore(path))
+
+	assert.Equal(t, Storage{1: {1: {Count: 7, Price: 120}}, 2: {}}, restored.carts())
+	assert.Equal(t, uint64(6), restored.seq.Load())
+	assert.Equal(t, int64(7), restored.amount.Load())
+}
+
+func TestWALTruncatesTornRecord(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "carts.json")
+	walPath := filepath.Join(dir, "carts.json.wal")
+	require.NoError(t, os.WriteFile(walPath, []byte(`{"seq":1,"op":"add","user":1,"sku":1,"count":5,"price":100}`+"\n"+`{"seq":2,"op":"ad`), 0o644))
+
+	wal, err := OpenWAL(walPath)
+	require.NoError(t, err)
+	repo := NewCartMemoryRepository(WithWAL(wal))
+	require.NoError(t, repo.Restore(path))
+	// первое изменение после падения не склеивается с оборванной записью
+	require.NoError(t, repo.AddProduct(ctx, 2, 1, 1, 100))
+	require.NoError(t, wal.Close())
+
+	wal, err = OpenWAL(walPath)
+	require.NoError(t, err)
+	defer wal.Close()
+	restored := NewCartMemoryRepository(WithWAL(wal))
+	require.NoError(t, restored.Restore(path))
+	assert.Equal(t, Storage{1: {1: {Count: 5, Price: 100}}, 2: {1: {Count: 1, Price: 100}}}, restored.carts())
+}
+
+func TestSnapshotRotatesWAL(t *testing.T) {
+	ctx := context.Background()
+	dir := t.TempDir()
+	path := filepath.Join(dir, "carts.json")
+	walPath := filepath.Join(dir, "carts.json.wal")
+
+	wal, err := OpenWAL(walPath)
+	require.NoError(t, err)
+	defer wal.Close()
+	repo := NewCartMemoryRepository(WithWAL(wal))
+	require.NoError(t, repo.AddProduct(ctx, 1, 1, 5, 100))
+	require.NoError(t, repo.Snapshot(path))
+
+	info, err := os.Stat(walPath)
+	require.NoError(t, err)
+	assert.Zero(t, info.Size())
+	assert.NoFileExists(t, walPath+".prev")
+}
@@ -0,0 +1,211 @@
+package repository
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"route256/cart/internal/pkg/model"
+	"sync"
+)
+
+type walOp string
+
+const (
+	walOpAdd    walOp = "add"
+	walOpRemove walOp = "remove"
+	walOpClear  walOp = "clear"
+)
+
+type walRecord struct {
+	Seq   uint64           `json:"seq"`
+	Op    walOp            `json:"op"`
+	User  model.UserId     `json:"user"`
+	Sku   model.ProductSku `json:"sku,omitempty"`
+	Count uint16           `json:"count,omitempty"`
+	Price uint32           `json:"price,omitempty"`
+}
+
+// WAL - журнал изменений корзин в формате JSON Lines. Записи не синхронизируются
+// на диск по одной, поэтому переживают падение процесса, но не падение машины.
+//
+// При снапшоте текущий журнал переименовывается в path.prev и удаляется после
+// успешной записи снапшота, так что изменения не теряются ни в какой момент.
+type WAL struct {
+	mx   sync.Mutex
+	path string
+	file *os.File
+}
+
+func OpenWAL(path string) (*WAL, error) {
+	for _, p := range []string{path + ".prev", path} {
+		if err := truncateTorn(p); err != nil {
+			return nil, fmt.Errorf("truncateTorn %s: %w", p, err)
+		}
+	}
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return nil, fmt.Errorf("os.OpenFile: %w", err)
+	}
+	return &WAL{
+		path: path,
+		file: file,
+	}, nil
+}
+
+func (w *WAL) prevPath() string {
+	return w.path + ".prev"
+}
+
+func (w *WAL) Append(record walRecord) error {
+	data, err := json.Marshal(record)
+	if err != nil {
+		return fmt.Errorf("json.Marshal: %w", err)
+	}
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	if _, err := w.file.Write(append(data, '\n')); err != nil {
+		return fmt.Errorf("w.file.Write: %w", err)
+	}
+	return nil
+}
+
+// rotate откладывает текущий журнал до записи снапшота и начинает новый.
+// Если прошлый снапшот не удался и path.prev остался, журнал дописывается к нему.
+func (w *WAL) rotate() error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+
+	if err := w.file.Close(); err != nil {
+		return fmt.Errorf("w.file.Close: %w", err)
+	}
+	if _, err := os.Stat(w.prevPath()); errors.Is(err, os.ErrNotExist) {
+		if err := os.Rename(w.path, w.prevPath()); err != nil {
+			return fmt.Errorf("os.Rename: %w", err)
+		}
+	} else if err := appendFile(w.prevPath(), w.path); err != nil {
+		return fmt.Errorf("appendFile: %w", err)
+	}
+
+	file, err := os.OpenFile(w.path, os.O_CREATE|os.O_WRONLY|os.O_TRUNC, 0o644)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	w.file = file
+	return nil
+}
+
+// dropPrev удаляет журнал, изменения из которого уже попали в снапшот.
+func (w *WAL) dropPrev() error {
+	if err := os.Remove(w.prevPath()); err != nil && !errors.Is(err, os.ErrNotExist) {
+		return fmt.Errorf("os.Remove: %w", err)
+	}
+	return nil
+}
+
+// replay вызывает fn для всех записей отложенного и текущего журналов по порядку.
+func (w *WAL) replay(fn func(walRecord)) error {
+	for _, path := range []string{w.prevPath(), w.path} {
+		if err := replayFile(path, fn); err != nil {
+			return fmt.Errorf("replayFile %s: %w", path, err)
+		}
+	}
+	return nil
+}
+
+func (w *WAL) Close() error {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	if err := w.file.Sync(); err != nil {
+		return fmt.Errorf("w.file.Sync: %w", err)
+	}
+	return w.file.Close()
+}
+
+func replayFile(path string, fn func(walRecord)) error {
+	file, err := os.Open(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer file.Close()
+
+	reader := bufio.NewReader(file)
+	for {
+		line, err := reader.ReadBytes('\n')
+		if errors.Is(err, io.EOF) {
+			// недописанная последняя строка - процесс упал во время записи
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("reader.ReadBytes: %w", err)
+		}
+		var record walRecord
+		if err := json.Unmarshal(line, &record); err != nil {
+			// оборванная запись от упавшего процесса, за ней могут идти целые
+			continue
+		}
+		fn(record)
+	}
+}
+
+// truncateTorn обрезает недописанную последнюю строку журнала от упавшего процесса.
+// Иначе следующая запись допишется к ней, не разберется при replay и потеряется.
+func truncateTorn(path string) error {
+	file, err := os.OpenFile(path, os.O_RDWR, 0)
+	if errors.Is(err, os.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	defer file.Close()
+
+	info, err := file.Stat()
+	if err != nil {
+		return fmt.Errorf("file.Stat: %w", err)
+	}
+	// ищем последний перевод строки с конца файла
+	buf := make([]byte, 4096)
+	end := info.Size()
+	for end > 0 {
+		n := min(int64(len(buf)), end)
+		if _, err := file.ReadAt(buf[:n], end-n); err != nil {
+			return fmt.Errorf("file.ReadAt: %w", err)
+		}
+		if i := bytes.LastIndexByte(buf[:n], '\n'); i >= 0 {
+			end = end - n + int64(i) + 1
+			break
+		}
+		end -= n
+	}
+	if end == info.Size() {
+		return nil
+	}
+	if err := file.Truncate(end); err != nil {
+		return fmt.Errorf("file.Truncate: %w", err)
+	}
+	return nil
+}
+
+func appendFile(dst, src string) error {
+	in, err := os.Open(src)
+	if err != nil {
+		return fmt.Errorf("os.Open: %w", err)
+	}
+	defer in.Close()
+	out, err := os.OpenFile(dst, os.O_WRONLY|os.O_APPEND, 0o644)
+	if err != nil {
+		return fmt.Errorf("os.OpenFile: %w", err)
+	}
+	if _, err := io.Copy(out, in); err != nil {
+		out.Close()
+		return fmt.Errorf("io.Copy: %w", err)
+	}
+	return out.Close()
+}
@@ -7,18 +7,35 @@ import (
 	"net/http"
 	"route256/cart/internal/pkg/customerror"
 	"route256/cart/internal/pkg/model"
-	"route256/cart/internal/pkg/utils"
+	"route256/cart/internal/pkg/repository"
+	"route256/cart/pkg/logger"
 	"route256/cart/pkg/tracing"
+	"sort"
+	"sync"
+	"sync/atomic"
 	"time"
+
+	"go.opentelemetry.io/otel/trace"
+)
+
+const (
+	defaultProductRps = 10
+	productTimeout    = 3 * time.Second
 )
 
-const rps = 10
+// коды ошибок превышения ограничений корзины
+const (
+	ErrCodeLineLimit  = "cart_line_limit_exceeded"
+	ErrCodeSkusLimit  = "cart_skus_limit_exceeded"
+	ErrCodeValueLimit = "cart_value_limit_exceeded"
+)
 
 type cartRepository interface {
-	AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) error
+	AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) error
 	RemoveProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) error
 	ClearCart(ctx context.Context, userId model.UserId) error
 	GetCart(ctx context.Context, userId model.UserId) (model.Cart, error)
+	GetCartItems(ctx context.Context, userId model.UserId) (model.CartItems, error)
 	GetProductCount(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) (uint16, error)
 }
 
@@ -27,21 +44,53 @@ type productService interface {
 }
 
 type lomsService interface {
-	OrderCreate(ctx context.Context, user model.UserId, cart model.Cart) (model.OrderId, error)
+	OrderCreate(ctx context.Context, user model.UserId, cart model.Cart, requestId string) (model.OrderId, error)
 	StocksInfo(ctx context.Context, sku model.ProductSku) (uint64, error)
+	StocksInfoBatch(ctx context.Context, skus []model.ProductSku) (map[model.ProductSku]uint64, []model.ProductSku, error)
+}
+
+type holdService interface {
+	Hold(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) error
+	Extend(ctx context.Context, user model.UserId) error
+	Release(ctx context.Context, user model.UserId, sku model.ProductSku) error
+	ReleaseAll(ctx context.Context, user model.UserId) error
+	Forget(user model.UserId)
+	Held(user model.UserId, sku model.ProductSku) uint16
+}
+
+type historyRepository interface {
+	Append(ctx context.Context, events ...model.CartEvent) error
+	List(ctx context.Context, userId model.UserId, filter model.CartHistoryFilter) ([]model.CartEvent, error)
 }
 
 type CartService struct {
 	cartRepository cartRepository
 	productService productService
 	lomsService    lomsService
+	holdService    holdService
+	history        historyRepository
+	limits         model.CartLimits
+	productRps     atomic.Int64
 }
 
-func NewCartService(cartRepository cartRepository, productService productService, lomsService lomsService) *CartService {
-	return &CartService{
+func NewCartService(cartRepository cartRepository, productService productService, lomsService lomsService, opts ...Option) *CartService {
+	s := &CartService{
 		cartRepository: cartRepository,
 		productService: productService,
 		lomsService:    lomsService,
+		limits:         model.DefaultCartLimits(),
+	}
+	s.productRps.Store(defaultProductRps)
+	for _, opt := range opts {
+		opt.Apply(s)
+	}
+	return s
+}
+
+// SetProductRps меняет ограничение запросов к ProductService при загрузке корзины.
+func (r *CartService) SetProductRps(rps int) {
+	if rps > 0 {
+		r.productRps.Store(int64(rps))
 	}
 }
 
@@ -52,24 +101,114 @@ func (r *CartService) AddProduct(ctx context.Context, userId model.UserId, Produ
 	if userId < 1 || ProductSku < 1 || count < 1 {
 		return errors.New("invalid userId or ProductSku or count")
 	}
-	if _, err := r.productService.GetProduct(ctx, ProductSku); err != nil {
+	product, err := r.productService.GetProduct(ctx, ProductSku)
+	if err != nil {
 		return fmt.Errorf("r.productService.GetProduct: %w", err)
 	}
 	cartProductCount, err := r.cartRepository.GetProductCount(ctx, userId, ProductSku)
 	if err != nil {
 		return fmt.Errorf("r.cartRepository.GetCart: %w", err)
 	}
-	if stockCount, err := r.lomsService.StocksInfo(ctx, ProductSku); err != nil {
+	// считаем в uint64, чтобы сумма не переполнила uint16
+	totalCount := uint64(cartProductCount) + uint64(count)
+	if totalCount > uint64(r.limits.MaxLineCount) {
+		return errLineLimit(r.limits.MaxLineCount)
+	}
+	if err := r.checkCartLimits(ctx, userId, ProductSku, product.Price, cartProductCount, totalCount); err != nil {
+		return err
+	}
+	if r.holdService != nil {
+		// холд сам проверяет остаток в LOMS
+		if err := r.holdService.Hold(ctx, userId, ProductSku, uint16(totalCount)); err != nil {
+			return fmt.Errorf("r.holdService.Hold: %w", err)
+		}
+	} else if stockCount, err := r.lomsService.StocksInfo(ctx, ProductSku); err != nil {
 		return fmt.Errorf("r.lomsService.StocksInfo: %w", err)
-	} else if stockCount < uint64(cartProductCount+count) {
+	} else if stockCount < totalCount {
 		return customerror.NewErrStatusCode("not enough products in stock", http.StatusPreconditionFailed)
 	}
-	if err := r.cartRepository.AddProduct(ctx, userId, ProductSku, count); err != nil {
+	err = r.cartRepository.AddProduct(ctx, userId, ProductSku, count, product.Price)
+	if err != nil && r.holdService != nil {
+		// строка корзины не изменилась, иначе больший холд держал бы остаток других пользователей до ttl
+		if holdErr := r.restoreHold(ctx, userId, ProductSku, cartProductCount); holdErr != nil {
+			err = errors.Join(err, holdErr)
+		}
+	}
+	if errors.Is(err, repository.ErrCountOverflow) {
+		return errLineLimit(r.limits.MaxLineCount)
+	}
+	if err != nil {
 		return fmt.Errorf("r.cartRepository.AddProduct: %w", err)
 	}
+	r.recordHistory(ctx, model.CartEvent{
+		User:   userId,
+		Sku:    ProductSku,
+		Delta:  int32(count),
+		Action: model.CartEventAdd,
+		Source: model.CartEventSourceUser,
+	})
+	return nil
+}
+
+// restoreHold возвращает холд sku к количеству в корзине, без товара в корзине холд снимается.
+func (r *CartService) restoreHold(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) error {
+	if count == 0 {
+		if err := r.holdService.Release(ctx, userId, ProductSku); err != nil {
+			return fmt.Errorf("r.holdService.Release: %w", err)
+		}
+		return nil
+	}
+	if err := r.holdService.Hold(ctx, userId, ProductSku, count); err != nil {
+		return fmt.Errorf("r.holdService.Hold: %w", err)
+	}
 	return nil
 }
 
+// checkCartLimits проверяет ограничения на количество товаров и стоимость корзины
+// с учетом добавляемой строки.
+func (r *CartService) checkCartLimits(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, price uint32, cartProductCount uint16, totalCount uint64) error {
+	if r.limits.MaxSkus <= 0 && r.limits.MaxValue == 0 {
+		return nil
+	}
+	items, err := r.cartRepository.GetCartItems(ctx, userId)
+	if err != nil {
+		return fmt.Errorf("r.cartRepository.GetCartItems: %w", err)
+	}
+	if r.limits.MaxSkus > 0 && cartProductCount == 0 && len(items) >= r.limits.MaxSkus {
+		return customerror.NewErrStatusCodeWithCode(
+			fmt.Sprintf("cart can contain at most %d different products", r.limits.MaxSkus),
+			http.StatusPreconditionFailed,
+			ErrCodeSkusLimit,
+		)
+	}
+	if r.limits.MaxValue == 0 {
+		return nil
+	}
+	// uint32*uint16 всегда помещается в uint64, сумма по корзине тоже
+	value := uint64(price) * totalCount
+	for sku, item := range items {
+		if sku != ProductSku {
+			value += uint64(item.Price) * uint64(item.Count)
+		}
+	}
+	if value > r.limits.MaxValue {
+		return customerror.NewErrStatusCodeWithCode(
+			fmt.Sprintf("cart value can not exceed %d", r.limits.MaxValue),
+			http.StatusPreconditionFailed,
+			ErrCodeValueLimit,
+		)
+	}
+	return nil
+}
+
+func errLineLimit(limit uint16) error {
+	return customerror.NewErrStatusCodeWithCode(
+		fmt.Sprintf("cart can contain at most %d units of a product", limit),
+		http.StatusPreconditionFailed,
+		ErrCodeLineLimit,
+	)
+}
+
 func (r *CartService) RemoveProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) (err error) {
 	ctx, span := tracing.Start(ctx, "CartService.RemoveProduct")
 	defer tracing.EndWithCheckError(span, &err)
@@ -77,9 +216,30 @@ func (r *CartService) RemoveProduct(ctx context.Context, userId model.UserId, Pr
 	if userId < 1 || ProductSku < 1 {
 		return errors.New("invalid userId or ProductSku")
 	}
+	var removed uint16
+	if r.history != nil {
+		if removed, err = r.cartRepository.GetProductCount(ctx, userId, ProductSku); err != nil {
+			return fmt.Errorf("r.cartRepository.GetProductCount: %w", err)
+		}
+	}
 	if err := r.cartRepository.RemoveProduct(ctx, userId, ProductSku); err != nil {
 		return fmt.Errorf("r.cartRepository.RemoveProduct: %w", err)
 	}
+	if removed > 0 {
+		r.recordHistory(ctx, model.CartEvent{
+			User:   userId,
+			Sku:    ProductSku,
+			Delta:  -int32(removed),
+			Action: model.CartEventRemove,
+			Source: model.CartEventSourceUser,
+		})
+	}
+	if r.holdService != nil {
+		// холд все равно истечет, поэтому ошибку только логируем
+		if err := r.holdService.Release(ctx, userId, ProductSku); err != nil {
+			logger.Errorw(ctx, "r.holdService.Release", "err", err)
+		}
+	}
 	return nil
 }
 
@@ -90,51 +250,185 @@ func (r *CartService) ClearCart(ctx context.Context, userId model.UserId) (err e
 	if userId < 1 {
 		return errors.New("invalid userId")
 	}
+	var cart model.Cart
+	if r.history != nil {
+		if cart, err = r.cartRepository.GetCart(ctx, userId); err != nil {
+			return fmt.Errorf("r.cartRepository.GetCart: %w", err)
+		}
+	}
 	if err := r.cartRepository.ClearCart(ctx, userId); err != nil {
 		return fmt.Errorf("r.cartRepository.ClearCart: %w", err)
 	}
+	r.recordClear(ctx, userId, cart, model.CartEventSourceUser)
+	if r.holdService != nil {
+		if err := r.holdService.ReleaseAll(ctx, userId); err != nil {
+			logger.Errorw(ctx, "r.holdService.ReleaseAll", "err", err)
+		}
+	}
 	return nil
 }
 
-func (r *CartService) GetCart(ctx context.Context, userId model.UserId) (_ model.CartFull, err error) {
+func (r *CartService) GetCart(ctx context.Context, userId model.UserId) (_ model.CartView, err error) {
 	ctx, span := tracing.Start(ctx, "CartService.GetCart")
 	defer tracing.EndWithCheckError(span, &err)
 
 	if userId < 1 {
-		return nil, errors.New("invalid userId")
+		return model.CartView{}, errors.New("invalid userId")
 	}
 
-	cart, err := r.cartRepository.GetCart(ctx, userId)
+	items, err := r.cartRepository.GetCartItems(ctx, userId)
 	if err != nil {
-		return nil, fmt.Errorf("r.cartRepository.ClearCart: %w", err)
+		return model.CartView{}, fmt.Errorf("r.cartRepository.GetCartItems: %w", err)
+	}
+	if r.holdService != nil {
+		if err := r.holdService.Extend(ctx, userId); err != nil {
+			logger.Errorw(ctx, "r.holdService.Extend", "err", err)
+		}
 	}
 
-	eg, ctx := utils.NewErrGroup(ctx)
-	cartFullMx := model.NewCartFullMx(len(cart))
+	var wg sync.WaitGroup
+	cartFullMx := model.NewCartFullMx(len(items))
+	warnings := newCartWarnings()
+	unavailable := newCartUnavailable()
 
-	period := time.NewTicker(time.Second / rps)
+	period := time.NewTicker(time.Second / time.Duration(r.productRps.Load()))
 	defer period.Stop()
 
-	for productSku, count := range cart {
-		eg.Go(func() error {
-			<-period.C
-			product, err := r.productService.GetProduct(ctx, productSku)
+	// ошибка одного товара не отменяет загрузку остальных, такие строки помечаем недоступными
+	for productSku, item := range items {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			select {
+			case <-period.C:
+			case <-ctx.Done():
+				unavailable.Add(productSku, item.Count, ctx.Err())
+				return
+			}
+			productCtx, cancel := context.WithTimeout(ctx, productTimeout)
+			defer cancel()
+			product, err := r.productService.GetProduct(productCtx, productSku)
 			if err != nil {
-				return fmt.Errorf("r.productService.GetProduct: %w", err)
+				logger.Errorw(ctx, "r.productService.GetProduct", "sku", productSku, "err", err)
+				unavailable.Add(productSku, item.Count, err)
+				return
 			}
-			cartFullMx.Add(*product, count)
-			return nil
-		})
+			cartFullMx.Add(*product, item.Count)
+			// цена 0 - строка добавлена до того, как стали запоминать цену
+			if item.Price != 0 && item.Price != product.Price {
+				warnings.Add(productSku, model.CartWarning{
+					Type: model.CartWarningPriceChanged,
+					Old:  uint64(item.Price),
+					New:  uint64(product.Price),
+				})
+			}
+		}()
 	}
+	wg.Wait()
+
+	r.checkStocks(ctx, userId, items, warnings)
 
-	if err := eg.Wait(); err != nil {
-		return nil, fmt.Errorf("eg.Wait: %w", err)
+	return model.CartView{
+		Items:       cartFullMx.GetCartFull(),
+		Warnings:    warnings.Get(),
+		Unavailable: unavailable.Get(),
+	}, nil
+}
+
+// checkStocks помечает строки, на которые в LOMS уже не хватает остатка.
+// Корзину без проверки остатков все равно отдаем, поэтому ошибки только логируем.
+func (r *CartService) checkStocks(ctx context.Context, userId model.UserId, items model.CartItems, warnings *cartWarnings) {
+	if len(items) == 0 {
+		return
+	}
+	skus := make([]model.ProductSku, 0, len(items))
+	for sku := range items {
+		skus = append(skus, sku)
+	}
+	sort.Slice(skus, func(i, j int) bool {
+		return skus[i] < skus[j]
+	})
+	stocks, _, err := r.lomsService.StocksInfoBatch(ctx, skus)
+	if err != nil {
+		logger.Errorw(ctx, "r.lomsService.StocksInfoBatch", "err", err)
+		return
+	}
+	for sku, item := range items {
+		// неизвестные LOMS sku приходят без остатка, то есть с нулем
+		available := stocks[sku]
+		if r.holdService != nil {
+			// собственный холд пользователя уже вычтен из остатка
+			available += uint64(r.holdService.Held(userId, sku))
+		}
+		if available < uint64(item.Count) {
+			warnings.Add(sku, model.CartWarning{
+				Type: model.CartWarningNotEnoughStock,
+				Old:  uint64(item.Count),
+				New:  available,
+			})
+		}
+	}
+}
+
+type cartWarnings struct {
+	mx       sync.Mutex
+	warnings map[model.ProductSku][]model.CartWarning
+}
+
+func newCartWarnings() *cartWarnings {
+	return &cartWarnings{
+		warnings: make(map[model.ProductSku][]model.CartWarning),
+	}
+}
+
+func (w *cartWarnings) Add(sku model.ProductSku, warning model.CartWarning) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	w.warnings[sku] = append(w.warnings[sku], warning)
+}
+
+func (w *cartWarnings) Get() map[model.ProductSku][]model.CartWarning {
+	return w.warnings
+}
+
+type cartUnavailable struct {
+	mx    sync.Mutex
+	items map[model.ProductSku]model.CartUnavailableItem
+}
+
+func newCartUnavailable() *cartUnavailable {
+	return &cartUnavailable{
+		items: make(map[model.ProductSku]model.CartUnavailableItem),
+	}
+}
+
+func (u *cartUnavailable) Add(sku model.ProductSku, count uint16, err error) {
+	u.mx.Lock()
+	defer u.mx.Unlock()
+	u.items[sku] = model.CartUnavailableItem{
+		Count:  count,
+		Reason: unavailableReason(err),
 	}
+}
+
+func (u *cartUnavailable) Get() map[model.ProductSku]model.CartUnavailableItem {
+	return u.items
+}
 
-	return cartFullMx.GetCartFull(), nil
+func unavailableReason(err error) model.UnavailableReason {
+	var errStatusCode customerror.ErrStatusCode
+	switch {
+	case errors.As(err, &errStatusCode) && errStatusCode.Status == http.StatusPreconditionFailed:
+		return model.UnavailableReasonNotFound
+	case errors.Is(err, context.DeadlineExceeded):
+		return model.UnavailableReasonTimeout
+	}
+	return model.UnavailableReasonUpstreamError
 }
 
-func (r *CartService) Checkout(ctx context.Context, userId model.UserId) (_ model.OrderId, err error) {
+// Checkout оформляет заказ из корзины. requestId передается в LOMS как ключ идемпотентности,
+// чтобы повтор после сетевой ошибки не создал второй заказ.
+func (r *CartService) Checkout(ctx context.Context, userId model.UserId, requestId string) (_ model.OrderId, err error) {
 	ctx, span := tracing.Start(ctx, "CartService.Checkout")
 	defer tracing.EndWithCheckError(span, &err)
 
@@ -145,12 +439,72 @@ func (r *CartService) Checkout(ctx context.Context, userId model.UserId) (_ mode
 	if err != nil {
 		return 0, fmt.Errorf("r.GetCart: %w", err)
 	}
-	orderId, err := r.lomsService.OrderCreate(ctx, userId, cart)
+	orderId, err := r.lomsService.OrderCreate(ctx, userId, cart, requestId)
 	if err != nil {
 		return 0, fmt.Errorf("r.lomsService.OrderCreate: %w", err)
 	}
+	if r.holdService != nil {
+		// LOMS перевел холды в резерв заказа
+		r.holdService.Forget(userId)
+	}
 	if err := r.cartRepository.ClearCart(ctx, userId); err != nil {
 		return 0, fmt.Errorf("r.cartRepository.ClearCart: %w", err)
 	}
+	r.recordClear(ctx, userId, cart, model.CartEventSourceCheckout)
 	return orderId, nil
 }
+
+func (r *CartService) GetHistory(ctx context.Context, userId model.UserId, filter model.CartHistoryFilter) (_ []model.CartEvent, err error) {
+	ctx, span := tracing.Start(ctx, "CartService.GetHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	if userId < 1 {
+		return nil, errors.New("invalid userId")
+	}
+	if r.history == nil {
+		return nil, customerror.NewErrStatusCode("cart history is disabled", http.StatusNotFound)
+	}
+	events, err := r.history.List(ctx, userId, filter)
+	if err != nil {
+		return nil, fmt.Errorf("r.history.List: %w", err)
+	}
+	return events, nil
+}
+
+// recordClear записывает удаление каждой строки очищенной корзины.
+func (r *CartService) recordClear(ctx context.Context, userId model.UserId, cart model.Cart, source model.CartEventSource) {
+	if r.history == nil || len(cart) == 0 {
+		return
+	}
+	skus := make([]model.ProductSku, 0, len(cart))
+	for sku := range cart {
+		skus = append(skus, sku)
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i] < skus[j] })
+	events := make([]model.CartEvent, 0, len(cart))
+	for _, sku := range skus {
+		events = append(events, model.CartEvent{
+			User:   userId,
+			Sku:    sku,
+			Delta:  -int32(cart[sku]),
+			Action: model.CartEventClear,
+			Source: source,
+		})
+	}
+	r.recordHistory(ctx, events...)
+}
+
+// recordHistory не влияет на результат операции: корзина уже изменена, ошибку только логируем.
+func (r *CartService) recordHistory(ctx context.Context, events ...model.CartEvent) {
+	if r.history == nil {
+		return
+	}
+	if spanContext := trace.SpanContextFromContext(ctx); spanContext.HasTraceID() {
+		for i := range events {
+			events[i].TraceID = spanContext.TraceID().String()
+		}
+	}
+	if err := r.history.Append(ctx, events...); err != nil {
+		logger.Errorw(ctx, "r.history.Append", "err", err)
+	}
+}
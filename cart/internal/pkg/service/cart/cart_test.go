@@ -2,10 +2,16 @@ package cart
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"math"
+	"net/http"
 	"route256/cart/internal/pkg/customerror"
 	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/repository"
 	"route256/cart/internal/pkg/service/cart/mock"
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock/v3"
 	"github.com/stretchr/testify/assert"
@@ -44,7 +50,7 @@ func TestAddProduct(t *testing.T) {
 			productSku: 1,
 			count:      1,
 			prepare: func(mocks *mocks) {
-				mocks.cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1).Return(nil)
+				mocks.cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1, 100).Return(nil)
 				mocks.cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(0, nil)
 				mocks.productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{
 					Sku:   1,
@@ -63,7 +69,7 @@ func TestAddProduct(t *testing.T) {
 			productSku: 1,
 			count:      1,
 			prepare: func(mocks *mocks) {
-				mocks.cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1).Return(nil)
+				mocks.cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1, 100).Return(nil)
 				mocks.cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(0, nil)
 				mocks.productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{
 					Sku:   1,
@@ -128,6 +134,180 @@ func TestAddProduct(t *testing.T) {
 	}
 }
 
+func TestAddProductWithHolds(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	cartRepositoryMock := mock.NewCartRepositoryMock(ctrl)
+	productServiceMock := mock.NewProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
+	holdServiceMock := mock.NewHoldServiceMock(ctrl)
+	cartService := NewCartService(cartRepositoryMock, productServiceMock, lomsServiceMock, WithHoldService(holdServiceMock))
+
+	testData := []struct {
+		name    string
+		prepare func()
+		test    func(err error)
+	}{
+		{
+			name: "hold on add",
+			prepare: func() {
+				productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(2, nil)
+				holdServiceMock.HoldMock.Expect(ctx, 1, 1, 3).Return(nil)
+				cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1, 100).Return(nil)
+			},
+			test: func(err error) {
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name: "not enough stock for hold",
+			prepare: func() {
+				productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(0, nil)
+				holdServiceMock.HoldMock.Expect(ctx, 1, 1, 1).Return(customerror.NewErrStatusCode("not enough products in stock", 412))
+			},
+			test: func(err error) {
+				assert.ErrorAs(t, err, &customerror.ErrStatusCode{})
+			},
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			err := cartService.AddProduct(ctx, 1, 1, 1)
+			tt.test(err)
+		})
+	}
+}
+
+func TestAddProductRestoresHold(t *testing.T) {
+	ctx := context.Background()
+	newService := func(t *testing.T, inCart uint16) (*CartService, *mock.CartRepositoryMock, *mock.HoldServiceMock) {
+		ctrl := minimock.NewController(t)
+		cartRepositoryMock := mock.NewCartRepositoryMock(ctrl)
+		productServiceMock := mock.NewProductServiceMock(ctrl)
+		holdServiceMock := mock.NewHoldServiceMock(ctrl)
+		productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+		cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(inCart, nil)
+		cartService := NewCartService(cartRepositoryMock, productServiceMock, mock.NewLomsServiceMock(ctrl), WithHoldService(holdServiceMock))
+		return cartService, cartRepositoryMock, holdServiceMock
+	}
+
+	t.Run("hold back to cart count", func(t *testing.T) {
+		cartService, cartRepositoryMock, holdServiceMock := newService(t, 2)
+		holdServiceMock.HoldMock.When(ctx, 1, 1, 3).Then(nil)
+		cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1, 100).Return(repository.ErrCountOverflow)
+		// строка не изменилась - холд возвращается к 2
+		holdServiceMock.HoldMock.When(ctx, 1, 1, 2).Then(nil)
+
+		err := cartService.AddProduct(ctx, 1, 1, 1)
+		assert.ErrorAs(t, err, &customerror.ErrStatusCode{})
+		assert.Equal(t, uint64(2), holdServiceMock.HoldAfterCounter())
+	})
+	t.Run("release new hold", func(t *testing.T) {
+		cartService, cartRepositoryMock, holdServiceMock := newService(t, 0)
+		errRepository := errors.New("repository error")
+		holdServiceMock.HoldMock.Expect(ctx, 1, 1, 1).Return(nil)
+		cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 1, 100).Return(errRepository)
+		holdServiceMock.ReleaseMock.Expect(ctx, 1, 1).Return(nil)
+
+		err := cartService.AddProduct(ctx, 1, 1, 1)
+		assert.ErrorIs(t, err, errRepository)
+	})
+}
+
+func TestAddProductLimits(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	cartRepositoryMock := mock.NewCartRepositoryMock(ctrl)
+	productServiceMock := mock.NewProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
+	cartService := NewCartService(cartRepositoryMock, productServiceMock, lomsServiceMock, WithLimits(model.CartLimits{
+		MaxLineCount: 10,
+		MaxSkus:      2,
+		MaxValue:     1000,
+	}))
+
+	testData := []struct {
+		name    string
+		count   uint16
+		prepare func()
+		code    string
+	}{
+		{
+			name:  "within limits",
+			count: 2,
+			prepare: func() {
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(3, nil)
+				cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 3, Price: 100},
+					2: {Count: 1, Price: 200},
+				}, nil)
+				lomsServiceMock.StocksInfoMock.Expect(ctx, 1).Return(5, nil)
+				cartRepositoryMock.AddProductMock.Expect(ctx, 1, 1, 2, 100).Return(nil)
+			},
+		},
+		{
+			name:  "line limit",
+			count: 8,
+			prepare: func() {
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(3, nil)
+			},
+			code: ErrCodeLineLimit,
+		},
+		{
+			name:  "uint16 overflow",
+			count: math.MaxUint16,
+			prepare: func() {
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(1, nil)
+			},
+			code: ErrCodeLineLimit,
+		},
+		{
+			name:  "skus limit",
+			count: 1,
+			prepare: func() {
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(0, nil)
+				cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					2: {Count: 1, Price: 100},
+					3: {Count: 1, Price: 100},
+				}, nil)
+			},
+			code: ErrCodeSkusLimit,
+		},
+		{
+			name:  "value limit",
+			count: 2,
+			prepare: func() {
+				cartRepositoryMock.GetProductCountMock.Expect(ctx, 1, 1).Return(1, nil)
+				cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
+					2: {Count: 4, Price: 200},
+				}, nil)
+			},
+			code: ErrCodeValueLimit,
+		},
+	}
+
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+			tt.prepare()
+			err := cartService.AddProduct(ctx, 1, 1, tt.count)
+			if tt.code == "" {
+				assert.NoError(t, err)
+				return
+			}
+			var errStatusCode customerror.ErrStatusCode
+			assert.ErrorAs(t, err, &errStatusCode)
+			assert.Equal(t, http.StatusPreconditionFailed, errStatusCode.Status)
+			assert.Equal(t, tt.code, errStatusCode.Code)
+		})
+	}
+}
+
 func TestRemoveProduct(t *testing.T) {
 	ctx := context.Background()
 	ctrl := minimock.NewController(t)
@@ -226,59 +406,162 @@ func TestGetCart(t *testing.T) {
 	type mocks struct {
 		cartRepositoryMock *mock.CartRepositoryMock
 		productServiceMock *mock.ProductServiceMock
+		lomsServiceMock    *mock.LomsServiceMock
 	}
 	testMocks := mocks{
 		cartRepositoryMock,
 		productServiceMock,
+		lomsServiceMock,
 	}
 
 	testData := []struct {
 		name    string
 		userId  model.UserId
 		prepare func(mocks *mocks)
-		test    func(cart model.CartFull, err error)
+		test    func(cart model.CartView, err error)
 	}{
 		{
 			name:   "valid params",
 			userId: 1,
 			prepare: func(mocks *mocks) {
-				mocks.cartRepositoryMock.GetCartMock.Expect(ctx, 1).Return(model.Cart{
-					1: 3,
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 3, Price: 100},
 				}, nil)
-				mocks.productServiceMock.GetProductMock.Expect(ctx, 1).Return(&model.Product{
-					Sku:   1111,
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(&model.Product{
+					Sku:   1,
 					Name:  "Book",
 					Price: 100,
 				}, nil)
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{1: 10}, nil, nil)
 			},
-			test: func(cart model.CartFull, err error) {
-				assert.Equal(t, cart[model.Product{
-					Sku:   1111,
+			test: func(cart model.CartView, err error) {
+				assert.Equal(t, cart.Items[model.Product{
+					Sku:   1,
 					Name:  "Book",
 					Price: 100,
 				}], uint16(3))
+				assert.Empty(t, cart.Warnings)
+				assert.NoError(t, err)
+			},
+		},
+		{
+			name:   "price changed and not enough stock",
+			userId: 1,
+			prepare: func(mocks *mocks) {
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 3, Price: 100},
+				}, nil)
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(&model.Product{
+					Sku:   1,
+					Name:  "Book",
+					Price: 120,
+				}, nil)
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{1: 2}, nil, nil)
+			},
+			test: func(cart model.CartView, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, []model.CartWarning{
+					{Type: model.CartWarningPriceChanged, Old: 100, New: 120},
+					{Type: model.CartWarningNotEnoughStock, Old: 3, New: 2},
+				}, cart.Warnings[1])
+			},
+		},
+		{
+			name:   "unknown sku in loms",
+			userId: 1,
+			prepare: func(mocks *mocks) {
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
+				}, nil)
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(&model.Product{
+					Sku:   1,
+					Name:  "Book",
+					Price: 100,
+				}, nil)
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{}, []model.ProductSku{1}, nil)
+			},
+			test: func(cart model.CartView, err error) {
 				assert.NoError(t, err)
+				assert.Equal(t, []model.CartWarning{
+					{Type: model.CartWarningNotEnoughStock, Old: 1, New: 0},
+				}, cart.Warnings[1])
+			},
+		},
+		{
+			name:   "loms unavailable",
+			userId: 1,
+			prepare: func(mocks *mocks) {
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
+				}, nil)
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(&model.Product{
+					Sku:   1,
+					Name:  "Book",
+					Price: 100,
+				}, nil)
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(nil, nil, errors.New("unavailable"))
+			},
+			test: func(cart model.CartView, err error) {
+				assert.NoError(t, err)
+				assert.Len(t, cart.Items, 1)
+				assert.Empty(t, cart.Warnings)
 			},
 		},
 		{
 			name:   "product not found",
 			userId: 1,
 			prepare: func(mocks *mocks) {
-				mocks.cartRepositoryMock.GetCartMock.Expect(ctx, 1).Return(model.Cart{
-					1: 1,
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
 				}, nil)
-				mocks.productServiceMock.GetProductMock.Expect(ctx, 1).Return(nil, customerror.ErrStatusCode{})
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(nil, customerror.NewErrStatusCode("sku 1 not found", http.StatusPreconditionFailed))
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{1: 10}, nil, nil)
 			},
-			test: func(cart model.CartFull, err error) {
-				assert.Nil(t, cart)
-				assert.ErrorAs(t, err, &customerror.ErrStatusCode{})
+			test: func(cart model.CartView, err error) {
+				assert.NoError(t, err)
+				assert.True(t, cart.Degraded())
+				assert.Empty(t, cart.Items)
+				assert.Equal(t, map[model.ProductSku]model.CartUnavailableItem{
+					1: {Count: 1, Reason: model.UnavailableReasonNotFound},
+				}, cart.Unavailable)
+			},
+		},
+		{
+			name:   "product timeout",
+			userId: 1,
+			prepare: func(mocks *mocks) {
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
+				}, nil)
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(nil, fmt.Errorf("http.Post: %w", context.DeadlineExceeded))
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{1: 10}, nil, nil)
+			},
+			test: func(cart model.CartView, err error) {
+				assert.NoError(t, err)
+				assert.Empty(t, cart.Items)
+				assert.Equal(t, model.UnavailableReasonTimeout, cart.Unavailable[1].Reason)
+			},
+		},
+		{
+			name:   "product service error",
+			userId: 1,
+			prepare: func(mocks *mocks) {
+				mocks.cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+					1: {Count: 1, Price: 100},
+				}, nil)
+				mocks.productServiceMock.GetProductMock.Expect(minimock.AnyContext, 1).Return(nil, customerror.NewErrStatusCode("product service responded 500", http.StatusBadGateway))
+				mocks.lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1}).Return(map[model.ProductSku]uint64{1: 10}, nil, nil)
+			},
+			test: func(cart model.CartView, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.UnavailableReasonUpstreamError, cart.Unavailable[1].Reason)
 			},
 		},
 		{
 			name:   "invalid userId",
 			userId: 0,
-			test: func(cart model.CartFull, err error) {
-				assert.Nil(t, cart)
+			test: func(cart model.CartView, err error) {
+				assert.Nil(t, cart.Items)
 				assert.Error(t, err)
 			},
 		},
@@ -294,3 +577,102 @@ func TestGetCart(t *testing.T) {
 		})
 	}
 }
+
+func TestGetCartPartial(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	cartRepositoryMock := mock.NewCartRepositoryMock(ctrl)
+	productServiceMock := mock.NewProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
+	cartService := NewCartService(cartRepositoryMock, productServiceMock, lomsServiceMock)
+
+	cartRepositoryMock.GetCartItemsMock.Expect(ctx, 1).Return(model.CartItems{
+		1: {Count: 1, Price: 100},
+		2: {Count: 2, Price: 200},
+	}, nil)
+	productServiceMock.GetProductMock.When(minimock.AnyContext, 1).Then(nil, customerror.NewErrStatusCode("sku 1 not found", http.StatusPreconditionFailed))
+	productServiceMock.GetProductMock.When(minimock.AnyContext, 2).Then(&model.Product{
+		Sku:   2,
+		Name:  "Pen",
+		Price: 200,
+	}, nil)
+	lomsServiceMock.StocksInfoBatchMock.Expect(ctx, []model.ProductSku{1, 2}).Return(map[model.ProductSku]uint64{1: 10, 2: 10}, nil, nil)
+
+	cart, err := cartService.GetCart(ctx, 1)
+	assert.NoError(t, err)
+	assert.True(t, cart.Degraded())
+	assert.Equal(t, model.CartFull{{Sku: 2, Name: "Pen", Price: 200}: 2}, cart.Items)
+	assert.Equal(t, map[model.ProductSku]model.CartUnavailableItem{
+		1: {Count: 1, Reason: model.UnavailableReasonNotFound},
+	}, cart.Unavailable)
+}
+
+func TestCheckout(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	productServiceMock := mock.NewProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
+	cartService := NewCartService(repository.NewCartMemoryRepository(), productServiceMock, lomsServiceMock)
+
+	productServiceMock.GetProductMock.Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+	lomsServiceMock.StocksInfoMock.Return(100, nil)
+	assert.NoError(t, cartService.AddProduct(ctx, 1, 1, 2))
+
+	// ключ идемпотентности передается в LOMS без изменений
+	lomsServiceMock.OrderCreateMock.Expect(ctx, 1, model.Cart{1: 2}, "checkout-1").Return(10, nil)
+	orderId, err := cartService.Checkout(ctx, 1, "checkout-1")
+	assert.NoError(t, err)
+	assert.Equal(t, model.OrderId(10), orderId)
+
+	// повтор после очистки корзины уходит в LOMS без позиций и получает тот же заказ
+	lomsServiceMock.OrderCreateMock.Expect(ctx, 1, model.Cart{}, "checkout-1").Return(10, nil)
+	orderId, err = cartService.Checkout(ctx, 1, "checkout-1")
+	assert.NoError(t, err)
+	assert.Equal(t, model.OrderId(10), orderId)
+}
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	productServiceMock := mock.NewProductServiceMock(ctrl)
+	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
+	history := repository.NewCartHistoryMemoryRepository(time.Hour)
+	cartService := NewCartService(repository.NewCartMemoryRepository(), productServiceMock, lomsServiceMock, WithHistory(history))
+
+	productServiceMock.GetProductMock.Return(&model.Product{Sku: 1, Name: "Book", Price: 100}, nil)
+	lomsServiceMock.StocksInfoMock.Return(100, nil)
+	lomsServiceMock.OrderCreateMock.Return(1, nil)
+
+	assert.NoError(t, cartService.AddProduct(ctx, 1, 1, 3))
+	assert.NoError(t, cartService.AddProduct(ctx, 1, 2, 1))
+	assert.NoError(t, cartService.RemoveProduct(ctx, 1, 2))
+	assert.NoError(t, cartService.RemoveProduct(ctx, 1, 2))
+	assert.NoError(t, cartService.AddProduct(ctx, 1, 2, 2))
+	assert.NoError(t, cartService.ClearCart(ctx, 1))
+	assert.NoError(t, cartService.AddProduct(ctx, 1, 1, 1))
+	_, err := cartService.Checkout(ctx, 1, "")
+	assert.NoError(t, err)
+
+	type event struct {
+		Sku    model.ProductSku
+		Delta  int32
+		Action model.CartEventAction
+		Source model.CartEventSource
+	}
+	events, err := cartService.GetHistory(ctx, 1, model.CartHistoryFilter{Limit: 100})
+	assert.NoError(t, err)
+	got := make([]event, 0, len(events))
+	for _, e := range events {
+		got = append(got, event{e.Sku, e.Delta, e.Action, e.Source})
+	}
+	assert.Equal(t, []event{
+		{1, 3, model.CartEventAdd, model.CartEventSourceUser},
+		{2, 1, model.CartEventAdd, model.CartEventSourceUser},
+		{2, -1, model.CartEventRemove, model.CartEventSourceUser},
+		{2, 2, model.CartEventAdd, model.CartEventSourceUser},
+		{1, -3, model.CartEventClear, model.CartEventSourceUser},
+		{2, -2, model.CartEventClear, model.CartEventSourceUser},
+		{1, 1, model.CartEventAdd, model.CartEventSourceUser},
+		{1, -1, model.CartEventClear, model.CartEventSourceCheckout},
+	}, got)
+}
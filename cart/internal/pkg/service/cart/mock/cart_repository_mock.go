@@ -19,8 +19,8 @@ type CartRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcAddProduct          func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) (err error)
-	inspectFuncAddProduct   func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16)
+	funcAddProduct          func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) (err error)
+	inspectFuncAddProduct   func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32)
 	afterAddProductCounter  uint64
 	beforeAddProductCounter uint64
 	AddProductMock          mCartRepositoryMockAddProduct
@@ -37,6 +37,12 @@ type CartRepositoryMock struct {
 	beforeGetCartCounter uint64
 	GetCartMock          mCartRepositoryMockGetCart
 
+	funcGetCartItems          func(ctx context.Context, userId model.UserId) (c2 model.CartItems, err error)
+	inspectFuncGetCartItems   func(ctx context.Context, userId model.UserId)
+	afterGetCartItemsCounter  uint64
+	beforeGetCartItemsCounter uint64
+	GetCartItemsMock          mCartRepositoryMockGetCartItems
+
 	funcGetProductCount          func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku) (u1 uint16, err error)
 	inspectFuncGetProductCount   func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku)
 	afterGetProductCountCounter  uint64
@@ -67,6 +73,9 @@ func NewCartRepositoryMock(t minimock.Tester) *CartRepositoryMock {
 	m.GetCartMock = mCartRepositoryMockGetCart{mock: m}
 	m.GetCartMock.callArgs = []*CartRepositoryMockGetCartParams{}
 
+	m.GetCartItemsMock = mCartRepositoryMockGetCartItems{mock: m}
+	m.GetCartItemsMock.callArgs = []*CartRepositoryMockGetCartItemsParams{}
+
 	m.GetProductCountMock = mCartRepositoryMockGetProductCount{mock: m}
 	m.GetProductCountMock.callArgs = []*CartRepositoryMockGetProductCountParams{}
 
@@ -105,6 +114,7 @@ type CartRepositoryMockAddProductParams struct {
 	userId     model.UserId
 	ProductSku model.ProductSku
 	count      uint16
+	price      uint32
 }
 
 // CartRepositoryMockAddProductParamPtrs contains pointers to parameters of the cartRepository.AddProduct
@@ -113,6 +123,7 @@ type CartRepositoryMockAddProductParamPtrs struct {
 	userId     *model.UserId
 	ProductSku *model.ProductSku
 	count      *uint16
+	price      *uint32
 }
 
 // CartRepositoryMockAddProductResults contains results of the cartRepository.AddProduct
@@ -131,7 +142,7 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) Optional() *mCartRepositoryMo
 }
 
 // Expect sets up expected params for cartRepository.AddProduct
-func (mmAddProduct *mCartRepositoryMockAddProduct) Expect(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) *mCartRepositoryMockAddProduct {
+func (mmAddProduct *mCartRepositoryMockAddProduct) Expect(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) *mCartRepositoryMockAddProduct {
 	if mmAddProduct.mock.funcAddProduct != nil {
 		mmAddProduct.mock.t.Fatalf("CartRepositoryMock.AddProduct mock is already set by Set")
 	}
@@ -144,7 +155,7 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) Expect(ctx context.Context, u
 		mmAddProduct.mock.t.Fatalf("CartRepositoryMock.AddProduct mock is already set by ExpectParams functions")
 	}
 
-	mmAddProduct.defaultExpectation.params = &CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count}
+	mmAddProduct.defaultExpectation.params = &CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count, price}
 	for _, e := range mmAddProduct.expectations {
 		if minimock.Equal(e.params, mmAddProduct.defaultExpectation.params) {
 			mmAddProduct.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddProduct.defaultExpectation.params)
@@ -242,8 +253,30 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) ExpectCountParam4(count uint1
 	return mmAddProduct
 }
 
+// ExpectPriceParam5 sets up expected param price for cartRepository.AddProduct
+func (mmAddProduct *mCartRepositoryMockAddProduct) ExpectPriceParam5(price uint32) *mCartRepositoryMockAddProduct {
+	if mmAddProduct.mock.funcAddProduct != nil {
+		mmAddProduct.mock.t.Fatalf("CartRepositoryMock.AddProduct mock is already set by Set")
+	}
+
+	if mmAddProduct.defaultExpectation == nil {
+		mmAddProduct.defaultExpectation = &CartRepositoryMockAddProductExpectation{}
+	}
+
+	if mmAddProduct.defaultExpectation.params != nil {
+		mmAddProduct.mock.t.Fatalf("CartRepositoryMock.AddProduct mock is already set by Expect")
+	}
+
+	if mmAddProduct.defaultExpectation.paramPtrs == nil {
+		mmAddProduct.defaultExpectation.paramPtrs = &CartRepositoryMockAddProductParamPtrs{}
+	}
+	mmAddProduct.defaultExpectation.paramPtrs.price = &price
+
+	return mmAddProduct
+}
+
 // Inspect accepts an inspector function that has same arguments as the cartRepository.AddProduct
-func (mmAddProduct *mCartRepositoryMockAddProduct) Inspect(f func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16)) *mCartRepositoryMockAddProduct {
+func (mmAddProduct *mCartRepositoryMockAddProduct) Inspect(f func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32)) *mCartRepositoryMockAddProduct {
 	if mmAddProduct.mock.inspectFuncAddProduct != nil {
 		mmAddProduct.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.AddProduct")
 	}
@@ -267,7 +300,7 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) Return(err error) *CartReposi
 }
 
 // Set uses given function f to mock the cartRepository.AddProduct method
-func (mmAddProduct *mCartRepositoryMockAddProduct) Set(f func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) (err error)) *CartRepositoryMock {
+func (mmAddProduct *mCartRepositoryMockAddProduct) Set(f func(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) (err error)) *CartRepositoryMock {
 	if mmAddProduct.defaultExpectation != nil {
 		mmAddProduct.mock.t.Fatalf("Default expectation is already set for the cartRepository.AddProduct method")
 	}
@@ -282,14 +315,14 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) Set(f func(ctx context.Contex
 
 // When sets expectation for the cartRepository.AddProduct which will trigger the result defined by the following
 // Then helper
-func (mmAddProduct *mCartRepositoryMockAddProduct) When(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) *CartRepositoryMockAddProductExpectation {
+func (mmAddProduct *mCartRepositoryMockAddProduct) When(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) *CartRepositoryMockAddProductExpectation {
 	if mmAddProduct.mock.funcAddProduct != nil {
 		mmAddProduct.mock.t.Fatalf("CartRepositoryMock.AddProduct mock is already set by Set")
 	}
 
 	expectation := &CartRepositoryMockAddProductExpectation{
 		mock:   mmAddProduct.mock,
-		params: &CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count},
+		params: &CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count, price},
 	}
 	mmAddProduct.expectations = append(mmAddProduct.expectations, expectation)
 	return expectation
@@ -322,15 +355,15 @@ func (mmAddProduct *mCartRepositoryMockAddProduct) invocationsDone() bool {
 }
 
 // AddProduct implements cart.cartRepository
-func (mmAddProduct *CartRepositoryMock) AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16) (err error) {
+func (mmAddProduct *CartRepositoryMock) AddProduct(ctx context.Context, userId model.UserId, ProductSku model.ProductSku, count uint16, price uint32) (err error) {
 	mm_atomic.AddUint64(&mmAddProduct.beforeAddProductCounter, 1)
 	defer mm_atomic.AddUint64(&mmAddProduct.afterAddProductCounter, 1)
 
 	if mmAddProduct.inspectFuncAddProduct != nil {
-		mmAddProduct.inspectFuncAddProduct(ctx, userId, ProductSku, count)
+		mmAddProduct.inspectFuncAddProduct(ctx, userId, ProductSku, count, price)
 	}
 
-	mm_params := CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count}
+	mm_params := CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count, price}
 
 	// Record call args
 	mmAddProduct.AddProductMock.mutex.Lock()
@@ -349,7 +382,7 @@ func (mmAddProduct *CartRepositoryMock) AddProduct(ctx context.Context, userId m
 		mm_want := mmAddProduct.AddProductMock.defaultExpectation.params
 		mm_want_ptrs := mmAddProduct.AddProductMock.defaultExpectation.paramPtrs
 
-		mm_got := CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count}
+		mm_got := CartRepositoryMockAddProductParams{ctx, userId, ProductSku, count, price}
 
 		if mm_want_ptrs != nil {
 
@@ -369,6 +402,10 @@ func (mmAddProduct *CartRepositoryMock) AddProduct(ctx context.Context, userId m
 				mmAddProduct.t.Errorf("CartRepositoryMock.AddProduct got unexpected parameter count, want: %#v, got: %#v%s\n", *mm_want_ptrs.count, mm_got.count, minimock.Diff(*mm_want_ptrs.count, mm_got.count))
 			}
 
+			if mm_want_ptrs.price != nil && !minimock.Equal(*mm_want_ptrs.price, mm_got.price) {
+				mmAddProduct.t.Errorf("CartRepositoryMock.AddProduct got unexpected parameter price, want: %#v, got: %#v%s\n", *mm_want_ptrs.price, mm_got.price, minimock.Diff(*mm_want_ptrs.price, mm_got.price))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmAddProduct.t.Errorf("CartRepositoryMock.AddProduct got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -380,9 +417,9 @@ func (mmAddProduct *CartRepositoryMock) AddProduct(ctx context.Context, userId m
 		return (*mm_results).err
 	}
 	if mmAddProduct.funcAddProduct != nil {
-		return mmAddProduct.funcAddProduct(ctx, userId, ProductSku, count)
+		return mmAddProduct.funcAddProduct(ctx, userId, ProductSku, count, price)
 	}
-	mmAddProduct.t.Fatalf("Unexpected call to CartRepositoryMock.AddProduct. %v %v %v %v", ctx, userId, ProductSku, count)
+	mmAddProduct.t.Fatalf("Unexpected call to CartRepositoryMock.AddProduct. %v %v %v %v %v", ctx, userId, ProductSku, count, price)
 	return
 }
 
@@ -1095,6 +1132,327 @@ func (m *CartRepositoryMock) MinimockGetCartInspect() {
 	}
 }
 
+type mCartRepositoryMockGetCartItems struct {
+	optional           bool
+	mock               *CartRepositoryMock
+	defaultExpectation *CartRepositoryMockGetCartItemsExpectation
+	expectations       []*CartRepositoryMockGetCartItemsExpectation
+
+	callArgs []*CartRepositoryMockGetCartItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// CartRepositoryMockGetCartItemsExpectation specifies expectation struct of the cartRepository.GetCartItems
+type CartRepositoryMockGetCartItemsExpectation struct {
+	mock      *CartRepositoryMock
+	params    *CartRepositoryMockGetCartItemsParams
+	paramPtrs *CartRepositoryMockGetCartItemsParamPtrs
+	results   *CartRepositoryMockGetCartItemsResults
+	Counter   uint64
+}
+
+// CartRepositoryMockGetCartItemsParams contains parameters of the cartRepository.GetCartItems
+type CartRepositoryMockGetCartItemsParams struct {
+	ctx    context.Context
+	userId model.UserId
+}
+
+// CartRepositoryMockGetCartItemsParamPtrs contains pointers to parameters of the cartRepository.GetCartItems
+type CartRepositoryMockGetCartItemsParamPtrs struct {
+	ctx    *context.Context
+	userId *model.UserId
+}
+
+// CartRepositoryMockGetCartItemsResults contains results of the cartRepository.GetCartItems
+type CartRepositoryMockGetCartItemsResults struct {
+	c2  model.CartItems
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Optional() *mCartRepositoryMockGetCartItems {
+	mmGetCartItems.optional = true
+	return mmGetCartItems
+}
+
+// Expect sets up expected params for cartRepository.GetCartItems
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Expect(ctx context.Context, userId model.UserId) *mCartRepositoryMockGetCartItems {
+	if mmGetCartItems.mock.funcGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Set")
+	}
+
+	if mmGetCartItems.defaultExpectation == nil {
+		mmGetCartItems.defaultExpectation = &CartRepositoryMockGetCartItemsExpectation{}
+	}
+
+	if mmGetCartItems.defaultExpectation.paramPtrs != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by ExpectParams functions")
+	}
+
+	mmGetCartItems.defaultExpectation.params = &CartRepositoryMockGetCartItemsParams{ctx, userId}
+	for _, e := range mmGetCartItems.expectations {
+		if minimock.Equal(e.params, mmGetCartItems.defaultExpectation.params) {
+			mmGetCartItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetCartItems.defaultExpectation.params)
+		}
+	}
+
+	return mmGetCartItems
+}
+
+// ExpectCtxParam1 sets up expected param ctx for cartRepository.GetCartItems
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) ExpectCtxParam1(ctx context.Context) *mCartRepositoryMockGetCartItems {
+	if mmGetCartItems.mock.funcGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Set")
+	}
+
+	if mmGetCartItems.defaultExpectation == nil {
+		mmGetCartItems.defaultExpectation = &CartRepositoryMockGetCartItemsExpectation{}
+	}
+
+	if mmGetCartItems.defaultExpectation.params != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Expect")
+	}
+
+	if mmGetCartItems.defaultExpectation.paramPtrs == nil {
+		mmGetCartItems.defaultExpectation.paramPtrs = &CartRepositoryMockGetCartItemsParamPtrs{}
+	}
+	mmGetCartItems.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetCartItems
+}
+
+// ExpectUserIdParam2 sets up expected param userId for cartRepository.GetCartItems
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) ExpectUserIdParam2(userId model.UserId) *mCartRepositoryMockGetCartItems {
+	if mmGetCartItems.mock.funcGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Set")
+	}
+
+	if mmGetCartItems.defaultExpectation == nil {
+		mmGetCartItems.defaultExpectation = &CartRepositoryMockGetCartItemsExpectation{}
+	}
+
+	if mmGetCartItems.defaultExpectation.params != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Expect")
+	}
+
+	if mmGetCartItems.defaultExpectation.paramPtrs == nil {
+		mmGetCartItems.defaultExpectation.paramPtrs = &CartRepositoryMockGetCartItemsParamPtrs{}
+	}
+	mmGetCartItems.defaultExpectation.paramPtrs.userId = &userId
+
+	return mmGetCartItems
+}
+
+// Inspect accepts an inspector function that has same arguments as the cartRepository.GetCartItems
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Inspect(f func(ctx context.Context, userId model.UserId)) *mCartRepositoryMockGetCartItems {
+	if mmGetCartItems.mock.inspectFuncGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("Inspect function is already set for CartRepositoryMock.GetCartItems")
+	}
+
+	mmGetCartItems.mock.inspectFuncGetCartItems = f
+
+	return mmGetCartItems
+}
+
+// Return sets up results that will be returned by cartRepository.GetCartItems
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Return(c2 model.CartItems, err error) *CartRepositoryMock {
+	if mmGetCartItems.mock.funcGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Set")
+	}
+
+	if mmGetCartItems.defaultExpectation == nil {
+		mmGetCartItems.defaultExpectation = &CartRepositoryMockGetCartItemsExpectation{mock: mmGetCartItems.mock}
+	}
+	mmGetCartItems.defaultExpectation.results = &CartRepositoryMockGetCartItemsResults{c2, err}
+	return mmGetCartItems.mock
+}
+
+// Set uses given function f to mock the cartRepository.GetCartItems method
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Set(f func(ctx context.Context, userId model.UserId) (c2 model.CartItems, err error)) *CartRepositoryMock {
+	if mmGetCartItems.defaultExpectation != nil {
+		mmGetCartItems.mock.t.Fatalf("Default expectation is already set for the cartRepository.GetCartItems method")
+	}
+
+	if len(mmGetCartItems.expectations) > 0 {
+		mmGetCartItems.mock.t.Fatalf("Some expectations are already set for the cartRepository.GetCartItems method")
+	}
+
+	mmGetCartItems.mock.funcGetCartItems = f
+	return mmGetCartItems.mock
+}
+
+// When sets expectation for the cartRepository.GetCartItems which will trigger the result defined by the following
+// Then helper
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) When(ctx context.Context, userId model.UserId) *CartRepositoryMockGetCartItemsExpectation {
+	if mmGetCartItems.mock.funcGetCartItems != nil {
+		mmGetCartItems.mock.t.Fatalf("CartRepositoryMock.GetCartItems mock is already set by Set")
+	}
+
+	expectation := &CartRepositoryMockGetCartItemsExpectation{
+		mock:   mmGetCartItems.mock,
+		params: &CartRepositoryMockGetCartItemsParams{ctx, userId},
+	}
+	mmGetCartItems.expectations = append(mmGetCartItems.expectations, expectation)
+	return expectation
+}
+
+// Then sets up cartRepository.GetCartItems return parameters for the expectation previously defined by the When method
+func (e *CartRepositoryMockGetCartItemsExpectation) Then(c2 model.CartItems, err error) *CartRepositoryMock {
+	e.results = &CartRepositoryMockGetCartItemsResults{c2, err}
+	return e.mock
+}
+
+// Times sets number of times cartRepository.GetCartItems should be invoked
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Times(n uint64) *mCartRepositoryMockGetCartItems {
+	if n == 0 {
+		mmGetCartItems.mock.t.Fatalf("Times of CartRepositoryMock.GetCartItems mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetCartItems.expectedInvocations, n)
+	return mmGetCartItems
+}
+
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) invocationsDone() bool {
+	if len(mmGetCartItems.expectations) == 0 && mmGetCartItems.defaultExpectation == nil && mmGetCartItems.mock.funcGetCartItems == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetCartItems.mock.afterGetCartItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetCartItems.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetCartItems implements cart.cartRepository
+func (mmGetCartItems *CartRepositoryMock) GetCartItems(ctx context.Context, userId model.UserId) (c2 model.CartItems, err error) {
+	mm_atomic.AddUint64(&mmGetCartItems.beforeGetCartItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetCartItems.afterGetCartItemsCounter, 1)
+
+	if mmGetCartItems.inspectFuncGetCartItems != nil {
+		mmGetCartItems.inspectFuncGetCartItems(ctx, userId)
+	}
+
+	mm_params := CartRepositoryMockGetCartItemsParams{ctx, userId}
+
+	// Record call args
+	mmGetCartItems.GetCartItemsMock.mutex.Lock()
+	mmGetCartItems.GetCartItemsMock.callArgs = append(mmGetCartItems.GetCartItemsMock.callArgs, &mm_params)
+	mmGetCartItems.GetCartItemsMock.mutex.Unlock()
+
+	for _, e := range mmGetCartItems.GetCartItemsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.c2, e.results.err
+		}
+	}
+
+	if mmGetCartItems.GetCartItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetCartItems.GetCartItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetCartItems.GetCartItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetCartItems.GetCartItemsMock.defaultExpectation.paramPtrs
+
+		mm_got := CartRepositoryMockGetCartItemsParams{ctx, userId}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetCartItems.t.Errorf("CartRepositoryMock.GetCartItems got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.userId != nil && !minimock.Equal(*mm_want_ptrs.userId, mm_got.userId) {
+				mmGetCartItems.t.Errorf("CartRepositoryMock.GetCartItems got unexpected parameter userId, want: %#v, got: %#v%s\n", *mm_want_ptrs.userId, mm_got.userId, minimock.Diff(*mm_want_ptrs.userId, mm_got.userId))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetCartItems.t.Errorf("CartRepositoryMock.GetCartItems got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetCartItems.GetCartItemsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetCartItems.t.Fatal("No results are set for the CartRepositoryMock.GetCartItems")
+		}
+		return (*mm_results).c2, (*mm_results).err
+	}
+	if mmGetCartItems.funcGetCartItems != nil {
+		return mmGetCartItems.funcGetCartItems(ctx, userId)
+	}
+	mmGetCartItems.t.Fatalf("Unexpected call to CartRepositoryMock.GetCartItems. %v %v", ctx, userId)
+	return
+}
+
+// GetCartItemsAfterCounter returns a count of finished CartRepositoryMock.GetCartItems invocations
+func (mmGetCartItems *CartRepositoryMock) GetCartItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCartItems.afterGetCartItemsCounter)
+}
+
+// GetCartItemsBeforeCounter returns a count of CartRepositoryMock.GetCartItems invocations
+func (mmGetCartItems *CartRepositoryMock) GetCartItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetCartItems.beforeGetCartItemsCounter)
+}
+
+// Calls returns a list of arguments used in each call to CartRepositoryMock.GetCartItems.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetCartItems *mCartRepositoryMockGetCartItems) Calls() []*CartRepositoryMockGetCartItemsParams {
+	mmGetCartItems.mutex.RLock()
+
+	argCopy := make([]*CartRepositoryMockGetCartItemsParams, len(mmGetCartItems.callArgs))
+	copy(argCopy, mmGetCartItems.callArgs)
+
+	mmGetCartItems.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetCartItemsDone returns true if the count of the GetCartItems invocations corresponds
+// the number of defined expectations
+func (m *CartRepositoryMock) MinimockGetCartItemsDone() bool {
+	if m.GetCartItemsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetCartItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetCartItemsMock.invocationsDone()
+}
+
+// MinimockGetCartItemsInspect logs each unmet expectation
+func (m *CartRepositoryMock) MinimockGetCartItemsInspect() {
+	for _, e := range m.GetCartItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetCartItems with params: %#v", *e.params)
+		}
+	}
+
+	afterGetCartItemsCounter := mm_atomic.LoadUint64(&m.afterGetCartItemsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetCartItemsMock.defaultExpectation != nil && afterGetCartItemsCounter < 1 {
+		if m.GetCartItemsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to CartRepositoryMock.GetCartItems")
+		} else {
+			m.t.Errorf("Expected call to CartRepositoryMock.GetCartItems with params: %#v", *m.GetCartItemsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetCartItems != nil && afterGetCartItemsCounter < 1 {
+		m.t.Error("Expected call to CartRepositoryMock.GetCartItems")
+	}
+
+	if !m.GetCartItemsMock.invocationsDone() && afterGetCartItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to CartRepositoryMock.GetCartItems but found %d calls",
+			mm_atomic.LoadUint64(&m.GetCartItemsMock.expectedInvocations), afterGetCartItemsCounter)
+	}
+}
+
 type mCartRepositoryMockGetProductCount struct {
 	optional           bool
 	mock               *CartRepositoryMock
@@ -1802,6 +2160,8 @@ func (m *CartRepositoryMock) MinimockFinish() {
 
 			m.MinimockGetCartInspect()
 
+			m.MinimockGetCartItemsInspect()
+
 			m.MinimockGetProductCountInspect()
 
 			m.MinimockRemoveProductInspect()
@@ -1832,6 +2192,7 @@ func (m *CartRepositoryMock) minimockDone() bool {
 		m.MinimockAddProductDone() &&
 		m.MinimockClearCartDone() &&
 		m.MinimockGetCartDone() &&
+		m.MinimockGetCartItemsDone() &&
 		m.MinimockGetProductCountDone() &&
 		m.MinimockRemoveProductDone()
 }
@@ -0,0 +1,2083 @@
+// Code generated by http://github.com/gojuno/minimock (v3.3.11). DO NOT EDIT.
+
+package mock
+
+//go:generate minimock -i route256/cart/internal/pkg/service/cart.holdService -o hold_service_mock_test.go -n HoldServiceMock -p mock
+
+import (
+	"context"
+	"route256/cart/internal/pkg/model"
+	"sync"
+	mm_atomic "sync/atomic"
+	mm_time "time"
+
+	"github.com/gojuno/minimock/v3"
+)
+
+// HoldServiceMock implements cart.holdService
+type HoldServiceMock struct {
+	t          minimock.Tester
+	finishOnce sync.Once
+
+	funcExtend          func(ctx context.Context, user model.UserId) (err error)
+	inspectFuncExtend   func(ctx context.Context, user model.UserId)
+	afterExtendCounter  uint64
+	beforeExtendCounter uint64
+	ExtendMock          mHoldServiceMockExtend
+
+	funcForget          func(user model.UserId)
+	inspectFuncForget   func(user model.UserId)
+	afterForgetCounter  uint64
+	beforeForgetCounter uint64
+	ForgetMock          mHoldServiceMockForget
+
+	funcHeld          func(user model.UserId, sku model.ProductSku) (u1 uint16)
+	inspectFuncHeld   func(user model.UserId, sku model.ProductSku)
+	afterHeldCounter  uint64
+	beforeHeldCounter uint64
+	HeldMock          mHoldServiceMockHeld
+
+	funcHold          func(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) (err error)
+	inspectFuncHold   func(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16)
+	afterHoldCounter  uint64
+	beforeHoldCounter uint64
+	HoldMock          mHoldServiceMockHold
+
+	funcRelease          func(ctx context.Context, user model.UserId, sku model.ProductSku) (err error)
+	inspectFuncRelease   func(ctx context.Context, user model.UserId, sku model.ProductSku)
+	afterReleaseCounter  uint64
+	beforeReleaseCounter uint64
+	ReleaseMock          mHoldServiceMockRelease
+
+	funcReleaseAll          func(ctx context.Context, user model.UserId) (err error)
+	inspectFuncReleaseAll   func(ctx context.Context, user model.UserId)
+	afterReleaseAllCounter  uint64
+	beforeReleaseAllCounter uint64
+	ReleaseAllMock          mHoldServiceMockReleaseAll
+}
+
+// NewHoldServiceMock returns a mock for cart.holdService
+func NewHoldServiceMock(t minimock.Tester) *HoldServiceMock {
+	m := &HoldServiceMock{t: t}
+
+	if controller, ok := t.(minimock.MockController); ok {
+		controller.RegisterMocker(m)
+	}
+
+	m.ExtendMock = mHoldServiceMockExtend{mock: m}
+	m.ExtendMock.callArgs = []*HoldServiceMockExtendParams{}
+
+	m.ForgetMock = mHoldServiceMockForget{mock: m}
+	m.ForgetMock.callArgs = []*HoldServiceMockForgetParams{}
+
+	m.HeldMock = mHoldServiceMockHeld{mock: m}
+	m.HeldMock.callArgs = []*HoldServiceMockHeldParams{}
+
+	m.HoldMock = mHoldServiceMockHold{mock: m}
+	m.HoldMock.callArgs = []*HoldServiceMockHoldParams{}
+
+	m.ReleaseMock = mHoldServiceMockRelease{mock: m}
+	m.ReleaseMock.callArgs = []*HoldServiceMockReleaseParams{}
+
+	m.ReleaseAllMock = mHoldServiceMockReleaseAll{mock: m}
+	m.ReleaseAllMock.callArgs = []*HoldServiceMockReleaseAllParams{}
+
+	t.Cleanup(m.MinimockFinish)
+
+	return m
+}
+
+type mHoldServiceMockExtend struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockExtendExpectation
+	expectations       []*HoldServiceMockExtendExpectation
+
+	callArgs []*HoldServiceMockExtendParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockExtendExpectation specifies expectation struct of the holdService.Extend
+type HoldServiceMockExtendExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockExtendParams
+	paramPtrs *HoldServiceMockExtendParamPtrs
+	results   *HoldServiceMockExtendResults
+	Counter   uint64
+}
+
+// HoldServiceMockExtendParams contains parameters of the holdService.Extend
+type HoldServiceMockExtendParams struct {
+	ctx  context.Context
+	user model.UserId
+}
+
+// HoldServiceMockExtendParamPtrs contains pointers to parameters of the holdService.Extend
+type HoldServiceMockExtendParamPtrs struct {
+	ctx  *context.Context
+	user *model.UserId
+}
+
+// HoldServiceMockExtendResults contains results of the holdService.Extend
+type HoldServiceMockExtendResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmExtend *mHoldServiceMockExtend) Optional() *mHoldServiceMockExtend {
+	mmExtend.optional = true
+	return mmExtend
+}
+
+// Expect sets up expected params for holdService.Extend
+func (mmExtend *mHoldServiceMockExtend) Expect(ctx context.Context, user model.UserId) *mHoldServiceMockExtend {
+	if mmExtend.mock.funcExtend != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Set")
+	}
+
+	if mmExtend.defaultExpectation == nil {
+		mmExtend.defaultExpectation = &HoldServiceMockExtendExpectation{}
+	}
+
+	if mmExtend.defaultExpectation.paramPtrs != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by ExpectParams functions")
+	}
+
+	mmExtend.defaultExpectation.params = &HoldServiceMockExtendParams{ctx, user}
+	for _, e := range mmExtend.expectations {
+		if minimock.Equal(e.params, mmExtend.defaultExpectation.params) {
+			mmExtend.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmExtend.defaultExpectation.params)
+		}
+	}
+
+	return mmExtend
+}
+
+// ExpectCtxParam1 sets up expected param ctx for holdService.Extend
+func (mmExtend *mHoldServiceMockExtend) ExpectCtxParam1(ctx context.Context) *mHoldServiceMockExtend {
+	if mmExtend.mock.funcExtend != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Set")
+	}
+
+	if mmExtend.defaultExpectation == nil {
+		mmExtend.defaultExpectation = &HoldServiceMockExtendExpectation{}
+	}
+
+	if mmExtend.defaultExpectation.params != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Expect")
+	}
+
+	if mmExtend.defaultExpectation.paramPtrs == nil {
+		mmExtend.defaultExpectation.paramPtrs = &HoldServiceMockExtendParamPtrs{}
+	}
+	mmExtend.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmExtend
+}
+
+// ExpectUserParam2 sets up expected param user for holdService.Extend
+func (mmExtend *mHoldServiceMockExtend) ExpectUserParam2(user model.UserId) *mHoldServiceMockExtend {
+	if mmExtend.mock.funcExtend != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Set")
+	}
+
+	if mmExtend.defaultExpectation == nil {
+		mmExtend.defaultExpectation = &HoldServiceMockExtendExpectation{}
+	}
+
+	if mmExtend.defaultExpectation.params != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Expect")
+	}
+
+	if mmExtend.defaultExpectation.paramPtrs == nil {
+		mmExtend.defaultExpectation.paramPtrs = &HoldServiceMockExtendParamPtrs{}
+	}
+	mmExtend.defaultExpectation.paramPtrs.user = &user
+
+	return mmExtend
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.Extend
+func (mmExtend *mHoldServiceMockExtend) Inspect(f func(ctx context.Context, user model.UserId)) *mHoldServiceMockExtend {
+	if mmExtend.mock.inspectFuncExtend != nil {
+		mmExtend.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.Extend")
+	}
+
+	mmExtend.mock.inspectFuncExtend = f
+
+	return mmExtend
+}
+
+// Return sets up results that will be returned by holdService.Extend
+func (mmExtend *mHoldServiceMockExtend) Return(err error) *HoldServiceMock {
+	if mmExtend.mock.funcExtend != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Set")
+	}
+
+	if mmExtend.defaultExpectation == nil {
+		mmExtend.defaultExpectation = &HoldServiceMockExtendExpectation{mock: mmExtend.mock}
+	}
+	mmExtend.defaultExpectation.results = &HoldServiceMockExtendResults{err}
+	return mmExtend.mock
+}
+
+// Set uses given function f to mock the holdService.Extend method
+func (mmExtend *mHoldServiceMockExtend) Set(f func(ctx context.Context, user model.UserId) (err error)) *HoldServiceMock {
+	if mmExtend.defaultExpectation != nil {
+		mmExtend.mock.t.Fatalf("Default expectation is already set for the holdService.Extend method")
+	}
+
+	if len(mmExtend.expectations) > 0 {
+		mmExtend.mock.t.Fatalf("Some expectations are already set for the holdService.Extend method")
+	}
+
+	mmExtend.mock.funcExtend = f
+	return mmExtend.mock
+}
+
+// When sets expectation for the holdService.Extend which will trigger the result defined by the following
+// Then helper
+func (mmExtend *mHoldServiceMockExtend) When(ctx context.Context, user model.UserId) *HoldServiceMockExtendExpectation {
+	if mmExtend.mock.funcExtend != nil {
+		mmExtend.mock.t.Fatalf("HoldServiceMock.Extend mock is already set by Set")
+	}
+
+	expectation := &HoldServiceMockExtendExpectation{
+		mock:   mmExtend.mock,
+		params: &HoldServiceMockExtendParams{ctx, user},
+	}
+	mmExtend.expectations = append(mmExtend.expectations, expectation)
+	return expectation
+}
+
+// Then sets up holdService.Extend return parameters for the expectation previously defined by the When method
+func (e *HoldServiceMockExtendExpectation) Then(err error) *HoldServiceMock {
+	e.results = &HoldServiceMockExtendResults{err}
+	return e.mock
+}
+
+// Times sets number of times holdService.Extend should be invoked
+func (mmExtend *mHoldServiceMockExtend) Times(n uint64) *mHoldServiceMockExtend {
+	if n == 0 {
+		mmExtend.mock.t.Fatalf("Times of HoldServiceMock.Extend mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmExtend.expectedInvocations, n)
+	return mmExtend
+}
+
+func (mmExtend *mHoldServiceMockExtend) invocationsDone() bool {
+	if len(mmExtend.expectations) == 0 && mmExtend.defaultExpectation == nil && mmExtend.mock.funcExtend == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmExtend.mock.afterExtendCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmExtend.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Extend implements cart.holdService
+func (mmExtend *HoldServiceMock) Extend(ctx context.Context, user model.UserId) (err error) {
+	mm_atomic.AddUint64(&mmExtend.beforeExtendCounter, 1)
+	defer mm_atomic.AddUint64(&mmExtend.afterExtendCounter, 1)
+
+	if mmExtend.inspectFuncExtend != nil {
+		mmExtend.inspectFuncExtend(ctx, user)
+	}
+
+	mm_params := HoldServiceMockExtendParams{ctx, user}
+
+	// Record call args
+	mmExtend.ExtendMock.mutex.Lock()
+	mmExtend.ExtendMock.callArgs = append(mmExtend.ExtendMock.callArgs, &mm_params)
+	mmExtend.ExtendMock.mutex.Unlock()
+
+	for _, e := range mmExtend.ExtendMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmExtend.ExtendMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmExtend.ExtendMock.defaultExpectation.Counter, 1)
+		mm_want := mmExtend.ExtendMock.defaultExpectation.params
+		mm_want_ptrs := mmExtend.ExtendMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockExtendParams{ctx, user}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmExtend.t.Errorf("HoldServiceMock.Extend got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmExtend.t.Errorf("HoldServiceMock.Extend got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmExtend.t.Errorf("HoldServiceMock.Extend got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmExtend.ExtendMock.defaultExpectation.results
+		if mm_results == nil {
+			mmExtend.t.Fatal("No results are set for the HoldServiceMock.Extend")
+		}
+		return (*mm_results).err
+	}
+	if mmExtend.funcExtend != nil {
+		return mmExtend.funcExtend(ctx, user)
+	}
+	mmExtend.t.Fatalf("Unexpected call to HoldServiceMock.Extend. %v %v", ctx, user)
+	return
+}
+
+// ExtendAfterCounter returns a count of finished HoldServiceMock.Extend invocations
+func (mmExtend *HoldServiceMock) ExtendAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmExtend.afterExtendCounter)
+}
+
+// ExtendBeforeCounter returns a count of HoldServiceMock.Extend invocations
+func (mmExtend *HoldServiceMock) ExtendBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmExtend.beforeExtendCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.Extend.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmExtend *mHoldServiceMockExtend) Calls() []*HoldServiceMockExtendParams {
+	mmExtend.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockExtendParams, len(mmExtend.callArgs))
+	copy(argCopy, mmExtend.callArgs)
+
+	mmExtend.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockExtendDone returns true if the count of the Extend invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockExtendDone() bool {
+	if m.ExtendMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ExtendMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ExtendMock.invocationsDone()
+}
+
+// MinimockExtendInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockExtendInspect() {
+	for _, e := range m.ExtendMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.Extend with params: %#v", *e.params)
+		}
+	}
+
+	afterExtendCounter := mm_atomic.LoadUint64(&m.afterExtendCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ExtendMock.defaultExpectation != nil && afterExtendCounter < 1 {
+		if m.ExtendMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.Extend")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.Extend with params: %#v", *m.ExtendMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcExtend != nil && afterExtendCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.Extend")
+	}
+
+	if !m.ExtendMock.invocationsDone() && afterExtendCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.Extend but found %d calls",
+			mm_atomic.LoadUint64(&m.ExtendMock.expectedInvocations), afterExtendCounter)
+	}
+}
+
+type mHoldServiceMockForget struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockForgetExpectation
+	expectations       []*HoldServiceMockForgetExpectation
+
+	callArgs []*HoldServiceMockForgetParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockForgetExpectation specifies expectation struct of the holdService.Forget
+type HoldServiceMockForgetExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockForgetParams
+	paramPtrs *HoldServiceMockForgetParamPtrs
+
+	Counter uint64
+}
+
+// HoldServiceMockForgetParams contains parameters of the holdService.Forget
+type HoldServiceMockForgetParams struct {
+	user model.UserId
+}
+
+// HoldServiceMockForgetParamPtrs contains pointers to parameters of the holdService.Forget
+type HoldServiceMockForgetParamPtrs struct {
+	user *model.UserId
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmForget *mHoldServiceMockForget) Optional() *mHoldServiceMockForget {
+	mmForget.optional = true
+	return mmForget
+}
+
+// Expect sets up expected params for holdService.Forget
+func (mmForget *mHoldServiceMockForget) Expect(user model.UserId) *mHoldServiceMockForget {
+	if mmForget.mock.funcForget != nil {
+		mmForget.mock.t.Fatalf("HoldServiceMock.Forget mock is already set by Set")
+	}
+
+	if mmForget.defaultExpectation == nil {
+		mmForget.defaultExpectation = &HoldServiceMockForgetExpectation{}
+	}
+
+	if mmForget.defaultExpectation.paramPtrs != nil {
+		mmForget.mock.t.Fatalf("HoldServiceMock.Forget mock is already set by ExpectParams functions")
+	}
+
+	mmForget.defaultExpectation.params = &HoldServiceMockForgetParams{user}
+	for _, e := range mmForget.expectations {
+		if minimock.Equal(e.params, mmForget.defaultExpectation.params) {
+			mmForget.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmForget.defaultExpectation.params)
+		}
+	}
+
+	return mmForget
+}
+
+// ExpectUserParam1 sets up expected param user for holdService.Forget
+func (mmForget *mHoldServiceMockForget) ExpectUserParam1(user model.UserId) *mHoldServiceMockForget {
+	if mmForget.mock.funcForget != nil {
+		mmForget.mock.t.Fatalf("HoldServiceMock.Forget mock is already set by Set")
+	}
+
+	if mmForget.defaultExpectation == nil {
+		mmForget.defaultExpectation = &HoldServiceMockForgetExpectation{}
+	}
+
+	if mmForget.defaultExpectation.params != nil {
+		mmForget.mock.t.Fatalf("HoldServiceMock.Forget mock is already set by Expect")
+	}
+
+	if mmForget.defaultExpectation.paramPtrs == nil {
+		mmForget.defaultExpectation.paramPtrs = &HoldServiceMockForgetParamPtrs{}
+	}
+	mmForget.defaultExpectation.paramPtrs.user = &user
+
+	return mmForget
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.Forget
+func (mmForget *mHoldServiceMockForget) Inspect(f func(user model.UserId)) *mHoldServiceMockForget {
+	if mmForget.mock.inspectFuncForget != nil {
+		mmForget.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.Forget")
+	}
+
+	mmForget.mock.inspectFuncForget = f
+
+	return mmForget
+}
+
+// Return sets up results that will be returned by holdService.Forget
+func (mmForget *mHoldServiceMockForget) Return() *HoldServiceMock {
+	if mmForget.mock.funcForget != nil {
+		mmForget.mock.t.Fatalf("HoldServiceMock.Forget mock is already set by Set")
+	}
+
+	if mmForget.defaultExpectation == nil {
+		mmForget.defaultExpectation = &HoldServiceMockForgetExpectation{mock: mmForget.mock}
+	}
+
+	return mmForget.mock
+}
+
+// Set uses given function f to mock the holdService.Forget method
+func (mmForget *mHoldServiceMockForget) Set(f func(user model.UserId)) *HoldServiceMock {
+	if mmForget.defaultExpectation != nil {
+		mmForget.mock.t.Fatalf("Default expectation is already set for the holdService.Forget method")
+	}
+
+	if len(mmForget.expectations) > 0 {
+		mmForget.mock.t.Fatalf("Some expectations are already set for the holdService.Forget method")
+	}
+
+	mmForget.mock.funcForget = f
+	return mmForget.mock
+}
+
+// Times sets number of times holdService.Forget should be invoked
+func (mmForget *mHoldServiceMockForget) Times(n uint64) *mHoldServiceMockForget {
+	if n == 0 {
+		mmForget.mock.t.Fatalf("Times of HoldServiceMock.Forget mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmForget.expectedInvocations, n)
+	return mmForget
+}
+
+func (mmForget *mHoldServiceMockForget) invocationsDone() bool {
+	if len(mmForget.expectations) == 0 && mmForget.defaultExpectation == nil && mmForget.mock.funcForget == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmForget.mock.afterForgetCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmForget.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Forget implements cart.holdService
+func (mmForget *HoldServiceMock) Forget(user model.UserId) {
+	mm_atomic.AddUint64(&mmForget.beforeForgetCounter, 1)
+	defer mm_atomic.AddUint64(&mmForget.afterForgetCounter, 1)
+
+	if mmForget.inspectFuncForget != nil {
+		mmForget.inspectFuncForget(user)
+	}
+
+	mm_params := HoldServiceMockForgetParams{user}
+
+	// Record call args
+	mmForget.ForgetMock.mutex.Lock()
+	mmForget.ForgetMock.callArgs = append(mmForget.ForgetMock.callArgs, &mm_params)
+	mmForget.ForgetMock.mutex.Unlock()
+
+	for _, e := range mmForget.ForgetMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return
+		}
+	}
+
+	if mmForget.ForgetMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmForget.ForgetMock.defaultExpectation.Counter, 1)
+		mm_want := mmForget.ForgetMock.defaultExpectation.params
+		mm_want_ptrs := mmForget.ForgetMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockForgetParams{user}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmForget.t.Errorf("HoldServiceMock.Forget got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmForget.t.Errorf("HoldServiceMock.Forget got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		return
+
+	}
+	if mmForget.funcForget != nil {
+		mmForget.funcForget(user)
+		return
+	}
+	mmForget.t.Fatalf("Unexpected call to HoldServiceMock.Forget. %v", user)
+
+}
+
+// ForgetAfterCounter returns a count of finished HoldServiceMock.Forget invocations
+func (mmForget *HoldServiceMock) ForgetAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmForget.afterForgetCounter)
+}
+
+// ForgetBeforeCounter returns a count of HoldServiceMock.Forget invocations
+func (mmForget *HoldServiceMock) ForgetBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmForget.beforeForgetCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.Forget.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmForget *mHoldServiceMockForget) Calls() []*HoldServiceMockForgetParams {
+	mmForget.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockForgetParams, len(mmForget.callArgs))
+	copy(argCopy, mmForget.callArgs)
+
+	mmForget.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockForgetDone returns true if the count of the Forget invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockForgetDone() bool {
+	if m.ForgetMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ForgetMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ForgetMock.invocationsDone()
+}
+
+// MinimockForgetInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockForgetInspect() {
+	for _, e := range m.ForgetMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.Forget with params: %#v", *e.params)
+		}
+	}
+
+	afterForgetCounter := mm_atomic.LoadUint64(&m.afterForgetCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ForgetMock.defaultExpectation != nil && afterForgetCounter < 1 {
+		if m.ForgetMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.Forget")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.Forget with params: %#v", *m.ForgetMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcForget != nil && afterForgetCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.Forget")
+	}
+
+	if !m.ForgetMock.invocationsDone() && afterForgetCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.Forget but found %d calls",
+			mm_atomic.LoadUint64(&m.ForgetMock.expectedInvocations), afterForgetCounter)
+	}
+}
+
+type mHoldServiceMockHeld struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockHeldExpectation
+	expectations       []*HoldServiceMockHeldExpectation
+
+	callArgs []*HoldServiceMockHeldParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockHeldExpectation specifies expectation struct of the holdService.Held
+type HoldServiceMockHeldExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockHeldParams
+	paramPtrs *HoldServiceMockHeldParamPtrs
+	results   *HoldServiceMockHeldResults
+	Counter   uint64
+}
+
+// HoldServiceMockHeldParams contains parameters of the holdService.Held
+type HoldServiceMockHeldParams struct {
+	user model.UserId
+	sku  model.ProductSku
+}
+
+// HoldServiceMockHeldParamPtrs contains pointers to parameters of the holdService.Held
+type HoldServiceMockHeldParamPtrs struct {
+	user *model.UserId
+	sku  *model.ProductSku
+}
+
+// HoldServiceMockHeldResults contains results of the holdService.Held
+type HoldServiceMockHeldResults struct {
+	u1 uint16
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHeld *mHoldServiceMockHeld) Optional() *mHoldServiceMockHeld {
+	mmHeld.optional = true
+	return mmHeld
+}
+
+// Expect sets up expected params for holdService.Held
+func (mmHeld *mHoldServiceMockHeld) Expect(user model.UserId, sku model.ProductSku) *mHoldServiceMockHeld {
+	if mmHeld.mock.funcHeld != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Set")
+	}
+
+	if mmHeld.defaultExpectation == nil {
+		mmHeld.defaultExpectation = &HoldServiceMockHeldExpectation{}
+	}
+
+	if mmHeld.defaultExpectation.paramPtrs != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by ExpectParams functions")
+	}
+
+	mmHeld.defaultExpectation.params = &HoldServiceMockHeldParams{user, sku}
+	for _, e := range mmHeld.expectations {
+		if minimock.Equal(e.params, mmHeld.defaultExpectation.params) {
+			mmHeld.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHeld.defaultExpectation.params)
+		}
+	}
+
+	return mmHeld
+}
+
+// ExpectUserParam1 sets up expected param user for holdService.Held
+func (mmHeld *mHoldServiceMockHeld) ExpectUserParam1(user model.UserId) *mHoldServiceMockHeld {
+	if mmHeld.mock.funcHeld != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Set")
+	}
+
+	if mmHeld.defaultExpectation == nil {
+		mmHeld.defaultExpectation = &HoldServiceMockHeldExpectation{}
+	}
+
+	if mmHeld.defaultExpectation.params != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Expect")
+	}
+
+	if mmHeld.defaultExpectation.paramPtrs == nil {
+		mmHeld.defaultExpectation.paramPtrs = &HoldServiceMockHeldParamPtrs{}
+	}
+	mmHeld.defaultExpectation.paramPtrs.user = &user
+
+	return mmHeld
+}
+
+// ExpectSkuParam2 sets up expected param sku for holdService.Held
+func (mmHeld *mHoldServiceMockHeld) ExpectSkuParam2(sku model.ProductSku) *mHoldServiceMockHeld {
+	if mmHeld.mock.funcHeld != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Set")
+	}
+
+	if mmHeld.defaultExpectation == nil {
+		mmHeld.defaultExpectation = &HoldServiceMockHeldExpectation{}
+	}
+
+	if mmHeld.defaultExpectation.params != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Expect")
+	}
+
+	if mmHeld.defaultExpectation.paramPtrs == nil {
+		mmHeld.defaultExpectation.paramPtrs = &HoldServiceMockHeldParamPtrs{}
+	}
+	mmHeld.defaultExpectation.paramPtrs.sku = &sku
+
+	return mmHeld
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.Held
+func (mmHeld *mHoldServiceMockHeld) Inspect(f func(user model.UserId, sku model.ProductSku)) *mHoldServiceMockHeld {
+	if mmHeld.mock.inspectFuncHeld != nil {
+		mmHeld.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.Held")
+	}
+
+	mmHeld.mock.inspectFuncHeld = f
+
+	return mmHeld
+}
+
+// Return sets up results that will be returned by holdService.Held
+func (mmHeld *mHoldServiceMockHeld) Return(u1 uint16) *HoldServiceMock {
+	if mmHeld.mock.funcHeld != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Set")
+	}
+
+	if mmHeld.defaultExpectation == nil {
+		mmHeld.defaultExpectation = &HoldServiceMockHeldExpectation{mock: mmHeld.mock}
+	}
+	mmHeld.defaultExpectation.results = &HoldServiceMockHeldResults{u1}
+	return mmHeld.mock
+}
+
+// Set uses given function f to mock the holdService.Held method
+func (mmHeld *mHoldServiceMockHeld) Set(f func(user model.UserId, sku model.ProductSku) (u1 uint16)) *HoldServiceMock {
+	if mmHeld.defaultExpectation != nil {
+		mmHeld.mock.t.Fatalf("Default expectation is already set for the holdService.Held method")
+	}
+
+	if len(mmHeld.expectations) > 0 {
+		mmHeld.mock.t.Fatalf("Some expectations are already set for the holdService.Held method")
+	}
+
+	mmHeld.mock.funcHeld = f
+	return mmHeld.mock
+}
+
+// When sets expectation for the holdService.Held which will trigger the result defined by the following
+// Then helper
+func (mmHeld *mHoldServiceMockHeld) When(user model.UserId, sku model.ProductSku) *HoldServiceMockHeldExpectation {
+	if mmHeld.mock.funcHeld != nil {
+		mmHeld.mock.t.Fatalf("HoldServiceMock.Held mock is already set by Set")
+	}
+
+	expectation := &HoldServiceMockHeldExpectation{
+		mock:   mmHeld.mock,
+		params: &HoldServiceMockHeldParams{user, sku},
+	}
+	mmHeld.expectations = append(mmHeld.expectations, expectation)
+	return expectation
+}
+
+// Then sets up holdService.Held return parameters for the expectation previously defined by the When method
+func (e *HoldServiceMockHeldExpectation) Then(u1 uint16) *HoldServiceMock {
+	e.results = &HoldServiceMockHeldResults{u1}
+	return e.mock
+}
+
+// Times sets number of times holdService.Held should be invoked
+func (mmHeld *mHoldServiceMockHeld) Times(n uint64) *mHoldServiceMockHeld {
+	if n == 0 {
+		mmHeld.mock.t.Fatalf("Times of HoldServiceMock.Held mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHeld.expectedInvocations, n)
+	return mmHeld
+}
+
+func (mmHeld *mHoldServiceMockHeld) invocationsDone() bool {
+	if len(mmHeld.expectations) == 0 && mmHeld.defaultExpectation == nil && mmHeld.mock.funcHeld == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHeld.mock.afterHeldCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHeld.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Held implements cart.holdService
+func (mmHeld *HoldServiceMock) Held(user model.UserId, sku model.ProductSku) (u1 uint16) {
+	mm_atomic.AddUint64(&mmHeld.beforeHeldCounter, 1)
+	defer mm_atomic.AddUint64(&mmHeld.afterHeldCounter, 1)
+
+	if mmHeld.inspectFuncHeld != nil {
+		mmHeld.inspectFuncHeld(user, sku)
+	}
+
+	mm_params := HoldServiceMockHeldParams{user, sku}
+
+	// Record call args
+	mmHeld.HeldMock.mutex.Lock()
+	mmHeld.HeldMock.callArgs = append(mmHeld.HeldMock.callArgs, &mm_params)
+	mmHeld.HeldMock.mutex.Unlock()
+
+	for _, e := range mmHeld.HeldMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.u1
+		}
+	}
+
+	if mmHeld.HeldMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHeld.HeldMock.defaultExpectation.Counter, 1)
+		mm_want := mmHeld.HeldMock.defaultExpectation.params
+		mm_want_ptrs := mmHeld.HeldMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockHeldParams{user, sku}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmHeld.t.Errorf("HoldServiceMock.Held got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+			if mm_want_ptrs.sku != nil && !minimock.Equal(*mm_want_ptrs.sku, mm_got.sku) {
+				mmHeld.t.Errorf("HoldServiceMock.Held got unexpected parameter sku, want: %#v, got: %#v%s\n", *mm_want_ptrs.sku, mm_got.sku, minimock.Diff(*mm_want_ptrs.sku, mm_got.sku))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHeld.t.Errorf("HoldServiceMock.Held got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHeld.HeldMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHeld.t.Fatal("No results are set for the HoldServiceMock.Held")
+		}
+		return (*mm_results).u1
+	}
+	if mmHeld.funcHeld != nil {
+		return mmHeld.funcHeld(user, sku)
+	}
+	mmHeld.t.Fatalf("Unexpected call to HoldServiceMock.Held. %v %v", user, sku)
+	return
+}
+
+// HeldAfterCounter returns a count of finished HoldServiceMock.Held invocations
+func (mmHeld *HoldServiceMock) HeldAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHeld.afterHeldCounter)
+}
+
+// HeldBeforeCounter returns a count of HoldServiceMock.Held invocations
+func (mmHeld *HoldServiceMock) HeldBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHeld.beforeHeldCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.Held.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHeld *mHoldServiceMockHeld) Calls() []*HoldServiceMockHeldParams {
+	mmHeld.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockHeldParams, len(mmHeld.callArgs))
+	copy(argCopy, mmHeld.callArgs)
+
+	mmHeld.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHeldDone returns true if the count of the Held invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockHeldDone() bool {
+	if m.HeldMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HeldMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HeldMock.invocationsDone()
+}
+
+// MinimockHeldInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockHeldInspect() {
+	for _, e := range m.HeldMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.Held with params: %#v", *e.params)
+		}
+	}
+
+	afterHeldCounter := mm_atomic.LoadUint64(&m.afterHeldCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HeldMock.defaultExpectation != nil && afterHeldCounter < 1 {
+		if m.HeldMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.Held")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.Held with params: %#v", *m.HeldMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHeld != nil && afterHeldCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.Held")
+	}
+
+	if !m.HeldMock.invocationsDone() && afterHeldCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.Held but found %d calls",
+			mm_atomic.LoadUint64(&m.HeldMock.expectedInvocations), afterHeldCounter)
+	}
+}
+
+type mHoldServiceMockHold struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockHoldExpectation
+	expectations       []*HoldServiceMockHoldExpectation
+
+	callArgs []*HoldServiceMockHoldParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockHoldExpectation specifies expectation struct of the holdService.Hold
+type HoldServiceMockHoldExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockHoldParams
+	paramPtrs *HoldServiceMockHoldParamPtrs
+	results   *HoldServiceMockHoldResults
+	Counter   uint64
+}
+
+// HoldServiceMockHoldParams contains parameters of the holdService.Hold
+type HoldServiceMockHoldParams struct {
+	ctx   context.Context
+	user  model.UserId
+	sku   model.ProductSku
+	count uint16
+}
+
+// HoldServiceMockHoldParamPtrs contains pointers to parameters of the holdService.Hold
+type HoldServiceMockHoldParamPtrs struct {
+	ctx   *context.Context
+	user  *model.UserId
+	sku   *model.ProductSku
+	count *uint16
+}
+
+// HoldServiceMockHoldResults contains results of the holdService.Hold
+type HoldServiceMockHoldResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHold *mHoldServiceMockHold) Optional() *mHoldServiceMockHold {
+	mmHold.optional = true
+	return mmHold
+}
+
+// Expect sets up expected params for holdService.Hold
+func (mmHold *mHoldServiceMockHold) Expect(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) *mHoldServiceMockHold {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{}
+	}
+
+	if mmHold.defaultExpectation.paramPtrs != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by ExpectParams functions")
+	}
+
+	mmHold.defaultExpectation.params = &HoldServiceMockHoldParams{ctx, user, sku, count}
+	for _, e := range mmHold.expectations {
+		if minimock.Equal(e.params, mmHold.defaultExpectation.params) {
+			mmHold.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHold.defaultExpectation.params)
+		}
+	}
+
+	return mmHold
+}
+
+// ExpectCtxParam1 sets up expected param ctx for holdService.Hold
+func (mmHold *mHoldServiceMockHold) ExpectCtxParam1(ctx context.Context) *mHoldServiceMockHold {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{}
+	}
+
+	if mmHold.defaultExpectation.params != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Expect")
+	}
+
+	if mmHold.defaultExpectation.paramPtrs == nil {
+		mmHold.defaultExpectation.paramPtrs = &HoldServiceMockHoldParamPtrs{}
+	}
+	mmHold.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHold
+}
+
+// ExpectUserParam2 sets up expected param user for holdService.Hold
+func (mmHold *mHoldServiceMockHold) ExpectUserParam2(user model.UserId) *mHoldServiceMockHold {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{}
+	}
+
+	if mmHold.defaultExpectation.params != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Expect")
+	}
+
+	if mmHold.defaultExpectation.paramPtrs == nil {
+		mmHold.defaultExpectation.paramPtrs = &HoldServiceMockHoldParamPtrs{}
+	}
+	mmHold.defaultExpectation.paramPtrs.user = &user
+
+	return mmHold
+}
+
+// ExpectSkuParam3 sets up expected param sku for holdService.Hold
+func (mmHold *mHoldServiceMockHold) ExpectSkuParam3(sku model.ProductSku) *mHoldServiceMockHold {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{}
+	}
+
+	if mmHold.defaultExpectation.params != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Expect")
+	}
+
+	if mmHold.defaultExpectation.paramPtrs == nil {
+		mmHold.defaultExpectation.paramPtrs = &HoldServiceMockHoldParamPtrs{}
+	}
+	mmHold.defaultExpectation.paramPtrs.sku = &sku
+
+	return mmHold
+}
+
+// ExpectCountParam4 sets up expected param count for holdService.Hold
+func (mmHold *mHoldServiceMockHold) ExpectCountParam4(count uint16) *mHoldServiceMockHold {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{}
+	}
+
+	if mmHold.defaultExpectation.params != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Expect")
+	}
+
+	if mmHold.defaultExpectation.paramPtrs == nil {
+		mmHold.defaultExpectation.paramPtrs = &HoldServiceMockHoldParamPtrs{}
+	}
+	mmHold.defaultExpectation.paramPtrs.count = &count
+
+	return mmHold
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.Hold
+func (mmHold *mHoldServiceMockHold) Inspect(f func(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16)) *mHoldServiceMockHold {
+	if mmHold.mock.inspectFuncHold != nil {
+		mmHold.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.Hold")
+	}
+
+	mmHold.mock.inspectFuncHold = f
+
+	return mmHold
+}
+
+// Return sets up results that will be returned by holdService.Hold
+func (mmHold *mHoldServiceMockHold) Return(err error) *HoldServiceMock {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	if mmHold.defaultExpectation == nil {
+		mmHold.defaultExpectation = &HoldServiceMockHoldExpectation{mock: mmHold.mock}
+	}
+	mmHold.defaultExpectation.results = &HoldServiceMockHoldResults{err}
+	return mmHold.mock
+}
+
+// Set uses given function f to mock the holdService.Hold method
+func (mmHold *mHoldServiceMockHold) Set(f func(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) (err error)) *HoldServiceMock {
+	if mmHold.defaultExpectation != nil {
+		mmHold.mock.t.Fatalf("Default expectation is already set for the holdService.Hold method")
+	}
+
+	if len(mmHold.expectations) > 0 {
+		mmHold.mock.t.Fatalf("Some expectations are already set for the holdService.Hold method")
+	}
+
+	mmHold.mock.funcHold = f
+	return mmHold.mock
+}
+
+// When sets expectation for the holdService.Hold which will trigger the result defined by the following
+// Then helper
+func (mmHold *mHoldServiceMockHold) When(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) *HoldServiceMockHoldExpectation {
+	if mmHold.mock.funcHold != nil {
+		mmHold.mock.t.Fatalf("HoldServiceMock.Hold mock is already set by Set")
+	}
+
+	expectation := &HoldServiceMockHoldExpectation{
+		mock:   mmHold.mock,
+		params: &HoldServiceMockHoldParams{ctx, user, sku, count},
+	}
+	mmHold.expectations = append(mmHold.expectations, expectation)
+	return expectation
+}
+
+// Then sets up holdService.Hold return parameters for the expectation previously defined by the When method
+func (e *HoldServiceMockHoldExpectation) Then(err error) *HoldServiceMock {
+	e.results = &HoldServiceMockHoldResults{err}
+	return e.mock
+}
+
+// Times sets number of times holdService.Hold should be invoked
+func (mmHold *mHoldServiceMockHold) Times(n uint64) *mHoldServiceMockHold {
+	if n == 0 {
+		mmHold.mock.t.Fatalf("Times of HoldServiceMock.Hold mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHold.expectedInvocations, n)
+	return mmHold
+}
+
+func (mmHold *mHoldServiceMockHold) invocationsDone() bool {
+	if len(mmHold.expectations) == 0 && mmHold.defaultExpectation == nil && mmHold.mock.funcHold == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHold.mock.afterHoldCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHold.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Hold implements cart.holdService
+func (mmHold *HoldServiceMock) Hold(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) (err error) {
+	mm_atomic.AddUint64(&mmHold.beforeHoldCounter, 1)
+	defer mm_atomic.AddUint64(&mmHold.afterHoldCounter, 1)
+
+	if mmHold.inspectFuncHold != nil {
+		mmHold.inspectFuncHold(ctx, user, sku, count)
+	}
+
+	mm_params := HoldServiceMockHoldParams{ctx, user, sku, count}
+
+	// Record call args
+	mmHold.HoldMock.mutex.Lock()
+	mmHold.HoldMock.callArgs = append(mmHold.HoldMock.callArgs, &mm_params)
+	mmHold.HoldMock.mutex.Unlock()
+
+	for _, e := range mmHold.HoldMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHold.HoldMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHold.HoldMock.defaultExpectation.Counter, 1)
+		mm_want := mmHold.HoldMock.defaultExpectation.params
+		mm_want_ptrs := mmHold.HoldMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockHoldParams{ctx, user, sku, count}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHold.t.Errorf("HoldServiceMock.Hold got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmHold.t.Errorf("HoldServiceMock.Hold got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+			if mm_want_ptrs.sku != nil && !minimock.Equal(*mm_want_ptrs.sku, mm_got.sku) {
+				mmHold.t.Errorf("HoldServiceMock.Hold got unexpected parameter sku, want: %#v, got: %#v%s\n", *mm_want_ptrs.sku, mm_got.sku, minimock.Diff(*mm_want_ptrs.sku, mm_got.sku))
+			}
+
+			if mm_want_ptrs.count != nil && !minimock.Equal(*mm_want_ptrs.count, mm_got.count) {
+				mmHold.t.Errorf("HoldServiceMock.Hold got unexpected parameter count, want: %#v, got: %#v%s\n", *mm_want_ptrs.count, mm_got.count, minimock.Diff(*mm_want_ptrs.count, mm_got.count))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHold.t.Errorf("HoldServiceMock.Hold got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHold.HoldMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHold.t.Fatal("No results are set for the HoldServiceMock.Hold")
+		}
+		return (*mm_results).err
+	}
+	if mmHold.funcHold != nil {
+		return mmHold.funcHold(ctx, user, sku, count)
+	}
+	mmHold.t.Fatalf("Unexpected call to HoldServiceMock.Hold. %v %v %v %v", ctx, user, sku, count)
+	return
+}
+
+// HoldAfterCounter returns a count of finished HoldServiceMock.Hold invocations
+func (mmHold *HoldServiceMock) HoldAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHold.afterHoldCounter)
+}
+
+// HoldBeforeCounter returns a count of HoldServiceMock.Hold invocations
+func (mmHold *HoldServiceMock) HoldBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHold.beforeHoldCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.Hold.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHold *mHoldServiceMockHold) Calls() []*HoldServiceMockHoldParams {
+	mmHold.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockHoldParams, len(mmHold.callArgs))
+	copy(argCopy, mmHold.callArgs)
+
+	mmHold.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHoldDone returns true if the count of the Hold invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockHoldDone() bool {
+	if m.HoldMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HoldMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HoldMock.invocationsDone()
+}
+
+// MinimockHoldInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockHoldInspect() {
+	for _, e := range m.HoldMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.Hold with params: %#v", *e.params)
+		}
+	}
+
+	afterHoldCounter := mm_atomic.LoadUint64(&m.afterHoldCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HoldMock.defaultExpectation != nil && afterHoldCounter < 1 {
+		if m.HoldMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.Hold")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.Hold with params: %#v", *m.HoldMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHold != nil && afterHoldCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.Hold")
+	}
+
+	if !m.HoldMock.invocationsDone() && afterHoldCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.Hold but found %d calls",
+			mm_atomic.LoadUint64(&m.HoldMock.expectedInvocations), afterHoldCounter)
+	}
+}
+
+type mHoldServiceMockRelease struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockReleaseExpectation
+	expectations       []*HoldServiceMockReleaseExpectation
+
+	callArgs []*HoldServiceMockReleaseParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockReleaseExpectation specifies expectation struct of the holdService.Release
+type HoldServiceMockReleaseExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockReleaseParams
+	paramPtrs *HoldServiceMockReleaseParamPtrs
+	results   *HoldServiceMockReleaseResults
+	Counter   uint64
+}
+
+// HoldServiceMockReleaseParams contains parameters of the holdService.Release
+type HoldServiceMockReleaseParams struct {
+	ctx  context.Context
+	user model.UserId
+	sku  model.ProductSku
+}
+
+// HoldServiceMockReleaseParamPtrs contains pointers to parameters of the holdService.Release
+type HoldServiceMockReleaseParamPtrs struct {
+	ctx  *context.Context
+	user *model.UserId
+	sku  *model.ProductSku
+}
+
+// HoldServiceMockReleaseResults contains results of the holdService.Release
+type HoldServiceMockReleaseResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmRelease *mHoldServiceMockRelease) Optional() *mHoldServiceMockRelease {
+	mmRelease.optional = true
+	return mmRelease
+}
+
+// Expect sets up expected params for holdService.Release
+func (mmRelease *mHoldServiceMockRelease) Expect(ctx context.Context, user model.UserId, sku model.ProductSku) *mHoldServiceMockRelease {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	if mmRelease.defaultExpectation == nil {
+		mmRelease.defaultExpectation = &HoldServiceMockReleaseExpectation{}
+	}
+
+	if mmRelease.defaultExpectation.paramPtrs != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by ExpectParams functions")
+	}
+
+	mmRelease.defaultExpectation.params = &HoldServiceMockReleaseParams{ctx, user, sku}
+	for _, e := range mmRelease.expectations {
+		if minimock.Equal(e.params, mmRelease.defaultExpectation.params) {
+			mmRelease.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmRelease.defaultExpectation.params)
+		}
+	}
+
+	return mmRelease
+}
+
+// ExpectCtxParam1 sets up expected param ctx for holdService.Release
+func (mmRelease *mHoldServiceMockRelease) ExpectCtxParam1(ctx context.Context) *mHoldServiceMockRelease {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	if mmRelease.defaultExpectation == nil {
+		mmRelease.defaultExpectation = &HoldServiceMockReleaseExpectation{}
+	}
+
+	if mmRelease.defaultExpectation.params != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Expect")
+	}
+
+	if mmRelease.defaultExpectation.paramPtrs == nil {
+		mmRelease.defaultExpectation.paramPtrs = &HoldServiceMockReleaseParamPtrs{}
+	}
+	mmRelease.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmRelease
+}
+
+// ExpectUserParam2 sets up expected param user for holdService.Release
+func (mmRelease *mHoldServiceMockRelease) ExpectUserParam2(user model.UserId) *mHoldServiceMockRelease {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	if mmRelease.defaultExpectation == nil {
+		mmRelease.defaultExpectation = &HoldServiceMockReleaseExpectation{}
+	}
+
+	if mmRelease.defaultExpectation.params != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Expect")
+	}
+
+	if mmRelease.defaultExpectation.paramPtrs == nil {
+		mmRelease.defaultExpectation.paramPtrs = &HoldServiceMockReleaseParamPtrs{}
+	}
+	mmRelease.defaultExpectation.paramPtrs.user = &user
+
+	return mmRelease
+}
+
+// ExpectSkuParam3 sets up expected param sku for holdService.Release
+func (mmRelease *mHoldServiceMockRelease) ExpectSkuParam3(sku model.ProductSku) *mHoldServiceMockRelease {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	if mmRelease.defaultExpectation == nil {
+		mmRelease.defaultExpectation = &HoldServiceMockReleaseExpectation{}
+	}
+
+	if mmRelease.defaultExpectation.params != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Expect")
+	}
+
+	if mmRelease.defaultExpectation.paramPtrs == nil {
+		mmRelease.defaultExpectation.paramPtrs = &HoldServiceMockReleaseParamPtrs{}
+	}
+	mmRelease.defaultExpectation.paramPtrs.sku = &sku
+
+	return mmRelease
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.Release
+func (mmRelease *mHoldServiceMockRelease) Inspect(f func(ctx context.Context, user model.UserId, sku model.ProductSku)) *mHoldServiceMockRelease {
+	if mmRelease.mock.inspectFuncRelease != nil {
+		mmRelease.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.Release")
+	}
+
+	mmRelease.mock.inspectFuncRelease = f
+
+	return mmRelease
+}
+
+// Return sets up results that will be returned by holdService.Release
+func (mmRelease *mHoldServiceMockRelease) Return(err error) *HoldServiceMock {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	if mmRelease.defaultExpectation == nil {
+		mmRelease.defaultExpectation = &HoldServiceMockReleaseExpectation{mock: mmRelease.mock}
+	}
+	mmRelease.defaultExpectation.results = &HoldServiceMockReleaseResults{err}
+	return mmRelease.mock
+}
+
+// Set uses given function f to mock the holdService.Release method
+func (mmRelease *mHoldServiceMockRelease) Set(f func(ctx context.Context, user model.UserId, sku model.ProductSku) (err error)) *HoldServiceMock {
+	if mmRelease.defaultExpectation != nil {
+		mmRelease.mock.t.Fatalf("Default expectation is already set for the holdService.Release method")
+	}
+
+	if len(mmRelease.expectations) > 0 {
+		mmRelease.mock.t.Fatalf("Some expectations are already set for the holdService.Release method")
+	}
+
+	mmRelease.mock.funcRelease = f
+	return mmRelease.mock
+}
+
+// When sets expectation for the holdService.Release which will trigger the result defined by the following
+// Then helper
+func (mmRelease *mHoldServiceMockRelease) When(ctx context.Context, user model.UserId, sku model.ProductSku) *HoldServiceMockReleaseExpectation {
+	if mmRelease.mock.funcRelease != nil {
+		mmRelease.mock.t.Fatalf("HoldServiceMock.Release mock is already set by Set")
+	}
+
+	expectation := &HoldServiceMockReleaseExpectation{
+		mock:   mmRelease.mock,
+		params: &HoldServiceMockReleaseParams{ctx, user, sku},
+	}
+	mmRelease.expectations = append(mmRelease.expectations, expectation)
+	return expectation
+}
+
+// Then sets up holdService.Release return parameters for the expectation previously defined by the When method
+func (e *HoldServiceMockReleaseExpectation) Then(err error) *HoldServiceMock {
+	e.results = &HoldServiceMockReleaseResults{err}
+	return e.mock
+}
+
+// Times sets number of times holdService.Release should be invoked
+func (mmRelease *mHoldServiceMockRelease) Times(n uint64) *mHoldServiceMockRelease {
+	if n == 0 {
+		mmRelease.mock.t.Fatalf("Times of HoldServiceMock.Release mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmRelease.expectedInvocations, n)
+	return mmRelease
+}
+
+func (mmRelease *mHoldServiceMockRelease) invocationsDone() bool {
+	if len(mmRelease.expectations) == 0 && mmRelease.defaultExpectation == nil && mmRelease.mock.funcRelease == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmRelease.mock.afterReleaseCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmRelease.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// Release implements cart.holdService
+func (mmRelease *HoldServiceMock) Release(ctx context.Context, user model.UserId, sku model.ProductSku) (err error) {
+	mm_atomic.AddUint64(&mmRelease.beforeReleaseCounter, 1)
+	defer mm_atomic.AddUint64(&mmRelease.afterReleaseCounter, 1)
+
+	if mmRelease.inspectFuncRelease != nil {
+		mmRelease.inspectFuncRelease(ctx, user, sku)
+	}
+
+	mm_params := HoldServiceMockReleaseParams{ctx, user, sku}
+
+	// Record call args
+	mmRelease.ReleaseMock.mutex.Lock()
+	mmRelease.ReleaseMock.callArgs = append(mmRelease.ReleaseMock.callArgs, &mm_params)
+	mmRelease.ReleaseMock.mutex.Unlock()
+
+	for _, e := range mmRelease.ReleaseMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmRelease.ReleaseMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmRelease.ReleaseMock.defaultExpectation.Counter, 1)
+		mm_want := mmRelease.ReleaseMock.defaultExpectation.params
+		mm_want_ptrs := mmRelease.ReleaseMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockReleaseParams{ctx, user, sku}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmRelease.t.Errorf("HoldServiceMock.Release got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmRelease.t.Errorf("HoldServiceMock.Release got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+			if mm_want_ptrs.sku != nil && !minimock.Equal(*mm_want_ptrs.sku, mm_got.sku) {
+				mmRelease.t.Errorf("HoldServiceMock.Release got unexpected parameter sku, want: %#v, got: %#v%s\n", *mm_want_ptrs.sku, mm_got.sku, minimock.Diff(*mm_want_ptrs.sku, mm_got.sku))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmRelease.t.Errorf("HoldServiceMock.Release got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmRelease.ReleaseMock.defaultExpectation.results
+		if mm_results == nil {
+			mmRelease.t.Fatal("No results are set for the HoldServiceMock.Release")
+		}
+		return (*mm_results).err
+	}
+	if mmRelease.funcRelease != nil {
+		return mmRelease.funcRelease(ctx, user, sku)
+	}
+	mmRelease.t.Fatalf("Unexpected call to HoldServiceMock.Release. %v %v %v", ctx, user, sku)
+	return
+}
+
+// ReleaseAfterCounter returns a count of finished HoldServiceMock.Release invocations
+func (mmRelease *HoldServiceMock) ReleaseAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRelease.afterReleaseCounter)
+}
+
+// ReleaseBeforeCounter returns a count of HoldServiceMock.Release invocations
+func (mmRelease *HoldServiceMock) ReleaseBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmRelease.beforeReleaseCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.Release.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmRelease *mHoldServiceMockRelease) Calls() []*HoldServiceMockReleaseParams {
+	mmRelease.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockReleaseParams, len(mmRelease.callArgs))
+	copy(argCopy, mmRelease.callArgs)
+
+	mmRelease.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReleaseDone returns true if the count of the Release invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockReleaseDone() bool {
+	if m.ReleaseMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ReleaseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ReleaseMock.invocationsDone()
+}
+
+// MinimockReleaseInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockReleaseInspect() {
+	for _, e := range m.ReleaseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.Release with params: %#v", *e.params)
+		}
+	}
+
+	afterReleaseCounter := mm_atomic.LoadUint64(&m.afterReleaseCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReleaseMock.defaultExpectation != nil && afterReleaseCounter < 1 {
+		if m.ReleaseMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.Release")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.Release with params: %#v", *m.ReleaseMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcRelease != nil && afterReleaseCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.Release")
+	}
+
+	if !m.ReleaseMock.invocationsDone() && afterReleaseCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.Release but found %d calls",
+			mm_atomic.LoadUint64(&m.ReleaseMock.expectedInvocations), afterReleaseCounter)
+	}
+}
+
+type mHoldServiceMockReleaseAll struct {
+	optional           bool
+	mock               *HoldServiceMock
+	defaultExpectation *HoldServiceMockReleaseAllExpectation
+	expectations       []*HoldServiceMockReleaseAllExpectation
+
+	callArgs []*HoldServiceMockReleaseAllParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// HoldServiceMockReleaseAllExpectation specifies expectation struct of the holdService.ReleaseAll
+type HoldServiceMockReleaseAllExpectation struct {
+	mock      *HoldServiceMock
+	params    *HoldServiceMockReleaseAllParams
+	paramPtrs *HoldServiceMockReleaseAllParamPtrs
+	results   *HoldServiceMockReleaseAllResults
+	Counter   uint64
+}
+
+// HoldServiceMockReleaseAllParams contains parameters of the holdService.ReleaseAll
+type HoldServiceMockReleaseAllParams struct {
+	ctx  context.Context
+	user model.UserId
+}
+
+// HoldServiceMockReleaseAllParamPtrs contains pointers to parameters of the holdService.ReleaseAll
+type HoldServiceMockReleaseAllParamPtrs struct {
+	ctx  *context.Context
+	user *model.UserId
+}
+
+// HoldServiceMockReleaseAllResults contains results of the holdService.ReleaseAll
+type HoldServiceMockReleaseAllResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Optional() *mHoldServiceMockReleaseAll {
+	mmReleaseAll.optional = true
+	return mmReleaseAll
+}
+
+// Expect sets up expected params for holdService.ReleaseAll
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Expect(ctx context.Context, user model.UserId) *mHoldServiceMockReleaseAll {
+	if mmReleaseAll.mock.funcReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Set")
+	}
+
+	if mmReleaseAll.defaultExpectation == nil {
+		mmReleaseAll.defaultExpectation = &HoldServiceMockReleaseAllExpectation{}
+	}
+
+	if mmReleaseAll.defaultExpectation.paramPtrs != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by ExpectParams functions")
+	}
+
+	mmReleaseAll.defaultExpectation.params = &HoldServiceMockReleaseAllParams{ctx, user}
+	for _, e := range mmReleaseAll.expectations {
+		if minimock.Equal(e.params, mmReleaseAll.defaultExpectation.params) {
+			mmReleaseAll.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReleaseAll.defaultExpectation.params)
+		}
+	}
+
+	return mmReleaseAll
+}
+
+// ExpectCtxParam1 sets up expected param ctx for holdService.ReleaseAll
+func (mmReleaseAll *mHoldServiceMockReleaseAll) ExpectCtxParam1(ctx context.Context) *mHoldServiceMockReleaseAll {
+	if mmReleaseAll.mock.funcReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Set")
+	}
+
+	if mmReleaseAll.defaultExpectation == nil {
+		mmReleaseAll.defaultExpectation = &HoldServiceMockReleaseAllExpectation{}
+	}
+
+	if mmReleaseAll.defaultExpectation.params != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Expect")
+	}
+
+	if mmReleaseAll.defaultExpectation.paramPtrs == nil {
+		mmReleaseAll.defaultExpectation.paramPtrs = &HoldServiceMockReleaseAllParamPtrs{}
+	}
+	mmReleaseAll.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmReleaseAll
+}
+
+// ExpectUserParam2 sets up expected param user for holdService.ReleaseAll
+func (mmReleaseAll *mHoldServiceMockReleaseAll) ExpectUserParam2(user model.UserId) *mHoldServiceMockReleaseAll {
+	if mmReleaseAll.mock.funcReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Set")
+	}
+
+	if mmReleaseAll.defaultExpectation == nil {
+		mmReleaseAll.defaultExpectation = &HoldServiceMockReleaseAllExpectation{}
+	}
+
+	if mmReleaseAll.defaultExpectation.params != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Expect")
+	}
+
+	if mmReleaseAll.defaultExpectation.paramPtrs == nil {
+		mmReleaseAll.defaultExpectation.paramPtrs = &HoldServiceMockReleaseAllParamPtrs{}
+	}
+	mmReleaseAll.defaultExpectation.paramPtrs.user = &user
+
+	return mmReleaseAll
+}
+
+// Inspect accepts an inspector function that has same arguments as the holdService.ReleaseAll
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Inspect(f func(ctx context.Context, user model.UserId)) *mHoldServiceMockReleaseAll {
+	if mmReleaseAll.mock.inspectFuncReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("Inspect function is already set for HoldServiceMock.ReleaseAll")
+	}
+
+	mmReleaseAll.mock.inspectFuncReleaseAll = f
+
+	return mmReleaseAll
+}
+
+// Return sets up results that will be returned by holdService.ReleaseAll
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Return(err error) *HoldServiceMock {
+	if mmReleaseAll.mock.funcReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Set")
+	}
+
+	if mmReleaseAll.defaultExpectation == nil {
+		mmReleaseAll.defaultExpectation = &HoldServiceMockReleaseAllExpectation{mock: mmReleaseAll.mock}
+	}
+	mmReleaseAll.defaultExpectation.results = &HoldServiceMockReleaseAllResults{err}
+	return mmReleaseAll.mock
+}
+
+// Set uses given function f to mock the holdService.ReleaseAll method
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Set(f func(ctx context.Context, user model.UserId) (err error)) *HoldServiceMock {
+	if mmReleaseAll.defaultExpectation != nil {
+		mmReleaseAll.mock.t.Fatalf("Default expectation is already set for the holdService.ReleaseAll method")
+	}
+
+	if len(mmReleaseAll.expectations) > 0 {
+		mmReleaseAll.mock.t.Fatalf("Some expectations are already set for the holdService.ReleaseAll method")
+	}
+
+	mmReleaseAll.mock.funcReleaseAll = f
+	return mmReleaseAll.mock
+}
+
+// When sets expectation for the holdService.ReleaseAll which will trigger the result defined by the following
+// Then helper
+func (mmReleaseAll *mHoldServiceMockReleaseAll) When(ctx context.Context, user model.UserId) *HoldServiceMockReleaseAllExpectation {
+	if mmReleaseAll.mock.funcReleaseAll != nil {
+		mmReleaseAll.mock.t.Fatalf("HoldServiceMock.ReleaseAll mock is already set by Set")
+	}
+
+	expectation := &HoldServiceMockReleaseAllExpectation{
+		mock:   mmReleaseAll.mock,
+		params: &HoldServiceMockReleaseAllParams{ctx, user},
+	}
+	mmReleaseAll.expectations = append(mmReleaseAll.expectations, expectation)
+	return expectation
+}
+
+// Then sets up holdService.ReleaseAll return parameters for the expectation previously defined by the When method
+func (e *HoldServiceMockReleaseAllExpectation) Then(err error) *HoldServiceMock {
+	e.results = &HoldServiceMockReleaseAllResults{err}
+	return e.mock
+}
+
+// Times sets number of times holdService.ReleaseAll should be invoked
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Times(n uint64) *mHoldServiceMockReleaseAll {
+	if n == 0 {
+		mmReleaseAll.mock.t.Fatalf("Times of HoldServiceMock.ReleaseAll mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReleaseAll.expectedInvocations, n)
+	return mmReleaseAll
+}
+
+func (mmReleaseAll *mHoldServiceMockReleaseAll) invocationsDone() bool {
+	if len(mmReleaseAll.expectations) == 0 && mmReleaseAll.defaultExpectation == nil && mmReleaseAll.mock.funcReleaseAll == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReleaseAll.mock.afterReleaseAllCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReleaseAll.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ReleaseAll implements cart.holdService
+func (mmReleaseAll *HoldServiceMock) ReleaseAll(ctx context.Context, user model.UserId) (err error) {
+	mm_atomic.AddUint64(&mmReleaseAll.beforeReleaseAllCounter, 1)
+	defer mm_atomic.AddUint64(&mmReleaseAll.afterReleaseAllCounter, 1)
+
+	if mmReleaseAll.inspectFuncReleaseAll != nil {
+		mmReleaseAll.inspectFuncReleaseAll(ctx, user)
+	}
+
+	mm_params := HoldServiceMockReleaseAllParams{ctx, user}
+
+	// Record call args
+	mmReleaseAll.ReleaseAllMock.mutex.Lock()
+	mmReleaseAll.ReleaseAllMock.callArgs = append(mmReleaseAll.ReleaseAllMock.callArgs, &mm_params)
+	mmReleaseAll.ReleaseAllMock.mutex.Unlock()
+
+	for _, e := range mmReleaseAll.ReleaseAllMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmReleaseAll.ReleaseAllMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReleaseAll.ReleaseAllMock.defaultExpectation.Counter, 1)
+		mm_want := mmReleaseAll.ReleaseAllMock.defaultExpectation.params
+		mm_want_ptrs := mmReleaseAll.ReleaseAllMock.defaultExpectation.paramPtrs
+
+		mm_got := HoldServiceMockReleaseAllParams{ctx, user}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReleaseAll.t.Errorf("HoldServiceMock.ReleaseAll got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.user != nil && !minimock.Equal(*mm_want_ptrs.user, mm_got.user) {
+				mmReleaseAll.t.Errorf("HoldServiceMock.ReleaseAll got unexpected parameter user, want: %#v, got: %#v%s\n", *mm_want_ptrs.user, mm_got.user, minimock.Diff(*mm_want_ptrs.user, mm_got.user))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReleaseAll.t.Errorf("HoldServiceMock.ReleaseAll got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReleaseAll.ReleaseAllMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReleaseAll.t.Fatal("No results are set for the HoldServiceMock.ReleaseAll")
+		}
+		return (*mm_results).err
+	}
+	if mmReleaseAll.funcReleaseAll != nil {
+		return mmReleaseAll.funcReleaseAll(ctx, user)
+	}
+	mmReleaseAll.t.Fatalf("Unexpected call to HoldServiceMock.ReleaseAll. %v %v", ctx, user)
+	return
+}
+
+// ReleaseAllAfterCounter returns a count of finished HoldServiceMock.ReleaseAll invocations
+func (mmReleaseAll *HoldServiceMock) ReleaseAllAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReleaseAll.afterReleaseAllCounter)
+}
+
+// ReleaseAllBeforeCounter returns a count of HoldServiceMock.ReleaseAll invocations
+func (mmReleaseAll *HoldServiceMock) ReleaseAllBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReleaseAll.beforeReleaseAllCounter)
+}
+
+// Calls returns a list of arguments used in each call to HoldServiceMock.ReleaseAll.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReleaseAll *mHoldServiceMockReleaseAll) Calls() []*HoldServiceMockReleaseAllParams {
+	mmReleaseAll.mutex.RLock()
+
+	argCopy := make([]*HoldServiceMockReleaseAllParams, len(mmReleaseAll.callArgs))
+	copy(argCopy, mmReleaseAll.callArgs)
+
+	mmReleaseAll.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReleaseAllDone returns true if the count of the ReleaseAll invocations corresponds
+// the number of defined expectations
+func (m *HoldServiceMock) MinimockReleaseAllDone() bool {
+	if m.ReleaseAllMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ReleaseAllMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ReleaseAllMock.invocationsDone()
+}
+
+// MinimockReleaseAllInspect logs each unmet expectation
+func (m *HoldServiceMock) MinimockReleaseAllInspect() {
+	for _, e := range m.ReleaseAllMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to HoldServiceMock.ReleaseAll with params: %#v", *e.params)
+		}
+	}
+
+	afterReleaseAllCounter := mm_atomic.LoadUint64(&m.afterReleaseAllCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReleaseAllMock.defaultExpectation != nil && afterReleaseAllCounter < 1 {
+		if m.ReleaseAllMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to HoldServiceMock.ReleaseAll")
+		} else {
+			m.t.Errorf("Expected call to HoldServiceMock.ReleaseAll with params: %#v", *m.ReleaseAllMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReleaseAll != nil && afterReleaseAllCounter < 1 {
+		m.t.Error("Expected call to HoldServiceMock.ReleaseAll")
+	}
+
+	if !m.ReleaseAllMock.invocationsDone() && afterReleaseAllCounter > 0 {
+		m.t.Errorf("Expected %d calls to HoldServiceMock.ReleaseAll but found %d calls",
+			mm_atomic.LoadUint64(&m.ReleaseAllMock.expectedInvocations), afterReleaseAllCounter)
+	}
+}
+
+// MinimockFinish checks that all mocked methods have been called the expected number of times
+func (m *HoldServiceMock) MinimockFinish() {
+	m.finishOnce.Do(func() {
+		if !m.minimockDone() {
+			m.MinimockExtendInspect()
+
+			m.MinimockForgetInspect()
+
+			m.MinimockHeldInspect()
+
+			m.MinimockHoldInspect()
+
+			m.MinimockReleaseInspect()
+
+			m.MinimockReleaseAllInspect()
+			m.t.FailNow()
+		}
+	})
+}
+
+// MinimockWait waits for all mocked methods to be called the expected number of times
+func (m *HoldServiceMock) MinimockWait(timeout mm_time.Duration) {
+	timeoutCh := mm_time.After(timeout)
+	for {
+		if m.minimockDone() {
+			return
+		}
+		select {
+		case <-timeoutCh:
+			m.MinimockFinish()
+			return
+		case <-mm_time.After(10 * mm_time.Millisecond):
+		}
+	}
+}
+
+func (m *HoldServiceMock) minimockDone() bool {
+	done := true
+	return done &&
+		m.MinimockExtendDone() &&
+		m.MinimockForgetDone() &&
+		m.MinimockHeldDone() &&
+		m.MinimockHoldDone() &&
+		m.MinimockReleaseDone() &&
+		m.MinimockReleaseAllDone()
+}
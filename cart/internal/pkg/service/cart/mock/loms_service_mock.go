@@ -19,8 +19,8 @@ type LomsServiceMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
-	funcOrderCreate          func(ctx context.Context, user model.UserId, cart model.Cart) (o1 model.OrderId, err error)
-	inspectFuncOrderCreate   func(ctx context.Context, user model.UserId, cart model.Cart)
+	funcOrderCreate          func(ctx context.Context, user model.UserId, cart model.Cart, requestId string) (o1 model.OrderId, err error)
+	inspectFuncOrderCreate   func(ctx context.Context, user model.UserId, cart model.Cart, requestId string)
 	afterOrderCreateCounter  uint64
 	beforeOrderCreateCounter uint64
 	OrderCreateMock          mLomsServiceMockOrderCreate
@@ -30,6 +30,12 @@ type LomsServiceMock struct {
 	afterStocksInfoCounter  uint64
 	beforeStocksInfoCounter uint64
 	StocksInfoMock          mLomsServiceMockStocksInfo
+
+	funcStocksInfoBatch          func(ctx context.Context, skus []model.ProductSku) (m1 map[model.ProductSku]uint64, pa1 []model.ProductSku, err error)
+	inspectFuncStocksInfoBatch   func(ctx context.Context, skus []model.ProductSku)
+	afterStocksInfoBatchCounter  uint64
+	beforeStocksInfoBatchCounter uint64
+	StocksInfoBatchMock          mLomsServiceMockStocksInfoBatch
 }
 
 // NewLomsServiceMock returns a mock for cart.lomsService
@@ -46,6 +52,9 @@ func NewLomsServiceMock(t minimock.Tester) *LomsServiceMock {
 	m.StocksInfoMock = mLomsServiceMockStocksInfo{mock: m}
 	m.StocksInfoMock.callArgs = []*LomsServiceMockStocksInfoParams{}
 
+	m.StocksInfoBatchMock = mLomsServiceMockStocksInfoBatch{mock: m}
+	m.StocksInfoBatchMock.callArgs = []*LomsServiceMockStocksInfoBatchParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
@@ -74,16 +83,18 @@ type LomsServiceMockOrderCreateExpectation struct {
 
 // LomsServiceMockOrderCreateParams contains parameters of the lomsService.OrderCreate
 type LomsServiceMockOrderCreateParams struct {
-	ctx  context.Context
-	user model.UserId
-	cart model.Cart
+	ctx       context.Context
+	user      model.UserId
+	cart      model.Cart
+	requestId string
 }
 
 // LomsServiceMockOrderCreateParamPtrs contains pointers to parameters of the lomsService.OrderCreate
 type LomsServiceMockOrderCreateParamPtrs struct {
-	ctx  *context.Context
-	user *model.UserId
-	cart *model.Cart
+	ctx       *context.Context
+	user      *model.UserId
+	cart      *model.Cart
+	requestId *string
 }
 
 // LomsServiceMockOrderCreateResults contains results of the lomsService.OrderCreate
@@ -103,7 +114,7 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) Optional() *mLomsServiceMockOr
 }
 
 // Expect sets up expected params for lomsService.OrderCreate
-func (mmOrderCreate *mLomsServiceMockOrderCreate) Expect(ctx context.Context, user model.UserId, cart model.Cart) *mLomsServiceMockOrderCreate {
+func (mmOrderCreate *mLomsServiceMockOrderCreate) Expect(ctx context.Context, user model.UserId, cart model.Cart, requestId string) *mLomsServiceMockOrderCreate {
 	if mmOrderCreate.mock.funcOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("LomsServiceMock.OrderCreate mock is already set by Set")
 	}
@@ -116,7 +127,7 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) Expect(ctx context.Context, us
 		mmOrderCreate.mock.t.Fatalf("LomsServiceMock.OrderCreate mock is already set by ExpectParams functions")
 	}
 
-	mmOrderCreate.defaultExpectation.params = &LomsServiceMockOrderCreateParams{ctx, user, cart}
+	mmOrderCreate.defaultExpectation.params = &LomsServiceMockOrderCreateParams{ctx, user, cart, requestId}
 	for _, e := range mmOrderCreate.expectations {
 		if minimock.Equal(e.params, mmOrderCreate.defaultExpectation.params) {
 			mmOrderCreate.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmOrderCreate.defaultExpectation.params)
@@ -192,8 +203,30 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) ExpectCartParam3(cart model.Ca
 	return mmOrderCreate
 }
 
+// ExpectRequestIdParam4 sets up expected param requestId for lomsService.OrderCreate
+func (mmOrderCreate *mLomsServiceMockOrderCreate) ExpectRequestIdParam4(requestId string) *mLomsServiceMockOrderCreate {
+	if mmOrderCreate.mock.funcOrderCreate != nil {
+		mmOrderCreate.mock.t.Fatalf("LomsServiceMock.OrderCreate mock is already set by Set")
+	}
+
+	if mmOrderCreate.defaultExpectation == nil {
+		mmOrderCreate.defaultExpectation = &LomsServiceMockOrderCreateExpectation{}
+	}
+
+	if mmOrderCreate.defaultExpectation.params != nil {
+		mmOrderCreate.mock.t.Fatalf("LomsServiceMock.OrderCreate mock is already set by Expect")
+	}
+
+	if mmOrderCreate.defaultExpectation.paramPtrs == nil {
+		mmOrderCreate.defaultExpectation.paramPtrs = &LomsServiceMockOrderCreateParamPtrs{}
+	}
+	mmOrderCreate.defaultExpectation.paramPtrs.requestId = &requestId
+
+	return mmOrderCreate
+}
+
 // Inspect accepts an inspector function that has same arguments as the lomsService.OrderCreate
-func (mmOrderCreate *mLomsServiceMockOrderCreate) Inspect(f func(ctx context.Context, user model.UserId, cart model.Cart)) *mLomsServiceMockOrderCreate {
+func (mmOrderCreate *mLomsServiceMockOrderCreate) Inspect(f func(ctx context.Context, user model.UserId, cart model.Cart, requestId string)) *mLomsServiceMockOrderCreate {
 	if mmOrderCreate.mock.inspectFuncOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("Inspect function is already set for LomsServiceMock.OrderCreate")
 	}
@@ -217,7 +250,7 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) Return(o1 model.OrderId, err e
 }
 
 // Set uses given function f to mock the lomsService.OrderCreate method
-func (mmOrderCreate *mLomsServiceMockOrderCreate) Set(f func(ctx context.Context, user model.UserId, cart model.Cart) (o1 model.OrderId, err error)) *LomsServiceMock {
+func (mmOrderCreate *mLomsServiceMockOrderCreate) Set(f func(ctx context.Context, user model.UserId, cart model.Cart, requestId string) (o1 model.OrderId, err error)) *LomsServiceMock {
 	if mmOrderCreate.defaultExpectation != nil {
 		mmOrderCreate.mock.t.Fatalf("Default expectation is already set for the lomsService.OrderCreate method")
 	}
@@ -232,14 +265,14 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) Set(f func(ctx context.Context
 
 // When sets expectation for the lomsService.OrderCreate which will trigger the result defined by the following
 // Then helper
-func (mmOrderCreate *mLomsServiceMockOrderCreate) When(ctx context.Context, user model.UserId, cart model.Cart) *LomsServiceMockOrderCreateExpectation {
+func (mmOrderCreate *mLomsServiceMockOrderCreate) When(ctx context.Context, user model.UserId, cart model.Cart, requestId string) *LomsServiceMockOrderCreateExpectation {
 	if mmOrderCreate.mock.funcOrderCreate != nil {
 		mmOrderCreate.mock.t.Fatalf("LomsServiceMock.OrderCreate mock is already set by Set")
 	}
 
 	expectation := &LomsServiceMockOrderCreateExpectation{
 		mock:   mmOrderCreate.mock,
-		params: &LomsServiceMockOrderCreateParams{ctx, user, cart},
+		params: &LomsServiceMockOrderCreateParams{ctx, user, cart, requestId},
 	}
 	mmOrderCreate.expectations = append(mmOrderCreate.expectations, expectation)
 	return expectation
@@ -272,15 +305,15 @@ func (mmOrderCreate *mLomsServiceMockOrderCreate) invocationsDone() bool {
 }
 
 // OrderCreate implements cart.lomsService
-func (mmOrderCreate *LomsServiceMock) OrderCreate(ctx context.Context, user model.UserId, cart model.Cart) (o1 model.OrderId, err error) {
+func (mmOrderCreate *LomsServiceMock) OrderCreate(ctx context.Context, user model.UserId, cart model.Cart, requestId string) (o1 model.OrderId, err error) {
 	mm_atomic.AddUint64(&mmOrderCreate.beforeOrderCreateCounter, 1)
 	defer mm_atomic.AddUint64(&mmOrderCreate.afterOrderCreateCounter, 1)
 
 	if mmOrderCreate.inspectFuncOrderCreate != nil {
-		mmOrderCreate.inspectFuncOrderCreate(ctx, user, cart)
+		mmOrderCreate.inspectFuncOrderCreate(ctx, user, cart, requestId)
 	}
 
-	mm_params := LomsServiceMockOrderCreateParams{ctx, user, cart}
+	mm_params := LomsServiceMockOrderCreateParams{ctx, user, cart, requestId}
 
 	// Record call args
 	mmOrderCreate.OrderCreateMock.mutex.Lock()
@@ -299,7 +332,7 @@ func (mmOrderCreate *LomsServiceMock) OrderCreate(ctx context.Context, user mode
 		mm_want := mmOrderCreate.OrderCreateMock.defaultExpectation.params
 		mm_want_ptrs := mmOrderCreate.OrderCreateMock.defaultExpectation.paramPtrs
 
-		mm_got := LomsServiceMockOrderCreateParams{ctx, user, cart}
+		mm_got := LomsServiceMockOrderCreateParams{ctx, user, cart, requestId}
 
 		if mm_want_ptrs != nil {
 
@@ -315,6 +348,10 @@ func (mmOrderCreate *LomsServiceMock) OrderCreate(ctx context.Context, user mode
 				mmOrderCreate.t.Errorf("LomsServiceMock.OrderCreate got unexpected parameter cart, want: %#v, got: %#v%s\n", *mm_want_ptrs.cart, mm_got.cart, minimock.Diff(*mm_want_ptrs.cart, mm_got.cart))
 			}
 
+			if mm_want_ptrs.requestId != nil && !minimock.Equal(*mm_want_ptrs.requestId, mm_got.requestId) {
+				mmOrderCreate.t.Errorf("LomsServiceMock.OrderCreate got unexpected parameter requestId, want: %#v, got: %#v%s\n", *mm_want_ptrs.requestId, mm_got.requestId, minimock.Diff(*mm_want_ptrs.requestId, mm_got.requestId))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmOrderCreate.t.Errorf("LomsServiceMock.OrderCreate got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -326,9 +363,9 @@ func (mmOrderCreate *LomsServiceMock) OrderCreate(ctx context.Context, user mode
 		return (*mm_results).o1, (*mm_results).err
 	}
 	if mmOrderCreate.funcOrderCreate != nil {
-		return mmOrderCreate.funcOrderCreate(ctx, user, cart)
+		return mmOrderCreate.funcOrderCreate(ctx, user, cart, requestId)
 	}
-	mmOrderCreate.t.Fatalf("Unexpected call to LomsServiceMock.OrderCreate. %v %v %v", ctx, user, cart)
+	mmOrderCreate.t.Fatalf("Unexpected call to LomsServiceMock.OrderCreate. %v %v %v %v", ctx, user, cart, requestId)
 	return
 }
 
@@ -721,6 +758,328 @@ func (m *LomsServiceMock) MinimockStocksInfoInspect() {
 	}
 }
 
+type mLomsServiceMockStocksInfoBatch struct {
+	optional           bool
+	mock               *LomsServiceMock
+	defaultExpectation *LomsServiceMockStocksInfoBatchExpectation
+	expectations       []*LomsServiceMockStocksInfoBatchExpectation
+
+	callArgs []*LomsServiceMockStocksInfoBatchParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// LomsServiceMockStocksInfoBatchExpectation specifies expectation struct of the lomsService.StocksInfoBatch
+type LomsServiceMockStocksInfoBatchExpectation struct {
+	mock      *LomsServiceMock
+	params    *LomsServiceMockStocksInfoBatchParams
+	paramPtrs *LomsServiceMockStocksInfoBatchParamPtrs
+	results   *LomsServiceMockStocksInfoBatchResults
+	Counter   uint64
+}
+
+// LomsServiceMockStocksInfoBatchParams contains parameters of the lomsService.StocksInfoBatch
+type LomsServiceMockStocksInfoBatchParams struct {
+	ctx  context.Context
+	skus []model.ProductSku
+}
+
+// LomsServiceMockStocksInfoBatchParamPtrs contains pointers to parameters of the lomsService.StocksInfoBatch
+type LomsServiceMockStocksInfoBatchParamPtrs struct {
+	ctx  *context.Context
+	skus *[]model.ProductSku
+}
+
+// LomsServiceMockStocksInfoBatchResults contains results of the lomsService.StocksInfoBatch
+type LomsServiceMockStocksInfoBatchResults struct {
+	m1  map[model.ProductSku]uint64
+	pa1 []model.ProductSku
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Optional() *mLomsServiceMockStocksInfoBatch {
+	mmStocksInfoBatch.optional = true
+	return mmStocksInfoBatch
+}
+
+// Expect sets up expected params for lomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Expect(ctx context.Context, skus []model.ProductSku) *mLomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &LomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by ExpectParams functions")
+	}
+
+	mmStocksInfoBatch.defaultExpectation.params = &LomsServiceMockStocksInfoBatchParams{ctx, skus}
+	for _, e := range mmStocksInfoBatch.expectations {
+		if minimock.Equal(e.params, mmStocksInfoBatch.defaultExpectation.params) {
+			mmStocksInfoBatch.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmStocksInfoBatch.defaultExpectation.params)
+		}
+	}
+
+	return mmStocksInfoBatch
+}
+
+// ExpectCtxParam1 sets up expected param ctx for lomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) ExpectCtxParam1(ctx context.Context) *mLomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &LomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &LomsServiceMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmStocksInfoBatch
+}
+
+// ExpectSkusParam2 sets up expected param skus for lomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) ExpectSkusParam2(skus []model.ProductSku) *mLomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &LomsServiceMockStocksInfoBatchExpectation{}
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.params != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Expect")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation.paramPtrs == nil {
+		mmStocksInfoBatch.defaultExpectation.paramPtrs = &LomsServiceMockStocksInfoBatchParamPtrs{}
+	}
+	mmStocksInfoBatch.defaultExpectation.paramPtrs.skus = &skus
+
+	return mmStocksInfoBatch
+}
+
+// Inspect accepts an inspector function that has same arguments as the lomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Inspect(f func(ctx context.Context, skus []model.ProductSku)) *mLomsServiceMockStocksInfoBatch {
+	if mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Inspect function is already set for LomsServiceMock.StocksInfoBatch")
+	}
+
+	mmStocksInfoBatch.mock.inspectFuncStocksInfoBatch = f
+
+	return mmStocksInfoBatch
+}
+
+// Return sets up results that will be returned by lomsService.StocksInfoBatch
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Return(m1 map[model.ProductSku]uint64, pa1 []model.ProductSku, err error) *LomsServiceMock {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	if mmStocksInfoBatch.defaultExpectation == nil {
+		mmStocksInfoBatch.defaultExpectation = &LomsServiceMockStocksInfoBatchExpectation{mock: mmStocksInfoBatch.mock}
+	}
+	mmStocksInfoBatch.defaultExpectation.results = &LomsServiceMockStocksInfoBatchResults{m1, pa1, err}
+	return mmStocksInfoBatch.mock
+}
+
+// Set uses given function f to mock the lomsService.StocksInfoBatch method
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Set(f func(ctx context.Context, skus []model.ProductSku) (m1 map[model.ProductSku]uint64, pa1 []model.ProductSku, err error)) *LomsServiceMock {
+	if mmStocksInfoBatch.defaultExpectation != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("Default expectation is already set for the lomsService.StocksInfoBatch method")
+	}
+
+	if len(mmStocksInfoBatch.expectations) > 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Some expectations are already set for the lomsService.StocksInfoBatch method")
+	}
+
+	mmStocksInfoBatch.mock.funcStocksInfoBatch = f
+	return mmStocksInfoBatch.mock
+}
+
+// When sets expectation for the lomsService.StocksInfoBatch which will trigger the result defined by the following
+// Then helper
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) When(ctx context.Context, skus []model.ProductSku) *LomsServiceMockStocksInfoBatchExpectation {
+	if mmStocksInfoBatch.mock.funcStocksInfoBatch != nil {
+		mmStocksInfoBatch.mock.t.Fatalf("LomsServiceMock.StocksInfoBatch mock is already set by Set")
+	}
+
+	expectation := &LomsServiceMockStocksInfoBatchExpectation{
+		mock:   mmStocksInfoBatch.mock,
+		params: &LomsServiceMockStocksInfoBatchParams{ctx, skus},
+	}
+	mmStocksInfoBatch.expectations = append(mmStocksInfoBatch.expectations, expectation)
+	return expectation
+}
+
+// Then sets up lomsService.StocksInfoBatch return parameters for the expectation previously defined by the When method
+func (e *LomsServiceMockStocksInfoBatchExpectation) Then(m1 map[model.ProductSku]uint64, pa1 []model.ProductSku, err error) *LomsServiceMock {
+	e.results = &LomsServiceMockStocksInfoBatchResults{m1, pa1, err}
+	return e.mock
+}
+
+// Times sets number of times lomsService.StocksInfoBatch should be invoked
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Times(n uint64) *mLomsServiceMockStocksInfoBatch {
+	if n == 0 {
+		mmStocksInfoBatch.mock.t.Fatalf("Times of LomsServiceMock.StocksInfoBatch mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmStocksInfoBatch.expectedInvocations, n)
+	return mmStocksInfoBatch
+}
+
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) invocationsDone() bool {
+	if len(mmStocksInfoBatch.expectations) == 0 && mmStocksInfoBatch.defaultExpectation == nil && mmStocksInfoBatch.mock.funcStocksInfoBatch == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.mock.afterStocksInfoBatchCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmStocksInfoBatch.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// StocksInfoBatch implements cart.lomsService
+func (mmStocksInfoBatch *LomsServiceMock) StocksInfoBatch(ctx context.Context, skus []model.ProductSku) (m1 map[model.ProductSku]uint64, pa1 []model.ProductSku, err error) {
+	mm_atomic.AddUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter, 1)
+	defer mm_atomic.AddUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter, 1)
+
+	if mmStocksInfoBatch.inspectFuncStocksInfoBatch != nil {
+		mmStocksInfoBatch.inspectFuncStocksInfoBatch(ctx, skus)
+	}
+
+	mm_params := LomsServiceMockStocksInfoBatchParams{ctx, skus}
+
+	// Record call args
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Lock()
+	mmStocksInfoBatch.StocksInfoBatchMock.callArgs = append(mmStocksInfoBatch.StocksInfoBatchMock.callArgs, &mm_params)
+	mmStocksInfoBatch.StocksInfoBatchMock.mutex.Unlock()
+
+	for _, e := range mmStocksInfoBatch.StocksInfoBatchMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.m1, e.results.pa1, e.results.err
+		}
+	}
+
+	if mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.Counter, 1)
+		mm_want := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.params
+		mm_want_ptrs := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.paramPtrs
+
+		mm_got := LomsServiceMockStocksInfoBatchParams{ctx, skus}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmStocksInfoBatch.t.Errorf("LomsServiceMock.StocksInfoBatch got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.skus != nil && !minimock.Equal(*mm_want_ptrs.skus, mm_got.skus) {
+				mmStocksInfoBatch.t.Errorf("LomsServiceMock.StocksInfoBatch got unexpected parameter skus, want: %#v, got: %#v%s\n", *mm_want_ptrs.skus, mm_got.skus, minimock.Diff(*mm_want_ptrs.skus, mm_got.skus))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmStocksInfoBatch.t.Errorf("LomsServiceMock.StocksInfoBatch got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmStocksInfoBatch.StocksInfoBatchMock.defaultExpectation.results
+		if mm_results == nil {
+			mmStocksInfoBatch.t.Fatal("No results are set for the LomsServiceMock.StocksInfoBatch")
+		}
+		return (*mm_results).m1, (*mm_results).pa1, (*mm_results).err
+	}
+	if mmStocksInfoBatch.funcStocksInfoBatch != nil {
+		return mmStocksInfoBatch.funcStocksInfoBatch(ctx, skus)
+	}
+	mmStocksInfoBatch.t.Fatalf("Unexpected call to LomsServiceMock.StocksInfoBatch. %v %v", ctx, skus)
+	return
+}
+
+// StocksInfoBatchAfterCounter returns a count of finished LomsServiceMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *LomsServiceMock) StocksInfoBatchAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.afterStocksInfoBatchCounter)
+}
+
+// StocksInfoBatchBeforeCounter returns a count of LomsServiceMock.StocksInfoBatch invocations
+func (mmStocksInfoBatch *LomsServiceMock) StocksInfoBatchBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStocksInfoBatch.beforeStocksInfoBatchCounter)
+}
+
+// Calls returns a list of arguments used in each call to LomsServiceMock.StocksInfoBatch.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmStocksInfoBatch *mLomsServiceMockStocksInfoBatch) Calls() []*LomsServiceMockStocksInfoBatchParams {
+	mmStocksInfoBatch.mutex.RLock()
+
+	argCopy := make([]*LomsServiceMockStocksInfoBatchParams, len(mmStocksInfoBatch.callArgs))
+	copy(argCopy, mmStocksInfoBatch.callArgs)
+
+	mmStocksInfoBatch.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockStocksInfoBatchDone returns true if the count of the StocksInfoBatch invocations corresponds
+// the number of defined expectations
+func (m *LomsServiceMock) MinimockStocksInfoBatchDone() bool {
+	if m.StocksInfoBatchMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.StocksInfoBatchMock.invocationsDone()
+}
+
+// MinimockStocksInfoBatchInspect logs each unmet expectation
+func (m *LomsServiceMock) MinimockStocksInfoBatchInspect() {
+	for _, e := range m.StocksInfoBatchMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to LomsServiceMock.StocksInfoBatch with params: %#v", *e.params)
+		}
+	}
+
+	afterStocksInfoBatchCounter := mm_atomic.LoadUint64(&m.afterStocksInfoBatchCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.StocksInfoBatchMock.defaultExpectation != nil && afterStocksInfoBatchCounter < 1 {
+		if m.StocksInfoBatchMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to LomsServiceMock.StocksInfoBatch")
+		} else {
+			m.t.Errorf("Expected call to LomsServiceMock.StocksInfoBatch with params: %#v", *m.StocksInfoBatchMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcStocksInfoBatch != nil && afterStocksInfoBatchCounter < 1 {
+		m.t.Error("Expected call to LomsServiceMock.StocksInfoBatch")
+	}
+
+	if !m.StocksInfoBatchMock.invocationsDone() && afterStocksInfoBatchCounter > 0 {
+		m.t.Errorf("Expected %d calls to LomsServiceMock.StocksInfoBatch but found %d calls",
+			mm_atomic.LoadUint64(&m.StocksInfoBatchMock.expectedInvocations), afterStocksInfoBatchCounter)
+	}
+}
+
 // MinimockFinish checks that all mocked methods have been called the expected number of times
 func (m *LomsServiceMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
@@ -728,6 +1087,8 @@ func (m *LomsServiceMock) MinimockFinish() {
 			m.MinimockOrderCreateInspect()
 
 			m.MinimockStocksInfoInspect()
+
+			m.MinimockStocksInfoBatchInspect()
 			m.t.FailNow()
 		}
 	})
@@ -753,5 +1114,6 @@ func (m *LomsServiceMock) minimockDone() bool {
 	done := true
 	return done &&
 		m.MinimockOrderCreateDone() &&
-		m.MinimockStocksInfoDone()
+		m.MinimockStocksInfoDone() &&
+		m.MinimockStocksInfoBatchDone()
 }
@@ -0,0 +1,47 @@
+package cart
+
+import (
+	"math"
+	"route256/cart/internal/pkg/model"
+)
+
+type Option interface {
+	Apply(*CartService)
+}
+
+type optionFn func(*CartService)
+
+func (fn optionFn) Apply(s *CartService) {
+	fn(s)
+}
+
+// WithHoldService включает холды остатков в LOMS при добавлении товаров в корзину.
+func WithHoldService(holdService holdService) Option {
+	return optionFn(func(s *CartService) {
+		s.holdService = holdService
+	})
+}
+
+// WithLimits задает ограничения корзины.
+func WithLimits(limits model.CartLimits) Option {
+	return optionFn(func(s *CartService) {
+		if limits.MaxLineCount == 0 {
+			limits.MaxLineCount = math.MaxUint16
+		}
+		s.limits = limits
+	})
+}
+
+// WithHistory включает запись журнала изменений корзин.
+func WithHistory(history historyRepository) Option {
+	return optionFn(func(s *CartService) {
+		s.history = history
+	})
+}
+
+// WithProductRps задает ограничение запросов к ProductService при загрузке корзины, по умолчанию 10.
+func WithProductRps(rps int) Option {
+	return optionFn(func(s *CartService) {
+		s.SetProductRps(rps)
+	})
+}
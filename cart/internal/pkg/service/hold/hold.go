@@ -0,0 +1,140 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/pkg/tracing"
+	"sync"
+	"time"
+)
+
+type lomsService interface {
+	HoldCreate(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16, ttl time.Duration) (model.HoldId, error)
+	HoldExtend(ctx context.Context, user model.UserId, holdId model.HoldId, ttl time.Duration) error
+	HoldRelease(ctx context.Context, user model.UserId, holdId model.HoldId) error
+}
+
+// HoldService держит в LOMS холды на товары в корзинах и помнит их id.
+// Холды в LOMS живут ttl, поэтому потеря id при рестарте не страшна.
+type HoldService struct {
+	lomsService lomsService
+	ttl         time.Duration
+	holds       map[model.UserId]map[model.ProductSku]hold
+	mx          sync.Mutex
+}
+
+type hold struct {
+	id    model.HoldId
+	count uint16
+}
+
+func NewHoldService(lomsService lomsService, ttl time.Duration) *HoldService {
+	return &HoldService{
+		lomsService: lomsService,
+		ttl:         ttl,
+		holds:       make(map[model.UserId]map[model.ProductSku]hold),
+	}
+}
+
+// Hold ставит холд на count товаров, count - общее количество sku в корзине.
+func (s *HoldService) Hold(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16) (err error) {
+	ctx, span := tracing.Start(ctx, "HoldService.Hold")
+	defer tracing.EndWithCheckError(span, &err)
+
+	holdId, err := s.lomsService.HoldCreate(ctx, user, sku, count, s.ttl)
+	if err != nil {
+		return fmt.Errorf("s.lomsService.HoldCreate: %w", err)
+	}
+
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if _, ok := s.holds[user]; !ok {
+		s.holds[user] = make(map[model.ProductSku]hold)
+	}
+	s.holds[user][sku] = hold{id: holdId, count: count}
+	return nil
+}
+
+func (s *HoldService) Extend(ctx context.Context, user model.UserId) (err error) {
+	ctx, span := tracing.Start(ctx, "HoldService.Extend")
+	defer tracing.EndWithCheckError(span, &err)
+
+	var errs []error
+	for sku, h := range s.userHolds(user) {
+		if err := s.lomsService.HoldExtend(ctx, user, h.id, s.ttl); err != nil {
+			// холд мог истечь, дальше считаем товар не захолженным
+			s.forgetHold(user, sku, h.id)
+			errs = append(errs, fmt.Errorf("s.lomsService.HoldExtend: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (s *HoldService) Release(ctx context.Context, user model.UserId, sku model.ProductSku) (err error) {
+	ctx, span := tracing.Start(ctx, "HoldService.Release")
+	defer tracing.EndWithCheckError(span, &err)
+
+	s.mx.Lock()
+	h, ok := s.holds[user][sku]
+	delete(s.holds[user], sku)
+	s.mx.Unlock()
+	if !ok {
+		return nil
+	}
+
+	if err := s.lomsService.HoldRelease(ctx, user, h.id); err != nil {
+		return fmt.Errorf("s.lomsService.HoldRelease: %w", err)
+	}
+	return nil
+}
+
+func (s *HoldService) ReleaseAll(ctx context.Context, user model.UserId) (err error) {
+	ctx, span := tracing.Start(ctx, "HoldService.ReleaseAll")
+	defer tracing.EndWithCheckError(span, &err)
+
+	holds := s.userHolds(user)
+	s.Forget(user)
+
+	var errs []error
+	for _, h := range holds {
+		if err := s.lomsService.HoldRelease(ctx, user, h.id); err != nil {
+			errs = append(errs, fmt.Errorf("s.lomsService.HoldRelease: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// Forget забывает холды пользователя без запросов в LOMS,
+// например после оформления заказа, когда холды уже стали резервом.
+func (s *HoldService) Forget(user model.UserId) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	delete(s.holds, user)
+}
+
+// Held возвращает количество товара, на которое у пользователя есть холд.
+func (s *HoldService) Held(user model.UserId, sku model.ProductSku) uint16 {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	return s.holds[user][sku].count
+}
+
+func (s *HoldService) userHolds(user model.UserId) map[model.ProductSku]hold {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	holds := make(map[model.ProductSku]hold, len(s.holds[user]))
+	for sku, h := range s.holds[user] {
+		holds[sku] = h
+	}
+	return holds
+}
+
+func (s *HoldService) forgetHold(user model.UserId, sku model.ProductSku, holdId model.HoldId) {
+	s.mx.Lock()
+	defer s.mx.Unlock()
+	if s.holds[user][sku].id == holdId {
+		delete(s.holds[user], sku)
+	}
+}
@@ -0,0 +1,81 @@
+package hold
+
+import (
+	"context"
+	"errors"
+	"route256/cart/internal/pkg/model"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type lomsServiceTest struct {
+	nextId   model.HoldId
+	holds    map[model.HoldId]uint16
+	extended []model.HoldId
+	mx       sync.Mutex
+}
+
+func newLomsServiceTest() *lomsServiceTest {
+	return &lomsServiceTest{
+		holds: make(map[model.HoldId]uint16),
+	}
+}
+
+func (l *lomsServiceTest) HoldCreate(_ context.Context, _ model.UserId, sku model.ProductSku, count uint16, _ time.Duration) (model.HoldId, error) {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	if sku == 404 {
+		return 0, errors.New("not enough products in stock")
+	}
+	l.nextId++
+	l.holds[l.nextId] = count
+	return l.nextId, nil
+}
+
+func (l *lomsServiceTest) HoldExtend(_ context.Context, _ model.UserId, holdId model.HoldId, _ time.Duration) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	l.extended = append(l.extended, holdId)
+	return nil
+}
+
+func (l *lomsServiceTest) HoldRelease(_ context.Context, _ model.UserId, holdId model.HoldId) error {
+	l.mx.Lock()
+	defer l.mx.Unlock()
+	delete(l.holds, holdId)
+	return nil
+}
+
+func TestHoldService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	lomsService := newLomsServiceTest()
+	holdService := NewHoldService(lomsService, time.Minute)
+
+	require.NoError(t, holdService.Hold(ctx, 1, 1, 2))
+	require.NoError(t, holdService.Hold(ctx, 1, 2, 1))
+	require.NoError(t, holdService.Hold(ctx, 2, 1, 1))
+	require.Error(t, holdService.Hold(ctx, 1, 404, 1))
+	require.Len(t, lomsService.holds, 3)
+	require.Equal(t, uint16(2), holdService.Held(1, 1))
+	require.Zero(t, holdService.Held(1, 404))
+
+	require.NoError(t, holdService.Extend(ctx, 1))
+	require.ElementsMatch(t, []model.HoldId{1, 2}, lomsService.extended)
+
+	require.NoError(t, holdService.Release(ctx, 1, 1))
+	require.NoError(t, holdService.Release(ctx, 1, 1)) // повторно ничего не делает
+	require.Len(t, lomsService.holds, 2)
+
+	require.NoError(t, holdService.ReleaseAll(ctx, 2))
+	require.Len(t, lomsService.holds, 1)
+
+	// после оформления заказа холды уже в резерве, в LOMS не ходим
+	holdService.Forget(1)
+	require.NoError(t, holdService.ReleaseAll(ctx, 1))
+	require.Len(t, lomsService.holds, 1)
+}
@@ -3,11 +3,16 @@ package loms
 import (
 	"context"
 	"fmt"
+	"net/http"
+	"route256/cart/internal/pkg/customerror"
 	"route256/cart/internal/pkg/model"
 	"route256/cart/internal/pkg/utils/metrics"
 	"route256/cart/pkg/api/loms/v1"
 	"route256/cart/pkg/tracing"
 	"time"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 )
 
 type LomsService struct {
@@ -20,7 +25,7 @@ func NewLomsService(client loms.LomsClient) *LomsService {
 	}
 }
 
-func (s *LomsService) OrderCreate(ctx context.Context, user model.UserId, cart model.Cart) (_ model.OrderId, err error) {
+func (s *LomsService) OrderCreate(ctx context.Context, user model.UserId, cart model.Cart, requestId string) (_ model.OrderId, err error) {
 	ctx, span := tracing.Start(ctx, "LomsService.OrderCreate")
 	defer tracing.EndWithCheckError(span, &err)
 
@@ -30,8 +35,9 @@ func (s *LomsService) OrderCreate(ctx context.Context, user model.UserId, cart m
 	}(time.Now())
 
 	req := loms.OrderCreateRequest{
-		User:  int64(user),
-		Items: make([]*loms.OrderItem, 0, len(cart)),
+		User:      int64(user),
+		Items:     make([]*loms.OrderItem, 0, len(cart)),
+		RequestId: requestId,
 	}
 	for productSku, count := range cart {
 		req.Items = append(req.Items, &loms.OrderItem{
@@ -63,3 +69,99 @@ func (s *LomsService) StocksInfo(ctx context.Context, sku model.ProductSku) (_ u
 	}
 	return res.Count, nil
 }
+
+// StocksInfoBatch возвращает остатки по всем sku одним запросом.
+// Sku, которых нет в LOMS, возвращаются отдельно, а не ошибкой.
+func (s *LomsService) StocksInfoBatch(ctx context.Context, skus []model.ProductSku) (_ map[model.ProductSku]uint64, _ []model.ProductSku, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StocksInfoBatch")
+	defer tracing.EndWithCheckError(span, &err)
+
+	metrics.ExternalRequestCounter("loms.StocksInfoBatch")
+	defer func(start time.Time) {
+		metrics.ExternalRequestDurationWithError("loms.StocksInfoBatch", err, time.Since(start).Seconds())
+	}(time.Now())
+
+	req := loms.StocksInfoBatchRequest{
+		Skus: make([]uint32, 0, len(skus)),
+	}
+	for _, sku := range skus {
+		req.Skus = append(req.Skus, uint32(sku))
+	}
+	res, err := s.client.StocksInfoBatch(ctx, &req)
+	if err != nil {
+		return nil, nil, fmt.Errorf("lomsClient.StocksInfoBatch: %w", err)
+	}
+
+	stocks := make(map[model.ProductSku]uint64, len(res.Stocks))
+	for _, stock := range res.Stocks {
+		stocks[model.ProductSku(stock.Sku)] = stock.Count
+	}
+	unknownSkus := make([]model.ProductSku, 0, len(res.UnknownSkus))
+	for _, sku := range res.UnknownSkus {
+		unknownSkus = append(unknownSkus, model.ProductSku(sku))
+	}
+	return stocks, unknownSkus, nil
+}
+
+func (s *LomsService) HoldCreate(ctx context.Context, user model.UserId, sku model.ProductSku, count uint16, ttl time.Duration) (_ model.HoldId, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldCreate")
+	defer tracing.EndWithCheckError(span, &err)
+
+	metrics.ExternalRequestCounter("loms.HoldCreate")
+	defer func(start time.Time) {
+		metrics.ExternalRequestDurationWithError("loms.HoldCreate", err, time.Since(start).Seconds())
+	}(time.Now())
+
+	res, err := s.client.HoldCreate(ctx, &loms.HoldCreateRequest{
+		User:       int64(user),
+		Sku:        uint32(sku),
+		Count:      uint32(count),
+		TtlSeconds: uint32(ttl.Seconds()),
+	})
+	if status.Code(err) == codes.FailedPrecondition {
+		return 0, customerror.NewErrStatusCode("not enough products in stock", http.StatusPreconditionFailed)
+	}
+	if err != nil {
+		return 0, fmt.Errorf("lomsClient.HoldCreate: %w", err)
+	}
+	return model.HoldId(res.HoldId), nil
+}
+
+func (s *LomsService) HoldExtend(ctx context.Context, user model.UserId, holdId model.HoldId, ttl time.Duration) (err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldExtend")
+	defer tracing.EndWithCheckError(span, &err)
+
+	metrics.ExternalRequestCounter("loms.HoldExtend")
+	defer func(start time.Time) {
+		metrics.ExternalRequestDurationWithError("loms.HoldExtend", err, time.Since(start).Seconds())
+	}(time.Now())
+
+	_, err = s.client.HoldExtend(ctx, &loms.HoldExtendRequest{
+		HoldId:     int64(holdId),
+		TtlSeconds: uint32(ttl.Seconds()),
+		User:       int64(user),
+	})
+	if err != nil {
+		return fmt.Errorf("lomsClient.HoldExtend: %w", err)
+	}
+	return nil
+}
+
+func (s *LomsService) HoldRelease(ctx context.Context, user model.UserId, holdId model.HoldId) (err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldRelease")
+	defer tracing.EndWithCheckError(span, &err)
+
+	metrics.ExternalRequestCounter("loms.HoldRelease")
+	defer func(start time.Time) {
+		metrics.ExternalRequestDurationWithError("loms.HoldRelease", err, time.Since(start).Seconds())
+	}(time.Now())
+
+	_, err = s.client.HoldRelease(ctx, &loms.HoldReleaseRequest{
+		HoldId: int64(holdId),
+		User:   int64(user),
+	})
+	if err != nil {
+		return fmt.Errorf("lomsClient.HoldRelease: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,85 @@
+// Package catalog читает каталог товаров из json или csv файла.
+package catalog
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+)
+
+var ErrUnknownFixtureFormat = errors.New("unknown fixture format")
+
+type Product struct {
+	Sku   uint32 `json:"sku"`
+	Name  string `json:"name"`
+	Price uint32 `json:"price"`
+}
+
+type Catalog map[uint32]Product
+
+// LoadCatalog читает товары из json (массив объектов sku, name, price)
+// или csv (колонки sku,name,price, первая строка - заголовок) файла.
+func LoadCatalog(path string) (Catalog, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".json":
+		return ReadJSONCatalog(f)
+	case ".csv":
+		return ReadCSVCatalog(f)
+	}
+	return nil, fmt.Errorf("%w: %s", ErrUnknownFixtureFormat, path)
+}
+
+func ReadJSONCatalog(r io.Reader) (Catalog, error) {
+	var products []Product
+	if err := json.NewDecoder(r).Decode(&products); err != nil {
+		return nil, fmt.Errorf("json.Decode: %w", err)
+	}
+	catalog := make(Catalog, len(products))
+	for _, product := range products {
+		catalog[product.Sku] = product
+	}
+	return catalog, nil
+}
+
+func ReadCSVCatalog(r io.Reader) (Catalog, error) {
+	records, err := csv.NewReader(r).ReadAll()
+	if err != nil {
+		return nil, fmt.Errorf("csv.ReadAll: %w", err)
+	}
+	if len(records) == 0 {
+		return Catalog{}, nil
+	}
+
+	catalog := make(Catalog, len(records)-1)
+	for i, record := range records[1:] {
+		if len(record) != 3 {
+			return nil, fmt.Errorf("line %d: expected 3 columns, got %d", i+2, len(record))
+		}
+		sku, err := strconv.ParseUint(record[0], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: strconv.ParseUint sku: %w", i+2, err)
+		}
+		price, err := strconv.ParseUint(record[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("line %d: strconv.ParseUint price: %w", i+2, err)
+		}
+		catalog[uint32(sku)] = Product{
+			Sku:   uint32(sku),
+			Name:  record[1],
+			Price: uint32(price),
+		}
+	}
+	return catalog, nil
+}
@@ -0,0 +1,44 @@
+package catalog_test
+
+import (
+	"route256/cart/internal/pkg/service/product/catalog"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testCatalog = catalog.Catalog{
+	1076963: {Sku: 1076963, Name: "Теория нравственных чувств | Смит Адам", Price: 3379},
+	1148162: {Sku: 1148162, Name: "Кулинар Гуров", Price: 2567},
+}
+
+func TestReadCatalog(t *testing.T) {
+	t.Parallel()
+
+	jsonCatalog, err := catalog.ReadJSONCatalog(strings.NewReader(
+		`[{"sku":1076963,"name":"Теория нравственных чувств | Смит Адам","price":3379},{"sku":1148162,"name":"Кулинар Гуров","price":2567}]`,
+	))
+	require.NoError(t, err)
+	require.Equal(t, testCatalog, jsonCatalog)
+
+	csvCatalog, err := catalog.ReadCSVCatalog(strings.NewReader(
+		"sku,name,price\n1076963,Теория нравственных чувств | Смит Адам,3379\n1148162,Кулинар Гуров,2567\n",
+	))
+	require.NoError(t, err)
+	require.Equal(t, testCatalog, csvCatalog)
+
+	_, err = catalog.ReadCSVCatalog(strings.NewReader("sku,name,price\nabc,Кулинар Гуров,2567\n"))
+	require.Error(t, err)
+}
+
+func TestLoadCatalogFixture(t *testing.T) {
+	t.Parallel()
+
+	products, err := catalog.LoadCatalog("../../../../../test/testdata/products.json")
+	require.NoError(t, err)
+	require.Equal(t, uint32(3379), products[1076963].Price)
+
+	_, err = catalog.LoadCatalog("products.yaml")
+	require.Error(t, err)
+}
@@ -71,12 +71,18 @@ func (ps *ProductService) GetProduct(ctx context.Context, ProductSku model.Produ
 
 	metrics.ExternalRequestDuration(url, strconv.Itoa(res.StatusCode), time.Since(start).Seconds())
 
-	if res.StatusCode != http.StatusOK {
+	if res.StatusCode == http.StatusNotFound {
 		return nil, customerror.NewErrStatusCode(
 			fmt.Sprintf("sku %v not found", ProductSku),
 			http.StatusPreconditionFailed,
 		)
 	}
+	if res.StatusCode != http.StatusOK {
+		return nil, customerror.NewErrStatusCode(
+			fmt.Sprintf("product service responded %d for sku %v", res.StatusCode, ProductSku),
+			http.StatusBadGateway,
+		)
+	}
 
 	data, err := io.ReadAll(res.Body)
 	if err != nil {
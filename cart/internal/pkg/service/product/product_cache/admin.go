@@ -0,0 +1,113 @@
+package product_cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/pkg/tracing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+var ErrNotCached = errors.New("product is not cached")
+
+func (p *ProductCacheService) LookupProduct(ctx context.Context, ProductSku model.ProductSku) (_ *model.Product, err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.LookupProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	cacheProduct, err := p.cache.Get(ctx, getProductKey(ProductSku))
+	if err != nil {
+		return nil, fmt.Errorf("%w: sku %d: %w", ErrNotCached, ProductSku, err)
+	}
+	product, err := p.codec.Decode([]byte(cacheProduct))
+	if err != nil {
+		return nil, fmt.Errorf("%w: sku %d: p.codec.Decode: %w", ErrNotCached, ProductSku, err)
+	}
+	return &product, nil
+}
+
+func (p *ProductCacheService) InvalidateProduct(ctx context.Context, ProductSku model.ProductSku) (err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.InvalidateProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	if err := p.cache.Del(ctx, getProductKey(ProductSku)); err != nil {
+		return fmt.Errorf("p.cache.Del: %w", err)
+	}
+	return nil
+}
+
+func (p *ProductCacheService) RefreshProduct(ctx context.Context, ProductSku model.ProductSku) (_ *model.Product, err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.RefreshProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	product, err := p.productService.GetProduct(ctx, ProductSku)
+	if err != nil {
+		return nil, fmt.Errorf("p.productService.GetProduct: %w", err)
+	}
+	if err := p.set(ctx, getProductKey(ProductSku), product); err != nil {
+		return nil, fmt.Errorf("p.set: %w", err)
+	}
+	return product, nil
+}
+
+// InvalidatePrefix удаляет из кэша все товары, sku которых начинается с prefix.
+func (p *ProductCacheService) InvalidatePrefix(ctx context.Context, prefix string) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.InvalidatePrefix")
+	defer tracing.EndWithCheckError(span, &err)
+
+	skus, err := p.cachedSkus(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("p.cachedSkus: %w", err)
+	}
+	for i, sku := range skus {
+		if err := p.cache.Del(ctx, getProductKey(sku)); err != nil {
+			return i, fmt.Errorf("p.cache.Del: %w", err)
+		}
+	}
+	return len(skus), nil
+}
+
+// RefreshPrefix заново загружает из ProductService все закэшированные товары,
+// sku которых начинается с prefix, не превышая rps запросов в секунду.
+func (p *ProductCacheService) RefreshPrefix(ctx context.Context, prefix string, rps int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.RefreshPrefix")
+	defer tracing.EndWithCheckError(span, &err)
+
+	skus, err := p.cachedSkus(ctx, prefix)
+	if err != nil {
+		return 0, fmt.Errorf("p.cachedSkus: %w", err)
+	}
+
+	period := time.NewTicker(time.Second / time.Duration(rps))
+	defer period.Stop()
+
+	for i, sku := range skus {
+		select {
+		case <-ctx.Done():
+			return i, ctx.Err()
+		case <-period.C:
+		}
+		if _, err := p.RefreshProduct(ctx, sku); err != nil {
+			return i, fmt.Errorf("p.RefreshProduct: %w", err)
+		}
+	}
+	return len(skus), nil
+}
+
+func (p *ProductCacheService) cachedSkus(ctx context.Context, prefix string) ([]model.ProductSku, error) {
+	keys, err := p.cache.Keys(ctx, getProductCacheKey+":"+prefix)
+	if err != nil {
+		return nil, fmt.Errorf("p.cache.Keys: %w", err)
+	}
+	skus := make([]model.ProductSku, 0, len(keys))
+	for _, key := range keys {
+		sku, err := strconv.ParseInt(strings.TrimPrefix(key, getProductCacheKey+":"), 10, 64)
+		if err != nil {
+			continue
+		}
+		skus = append(skus, model.ProductSku(sku))
+	}
+	return skus, nil
+}
@@ -5,9 +5,11 @@ import (
 	"fmt"
 	"route256/cart/internal/pkg/model"
 	"route256/cart/internal/pkg/utils/metrics"
+	"route256/cart/pkg/logger"
 	"route256/cart/pkg/tracing"
 	"strconv"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
@@ -15,10 +17,16 @@ type ProductService interface {
 	GetProduct(context.Context, model.ProductSku) (*model.Product, error)
 }
 
+type ProductCodec interface {
+	Encode(model.Product) ([]byte, error)
+	Decode([]byte) (model.Product, error)
+}
+
 type Cacher interface {
 	Get(ctx context.Context, key string) (string, error)
 	Set(ctx context.Context, key string, value any, ttl time.Duration) error
 	Del(ctx context.Context, key string) error
+	Keys(ctx context.Context, prefix string) ([]string, error)
 }
 
 const (
@@ -28,18 +36,30 @@ const (
 type ProductCacheService struct {
 	productService ProductService
 	cache          Cacher
-	defaultTTL     time.Duration
+	codec          ProductCodec
+	defaultTTL     atomic.Int64
 	inProcess      map[string]chan struct{}
 	inProcessMx    sync.RWMutex
 }
 
-func NewProductCacheService(productService ProductService, cache Cacher, defaultTTL time.Duration) *ProductCacheService {
-	return &ProductCacheService{
+func getProductKey(sku model.ProductSku) string {
+	return getProductCacheKey + ":" + strconv.Itoa(int(sku))
+}
+
+func NewProductCacheService(productService ProductService, cache Cacher, codec ProductCodec, defaultTTL time.Duration) *ProductCacheService {
+	p := &ProductCacheService{
 		productService: productService,
 		cache:          cache,
-		defaultTTL:     defaultTTL,
+		codec:          codec,
 		inProcess:      make(map[string]chan struct{}),
 	}
+	p.SetDefaultTTL(defaultTTL)
+	return p
+}
+
+// SetDefaultTTL меняет TTL для новых записей кэша, уже сохраненные записи не трогает.
+func (p *ProductCacheService) SetDefaultTTL(ttl time.Duration) {
+	p.defaultTTL.Store(int64(ttl))
 }
 
 func (p *ProductCacheService) GetProduct(ctx context.Context, ProductSku model.ProductSku) (_ *model.Product, err error) {
@@ -50,19 +70,22 @@ func (p *ProductCacheService) GetProduct(ctx context.Context, ProductSku model.P
 
 	start := time.Now()
 
-	key := getProductCacheKey + ":" + strconv.Itoa(int(ProductSku))
+	key := getProductKey(ProductSku)
 
 	if cacheProduct, err := p.cache.Get(ctx, key); err == nil {
-		go func(start time.Time) {
-			metrics.CacheHitCounter(serviceHandler)
-			metrics.CacheHitDuration(serviceHandler, time.Since(start).Seconds())
-		}(start)
-
-		var product model.Product
-		if err := product.UnmarshalBinary([]byte(cacheProduct)); err != nil {
-			return nil, fmt.Errorf("json.Unmarshal: %w", err)
+		product, err := p.codec.Decode([]byte(cacheProduct))
+		if err == nil {
+			go func(start time.Time) {
+				metrics.CacheHitCounter(serviceHandler)
+				metrics.CacheHitDuration(serviceHandler, time.Since(start).Seconds())
+			}(start)
+			return &product, nil
+		}
+		// значение другой версии формата (например, при раскатке или откате) считаем промахом
+		logger.Errorw(ctx, "product cache: p.codec.Decode", "key", key, "err", err)
+		if err := p.cache.Del(ctx, key); err != nil {
+			logger.Errorw(ctx, "product cache: p.cache.Del", "key", key, "err", err)
 		}
-		return &product, nil
 	}
 
 	p.inProcessMx.RLock()
@@ -92,9 +115,19 @@ func (p *ProductCacheService) GetProduct(ctx context.Context, ProductSku model.P
 		metrics.CacheMissDuration(serviceHandler, time.Since(start).Seconds())
 	}(start)
 
-	err = p.cache.Set(ctx, key, *product, p.defaultTTL)
-	if err != nil {
-		return nil, fmt.Errorf("p.cache.Set: %w", err)
+	if err := p.set(ctx, key, product); err != nil {
+		return nil, fmt.Errorf("p.set: %w", err)
 	}
 	return product, nil
 }
+
+func (p *ProductCacheService) set(ctx context.Context, key string, product *model.Product) error {
+	data, err := p.codec.Encode(*product)
+	if err != nil {
+		return fmt.Errorf("p.codec.Encode: %w", err)
+	}
+	if err := p.cache.Set(ctx, key, data, time.Duration(p.defaultTTL.Load())); err != nil {
+		return fmt.Errorf("p.cache.Set: %w", err)
+	}
+	return nil
+}
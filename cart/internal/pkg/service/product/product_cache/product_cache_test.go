@@ -3,7 +3,9 @@ package product_cache
 import (
 	"context"
 	"errors"
+	"route256/cart/internal/pkg/cache/codec"
 	"route256/cart/internal/pkg/model"
+	"strings"
 	"sync"
 	"testing"
 	"time"
@@ -48,18 +50,34 @@ func (c *CacheTest) Set(ctx context.Context, key string, value any, ttl time.Dur
 	c.mx.Lock()
 	c.setCount++
 	defer c.mx.Unlock()
-	val, err := value.(model.Product).MarshalBinary()
-	c.cache[key] = string(val)
-	return err
+	c.cache[key] = string(value.([]byte))
+	return nil
 }
 
-func (c *CacheTest) Del(ctx context.Context, key string) error { return nil }
+func (c *CacheTest) Del(ctx context.Context, key string) error {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	delete(c.cache, key)
+	return nil
+}
+
+func (c *CacheTest) Keys(ctx context.Context, prefix string) ([]string, error) {
+	c.mx.Lock()
+	defer c.mx.Unlock()
+	keys := make([]string, 0, len(c.cache))
+	for key := range c.cache {
+		if strings.HasPrefix(key, prefix) {
+			keys = append(keys, key)
+		}
+	}
+	return keys, nil
+}
 
 func TestProductCacheServiceWaitForCache(t *testing.T) {
 	productServiceTest := &ProductServiceTest{}
 	cache := &CacheTest{cache: make(map[string]string)}
 
-	pcs := NewProductCacheService(productServiceTest, cache, 5*time.Second)
+	pcs := NewProductCacheService(productServiceTest, cache, codec.JSON[model.Product]{}, 5*time.Second)
 
 	ctx := context.Background()
 	wg := &sync.WaitGroup{}
@@ -80,3 +98,72 @@ func TestProductCacheServiceWaitForCache(t *testing.T) {
 	assert.Equal(t, 1, cache.setCount, "cacheTest.setCahce")
 	assert.Equal(t, testCount*2-1, cache.getCount, "cacheTest.getCahce")
 }
+
+type ProductServiceSkuTest struct{}
+
+func (p *ProductServiceSkuTest) GetProduct(_ context.Context, sku model.ProductSku) (*model.Product, error) {
+	if sku == 404 {
+		return nil, errors.New("not found")
+	}
+	return &model.Product{
+		Sku:  sku,
+		Name: "test",
+	}, nil
+}
+
+func TestProductCacheServiceWarmup(t *testing.T) {
+	ctx := context.Background()
+	cache := &CacheTest{cache: make(map[string]string)}
+	pcs := NewProductCacheService(&ProductServiceSkuTest{}, cache, codec.JSON[model.Product]{}, 5*time.Second)
+
+	loaded, err := pcs.Warmup(ctx, []model.ProductSku{1, 404, 3}, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, loaded)
+	assert.Len(t, cache.cache, 2)
+
+	product, err := pcs.LookupProduct(ctx, 3)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProductSku(3), product.Sku)
+
+	_, err = pcs.LookupProduct(ctx, 404)
+	assert.ErrorIs(t, err, ErrNotCached)
+}
+
+func TestProductCacheServiceInvalidate(t *testing.T) {
+	ctx := context.Background()
+	cache := &CacheTest{cache: make(map[string]string)}
+	pcs := NewProductCacheService(&ProductServiceSkuTest{}, cache, codec.JSON[model.Product]{}, 5*time.Second)
+
+	_, err := pcs.Warmup(ctx, []model.ProductSku{1001, 1002, 2001}, 100)
+	assert.NoError(t, err)
+
+	err = pcs.InvalidateProduct(ctx, 2001)
+	assert.NoError(t, err)
+	_, err = pcs.LookupProduct(ctx, 2001)
+	assert.ErrorIs(t, err, ErrNotCached)
+
+	count, err := pcs.RefreshPrefix(ctx, "10", 100)
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+
+	count, err = pcs.InvalidatePrefix(ctx, "10")
+	assert.NoError(t, err)
+	assert.Equal(t, 2, count)
+	assert.Empty(t, cache.cache)
+}
+
+func TestProductCacheServiceUndecodable(t *testing.T) {
+	ctx := context.Background()
+	cache := &CacheTest{cache: map[string]string{getProductKey(5): "\x09garbage"}}
+	pcs := NewProductCacheService(&ProductServiceSkuTest{}, cache, codec.JSON[model.Product]{}, 5*time.Second)
+
+	// нечитаемое значение - промах: товар перезапрашивается и перезаписывается
+	product, err := pcs.GetProduct(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProductSku(5), product.Sku)
+	assert.Equal(t, 1, cache.setCount)
+
+	product, err = pcs.LookupProduct(ctx, 5)
+	assert.NoError(t, err)
+	assert.Equal(t, model.ProductSku(5), product.Sku)
+}
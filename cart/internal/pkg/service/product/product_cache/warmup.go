@@ -0,0 +1,67 @@
+package product_cache
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"os"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/pkg/logger"
+	"route256/cart/pkg/tracing"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Warmup загружает товары в кэш, не превышая rps запросов в секунду к ProductService.
+// Ошибки по отдельным sku только логируются, чтобы один битый sku не останавливал прогрев.
+func (p *ProductCacheService) Warmup(ctx context.Context, skus []model.ProductSku, rps int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "ProductCache.Warmup")
+	defer tracing.EndWithCheckError(span, &err)
+
+	period := time.NewTicker(time.Second / time.Duration(rps))
+	defer period.Stop()
+
+	loaded := 0
+	for _, sku := range skus {
+		select {
+		case <-ctx.Done():
+			return loaded, ctx.Err()
+		case <-period.C:
+		}
+		if _, err := p.GetProduct(ctx, sku); err != nil {
+			logger.Errorw(ctx, "cache warmup: p.GetProduct", "sku", sku, "err", err)
+			continue
+		}
+		loaded++
+	}
+	return loaded, nil
+}
+
+// ReadWarmupSkus читает sku для прогрева из файла: по одному sku в строке,
+// пустые строки и строки, начинающиеся с #, пропускаются.
+func ReadWarmupSkus(path string) ([]model.ProductSku, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, fmt.Errorf("os.Open: %w", err)
+	}
+	defer f.Close()
+
+	skus := make([]model.ProductSku, 0)
+	scanner := bufio.NewScanner(f)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimSpace(scanner.Text())
+		if text == "" || strings.HasPrefix(text, "#") {
+			continue
+		}
+		sku, err := strconv.ParseInt(text, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("strconv.ParseInt line %d: %w", line, err)
+		}
+		skus = append(skus, model.ProductSku(sku))
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("scanner.Err: %w", err)
+	}
+	return skus, nil
+}
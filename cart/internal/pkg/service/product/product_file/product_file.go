@@ -0,0 +1,109 @@
+package product_file
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"route256/cart/internal/pkg/customerror"
+	"route256/cart/internal/pkg/model"
+	"route256/cart/internal/pkg/service/product/catalog"
+	"route256/cart/pkg/logger"
+	"route256/cart/pkg/tracing"
+	"sync"
+	"time"
+)
+
+// ProductFileService отдает товары из локального файла каталога (json или csv)
+// без обращений к ProductService. Файл перечитывается при изменении.
+type ProductFileService struct {
+	path    string
+	catalog catalog.Catalog
+	modTime time.Time
+	size    int64
+	mx      sync.RWMutex
+}
+
+func NewProductFileService(path string) (*ProductFileService, error) {
+	p := &ProductFileService{
+		path: path,
+	}
+	if _, err := p.Reload(); err != nil {
+		return nil, fmt.Errorf("p.Reload: %w", err)
+	}
+	return p, nil
+}
+
+func (p *ProductFileService) GetProduct(ctx context.Context, ProductSku model.ProductSku) (_ *model.Product, err error) {
+	_, span := tracing.Start(ctx, "ProductFileService.GetProduct")
+	defer tracing.EndWithCheckError(span, &err)
+
+	p.mx.RLock()
+	product, ok := p.catalog[uint32(ProductSku)]
+	p.mx.RUnlock()
+	if !ok {
+		return nil, customerror.NewErrStatusCode(
+			fmt.Sprintf("sku %v not found", ProductSku),
+			http.StatusPreconditionFailed,
+		)
+	}
+
+	return &model.Product{
+		Sku:   ProductSku,
+		Name:  product.Name,
+		Price: product.Price,
+	}, nil
+}
+
+// Reload перечитывает файл, если он изменился с прошлой загрузки.
+// При ошибке остается предыдущий каталог.
+func (p *ProductFileService) Reload() (bool, error) {
+	info, err := os.Stat(p.path)
+	if err != nil {
+		return false, fmt.Errorf("os.Stat: %w", err)
+	}
+
+	p.mx.RLock()
+	changed := p.catalog == nil || !info.ModTime().Equal(p.modTime) || info.Size() != p.size
+	p.mx.RUnlock()
+	if !changed {
+		return false, nil
+	}
+
+	products, err := catalog.LoadCatalog(p.path)
+	if err != nil {
+		return false, fmt.Errorf("catalog.LoadCatalog: %w", err)
+	}
+
+	p.mx.Lock()
+	p.catalog = products
+	p.modTime = info.ModTime()
+	p.size = info.Size()
+	p.mx.Unlock()
+	return true, nil
+}
+
+// Watch проверяет файл каждые interval, пока не отменен ctx.
+func (p *ProductFileService) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := p.Reload()
+			if err != nil {
+				logger.Errorw(ctx, "product catalog reload failed", "path", p.path, "err", err)
+				continue
+			}
+			if reloaded {
+				p.mx.RLock()
+				count := len(p.catalog)
+				p.mx.RUnlock()
+				logger.Infow(ctx, "product catalog reloaded", "path", p.path, "products", count)
+			}
+		}
+	}
+}
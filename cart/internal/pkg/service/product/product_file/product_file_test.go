@@ -0,0 +1,86 @@
+package product_file
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"route256/cart/internal/pkg/model"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func writeCatalog(t *testing.T, path string, data string, modTime time.Time) {
+	require.NoError(t, os.WriteFile(path, []byte(data), 0o644))
+	require.NoError(t, os.Chtimes(path, modTime, modTime))
+}
+
+func TestProductFileService(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	path := filepath.Join(t.TempDir(), "products.csv")
+	now := time.Now()
+
+	writeCatalog(t, path, "sku,name,price\n1076963,Теория нравственных чувств | Смит Адам,3379\n", now)
+
+	productService, err := NewProductFileService(path)
+	require.NoError(t, err)
+
+	product, err := productService.GetProduct(ctx, 1076963)
+	require.NoError(t, err)
+	require.Equal(t, &model.Product{Sku: 1076963, Name: "Теория нравственных чувств | Смит Адам", Price: 3379}, product)
+
+	_, err = productService.GetProduct(ctx, 1148162)
+	require.Error(t, err)
+
+	reloaded, err := productService.Reload()
+	require.NoError(t, err)
+	require.False(t, reloaded)
+
+	writeCatalog(t, path, "sku,name,price\n1076963,Теория нравственных чувств | Смит Адам,3500\n1148162,Кулинар Гуров,2567\n", now.Add(time.Second))
+	reloaded, err = productService.Reload()
+	require.NoError(t, err)
+	require.True(t, reloaded)
+
+	product, err = productService.GetProduct(ctx, 1076963)
+	require.NoError(t, err)
+	require.Equal(t, uint32(3500), product.Price)
+	_, err = productService.GetProduct(ctx, 1148162)
+	require.NoError(t, err)
+
+	// битый файл не затирает загруженный каталог
+	writeCatalog(t, path, "sku,name,price\nabc,Кулинар Гуров,2567\n", now.Add(2*time.Second))
+	_, err = productService.Reload()
+	require.Error(t, err)
+	_, err = productService.GetProduct(ctx, 1148162)
+	require.NoError(t, err)
+}
+
+func TestProductFileServiceWatch(t *testing.T) {
+	t.Parallel()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	path := filepath.Join(t.TempDir(), "products.json")
+	now := time.Now()
+
+	writeCatalog(t, path, `[{"sku":1076963,"name":"Теория нравственных чувств | Смит Адам","price":3379}]`, now)
+	productService, err := NewProductFileService(path)
+	require.NoError(t, err)
+	go productService.Watch(ctx, 10*time.Millisecond)
+
+	writeCatalog(t, path, `[{"sku":1148162,"name":"Кулинар Гуров","price":2567}]`, now.Add(time.Second))
+	require.Eventually(t, func() bool {
+		_, err := productService.GetProduct(ctx, 1148162)
+		return err == nil
+	}, time.Second, 10*time.Millisecond)
+}
+
+func TestNewProductFileServiceMissingFile(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewProductFileService(filepath.Join(t.TempDir(), "products.json"))
+	require.Error(t, err)
+}
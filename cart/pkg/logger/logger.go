@@ -17,6 +17,9 @@ type Logger struct {
 
 var globalLogger *Logger
 
+// level общий для всех логгеров пакета и может меняться на лету.
+var level = zap.NewAtomicLevelAt(zapcore.InfoLevel)
+
 func init() {
 	var err error
 	globalLogger, err = New()
@@ -31,7 +34,7 @@ func Set(logger *Logger) {
 
 func New() (*Logger, error) {
 	config := zap.NewProductionConfig()
-	config.Level.SetLevel(zapcore.InfoLevel)
+	config.Level = level
 	config.DisableCaller = true
 
 	logger, err := config.Build()
@@ -42,6 +45,16 @@ func New() (*Logger, error) {
 	return &Logger{logger: logger.Sugar()}, nil
 }
 
+// SetLevel меняет уровень логирования: debug, info, warn, error.
+func SetLevel(text string) error {
+	l, err := zapcore.ParseLevel(text)
+	if err != nil {
+		return fmt.Errorf("zapcore.ParseLevel: %w", err)
+	}
+	level.SetLevel(l)
+	return nil
+}
+
 func With(args ...any) *Logger {
 	return &Logger{globalLogger.logger.With(args...)}
 }
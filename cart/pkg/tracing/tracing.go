@@ -10,6 +10,7 @@ import (
 	sdktrace "go.opentelemetry.io/otel/sdk/trace"
 	semconv "go.opentelemetry.io/otel/semconv/v1.26.0"
 	"go.opentelemetry.io/otel/trace"
+	"go.opentelemetry.io/otel/trace/noop"
 )
 
 var tracer trace.Tracer
@@ -20,7 +21,7 @@ func Set(trc trace.Tracer) {
 
 func Start(ctx context.Context, spanName string, opts ...trace.SpanStartOption) (context.Context, trace.Span) {
 	if tracer == nil {
-		return ctx, nil
+		return ctx, noop.Span{}
 	}
 	return tracer.Start(ctx, spanName, opts...)
 }
@@ -9,6 +9,8 @@ import (
 	"net/http/httptest"
 	"route256/cart/internal/app/server"
 	"route256/cart/internal/pkg/config"
+	"route256/cart/internal/pkg/productstub"
+	"route256/cart/internal/pkg/service/product/catalog"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -17,12 +19,24 @@ import (
 
 const contentType = "application/json"
 
+func newProductServer(t *testing.T) *httptest.Server {
+	products, err := catalog.LoadCatalog("../testdata/products.json")
+	require.NoError(t, err)
+	return httptest.NewServer(productstub.NewHandler(products))
+}
+
 func TestServer(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
 	ctx := context.Background()
 
-	app := server.NewApp(ctx, config.NewConfig())
+	productServer := newProductServer(t)
+	defer productServer.Close()
+	t.Setenv("PRODUCT_SERVICE_URL", productServer.URL)
+
+	config, err := config.NewConfig()
+	require.NoError(t, err)
+	app := server.NewApp(ctx, config)
 	serverApp := httptest.NewServer(app.Handler)
 	defer serverApp.Close()
 
@@ -81,7 +95,7 @@ func GetCart(serverApp *httptest.Server, t *testing.T) {
 	err = json.Unmarshal(data, &getCartResponse)
 	require.NoError(t, err)
 
-	require.Equal(t, uint32(3379), getCartResponse.TotalPrice)
+	require.Equal(t, uint64(3379), getCartResponse.TotalPrice)
 }
 
 func RemoveProduct(serverApp *httptest.Server, t *testing.T) {
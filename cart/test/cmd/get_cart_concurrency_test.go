@@ -18,7 +18,13 @@ func TestGetCart(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
 	ctx := context.Background()
-	config := config.NewConfig()
+
+	productServer := newProductServer(t)
+	defer productServer.Close()
+	t.Setenv("PRODUCT_SERVICE_URL", productServer.URL)
+
+	config, err := config.NewConfig()
+	require.NoError(t, err)
 	ctrl := minimock.NewController(t)
 
 	lomsServiceMock := mock.NewLomsServiceMock(ctrl)
@@ -29,7 +35,7 @@ func TestGetCart(t *testing.T) {
 	testData := []struct {
 		name string
 		cart model.Cart
-		test func(error)
+		test func(model.CartView, error)
 	}{
 		{
 			name: "valid params",
@@ -38,8 +44,9 @@ func TestGetCart(t *testing.T) {
 				1148162: 1,
 				1625903: 1,
 			},
-			test: func(err error) {
+			test: func(cart model.CartView, err error) {
 				require.NoError(t, err)
+				require.False(t, cart.Degraded())
 			},
 		},
 		{
@@ -67,8 +74,10 @@ func TestGetCart(t *testing.T) {
 				5415913: 1,
 				5647362: 1,
 			},
-			test: func(err error) {
-				require.Error(t, err)
+			test: func(cart model.CartView, err error) {
+				require.NoError(t, err)
+				require.True(t, cart.Degraded())
+				require.Equal(t, model.UnavailableReasonNotFound, cart.Unavailable[1].Reason)
 			},
 		},
 		{
@@ -96,8 +105,10 @@ func TestGetCart(t *testing.T) {
 				5415913: 1,
 				5647362: 1,
 			},
-			test: func(err error) {
-				require.Error(t, err)
+			test: func(cart model.CartView, err error) {
+				require.NoError(t, err)
+				require.True(t, cart.Degraded())
+				require.Equal(t, model.UnavailableReasonNotFound, cart.Unavailable[1].Reason)
 			},
 		},
 		{
@@ -125,17 +136,24 @@ func TestGetCart(t *testing.T) {
 				5647362: 1,
 				1:       1, // wrong
 			},
-			test: func(err error) {
-				require.Error(t, err)
+			test: func(cart model.CartView, err error) {
+				require.NoError(t, err)
+				require.True(t, cart.Degraded())
+				require.Equal(t, model.UnavailableReasonNotFound, cart.Unavailable[1].Reason)
 			},
 		},
 	}
 
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
-			cartRepository.GetCartMock.Return(tt.cart, nil)
-			_, err := cartService.GetCart(ctx, 1)
-			tt.test(err)
+			items := make(model.CartItems, len(tt.cart))
+			for sku, count := range tt.cart {
+				items[sku] = model.CartItem{Count: count}
+			}
+			cartRepository.GetCartItemsMock.Return(items, nil)
+			lomsServiceMock.StocksInfoBatchMock.Optional().Return(nil, nil, nil)
+			cart, err := cartService.GetCart(ctx, 1)
+			tt.test(cart, err)
 		})
 	}
 }
@@ -6,9 +6,11 @@ import (
 	"net/http/pprof"
 	"route256/loms/api/openapiv2"
 	"route256/loms/internal/pkg/config"
+	"route256/loms/internal/pkg/health"
 	"route256/loms/internal/pkg/middleware"
 	"route256/loms/pkg/api/loms/v1"
 	"route256/loms/pkg/logger"
+	"time"
 
 	"github.com/grpc-ecosystem/grpc-gateway/v2/runtime"
 	"github.com/prometheus/client_golang/prometheus/promhttp"
@@ -16,13 +18,23 @@ import (
 	"go.opentelemetry.io/contrib/instrumentation/google.golang.org/grpc/otelgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials/insecure"
+	grpchealth "google.golang.org/grpc/health"
+	healthpb "google.golang.org/grpc/health/grpc_health_v1"
 	"google.golang.org/grpc/reflection"
 )
 
+const (
+	healthCheckTimeout = 2 * time.Second
+	healthCheckPeriod  = 5 * time.Second
+)
+
 type App struct {
-	GrpcServer *grpc.Server
-	GwServer   *http.Server
-	LomsServer *Server
+	GrpcServer   *grpc.Server
+	GwServer     *http.Server
+	LomsServer   *Server
+	healthServer *grpchealth.Server
+	healthCancel context.CancelFunc
+	configCancel context.CancelFunc
 }
 
 func NewApp(ctx context.Context, config config.Config) *App {
@@ -39,6 +51,10 @@ func NewApp(ctx context.Context, config config.Config) *App {
 	)
 	reflection.Register(grpcServer)
 	loms.RegisterLomsServer(grpcServer, lomsServer)
+	healthServer := grpchealth.NewServer()
+	healthpb.RegisterHealthServer(grpcServer, healthServer)
+	healthCtx, healthCancel := context.WithCancel(ctx)
+	go watchHealth(healthCtx, lomsServer.checker, healthServer)
 
 	grpcClient, err := grpc.NewClient(config.GrpcUrl, grpc.WithTransportCredentials(insecure.NewCredentials()))
 	if err != nil {
@@ -58,21 +74,62 @@ func NewApp(ctx context.Context, config config.Config) *App {
 	mux.HandleFunc("/debug/pprof/trace", pprof.Trace)
 
 	mux.Handle("/metrics", promhttp.Handler())
+	mux.HandleFunc("GET /healthz", lomsServer.checker.Liveness)
+	mux.HandleFunc("GET /readyz", lomsServer.checker.Readiness)
 	mux.HandleFunc("/swagger.json", func(w http.ResponseWriter, r *http.Request) { w.Write(openapiv2.Doc) })
 	mux.HandleFunc("/swaggerui/", httpSwagger.Handler(httpSwagger.URL("/swagger.json")))
 	mux.Handle("/", gwmux)
 
-	return &App{
+	app := &App{
 		GrpcServer: grpcServer,
 		GwServer: &http.Server{
 			Addr:    config.HttpUrl,
 			Handler: mux,
 		},
-		LomsServer: lomsServer,
+		LomsServer:   lomsServer,
+		healthServer: healthServer,
+		healthCancel: healthCancel,
+	}
+
+	if config.File != "" {
+		configCtx, cancel := context.WithCancel(ctx)
+		app.configCancel = cancel
+		go watchConfig(configCtx, config)
 	}
+
+	return app
 }
 
 func (app *App) Shutdown(ctx context.Context) {
 	logger.Infow(ctx, "shutting down loms server app")
+	app.healthCancel()
+	if app.configCancel != nil {
+		app.configCancel()
+	}
+	app.healthServer.Shutdown()
 	app.LomsServer.Shutdown()
 }
+
+// watchHealth периодически обновляет статус grpc.health.v1 по результатам проверки зависимостей.
+func watchHealth(ctx context.Context, checker *health.Checker, healthServer *grpchealth.Server) {
+	ticker := time.NewTicker(healthCheckPeriod)
+	defer ticker.Stop()
+
+	for {
+		status := healthpb.HealthCheckResponse_SERVING
+		for name, err := range checker.Check(ctx) {
+			if err != nil {
+				logger.Errorw(ctx, "health check failed", "check", name, "err", err)
+				status = healthpb.HealthCheckResponse_NOT_SERVING
+			}
+		}
+		healthServer.SetServingStatus("", status)
+		healthServer.SetServingStatus(loms.Loms_ServiceDesc.ServiceName, status)
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
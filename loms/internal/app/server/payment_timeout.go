@@ -0,0 +1,37 @@
+package server
+
+import (
+	"context"
+	"route256/loms/pkg/logger"
+	"time"
+)
+
+// unpaidBatchSize - сколько заказов отменяется с одного шарда за проход
+const unpaidBatchSize = 100
+
+type unpaidOrdersCanceller interface {
+	CancelUnpaidOrders(ctx context.Context, timeout time.Duration, limit int) (int, error)
+}
+
+// watchPaymentTimeout периодически отменяет заказы, не оплаченные за timeout.
+// Можно запускать в нескольких репликах: каждый шард за проход обрабатывает одна из них.
+func watchPaymentTimeout(ctx context.Context, canceller unpaidOrdersCanceller, interval, timeout time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infow(ctx, "[payment timeout] terminate")
+			return
+		case <-ticker.C:
+			cancelled, err := canceller.CancelUnpaidOrders(ctx, timeout, unpaidBatchSize)
+			if err != nil {
+				logger.Errorw(ctx, "[payment timeout] cancel unpaid orders failed", "err", err)
+			}
+			if cancelled > 0 {
+				logger.Infow(ctx, "[payment timeout] orders cancelled", "count", cancelled)
+			}
+		}
+	}
+}
@@ -0,0 +1,43 @@
+package server
+
+import (
+	"context"
+	"route256/loms/pkg/logger"
+	"time"
+)
+
+const (
+	// restockBatchSize - сколько возвратов пополняется с одного шарда за проход
+	restockBatchSize = 100
+	// restockInterval - как часто искать возвраты, не пополнившие остатки
+	restockInterval = time.Minute
+	// restockDelay - свежие возвраты еще пополняет сам запрос OrderReturn
+	restockDelay = time.Minute
+)
+
+type returnsRestocker interface {
+	RestockReturns(ctx context.Context, delay time.Duration, limit int) (int, error)
+}
+
+// watchReturnRestock периодически дополняет остатки по возвратам, которые не удалось пополнить сразу.
+// Можно запускать в нескольких репликах: каждый шард за проход обрабатывает одна из них.
+func watchReturnRestock(ctx context.Context, restocker returnsRestocker, interval, delay time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			logger.Infow(ctx, "[return restock] terminate")
+			return
+		case <-ticker.C:
+			restocked, err := restocker.RestockReturns(ctx, delay, restockBatchSize)
+			if err != nil {
+				logger.Errorw(ctx, "[return restock] restock returns failed", "err", err)
+			}
+			if restocked > 0 {
+				logger.Infow(ctx, "[return restock] returns restocked", "count", restocked)
+			}
+		}
+	}
+}
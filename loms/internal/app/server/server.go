@@ -2,8 +2,11 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/config"
+	"route256/loms/internal/pkg/health"
 	"route256/loms/internal/pkg/inrfa/kafka/producer"
 	"route256/loms/internal/pkg/inrfa/shard_manager"
 	"route256/loms/internal/pkg/middleware"
@@ -19,17 +22,37 @@ import (
 
 	"github.com/IBM/sarama"
 	"github.com/jackc/pgx/v5/pgxpool"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/timestamppb"
 )
 
 type LomsService interface {
-	OrderCreate(ctx context.Context, order model.Order) (model.OrderID, error)
+	OrderCreate(ctx context.Context, order model.Order) (model.Order, error)
 	OrderInfo(ctx context.Context, orderID model.OrderID) (model.Order, error)
 	OrderPay(ctx context.Context, orderID model.OrderID) error
 	OrderCancel(ctx context.Context, orderID model.OrderID) error
+	OrderHistory(ctx context.Context, orderID model.OrderID) ([]model.OrderStatusChange, error)
+	OrderReturn(ctx context.Context, orderID model.OrderID, items []model.OrderItem) (model.OrderReturn, error)
+	OrderUpdateItems(ctx context.Context, orderID model.OrderID, items []model.OrderItem) (model.Order, error)
 	StocksInfo(ctx context.Context, sku model.ProductSku) (uint64, error)
+	StocksInfoByWarehouse(ctx context.Context, sku model.ProductSku) ([]model.WarehouseStock, error)
+	StocksInfoBatch(ctx context.Context, skus []model.ProductSku) (map[model.ProductSku]uint64, error)
+	HoldCreate(ctx context.Context, user model.UserID, sku model.ProductSku, count uint16, ttl time.Duration) (model.StockHold, error)
+	HoldExtend(ctx context.Context, user model.UserID, holdID model.HoldID, ttl time.Duration) (time.Time, error)
+	HoldRelease(ctx context.Context, user model.UserID, holdID model.HoldID) error
 	GetAllOrders(ctx context.Context) ([]model.Order, error)
+	ListOrders(ctx context.Context, filter model.OrderFilter, pageToken string, pageSize int) ([]model.Order, string, error)
+	StreamOrders(ctx context.Context, afterID model.OrderID, send func(model.Order) error) error
+	StockChange(ctx context.Context, change model.StockChange) (model.StockMovement, error)
+	StockHistory(ctx context.Context, sku model.ProductSku, beforeID model.StockMovementID, limit int) ([]model.StockMovement, error)
 }
 
+const (
+	defaultStockHistoryPageSize = 50
+	defaultListOrdersPageSize   = 20
+)
+
 type Producer interface {
 	Close()
 	RunEventsHandle()
@@ -37,8 +60,11 @@ type Producer interface {
 
 type Server struct {
 	loms.UnimplementedLomsServer
-	service  LomsService
-	producer Producer
+	service            LomsService
+	producer           Producer
+	checker            *health.Checker
+	stopPaymentTimeout context.CancelFunc
+	stopReturnRestock  context.CancelFunc
 }
 
 func NewServer(config config.Config) *Server {
@@ -79,7 +105,10 @@ func NewServer(config config.Config) *Server {
 	orderRepository := orderrepository.NewDbOrderRepository(shardManager)
 	outboxRepository := outboxrepository.NewDbOutboxRepository(dbBalancer)
 	orderOutboxWrapper := middleware.NewOrderOutboxWrapper(orderRepository, outboxRepository, config.Kafka)
-	lomsService := service.NewLomsService(stockRepository, orderOutboxWrapper)
+	stockOutboxWrapper := middleware.NewStockOutboxWrapper(stockRepository, outboxRepository, config.Kafka)
+	lomsService := service.NewLomsService(stockOutboxWrapper, orderOutboxWrapper,
+		service.WithAllocationStrategy(allocation.Strategy(config.AllocationStrategy)),
+	)
 
 	prod, err := producer.NewProducer(ctx, config.Kafka, outboxRepository,
 		producer.WithProducerPartitioner(sarama.NewHashPartitioner),
@@ -97,13 +126,33 @@ func NewServer(config config.Config) *Server {
 	}
 	go prod.RunEventsHandle()
 
+	paymentTimeoutCtx, stopPaymentTimeout := context.WithCancel(ctx)
+	go watchPaymentTimeout(paymentTimeoutCtx, lomsService,
+		time.Duration(config.PaymentCheckInterval)*time.Second,
+		time.Duration(config.PaymentTimeout)*time.Second,
+	)
+	returnRestockCtx, stopReturnRestock := context.WithCancel(ctx)
+	go watchReturnRestock(returnRestockCtx, lomsService, restockInterval, restockDelay)
+
+	checker := health.NewChecker(healthCheckTimeout)
+	for i, pool := range shardManager.GetShards() {
+		checker.Add(fmt.Sprintf("postgres-shard-%d", i), pool.Ping)
+	}
+	checker.Add("postgres-replica", dbReplicaPool.Ping)
+	checker.Add("kafka", health.KafkaCheck(config.Kafka.Brokers))
+
 	return &Server{
-		service:  lomsService,
-		producer: prod,
+		service:            lomsService,
+		producer:           prod,
+		checker:            checker,
+		stopPaymentTimeout: stopPaymentTimeout,
+		stopReturnRestock:  stopReturnRestock,
 	}
 }
 
 func (s *Server) Shutdown() {
+	s.stopPaymentTimeout()
+	s.stopReturnRestock()
 	s.producer.Close()
 }
 
@@ -112,8 +161,9 @@ func (s *Server) OrderCreate(ctx context.Context, req *loms.OrderCreateRequest)
 	defer tracing.EndWithCheckError(span, &err)
 
 	order := model.Order{
-		User:  model.UserID(req.User),
-		Items: make([]model.OrderItem, 0, len(req.Items)),
+		User:      model.UserID(req.User),
+		Items:     make([]model.OrderItem, 0, len(req.Items)),
+		RequestID: req.RequestId,
 	}
 	for _, item := range req.Items {
 		order.Items = append(order.Items, model.OrderItem{
@@ -121,13 +171,26 @@ func (s *Server) OrderCreate(ctx context.Context, req *loms.OrderCreateRequest)
 			Count: uint16(item.Count),
 		})
 	}
-	orderId, err := s.service.OrderCreate(ctx, order)
+	if req.Delivery != nil {
+		order.Delivery = &model.Location{
+			Latitude:  req.Delivery.Latitude,
+			Longitude: req.Delivery.Longitude,
+		}
+	}
+	order, err = s.service.OrderCreate(ctx, order)
+	if errors.Is(err, model.ErrNotEnoughStock) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, model.ErrEmptyOrder) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
 	if err != nil {
 		logger.Errorw(ctx, "lomsService.OrderCreate", "err", err)
 		return nil, fmt.Errorf("lomsService.OrderCreate: %w", err)
 	}
 	return &loms.OrderCreateResponse{
-		OrderId: int64(orderId),
+		OrderId: int64(order.ID),
+		Status:  string(order.Status),
 	}, nil
 }
 
@@ -140,19 +203,27 @@ func (s *Server) OrderInfo(ctx context.Context, req *loms.OrderInfoRequest) (res
 		logger.Errorw(ctx, "lomsService.OrderInfo", "err", err)
 		return nil, fmt.Errorf("lomsService.OrderInfo: %w", err)
 	}
-	res = &loms.OrderInfoResponse{
+	return orderToProto(order), nil
+}
+
+func orderToProto(order model.Order) *loms.OrderInfoResponse {
+	res := &loms.OrderInfoResponse{
 		Id:     int64(order.ID),
 		Status: string(order.Status),
 		User:   int64(order.User),
 		Items:  make([]*loms.OrderItem, 0, len(order.Items)),
 	}
+	if !order.CreatedAt.IsZero() {
+		res.CreatedAt = timestamppb.New(order.CreatedAt)
+	}
 	for _, item := range order.Items {
 		res.Items = append(res.Items, &loms.OrderItem{
-			Sku:   uint32(item.Sku),
-			Count: uint32(item.Count),
+			Sku:         uint32(item.Sku),
+			Count:       uint32(item.Count),
+			WarehouseId: int64(item.Warehouse),
 		})
 	}
-	return res, nil
+	return res
 }
 
 func (s *Server) OrderPay(ctx context.Context, req *loms.OrderPayRequest) (res *loms.OrderPayResponse, err error) {
@@ -160,6 +231,9 @@ func (s *Server) OrderPay(ctx context.Context, req *loms.OrderPayRequest) (res *
 	defer tracing.EndWithCheckError(span, &err)
 
 	err = s.service.OrderPay(ctx, model.OrderID(req.OrderId))
+	if err := orderStatusError(err); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		logger.Errorw(ctx, "lomsService.OrderPay", "err", err)
 		return nil, fmt.Errorf("lomsService.OrderPay: %w", err)
@@ -172,6 +246,9 @@ func (s *Server) OrderCancel(ctx context.Context, req *loms.OrderCancelRequest)
 	defer tracing.EndWithCheckError(span, &err)
 
 	err = s.service.OrderCancel(ctx, model.OrderID(req.OrderId))
+	if err := orderStatusError(err); err != nil {
+		return nil, err
+	}
 	if err != nil {
 		logger.Errorw(ctx, "lomsService.OrderCancel", "err", err)
 		return nil, fmt.Errorf("lomsService.OrderCancel: %w", err)
@@ -179,10 +256,128 @@ func (s *Server) OrderCancel(ctx context.Context, req *loms.OrderCancelRequest)
 	return nil, nil
 }
 
+func (s *Server) OrderUpdateItems(ctx context.Context, req *loms.OrderUpdateItemsRequest) (res *loms.OrderUpdateItemsResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.OrderUpdateItems")
+	defer tracing.EndWithCheckError(span, &err)
+
+	items := make([]model.OrderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, model.OrderItem{
+			Sku:   model.ProductSku(item.Sku),
+			Count: uint16(item.Count),
+		})
+	}
+	order, err := s.service.OrderUpdateItems(ctx, model.OrderID(req.OrderId), items)
+	if errors.Is(err, model.ErrOrderNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, model.ErrOrderNotEditable) || errors.Is(err, model.ErrNotEnoughStock) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.OrderUpdateItems", "err", err)
+		return nil, fmt.Errorf("lomsService.OrderUpdateItems: %w", err)
+	}
+
+	res = &loms.OrderUpdateItemsResponse{
+		Items: make([]*loms.OrderItem, 0, len(order.Items)),
+	}
+	for _, item := range order.Items {
+		res.Items = append(res.Items, &loms.OrderItem{
+			Sku:         uint32(item.Sku),
+			Count:       uint32(item.Count),
+			WarehouseId: int64(item.Warehouse),
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) OrderReturn(ctx context.Context, req *loms.OrderReturnRequest) (res *loms.OrderReturnResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.OrderReturn")
+	defer tracing.EndWithCheckError(span, &err)
+
+	items := make([]model.OrderItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		items = append(items, model.OrderItem{
+			Sku:   model.ProductSku(item.Sku),
+			Count: uint16(item.Count),
+		})
+	}
+	orderReturn, err := s.service.OrderReturn(ctx, model.OrderID(req.OrderId), items)
+	if err := orderStatusError(err); err != nil {
+		return nil, err
+	}
+	if errors.Is(err, model.ErrOrderNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if errors.Is(err, model.ErrInvalidReturn) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.OrderReturn", "err", err)
+		return nil, fmt.Errorf("lomsService.OrderReturn: %w", err)
+	}
+
+	res = &loms.OrderReturnResponse{
+		ReturnId: int64(orderReturn.ID),
+		Status:   string(orderReturn.Status),
+		Items:    make([]*loms.OrderItem, 0, len(orderReturn.Items)),
+	}
+	for _, item := range orderReturn.Items {
+		res.Items = append(res.Items, &loms.OrderItem{
+			Sku:         uint32(item.Sku),
+			Count:       uint32(item.Count),
+			WarehouseId: int64(item.Warehouse),
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) OrderHistory(ctx context.Context, req *loms.OrderHistoryRequest) (res *loms.OrderHistoryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.OrderHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	history, err := s.service.OrderHistory(ctx, model.OrderID(req.OrderId))
+	if errors.Is(err, model.ErrOrderNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.OrderHistory", "err", err)
+		return nil, fmt.Errorf("lomsService.OrderHistory: %w", err)
+	}
+
+	res = &loms.OrderHistoryResponse{
+		Changes: make([]*loms.OrderStatusChange, 0, len(history)),
+	}
+	for _, change := range history {
+		res.Changes = append(res.Changes, &loms.OrderStatusChange{
+			FromStatus: string(change.From),
+			ToStatus:   string(change.To),
+			Reason:     change.Reason,
+			CreatedAt:  timestamppb.New(change.CreatedAt),
+		})
+	}
+	return res, nil
+}
+
+// orderStatusError переводит ошибки смены статуса заказа в коды grpc, для остальных возвращает nil.
+func orderStatusError(err error) error {
+	switch {
+	case errors.Is(err, model.ErrInvalidStatusTransition):
+		return status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, model.ErrOrderStatusConflict):
+		return status.Error(codes.Aborted, err.Error())
+	}
+	return nil
+}
+
 func (s *Server) StocksInfo(ctx context.Context, req *loms.StocksInfoRequest) (res *loms.StocksInfoResponse, err error) {
 	ctx, span := tracing.Start(ctx, "Server.StocksInfo")
 	defer tracing.EndWithCheckError(span, &err)
 
+	if req.ByWarehouse {
+		return s.stocksInfoByWarehouse(ctx, model.ProductSku(req.Sku))
+	}
 	count, err := s.service.StocksInfo(ctx, model.ProductSku(req.Sku))
 	if err != nil {
 		logger.Errorw(ctx, "lomsService.StocksInfo", "err", err)
@@ -193,6 +388,116 @@ func (s *Server) StocksInfo(ctx context.Context, req *loms.StocksInfoRequest) (r
 	}, nil
 }
 
+func (s *Server) stocksInfoByWarehouse(ctx context.Context, sku model.ProductSku) (*loms.StocksInfoResponse, error) {
+	stocks, err := s.service.StocksInfoByWarehouse(ctx, sku)
+	if errors.Is(err, model.ErrStockNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.StocksInfoByWarehouse", "err", err)
+		return nil, fmt.Errorf("lomsService.StocksInfoByWarehouse: %w", err)
+	}
+	res := &loms.StocksInfoResponse{
+		Warehouses: make([]*loms.WarehouseStock, 0, len(stocks)),
+	}
+	for _, stock := range stocks {
+		res.Count += stock.Count
+		res.Warehouses = append(res.Warehouses, &loms.WarehouseStock{
+			WarehouseId: int64(stock.Warehouse),
+			Count:       stock.Count,
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) StocksInfoBatch(ctx context.Context, req *loms.StocksInfoBatchRequest) (res *loms.StocksInfoBatchResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.StocksInfoBatch")
+	defer tracing.EndWithCheckError(span, &err)
+
+	skus := make([]model.ProductSku, 0, len(req.Skus))
+	for _, sku := range req.Skus {
+		skus = append(skus, model.ProductSku(sku))
+	}
+	stocks, err := s.service.StocksInfoBatch(ctx, skus)
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.StocksInfoBatch", "err", err)
+		return nil, fmt.Errorf("lomsService.StocksInfoBatch: %w", err)
+	}
+
+	// ответ в порядке запроса, повторяющиеся sku отдаем один раз
+	res = &loms.StocksInfoBatchResponse{
+		Stocks: make([]*loms.StockInfo, 0, len(stocks)),
+	}
+	seen := make(map[model.ProductSku]struct{}, len(skus))
+	for _, sku := range skus {
+		if _, ok := seen[sku]; ok {
+			continue
+		}
+		seen[sku] = struct{}{}
+		count, ok := stocks[sku]
+		if !ok {
+			res.UnknownSkus = append(res.UnknownSkus, uint32(sku))
+			continue
+		}
+		res.Stocks = append(res.Stocks, &loms.StockInfo{
+			Sku:   uint32(sku),
+			Count: count,
+		})
+	}
+	return res, nil
+}
+
+func (s *Server) HoldCreate(ctx context.Context, req *loms.HoldCreateRequest) (res *loms.HoldCreateResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.HoldCreate")
+	defer tracing.EndWithCheckError(span, &err)
+
+	hold, err := s.service.HoldCreate(ctx,
+		model.UserID(req.User),
+		model.ProductSku(req.Sku),
+		uint16(req.Count),
+		time.Duration(req.TtlSeconds)*time.Second,
+	)
+	if errors.Is(err, model.ErrNotEnoughStock) {
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.HoldCreate", "err", err)
+		return nil, fmt.Errorf("lomsService.HoldCreate: %w", err)
+	}
+	return &loms.HoldCreateResponse{
+		HoldId:    int64(hold.ID),
+		ExpiresAt: timestamppb.New(hold.ExpiresAt),
+	}, nil
+}
+
+func (s *Server) HoldExtend(ctx context.Context, req *loms.HoldExtendRequest) (res *loms.HoldExtendResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.HoldExtend")
+	defer tracing.EndWithCheckError(span, &err)
+
+	expiresAt, err := s.service.HoldExtend(ctx, model.UserID(req.User), model.HoldID(req.HoldId), time.Duration(req.TtlSeconds)*time.Second)
+	if errors.Is(err, model.ErrHoldNotFound) {
+		return nil, status.Error(codes.NotFound, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.HoldExtend", "err", err)
+		return nil, fmt.Errorf("lomsService.HoldExtend: %w", err)
+	}
+	return &loms.HoldExtendResponse{
+		ExpiresAt: timestamppb.New(expiresAt),
+	}, nil
+}
+
+func (s *Server) HoldRelease(ctx context.Context, req *loms.HoldReleaseRequest) (res *loms.HoldReleaseResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.HoldRelease")
+	defer tracing.EndWithCheckError(span, &err)
+
+	if err := s.service.HoldRelease(ctx, model.UserID(req.User), model.HoldID(req.HoldId)); err != nil {
+		logger.Errorw(ctx, "lomsService.HoldRelease", "err", err)
+		return nil, fmt.Errorf("lomsService.HoldRelease: %w", err)
+	}
+	return &loms.HoldReleaseResponse{}, nil
+}
+
 func (s *Server) GetAllOrders(ctx context.Context, req *loms.GetAllOrdersRequest) (res *loms.GetAllOrdersResponse, err error) {
 	ctx, span := tracing.Start(ctx, "Server.GetAllOrders")
 	defer tracing.EndWithCheckError(span, &err)
@@ -207,18 +512,177 @@ func (s *Server) GetAllOrders(ctx context.Context, req *loms.GetAllOrdersRequest
 		Orders: make([]*loms.OrderInfoResponse, 0, len(orders)),
 	}
 	for _, order := range orders {
-		res.Orders = append(res.Orders, &loms.OrderInfoResponse{
-			Id:     int64(order.ID),
-			Status: string(order.Status),
-			User:   int64(order.User),
-			Items:  make([]*loms.OrderItem, 0, len(order.Items)),
+		res.Orders = append(res.Orders, orderToProto(order))
+	}
+	return res, nil
+}
+
+func (s *Server) ListOrders(ctx context.Context, req *loms.ListOrdersRequest) (res *loms.ListOrdersResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.ListOrders")
+	defer tracing.EndWithCheckError(span, &err)
+
+	filter := model.OrderFilter{
+		User:     model.UserID(req.User),
+		Statuses: make([]model.OrderStatus, 0, len(req.Statuses)),
+	}
+	for _, status := range req.Statuses {
+		filter.Statuses = append(filter.Statuses, model.OrderStatus(status))
+	}
+	if req.CreatedFrom != nil {
+		filter.CreatedFrom = req.CreatedFrom.AsTime()
+	}
+	if req.CreatedTo != nil {
+		filter.CreatedTo = req.CreatedTo.AsTime()
+	}
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultListOrdersPageSize
+	}
+
+	orders, nextPageToken, err := s.service.ListOrders(ctx, filter, req.PageToken, pageSize)
+	if errors.Is(err, model.ErrInvalidPageToken) {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.ListOrders", "err", err)
+		return nil, fmt.Errorf("lomsService.ListOrders: %w", err)
+	}
+
+	res = &loms.ListOrdersResponse{
+		Orders:        make([]*loms.OrderInfoResponse, 0, len(orders)),
+		NextPageToken: nextPageToken,
+	}
+	for _, order := range orders {
+		res.Orders = append(res.Orders, orderToProto(order))
+	}
+	return res, nil
+}
+
+func (s *Server) StreamOrders(req *loms.StreamOrdersRequest, stream loms.Loms_StreamOrdersServer) (err error) {
+	ctx, span := tracing.Start(stream.Context(), "Server.StreamOrders")
+	defer tracing.EndWithCheckError(span, &err)
+
+	// интерсепторы сервера только unary, поэтому запрос проверяется здесь
+	if err := req.Validate(); err != nil {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
+	err = s.service.StreamOrders(ctx, model.OrderID(req.AfterId), func(order model.Order) error {
+		return stream.Send(&loms.StreamOrdersResponse{
+			Order: orderToProto(order),
 		})
-		for _, item := range order.Items {
-			res.Orders[len(res.Orders)-1].Items = append(res.Orders[len(res.Orders)-1].Items, &loms.OrderItem{
-				Sku:   uint32(item.Sku),
-				Count: uint32(item.Count),
-			})
-		}
+	})
+	// клиент отключился - запросы к шардам уже остановлены
+	if errors.Is(err, context.Canceled) {
+		return status.Error(codes.Canceled, err.Error())
+	}
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.StreamOrders", "err", err)
+		return fmt.Errorf("lomsService.StreamOrders: %w", err)
+	}
+	return nil
+}
+
+func (s *Server) StockAdd(ctx context.Context, req *loms.StockAddRequest) (res *loms.StockChangeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.StockAdd")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockChange(ctx, model.StockChange{
+		Sku:       model.ProductSku(req.Sku),
+		Operation: model.StockOperationAdd,
+		Count:     int64(req.Count),
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		OrderID:   model.OrderID(req.OrderId),
+		Warehouse: model.WarehouseID(req.WarehouseId),
+	})
+}
+
+func (s *Server) StockAdjust(ctx context.Context, req *loms.StockAdjustRequest) (res *loms.StockChangeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.StockAdjust")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockChange(ctx, model.StockChange{
+		Sku:       model.ProductSku(req.Sku),
+		Operation: model.StockOperationAdjust,
+		Count:     req.Delta,
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		OrderID:   model.OrderID(req.OrderId),
+		Warehouse: model.WarehouseID(req.WarehouseId),
+	})
+}
+
+func (s *Server) StockSet(ctx context.Context, req *loms.StockSetRequest) (res *loms.StockChangeResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.StockSet")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockChange(ctx, model.StockChange{
+		Sku:       model.ProductSku(req.Sku),
+		Operation: model.StockOperationSet,
+		Count:     int64(req.Count),
+		Reason:    req.Reason,
+		Actor:     req.Actor,
+		OrderID:   model.OrderID(req.OrderId),
+		Warehouse: model.WarehouseID(req.WarehouseId),
+	})
+}
+
+func (s *Server) stockChange(ctx context.Context, change model.StockChange) (*loms.StockChangeResponse, error) {
+	movement, err := s.service.StockChange(ctx, change)
+	switch {
+	case errors.Is(err, model.ErrStockNotFound), errors.Is(err, model.ErrWarehouseNotFound):
+		return nil, status.Error(codes.NotFound, err.Error())
+	case errors.Is(err, model.ErrStockBelowReserved):
+		return nil, status.Error(codes.FailedPrecondition, err.Error())
+	case errors.Is(err, model.ErrInvalidStockChange):
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	case err != nil:
+		logger.Errorw(ctx, "lomsService.StockChange", "err", err)
+		return nil, fmt.Errorf("lomsService.StockChange: %w", err)
+	}
+	return &loms.StockChangeResponse{
+		Movement: stockMovementToProto(movement),
+	}, nil
+}
+
+func (s *Server) StockHistory(ctx context.Context, req *loms.StockHistoryRequest) (res *loms.StockHistoryResponse, err error) {
+	ctx, span := tracing.Start(ctx, "Server.StockHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	pageSize := int(req.PageSize)
+	if pageSize == 0 {
+		pageSize = defaultStockHistoryPageSize
+	}
+	movements, err := s.service.StockHistory(ctx, model.ProductSku(req.Sku), model.StockMovementID(req.BeforeId), pageSize)
+	if err != nil {
+		logger.Errorw(ctx, "lomsService.StockHistory", "err", err)
+		return nil, fmt.Errorf("lomsService.StockHistory: %w", err)
+	}
+
+	res = &loms.StockHistoryResponse{
+		Movements: make([]*loms.StockMovement, 0, len(movements)),
+	}
+	for _, movement := range movements {
+		res.Movements = append(res.Movements, stockMovementToProto(movement))
+	}
+	// неполная страница - дальше движений нет
+	if len(movements) == pageSize {
+		res.NextBeforeId = int64(movements[len(movements)-1].ID)
 	}
 	return res, nil
 }
+
+func stockMovementToProto(movement model.StockMovement) *loms.StockMovement {
+	return &loms.StockMovement{
+		Id:          int64(movement.ID),
+		Sku:         uint32(movement.Sku),
+		Operation:   string(movement.Operation),
+		Delta:       movement.Delta,
+		TotalCount:  movement.TotalCount,
+		Reason:      movement.Reason,
+		Actor:       movement.Actor,
+		OrderId:     int64(movement.OrderID),
+		CreatedAt:   timestamppb.New(movement.CreatedAt),
+		WarehouseId: int64(movement.Warehouse),
+	}
+}
@@ -0,0 +1,144 @@
+package allocation
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"route256/loms/internal/pkg/model"
+	"sort"
+)
+
+var ErrUnknownStrategy = errors.New("unknown allocation strategy")
+
+// Strategy - как выбирать склады для позиций заказа.
+type Strategy string
+
+const (
+	// StrategySingleFirst собирает заказ с одного склада, если это возможно, иначе делит его как split.
+	StrategySingleFirst Strategy = "single_first"
+	// StrategyNearest берет каждую позицию с ближайших к адресу доставки складов.
+	StrategyNearest Strategy = "nearest"
+	// StrategySplit берет позиции со складов по порядку их id.
+	StrategySplit Strategy = "split"
+)
+
+func ParseStrategy(raw string) (Strategy, error) {
+	switch strategy := Strategy(raw); strategy {
+	case StrategySingleFirst, StrategyNearest, StrategySplit:
+		return strategy, nil
+	}
+	return "", fmt.Errorf("%w: %q", ErrUnknownStrategy, raw)
+}
+
+// Stock - свободный остаток sku по складам.
+type Stock map[model.ProductSku]map[model.WarehouseID]uint64
+
+// Allocate распределяет позиции по складам. Позиция может разойтись на несколько складов,
+// тогда в результате будет несколько позиций с одним sku. Stock не меняется.
+func Allocate(strategy Strategy, items []model.OrderItem, stock Stock, warehouses []model.Warehouse, delivery *model.Location) ([]model.OrderItem, error) {
+	var ordered []model.WarehouseID
+	switch strategy {
+	case StrategySplit:
+		ordered = orderWarehouses(warehouses, nil)
+	case StrategyNearest:
+		ordered = orderWarehouses(warehouses, delivery)
+	case StrategySingleFirst:
+		ordered = orderWarehouses(warehouses, delivery)
+		if warehouse, ok := singleWarehouse(items, stock, ordered); ok {
+			allocated := make([]model.OrderItem, 0, len(items))
+			for _, item := range items {
+				item.Warehouse = warehouse
+				allocated = append(allocated, item)
+			}
+			return allocated, nil
+		}
+	default:
+		return nil, fmt.Errorf("%w: %q", ErrUnknownStrategy, strategy)
+	}
+	return greedy(items, stock, ordered)
+}
+
+// singleWarehouse возвращает первый склад, на котором есть весь заказ.
+func singleWarehouse(items []model.OrderItem, stock Stock, ordered []model.WarehouseID) (model.WarehouseID, bool) {
+	need := make(map[model.ProductSku]uint64, len(items))
+	for _, item := range items {
+		need[item.Sku] += uint64(item.Count)
+	}
+	for _, warehouse := range ordered {
+		enough := true
+		for sku, count := range need {
+			if stock[sku][warehouse] < count {
+				enough = false
+				break
+			}
+		}
+		if enough {
+			return warehouse, true
+		}
+	}
+	return 0, false
+}
+
+func greedy(items []model.OrderItem, stock Stock, ordered []model.WarehouseID) ([]model.OrderItem, error) {
+	left := make(map[model.ProductSku]map[model.WarehouseID]uint64, len(stock))
+	allocated := make([]model.OrderItem, 0, len(items))
+	for _, item := range items {
+		available, ok := left[item.Sku]
+		if !ok {
+			available = make(map[model.WarehouseID]uint64, len(stock[item.Sku]))
+			for warehouse, count := range stock[item.Sku] {
+				available[warehouse] = count
+			}
+			left[item.Sku] = available
+		}
+
+		need := uint64(item.Count)
+		for _, warehouse := range ordered {
+			if need == 0 {
+				break
+			}
+			take := min(need, available[warehouse])
+			if take == 0 {
+				continue
+			}
+			available[warehouse] -= take
+			need -= take
+			allocated = append(allocated, model.OrderItem{Sku: item.Sku, Count: uint16(take), Warehouse: warehouse})
+		}
+		if need > 0 {
+			return nil, fmt.Errorf("%w: sku %d", model.ErrNotEnoughStock, item.Sku)
+		}
+	}
+	return allocated, nil
+}
+
+// orderWarehouses сортирует склады по расстоянию до delivery, без адреса - по id.
+func orderWarehouses(warehouses []model.Warehouse, delivery *model.Location) []model.WarehouseID {
+	sorted := make([]model.Warehouse, len(warehouses))
+	copy(sorted, warehouses)
+	sort.SliceStable(sorted, func(i, j int) bool {
+		if delivery != nil {
+			di, dj := distance(*delivery, sorted[i].Location), distance(*delivery, sorted[j].Location)
+			if di != dj {
+				return di < dj
+			}
+		}
+		return sorted[i].ID < sorted[j].ID
+	})
+	ordered := make([]model.WarehouseID, 0, len(sorted))
+	for _, warehouse := range sorted {
+		ordered = append(ordered, warehouse.ID)
+	}
+	return ordered
+}
+
+const earthRadiusKm = 6371
+
+// distance - расстояние по поверхности Земли в км (формула гаверсинусов).
+func distance(a, b model.Location) float64 {
+	lat1, lat2 := a.Latitude*math.Pi/180, b.Latitude*math.Pi/180
+	dLat := lat2 - lat1
+	dLon := (b.Longitude - a.Longitude) * math.Pi / 180
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) + math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
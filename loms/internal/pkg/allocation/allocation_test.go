@@ -0,0 +1,121 @@
+package allocation
+
+import (
+	"route256/loms/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var (
+	moscow = model.Warehouse{ID: 1, Name: "moscow", Location: model.Location{Latitude: 55.7558, Longitude: 37.6173}}
+	spb    = model.Warehouse{ID: 2, Name: "saint-petersburg", Location: model.Location{Latitude: 59.9343, Longitude: 30.3351}}
+	kazan  = model.Warehouse{ID: 3, Name: "kazan", Location: model.Location{Latitude: 55.7963, Longitude: 49.1088}}
+
+	warehouses = []model.Warehouse{kazan, moscow, spb}
+	// адрес доставки в Новгороде, ближе всего Петербург
+	novgorod = &model.Location{Latitude: 58.5215, Longitude: 31.2755}
+)
+
+func TestAllocate(t *testing.T) {
+	t.Parallel()
+
+	stock := Stock{
+		1: {1: 2, 2: 5, 3: 1},
+		2: {1: 3, 3: 3},
+	}
+
+	tests := []struct {
+		name     string
+		strategy Strategy
+		items    []model.OrderItem
+		delivery *model.Location
+		want     []model.OrderItem
+		wantErr  error
+	}{
+		{
+			name:     "split takes warehouses by id",
+			strategy: StrategySplit,
+			items:    []model.OrderItem{{Sku: 1, Count: 4}},
+			delivery: novgorod,
+			want:     []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}, {Sku: 1, Count: 2, Warehouse: 2}},
+		},
+		{
+			name:     "nearest takes closest warehouse first",
+			strategy: StrategyNearest,
+			items:    []model.OrderItem{{Sku: 1, Count: 6}},
+			delivery: novgorod,
+			want:     []model.OrderItem{{Sku: 1, Count: 5, Warehouse: 2}, {Sku: 1, Count: 1, Warehouse: 1}},
+		},
+		{
+			name:     "nearest without delivery falls back to id order",
+			strategy: StrategyNearest,
+			items:    []model.OrderItem{{Sku: 2, Count: 4}},
+			want:     []model.OrderItem{{Sku: 2, Count: 3, Warehouse: 1}, {Sku: 2, Count: 1, Warehouse: 3}},
+		},
+		{
+			name:     "single warehouse has whole order",
+			strategy: StrategySingleFirst,
+			items:    []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 2, Count: 3}},
+			delivery: &kazan.Location,
+			want:     []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 3}, {Sku: 2, Count: 3, Warehouse: 3}},
+		},
+		{
+			name:     "single warehouse counts duplicated skus together",
+			strategy: StrategySingleFirst,
+			items:    []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 1, Count: 1}},
+			delivery: &kazan.Location,
+			want:     []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 1}},
+		},
+		{
+			name:     "single first falls back to split",
+			strategy: StrategySingleFirst,
+			items:    []model.OrderItem{{Sku: 1, Count: 3}, {Sku: 2, Count: 1}},
+			want:     []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 2}, {Sku: 2, Count: 1, Warehouse: 1}},
+		},
+		{
+			name:     "not enough stock in all warehouses",
+			strategy: StrategySplit,
+			items:    []model.OrderItem{{Sku: 2, Count: 4}, {Sku: 2, Count: 3}},
+			wantErr:  model.ErrNotEnoughStock,
+		},
+		{
+			name:     "unknown sku",
+			strategy: StrategySingleFirst,
+			items:    []model.OrderItem{{Sku: 3, Count: 1}},
+			wantErr:  model.ErrNotEnoughStock,
+		},
+		{
+			name:     "unknown strategy",
+			strategy: "random",
+			items:    []model.OrderItem{{Sku: 1, Count: 1}},
+			wantErr:  ErrUnknownStrategy,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := Allocate(tt.strategy, tt.items, stock, warehouses, tt.delivery)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+	// остаток не меняется
+	assert.Equal(t, Stock{1: {1: 2, 2: 5, 3: 1}, 2: {1: 3, 3: 3}}, stock)
+}
+
+func TestParseStrategy(t *testing.T) {
+	t.Parallel()
+
+	strategy, err := ParseStrategy("nearest")
+	require.NoError(t, err)
+	assert.Equal(t, StrategyNearest, strategy)
+
+	_, err = ParseStrategy("closest")
+	assert.ErrorIs(t, err, ErrUnknownStrategy)
+}
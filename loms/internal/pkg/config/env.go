@@ -0,0 +1,67 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strconv"
+	"strings"
+)
+
+// envReader переопределяет значения конфига переменными окружения и копит ошибки разбора,
+// чтобы при старте показать их все сразу.
+type envReader struct {
+	errs []error
+}
+
+func (e *envReader) lookup(name string) (string, bool) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func (e *envReader) String(name string, dst *string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = value
+	}
+}
+
+// StringSlice разбирает значения, перечисленные через запятую.
+func (e *envReader) StringSlice(name string, dst *[]string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = strings.Split(value, ",")
+	}
+}
+
+func (e *envReader) Int64(name string, dst *int64) {
+	value, ok := e.lookup(name)
+	if !ok {
+		return
+	}
+	v, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		e.errs = append(e.errs, fmt.Errorf("config: invalid %s=%q: %w", name, value, err))
+		return
+	}
+	*dst = v
+}
+
+func (e *envReader) Err() error {
+	return errors.Join(e.errs...)
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) Check(ok bool, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("config: "+format, args...))
+	}
+}
+
+func (v *validator) Err() error {
+	return errors.Join(v.errs...)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"fmt"
+	"io"
+	"net/url"
+	"reflect"
+
+	"gopkg.in/yaml.v3"
+)
+
+const redacted = "***"
+
+// Redacted возвращает копию конфига со скрытыми секретами:
+// у полей с тегом secret:"url" скрывается только пароль.
+func (c Config) Redacted() Config {
+	v := reflect.ValueOf(&c).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		field := v.Field(i)
+		if field.Kind() != reflect.String || field.String() == "" {
+			continue
+		}
+		switch t.Field(i).Tag.Get("secret") {
+		case "true":
+			field.SetString(redacted)
+		case "url":
+			field.SetString(redactUrl(field.String()))
+		}
+	}
+	return c
+}
+
+func redactUrl(raw string) string {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return redacted
+	}
+	return u.Redacted()
+}
+
+// Print выводит итоговый конфиг в yaml без секретов.
+func (c Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	if err := encoder.Encode(c.Redacted()); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,103 @@
+package health
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	StatusOk          = "ok"
+	StatusUnavailable = "unavailable"
+)
+
+type CheckFn func(ctx context.Context) error
+
+type check struct {
+	name string
+	fn   CheckFn
+}
+
+// Checker проверяет доступность зависимостей сервиса.
+type Checker struct {
+	timeout time.Duration
+	checks  []check
+}
+
+func NewChecker(timeout time.Duration) *Checker {
+	return &Checker{
+		timeout: timeout,
+	}
+}
+
+func (c *Checker) Add(name string, fn CheckFn) {
+	c.checks = append(c.checks, check{name: name, fn: fn})
+}
+
+// Check параллельно запускает все проверки и возвращает ошибку по каждой зависимости.
+func (c *Checker) Check(ctx context.Context) map[string]error {
+	ctx, cancel := context.WithTimeout(ctx, c.timeout)
+	defer cancel()
+
+	var (
+		mx     sync.Mutex
+		wg     sync.WaitGroup
+		result = make(map[string]error, len(c.checks))
+	)
+	for _, check := range c.checks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := check.fn(ctx)
+			mx.Lock()
+			defer mx.Unlock()
+			result[check.name] = err
+		}()
+	}
+	wg.Wait()
+	return result
+}
+
+type Response struct {
+	Status string            `json:"status"`
+	Checks map[string]string `json:"checks,omitempty"`
+}
+
+// Liveness - процесс запущен и обрабатывает запросы.
+func (c *Checker) Liveness(w http.ResponseWriter, r *http.Request) {
+	writeResponse(w, http.StatusOK, Response{Status: StatusOk})
+}
+
+// Readiness - все зависимости доступны.
+func (c *Checker) Readiness(w http.ResponseWriter, r *http.Request) {
+	response := Response{
+		Status: StatusOk,
+		Checks: make(map[string]string, len(c.checks)),
+	}
+	for name, err := range c.Check(r.Context()) {
+		if err != nil {
+			response.Status = StatusUnavailable
+			response.Checks[name] = err.Error()
+			continue
+		}
+		response.Checks[name] = StatusOk
+	}
+	status := http.StatusOK
+	if response.Status != StatusOk {
+		status = http.StatusServiceUnavailable
+	}
+	writeResponse(w, status, response)
+}
+
+func writeResponse(w http.ResponseWriter, status int, response Response) {
+	data, err := json.Marshal(response)
+	if err != nil {
+		w.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	w.Header().Add("Content-Type", "application/json")
+	w.WriteHeader(status)
+	w.Write(data)
+}
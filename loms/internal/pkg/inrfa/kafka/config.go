@@ -1,7 +1,8 @@
 package kafka
 
 type Config struct {
-	Brokers              []string
-	OrderEventsTopic     string
-	HandleEventsInterval int64
+	Brokers              []string `yaml:"brokers"`
+	OrderEventsTopic     string   `yaml:"order_events_topic"`
+	StockEventsTopic     string   `yaml:"stock_events_topic"`
+	HandleEventsInterval int64    `yaml:"handle_events_interval"`
 }
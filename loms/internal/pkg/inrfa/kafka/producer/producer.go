@@ -59,9 +59,13 @@ func (p *Producer) RunEventsHandle() {
 					continue
 				}
 
+				key := headers.Key
+				if key == "" {
+					key = strconv.FormatInt(int64(event.OrderID), 10)
+				}
 				msg := &sarama.ProducerMessage{
 					Topic: outboxItem.Topic,
-					Key:   sarama.StringEncoder(strconv.FormatInt(int64(event.OrderID), 10)),
+					Key:   sarama.StringEncoder(key),
 					Value: sarama.ByteEncoder(outboxItem.Event),
 					Headers: []sarama.RecordHeader{
 						{
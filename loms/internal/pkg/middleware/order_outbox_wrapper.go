@@ -13,8 +13,18 @@ import (
 type orderRepository interface {
 	Create(context.Context, model.Order, ...func(context.Context, model.OrderID, model.OrderStatus) error) (model.OrderID, error)
 	GetById(context.Context, model.OrderID) (model.Order, error)
-	SetStatus(context.Context, model.OrderID, model.OrderStatus, ...func(context.Context) error) error
+	GetByRequestID(context.Context, model.UserID, string) (model.Order, error)
+	SetStatus(context.Context, model.OrderID, model.OrderStatus, model.OrderStatus, ...func(context.Context) error) ([]model.OrderItem, error)
+	SetItems(context.Context, model.OrderID, []model.OrderItem) error
+	UpdateItems(context.Context, model.OrderID, []model.OrderItem, func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), ...func(context.Context, model.Order) error) (model.Order, error)
 	GetAll(context.Context) ([]model.Order, error)
+	GetStatusHistory(context.Context, model.OrderID) ([]model.OrderStatusChange, error)
+	AddReturn(context.Context, model.OrderID, []model.OrderItem, ...func(context.Context, model.OrderReturn) error) (model.OrderReturn, error)
+	ListByUser(context.Context, model.OrderFilter, *model.OrderCursor, int) ([]model.Order, error)
+	StreamAll(context.Context, model.OrderID, int, func(model.Order) error) error
+	HandleUnpaid(context.Context, time.Duration, int, func(context.Context, []model.OrderID) error) error
+	SetReturnRestocked(context.Context, model.OrderID, model.ReturnID) error
+	HandleNotRestocked(context.Context, time.Duration, int, func(context.Context, []model.OrderReturn) error) error
 }
 
 type outboxRepository interface {
@@ -63,7 +73,7 @@ func (r *OrderOutboxWrapper) Create(ctx context.Context, order model.Order) (_ m
 	return r.orderRepository.Create(ctx, order, inTx)
 }
 
-func (r *OrderOutboxWrapper) SetStatus(ctx context.Context, orderID model.OrderID, status model.OrderStatus) (err error) {
+func (r *OrderOutboxWrapper) SetStatus(ctx context.Context, orderID model.OrderID, from, to model.OrderStatus) (_ []model.OrderItem, err error) {
 	ctx, span := tracing.Start(ctx, "OrderOutboxWrapper.SetStatus")
 	defer tracing.EndWithCheckError(span, &err)
 	traceID := ""
@@ -71,8 +81,9 @@ func (r *OrderOutboxWrapper) SetStatus(ctx context.Context, orderID model.OrderI
 		traceID = span.SpanContext().TraceID().String()
 	}
 
+	reason := model.EventReasonFromContext(ctx)
 	inTx := func(ctx context.Context) error {
-		eventData, err := json.Marshal(model.Event{OrderID: orderID, Status: status, Time: time.Now()})
+		eventData, err := json.Marshal(model.Event{OrderID: orderID, Status: to, Time: time.Now(), Reason: reason})
 		if err != nil {
 			return fmt.Errorf("json.Marshal event: %w", err)
 		}
@@ -86,5 +97,75 @@ func (r *OrderOutboxWrapper) SetStatus(ctx context.Context, orderID model.OrderI
 		}
 		return nil
 	}
-	return r.orderRepository.SetStatus(ctx, orderID, status, inTx)
+	return r.orderRepository.SetStatus(ctx, orderID, from, to, inTx)
+}
+
+// AddReturn пишет событие о возврате в outbox в транзакции возврата. inTx вызывающего
+// выполняются после записи события.
+func (r *OrderOutboxWrapper) AddReturn(ctx context.Context, orderID model.OrderID, items []model.OrderItem, inTx ...func(context.Context, model.OrderReturn) error) (_ model.OrderReturn, err error) {
+	ctx, span := tracing.Start(ctx, "OrderOutboxWrapper.AddReturn")
+	defer tracing.EndWithCheckError(span, &err)
+	traceID := ""
+	if span != nil {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	reason := model.EventReasonFromContext(ctx)
+	writeEvent := func(ctx context.Context, orderReturn model.OrderReturn) error {
+		eventData, err := json.Marshal(model.Event{
+			OrderID:  orderReturn.OrderID,
+			Status:   orderReturn.Status,
+			Time:     time.Now(),
+			Reason:   reason,
+			ReturnID: orderReturn.ID,
+			Items:    orderReturn.Items,
+		})
+		if err != nil {
+			return fmt.Errorf("json.Marshal event: %w", err)
+		}
+		headersData, err := json.Marshal(model.Headers{TraceID: traceID})
+		if err != nil {
+			return fmt.Errorf("json.Marshal headers: %w", err)
+		}
+
+		if _, err := r.outboxRepository.Create(ctx, r.config.OrderEventsTopic, eventData, headersData); err != nil {
+			return fmt.Errorf("outboxRepository.Create: %w", err)
+		}
+		return nil
+	}
+	return r.orderRepository.AddReturn(ctx, orderID, items, append([]func(context.Context, model.OrderReturn) error{writeEvent}, inTx...)...)
+}
+
+// UpdateItems пишет событие updated с новыми позициями в outbox в транзакции изменения.
+// inTx вызывающего выполняются после записи события.
+func (r *OrderOutboxWrapper) UpdateItems(ctx context.Context, orderID model.OrderID, items []model.OrderItem, reserve func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), inTx ...func(context.Context, model.Order) error) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "OrderOutboxWrapper.UpdateItems")
+	defer tracing.EndWithCheckError(span, &err)
+	traceID := ""
+	if span != nil {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	writeEvent := func(ctx context.Context, order model.Order) error {
+		eventData, err := json.Marshal(model.Event{
+			OrderID: order.ID,
+			Status:  order.Status,
+			Time:    time.Now(),
+			Reason:  model.EventReasonUpdated,
+			Items:   order.Items,
+		})
+		if err != nil {
+			return fmt.Errorf("json.Marshal event: %w", err)
+		}
+		headersData, err := json.Marshal(model.Headers{TraceID: traceID})
+		if err != nil {
+			return fmt.Errorf("json.Marshal headers: %w", err)
+		}
+
+		if _, err := r.outboxRepository.Create(ctx, r.config.OrderEventsTopic, eventData, headersData); err != nil {
+			return fmt.Errorf("outboxRepository.Create: %w", err)
+		}
+		return nil
+	}
+	return r.orderRepository.UpdateItems(ctx, orderID, items, reserve, append([]func(context.Context, model.Order) error{writeEvent}, inTx...)...)
 }
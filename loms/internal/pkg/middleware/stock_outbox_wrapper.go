@@ -0,0 +1,97 @@
+package middleware
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"route256/loms/internal/pkg/allocation"
+	"route256/loms/internal/pkg/inrfa/kafka"
+	"route256/loms/internal/pkg/model"
+	"route256/loms/pkg/tracing"
+	"strconv"
+	"time"
+)
+
+type stockRepository interface {
+	Reserve(context.Context, model.UserID, []model.OrderItem, allocation.Strategy, *model.Location) ([]model.OrderItem, error)
+	ReserveRemove(context.Context, []model.OrderItem) error
+	ReserveCancel(context.Context, []model.OrderItem) error
+	ReserveAt(context.Context, []model.OrderItem) error
+	GetStocksBySku(context.Context, model.ProductSku) (uint64, error)
+	GetStocksByWarehouse(context.Context, model.ProductSku) ([]model.WarehouseStock, error)
+	GetStocksBySkus(context.Context, []model.ProductSku) (map[model.ProductSku]uint64, error)
+	HoldCreate(context.Context, model.UserID, model.ProductSku, uint16, time.Duration) (model.StockHold, error)
+	HoldExtend(context.Context, model.UserID, model.HoldID, time.Duration) (time.Time, error)
+	HoldRelease(context.Context, model.UserID, model.HoldID) error
+	ChangeStock(context.Context, model.StockChange, ...func(context.Context, model.StockMovement) error) (model.StockMovement, error)
+	ChangeStocks(context.Context, []model.StockChange, ...func(context.Context, model.StockMovement) error) ([]model.StockMovement, error)
+	GetMovements(context.Context, model.ProductSku, model.StockMovementID, int) ([]model.StockMovement, error)
+}
+
+// StockOutboxWrapper пишет событие об изменении остатка в outbox в транзакции изменения.
+type StockOutboxWrapper struct {
+	stockRepository
+	outboxRepository outboxRepository
+	config           kafka.Config
+}
+
+func NewStockOutboxWrapper(stockRepository stockRepository, outboxRepository outboxRepository, config kafka.Config) *StockOutboxWrapper {
+	return &StockOutboxWrapper{
+		stockRepository:  stockRepository,
+		outboxRepository: outboxRepository,
+		config:           config,
+	}
+}
+
+func (r *StockOutboxWrapper) ChangeStock(ctx context.Context, change model.StockChange) (_ model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "StockOutboxWrapper.ChangeStock")
+	defer tracing.EndWithCheckError(span, &err)
+	traceID := ""
+	if span != nil {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	return r.stockRepository.ChangeStock(ctx, change, r.writeEvent(traceID))
+}
+
+func (r *StockOutboxWrapper) ChangeStocks(ctx context.Context, changes []model.StockChange) (_ []model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "StockOutboxWrapper.ChangeStocks")
+	defer tracing.EndWithCheckError(span, &err)
+	traceID := ""
+	if span != nil {
+		traceID = span.SpanContext().TraceID().String()
+	}
+
+	return r.stockRepository.ChangeStocks(ctx, changes, r.writeEvent(traceID))
+}
+
+// writeEvent возвращает inTx, который пишет событие о движении остатка в outbox.
+func (r *StockOutboxWrapper) writeEvent(traceID string) func(context.Context, model.StockMovement) error {
+	return func(ctx context.Context, movement model.StockMovement) error {
+		eventData, err := json.Marshal(model.StockEvent{
+			MovementID: movement.ID,
+			Warehouse:  movement.Warehouse,
+			Sku:        movement.Sku,
+			Operation:  movement.Operation,
+			Delta:      movement.Delta,
+			TotalCount: movement.TotalCount,
+			Reason:     movement.Reason,
+			Actor:      movement.Actor,
+			OrderID:    movement.OrderID,
+			Time:       movement.CreatedAt,
+		})
+		if err != nil {
+			return fmt.Errorf("json.Marshal event: %w", err)
+		}
+		// события одного sku попадают в одну партицию
+		headersData, err := json.Marshal(model.Headers{TraceID: traceID, Key: strconv.FormatUint(uint64(movement.Sku), 10)})
+		if err != nil {
+			return fmt.Errorf("json.Marshal headers: %w", err)
+		}
+
+		if _, err := r.outboxRepository.Create(ctx, r.config.StockEventsTopic, eventData, headersData); err != nil {
+			return fmt.Errorf("outboxRepository.Create: %w", err)
+		}
+		return nil
+	}
+}
@@ -1,5 +1,27 @@
 package model
 
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrOrderNotFound = errors.New("order not found")
+	// ErrInvalidStatusTransition - переход не разрешен из текущего статуса заказа
+	ErrInvalidStatusTransition = errors.New("invalid order status transition")
+	// ErrOrderStatusConflict - статус заказа успели поменять между чтением и записью
+	ErrOrderStatusConflict = errors.New("order status changed concurrently")
+	ErrInvalidPageToken    = errors.New("invalid page token")
+	// ErrDuplicateOrderRequest - заказ с таким RequestID у пользователя уже создан
+	ErrDuplicateOrderRequest = errors.New("duplicate order request")
+	// ErrInvalidReturn - позиций возврата нет в заказе или они уже возвращены
+	ErrInvalidReturn = errors.New("invalid order return")
+	// ErrOrderNotEditable - позиции заказа можно менять только до оплаты
+	ErrOrderNotEditable = errors.New("order items can not be changed")
+	// ErrEmptyOrder - заказ без позиций, пустой запрос допустим только как повтор по RequestID
+	ErrEmptyOrder = errors.New("order has no items")
+)
+
 type OrderID int64
 
 type OrderStatus string
@@ -11,11 +33,36 @@ const (
 	OrderStatusFailed          OrderStatus = "failed"
 	OrderStatusPaid            OrderStatus = "payed"
 	OrderStatusCancelled       OrderStatus = "cancelled"
+	// OrderStatusPartiallyReturned - возвращена часть позиций, остальные можно вернуть позже
+	OrderStatusPartiallyReturned OrderStatus = "partially_returned"
+	OrderStatusReturned          OrderStatus = "returned"
 )
 
+// orderTransitions - все разрешенные переходы между статусами заказа
+var orderTransitions = map[OrderStatus][]OrderStatus{
+	OrderStatusNew:             {OrderStatusAwaitingPayment, OrderStatusFailed},
+	OrderStatusAwaitingPayment: {OrderStatusPaid, OrderStatusCancelled},
+	OrderStatusPaid:            {OrderStatusPartiallyReturned, OrderStatusReturned},
+	// каждый следующий частичный возврат тоже пишется в историю
+	OrderStatusPartiallyReturned: {OrderStatusPartiallyReturned, OrderStatusReturned},
+}
+
+// CanTransitionTo проверяет, можно ли перевести заказ из статуса s в to.
+func (s OrderStatus) CanTransitionTo(to OrderStatus) bool {
+	for _, status := range orderTransitions[s] {
+		if status == to {
+			return true
+		}
+	}
+	return false
+}
+
+// OrderItem - позиция заказа. Warehouse заполняется при резерве,
+// одна позиция запроса может разойтись на несколько складов.
 type OrderItem struct {
-	Sku   ProductSku
-	Count uint16
+	Sku       ProductSku
+	Count     uint16
+	Warehouse WarehouseID
 }
 
 type Order struct {
@@ -23,4 +70,40 @@ type Order struct {
 	Status OrderStatus
 	User   UserID
 	Items  []OrderItem
+	// Delivery - куда доставить заказ, используется для выбора ближайшего склада
+	Delivery  *Location
+	CreatedAt time.Time
+	// RequestID - ключ идемпотентности от клиента, пусто если клиент его не передал
+	RequestID string
+}
+
+// OrderItemsDiff - изменение резерва при смене позиций заказа. Reserve - сколько
+// дозарезервировать по sku, склады выбирает резерв; Release - какие позиции освободить, со складами.
+type OrderItemsDiff struct {
+	Reserve []OrderItem
+	Release []OrderItem
+}
+
+// OrderFilter - условия выборки заказов пользователя, пустые поля выборку не ограничивают.
+// CreatedTo не включается в интервал.
+type OrderFilter struct {
+	User        UserID
+	Statuses    []OrderStatus
+	CreatedFrom time.Time
+	CreatedTo   time.Time
+}
+
+// OrderCursor - последний заказ страницы в списке, упорядоченном по (CreatedAt, ID) от новых к старым.
+type OrderCursor struct {
+	CreatedAt time.Time
+	ID        OrderID
+}
+
+// OrderStatusChange - запись истории статусов заказа.
+type OrderStatusChange struct {
+	OrderID   OrderID
+	From      OrderStatus
+	To        OrderStatus
+	Reason    string
+	CreatedAt time.Time
 }
@@ -0,0 +1,15 @@
+package model
+
+import "time"
+
+type ReturnID int64
+
+// OrderReturn - возврат части позиций оплаченного заказа. Items разложены по складам,
+// с которых позиции были отгружены, Status - статус заказа после возврата.
+type OrderReturn struct {
+	ID        ReturnID
+	OrderID   OrderID
+	Items     []OrderItem
+	Status    OrderStatus
+	CreatedAt time.Time
+}
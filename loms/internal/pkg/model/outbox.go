@@ -1,17 +1,47 @@
 package model
 
 import (
+	"context"
 	"time"
 )
 
+const (
+	// EventReasonTimeout - заказ отменен, потому что его не оплатили вовремя
+	EventReasonTimeout = "timeout"
+	// EventReasonRollback - статус возвращен, потому что не удалось изменить остатки
+	EventReasonRollback = "rollback"
+	// EventReasonUpdated - изменились позиции заказа, статус прежний
+	EventReasonUpdated = "updated"
+)
+
 type Event struct {
 	OrderID OrderID
 	Status  OrderStatus
 	Time    time.Time
+	// Reason - почему сменился статус, пусто для действий пользователя
+	Reason string `json:",omitempty"`
+	// ReturnID и Items заполняются для событий о возврате позиций заказа,
+	// Items - еще и для события об изменении позиций
+	ReturnID ReturnID    `json:",omitempty"`
+	Items    []OrderItem `json:",omitempty"`
+}
+
+type eventReasonKey struct{}
+
+// WithEventReason добавляет в контекст причину, которая попадет в события о смене статуса заказа.
+func WithEventReason(ctx context.Context, reason string) context.Context {
+	return context.WithValue(ctx, eventReasonKey{}, reason)
+}
+
+func EventReasonFromContext(ctx context.Context) string {
+	reason, _ := ctx.Value(eventReasonKey{}).(string)
+	return reason
 }
 
 type Headers struct {
 	TraceID string
+	// Key - ключ сообщения в kafka, по умолчанию OrderID события
+	Key string `json:",omitempty"`
 }
 
 type OutboxItem struct {
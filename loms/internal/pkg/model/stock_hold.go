@@ -0,0 +1,21 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrNotEnoughStock = errors.New("not enough products in stock")
+	ErrHoldNotFound   = errors.New("hold not found or expired")
+)
+
+type HoldID int64
+
+type StockHold struct {
+	ID        HoldID
+	User      UserID
+	Sku       ProductSku
+	Count     uint16
+	ExpiresAt time.Time
+}
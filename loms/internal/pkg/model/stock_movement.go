@@ -0,0 +1,65 @@
+package model
+
+import (
+	"errors"
+	"time"
+)
+
+var (
+	ErrStockNotFound      = errors.New("stock not found")
+	ErrStockBelowReserved = errors.New("total count can not be less than reserved")
+	ErrInvalidStockChange = errors.New("invalid stock change")
+)
+
+type StockOperation string
+
+const (
+	StockOperationAdd    StockOperation = "add"
+	StockOperationAdjust StockOperation = "adjust"
+	StockOperationSet    StockOperation = "set"
+	// StockOperationReturn - возврат позиций заказа на склад, Count - сколько вернуть
+	StockOperationReturn StockOperation = "return"
+)
+
+// StockChange - изменение остатка. Count для add и return - сколько добавить,
+// для adjust - изменение со знаком, для set - новый total_count.
+type StockChange struct {
+	Warehouse WarehouseID
+	Sku       ProductSku
+	Operation StockOperation
+	Count     int64
+	Reason    string
+	Actor     string
+	OrderID   OrderID
+	// ReturnID - ключ идемпотентности пополнения по возврату заказа
+	ReturnID ReturnID
+}
+
+type StockMovementID int64
+
+type StockMovement struct {
+	ID         StockMovementID
+	Warehouse  WarehouseID
+	Sku        ProductSku
+	Operation  StockOperation
+	Delta      int64
+	TotalCount uint64
+	Reason     string
+	Actor      string
+	OrderID    OrderID
+	CreatedAt  time.Time
+}
+
+// StockEvent публикуется в топик stock-events на каждое изменение остатка.
+type StockEvent struct {
+	MovementID StockMovementID
+	Warehouse  WarehouseID
+	Sku        ProductSku
+	Operation  StockOperation
+	Delta      int64
+	TotalCount uint64
+	Reason     string
+	Actor      string
+	OrderID    OrderID
+	Time       time.Time
+}
@@ -0,0 +1,27 @@
+package model
+
+import "errors"
+
+var ErrWarehouseNotFound = errors.New("warehouse not found")
+
+type WarehouseID int64
+
+// DefaultWarehouseID - склад, на который идут изменения остатка без явного склада.
+const DefaultWarehouseID WarehouseID = 1
+
+type Location struct {
+	Latitude  float64
+	Longitude float64
+}
+
+type Warehouse struct {
+	ID       WarehouseID
+	Name     string
+	Location Location
+}
+
+// WarehouseStock - свободный остаток sku на складе.
+type WarehouseStock struct {
+	Warehouse WarehouseID
+	Count     uint64
+}
@@ -2,6 +2,7 @@ package orderrepository
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"route256/loms/internal/pkg/inrfa/shard_manager"
 	"route256/loms/internal/pkg/model"
@@ -10,10 +11,17 @@ import (
 	"route256/loms/pkg/tracing"
 	"sort"
 	"strconv"
+	"time"
 
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
 	"github.com/jackc/pgx/v5/pgxpool"
 )
 
+// requestIDIndex - уникальный индекс (user_id, request_id), его нарушение означает повтор запроса
+const requestIDIndex = "orders_user_id_request_id_idx"
+
 type shardManager interface {
 	GetShardIndex(key shard_manager.ShardKey) shard_manager.ShardIndex
 	GetShardIndexFromID(id int64) shard_manager.ShardIndex
@@ -35,7 +43,7 @@ func (r *DbOrderRepository) Create(ctx context.Context, order model.Order, inTx
 	ctx, span := tracing.Start(ctx, "DbOrderRepository.Create")
 	defer tracing.EndWithCheckError(span, &err)
 
-	shIndex := r.sm.GetShardIndex(shard_manager.ShardKey(strconv.FormatInt(int64(order.User), 10)))
+	shIndex := r.sm.GetShardIndex(userShardKey(order.User))
 	db, err := r.sm.Pick(shIndex)
 	if err != nil {
 		return 0, fmt.Errorf("r.sm.Pick: %w", err)
@@ -55,19 +63,34 @@ func (r *DbOrderRepository) Create(ctx context.Context, order model.Order, inTx
 	}
 
 	id, err := qtx.Create(ctx, sqlc_order.CreateParams{
-		UserID:  int64(order.User),
-		Status:  string(order.Status),
-		ShardID: int32(shIndex),
+		UserID:    int64(order.User),
+		Status:    string(order.Status),
+		ShardID:   int32(shIndex),
+		RequestID: pgtype.Text{String: order.RequestID, Valid: order.RequestID != ""},
 	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.ConstraintName == requestIDIndex {
+		return 0, fmt.Errorf("%w: user %d, request %q", model.ErrDuplicateOrderRequest, order.User, order.RequestID)
+	}
 	if err != nil {
 		return 0, fmt.Errorf("qtx.Create: %w", err)
 	}
+	err = qtx.AddStatusHistory(ctx, sqlc_order.AddStatusHistoryParams{
+		OrderID:    id,
+		FromStatus: string(model.OrderStatusNone),
+		ToStatus:   string(order.Status),
+		Reason:     model.EventReasonFromContext(ctx),
+	})
+	if err != nil {
+		return 0, fmt.Errorf("qtx.AddStatusHistory: %w", err)
+	}
 
 	for _, item := range order.Items {
 		err := qtx.AddItem(ctx, sqlc_order.AddItemParams{
-			OrderID: int64(id),
-			Sku:     int64(item.Sku),
-			Count:   int32(item.Count),
+			OrderID:     int64(id),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+			WarehouseID: int64(item.Warehouse),
 		})
 		if err != nil {
 			return 0, fmt.Errorf("qtx.AddItem: %w", err)
@@ -98,30 +121,123 @@ func (r *DbOrderRepository) GetById(ctx context.Context, orderID model.OrderID)
 	}
 	queries := sqlc_order.New(db)
 
-	orderItems, err := queries.GetById(ctx, int64(orderID))
+	row, err := queries.GetById(ctx, int64(orderID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Order{}, fmt.Errorf("%w: invalid order id: %d", model.ErrOrderNotFound, orderID)
+	}
 	if err != nil {
 		return model.Order{}, fmt.Errorf("r.queries.GetById: %w", err)
 	}
-	if len(orderItems) == 0 {
-		return model.Order{}, fmt.Errorf("invalid order id: %d", orderID)
+	orders, err := withItems(ctx, queries, []sqlc_order.Order{row})
+	if err != nil {
+		return model.Order{}, err
 	}
-	order := model.Order{
-		ID:     model.OrderID(orderItems[0].Order.ID),
-		Status: model.OrderStatus(orderItems[0].Order.Status),
-		User:   model.UserID(orderItems[0].Order.UserID),
-		Items:  make([]model.OrderItem, 0, len(orderItems)),
+	return orders[0], nil
+}
+
+// GetByRequestID ищет заказ пользователя по ключу идемпотентности в шарде пользователя.
+func (r *DbOrderRepository) GetByRequestID(ctx context.Context, user model.UserID, requestID string) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.GetByRequestID")
+	defer tracing.EndWithCheckError(span, &err)
+
+	db, err := r.sm.Pick(r.sm.GetShardIndex(userShardKey(user)))
+	if err != nil {
+		return model.Order{}, fmt.Errorf("r.sm.Pick: %w", err)
+	}
+	queries := sqlc_order.New(db)
+
+	row, err := queries.GetByRequestId(ctx, sqlc_order.GetByRequestIdParams{
+		UserID:    int64(user),
+		RequestID: requestID,
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Order{}, fmt.Errorf("%w: user %d, request %q", model.ErrOrderNotFound, user, requestID)
+	}
+	if err != nil {
+		return model.Order{}, fmt.Errorf("queries.GetByRequestId: %w", err)
 	}
-	for _, item := range orderItems {
-		order.Items = append(order.Items, model.OrderItem{
-			Sku:   model.ProductSku(item.OrderItem.Sku),
-			Count: uint16(item.OrderItem.Count),
+	orders, err := withItems(ctx, queries, []sqlc_order.Order{row})
+	if err != nil {
+		return model.Order{}, err
+	}
+	return orders[0], nil
+}
+
+// withItems дочитывает позиции заказов одним запросом. Отдельный запрос вместо LEFT JOIN:
+// заказ без позиций читается без ошибки, а limit в выборке заказов считает заказы, а не строки.
+func withItems(ctx context.Context, queries *sqlc_order.Queries, rows []sqlc_order.Order) ([]model.Order, error) {
+	orders := make([]model.Order, 0, len(rows))
+	orderIDs := make([]int64, 0, len(rows))
+	index := make(map[int64]int, len(rows))
+	for _, row := range rows {
+		index[row.ID] = len(orders)
+		orderIDs = append(orderIDs, row.ID)
+		orders = append(orders, model.Order{
+			ID:        model.OrderID(row.ID),
+			Status:    model.OrderStatus(row.Status),
+			User:      model.UserID(row.UserID),
+			CreatedAt: row.CreatedAt.Time,
+			RequestID: row.RequestID.String,
 		})
 	}
-	return order, nil
+	items, err := queries.GetItemsByOrderIds(ctx, orderIDs)
+	if err != nil {
+		return nil, fmt.Errorf("queries.GetItemsByOrderIds: %w", err)
+	}
+	for _, item := range items {
+		i := index[item.OrderID]
+		orders[i].Items = append(orders[i].Items, model.OrderItem{
+			Sku:       model.ProductSku(item.Sku),
+			Count:     uint16(item.Count),
+			Warehouse: model.WarehouseID(item.WarehouseID),
+		})
+	}
+	return orders, nil
 }
 
-func (r *DbOrderRepository) SetStatus(ctx context.Context, orderID model.OrderID, status model.OrderStatus, inTx ...func(context.Context) error) (err error) {
-	ctx, span := tracing.Start(ctx, "DbOrderRepository.SetStatus")
+// userShardKey - ключ шардирования: все заказы пользователя лежат в одном шарде.
+func userShardKey(user model.UserID) shard_manager.ShardKey {
+	return shard_manager.ShardKey(strconv.FormatInt(int64(user), 10))
+}
+
+// ListByUser возвращает до limit заказов пользователя из его шарда от новых к старым, начиная после after.
+func (r *DbOrderRepository) ListByUser(ctx context.Context, filter model.OrderFilter, after *model.OrderCursor, limit int) (_ []model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.ListByUser")
+	defer tracing.EndWithCheckError(span, &err)
+
+	db, err := r.sm.Pick(r.sm.GetShardIndex(userShardKey(filter.User)))
+	if err != nil {
+		return nil, fmt.Errorf("r.sm.Pick: %w", err)
+	}
+	queries := sqlc_order.New(db)
+
+	params := sqlc_order.ListByUserParams{
+		UserID:      int64(filter.User),
+		Statuses:    make([]string, 0, len(filter.Statuses)),
+		CreatedFrom: pgtype.Timestamp{Time: filter.CreatedFrom.UTC(), Valid: !filter.CreatedFrom.IsZero()},
+		CreatedTo:   pgtype.Timestamp{Time: filter.CreatedTo.UTC(), Valid: !filter.CreatedTo.IsZero()},
+		MaxCount:    int32(limit),
+	}
+	for _, status := range filter.Statuses {
+		params.Statuses = append(params.Statuses, string(status))
+	}
+	if after != nil {
+		params.AfterID = pgtype.Int8{Int64: int64(after.ID), Valid: true}
+		params.AfterCreatedAt = pgtype.Timestamp{Time: after.CreatedAt.UTC(), Valid: true}
+	}
+	rows, err := queries.ListByUser(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("queries.ListByUser: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, nil
+	}
+	return withItems(ctx, queries, rows)
+}
+
+// SetItems заменяет позиции заказа, например после распределения по складам.
+func (r *DbOrderRepository) SetItems(ctx context.Context, orderID model.OrderID, items []model.OrderItem) (err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.SetItems")
 	defer tracing.EndWithCheckError(span, &err)
 
 	shIndex := r.sm.GetShardIndexFromID(int64(orderID))
@@ -129,79 +245,227 @@ func (r *DbOrderRepository) SetStatus(ctx context.Context, orderID model.OrderID
 	if err != nil {
 		return fmt.Errorf("r.sm.Pick: %w", err)
 	}
-	queries := sqlc_order.New(db)
 
-	qtx := queries
-	if len(inTx) > 0 {
-		tx, err := db.Begin(ctx)
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	if err := replaceItems(ctx, qtx, orderID, items); err != nil {
+		return err
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
+func replaceItems(ctx context.Context, qtx *sqlc_order.Queries, orderID model.OrderID, items []model.OrderItem) error {
+	if err := qtx.DeleteItems(ctx, int64(orderID)); err != nil {
+		return fmt.Errorf("qtx.DeleteItems: %w", err)
+	}
+	for _, item := range items {
+		err := qtx.AddItem(ctx, sqlc_order.AddItemParams{
+			OrderID:     int64(orderID),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+			WarehouseID: int64(item.Warehouse),
+		})
 		if err != nil {
-			return fmt.Errorf("r.db.Begin: %w", err)
+			return fmt.Errorf("qtx.AddItem: %w", err)
 		}
-		defer tx.Rollback(ctx)
-		qtx = qtx.WithTx(tx)
-
-		ctxTx := context.WithValue(ctx, repository.CtxTxKey{}, tx)
-		for _, f := range inTx {
-			if err := f(ctxTx); err != nil {
-				return fmt.Errorf("inTx: %w", err)
-			}
+	}
+	return nil
+}
+
+func getItems(ctx context.Context, qtx *sqlc_order.Queries, orderID model.OrderID) ([]model.OrderItem, error) {
+	rows, err := qtx.GetItemsByOrderIds(ctx, []int64{int64(orderID)})
+	if err != nil {
+		return nil, fmt.Errorf("qtx.GetItemsByOrderIds: %w", err)
+	}
+	items := make([]model.OrderItem, 0, len(rows))
+	for _, row := range rows {
+		items = append(items, model.OrderItem{
+			Sku:       model.ProductSku(row.Sku),
+			Count:     uint16(row.Count),
+			Warehouse: model.WarehouseID(row.WarehouseID),
+		})
+	}
+	return items, nil
+}
+
+// SetStatus переводит заказ из статуса from в to, пишет переход в историю и возвращает
+// позиции заказа на момент перехода. Заказ блокируется до чтения позиций, поэтому
+// одновременный UpdateItems либо уже записал позиции, либо увидит новый статус.
+// Если статус уже не from, возвращает model.ErrOrderStatusConflict.
+func (r *DbOrderRepository) SetStatus(ctx context.Context, orderID model.OrderID, from, to model.OrderStatus, inTx ...func(context.Context) error) (_ []model.OrderItem, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.SetStatus")
+	defer tracing.EndWithCheckError(span, &err)
+
+	shIndex := r.sm.GetShardIndexFromID(int64(orderID))
+	db, err := r.sm.Pick(shIndex)
+	if err != nil {
+		return nil, fmt.Errorf("r.sm.Pick: %w", err)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	if _, err := qtx.LockOrder(ctx, int64(orderID)); err != nil {
+		if errors.Is(err, pgx.ErrNoRows) {
+			return nil, fmt.Errorf("%w: invalid order id: %d", model.ErrOrderNotFound, orderID)
 		}
+		return nil, fmt.Errorf("qtx.LockOrder: %w", err)
+	}
+	rows, err := qtx.SetStatus(ctx, sqlc_order.SetStatusParams{
+		ID:                    int64(orderID),
+		FromStatus:            string(from),
+		ToStatus:              string(to),
+		AwaitingPaymentStatus: string(model.OrderStatusAwaitingPayment),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("qtx.SetStatus: %w", err)
+	}
+	if rows == 0 {
+		return nil, fmt.Errorf("%w: orderID %d is not in status %s", model.ErrOrderStatusConflict, orderID, from)
+	}
+	err = qtx.AddStatusHistory(ctx, sqlc_order.AddStatusHistoryParams{
+		OrderID:    int64(orderID),
+		FromStatus: string(from),
+		ToStatus:   string(to),
+		Reason:     model.EventReasonFromContext(ctx),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("qtx.AddStatusHistory: %w", err)
+	}
+	items, err := getItems(ctx, qtx, orderID)
+	if err != nil {
+		return nil, err
+	}
 
-		defer func() {
-			if err = tx.Commit(ctx); err != nil {
-				err = fmt.Errorf("tx.Commit: %w", err)
-			}
-		}()
+	ctxTx := context.WithValue(ctx, repository.CtxTxKey{}, tx)
+	for _, f := range inTx {
+		if err := f(ctxTx); err != nil {
+			return nil, fmt.Errorf("inTx: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return items, nil
+}
+
+// GetStatusHistory возвращает переходы статусов заказа от старых к новым.
+func (r *DbOrderRepository) GetStatusHistory(ctx context.Context, orderID model.OrderID) (_ []model.OrderStatusChange, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.GetStatusHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	shIndex := r.sm.GetShardIndexFromID(int64(orderID))
+	db, err := r.sm.Pick(shIndex)
+	if err != nil {
+		return nil, fmt.Errorf("r.sm.Pick: %w", err)
 	}
 
-	err = qtx.SetStatus(ctx, sqlc_order.SetStatusParams{
-		ID:     int64(orderID),
-		Status: string(status),
+	rows, err := sqlc_order.New(db).GetStatusHistory(ctx, int64(orderID))
+	if err != nil {
+		return nil, fmt.Errorf("queries.GetStatusHistory: %w", err)
+	}
+	// у каждого заказа есть хотя бы запись о создании
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: %d", model.ErrOrderNotFound, orderID)
+	}
+	history := make([]model.OrderStatusChange, 0, len(rows))
+	for _, row := range rows {
+		history = append(history, model.OrderStatusChange{
+			OrderID:   model.OrderID(row.OrderID),
+			From:      model.OrderStatus(row.FromStatus),
+			To:        model.OrderStatus(row.ToStatus),
+			Reason:    row.Reason,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	return history, nil
+}
+
+// unpaidLockKey - ключ advisory-блокировки шарда на время отмены неоплаченных заказов
+const unpaidLockKey = 20240820
+
+// HandleUnpaid передает в handle заказы, которые ждут оплаты дольше timeout, по limit с каждого шарда.
+// Шард обрабатывает одна реплика: остальные не получают advisory-блокировку и пропускают его.
+func (r *DbOrderRepository) HandleUnpaid(ctx context.Context, timeout time.Duration, limit int, handle func(context.Context, []model.OrderID) error) (err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.HandleUnpaid")
+	defer tracing.EndWithCheckError(span, &err)
+
+	var errs []error
+	for i, db := range r.sm.GetShards() {
+		if err := r.handleUnpaidShard(ctx, db, timeout, limit, handle); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *DbOrderRepository) handleUnpaidShard(ctx context.Context, db *pgxpool.Pool, timeout time.Duration, limit int, handle func(context.Context, []model.OrderID) error) error {
+	// блокировка живет до конца транзакции, поэтому освобождается и при падении реплики
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	locked, err := qtx.TryLockShard(ctx, unpaidLockKey)
+	if err != nil {
+		return fmt.Errorf("qtx.TryLockShard: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	ids, err := qtx.GetUnpaid(ctx, sqlc_order.GetUnpaidParams{
+		Status:         string(model.OrderStatusAwaitingPayment),
+		TimeoutSeconds: int32(timeout.Seconds()),
+		MaxCount:       int32(limit),
 	})
 	if err != nil {
-		return fmt.Errorf("qtx.SetStatus: %w", err)
+		return fmt.Errorf("qtx.GetUnpaid: %w", err)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	orderIDs := make([]model.OrderID, 0, len(ids))
+	for _, id := range ids {
+		orderIDs = append(orderIDs, model.OrderID(id))
+	}
+	// отмена идет мимо транзакции блокировки, через обычные методы репозиториев
+	if err := handle(ctx, orderIDs); err != nil {
+		return fmt.Errorf("handle: %w", err)
 	}
 	return nil
 }
 
+// getAllBatchSize - по сколько заказов GetAll читает из шарда за запрос
+const getAllBatchSize = 1000
+
+// GetAll собирает в память все заказы от новых к старым. Для выгрузки есть StreamAll.
 func (r *DbOrderRepository) GetAll(ctx context.Context) (_ []model.Order, err error) {
 	ctx, span := tracing.Start(ctx, "DbOrderRepository.GetAll")
 	defer tracing.EndWithCheckError(span, &err)
 
 	orders := make([]model.Order, 0, 10)
-
-	for _, db := range r.sm.GetShards() {
-		queries := sqlc_order.New(db)
-		orderItems, err := queries.GetAll(ctx)
-		if err != nil {
-			return nil, fmt.Errorf("r.queries.GetAll: %w", err)
-		}
-		if len(orderItems) == 0 {
-			continue
-		}
-
-		var order model.Order
-		for _, item := range orderItems {
-			// Начался новый заказ
-			if item.Order.ID != int64(order.ID) {
-				// Старый сохраняем
-				if order.ID != 0 {
-					orders = append(orders, order)
-				}
-				order = model.Order{
-					ID:     model.OrderID(item.Order.ID),
-					Status: model.OrderStatus(item.Order.Status),
-					User:   model.UserID(item.Order.UserID),
-					Items:  make([]model.OrderItem, 0, 3),
-				}
-			}
-			order.Items = append(order.Items, model.OrderItem{
-				Sku:   model.ProductSku(item.OrderItem.Sku),
-				Count: uint16(item.OrderItem.Count),
-			})
-
-		}
+	err = r.StreamAll(ctx, 0, getAllBatchSize, func(order model.Order) error {
 		orders = append(orders, order)
+		return nil
+	})
+	if err != nil {
+		return nil, err
 	}
 
 	sort.Slice(orders, func(i, j int) bool {
@@ -210,3 +474,22 @@ func (r *DbOrderRepository) GetAll(ctx context.Context) (_ []model.Order, err er
 
 	return orders, nil
 }
+
+// StreamAll передает в fn заказы всех шардов по возрастанию ID, начиная после afterID.
+// Из каждого шарда в памяти не больше batchSize заказов. Ошибка fn или отмена ctx
+// останавливает чтение всех шардов.
+func (r *DbOrderRepository) StreamAll(ctx context.Context, afterID model.OrderID, batchSize int, fn func(model.Order) error) (err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.StreamAll")
+	defer tracing.EndWithCheckError(span, &err)
+
+	shards := r.sm.GetShards()
+	sources := make([]orderSource, 0, len(shards))
+	for _, db := range shards {
+		sources = append(sources, &shardSource{
+			queries:   sqlc_order.New(db),
+			afterID:   int64(afterID),
+			batchSize: batchSize,
+		})
+	}
+	return mergeByID(ctx, sources, fn)
+}
@@ -0,0 +1,83 @@
+package orderrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/loms/internal/pkg/model"
+	"route256/loms/internal/pkg/repository"
+	"route256/loms/internal/pkg/repository/order_repository/sqlc_order"
+	"route256/loms/pkg/tracing"
+
+	"github.com/jackc/pgx/v5"
+)
+
+// UpdateItems заменяет позиции неоплаченного заказа. reserve получает разницу с текущими
+// позициями, меняет резерв и возвращает дозарезервированные позиции со складами.
+// Заказ заблокирован до конца транзакции, поэтому одновременные изменения не теряются.
+// inTx выполняются в той же транзакции после записи позиций, например для записи события в outbox.
+func (r *DbOrderRepository) UpdateItems(ctx context.Context, orderID model.OrderID, items []model.OrderItem, reserve func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), inTx ...func(context.Context, model.Order) error) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.UpdateItems")
+	defer tracing.EndWithCheckError(span, &err)
+
+	db, err := r.sm.Pick(r.sm.GetShardIndexFromID(int64(orderID)))
+	if err != nil {
+		return model.Order{}, fmt.Errorf("r.sm.Pick: %w", err)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	row, err := qtx.LockOrder(ctx, int64(orderID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.Order{}, fmt.Errorf("%w: invalid order id: %d", model.ErrOrderNotFound, orderID)
+	}
+	if err != nil {
+		return model.Order{}, fmt.Errorf("qtx.LockOrder: %w", err)
+	}
+	if model.OrderStatus(row.Status) != model.OrderStatusAwaitingPayment {
+		return model.Order{}, fmt.Errorf("%w: orderID %d is in status %s", model.ErrOrderNotEditable, orderID, row.Status)
+	}
+	current, err := getItems(ctx, qtx, orderID)
+	if err != nil {
+		return model.Order{}, err
+	}
+
+	order := model.Order{
+		ID:        orderID,
+		Status:    model.OrderStatus(row.Status),
+		User:      model.UserID(row.UserID),
+		CreatedAt: row.CreatedAt.Time,
+		RequestID: row.RequestID.String,
+		Items:     current,
+	}
+	diff := diffItems(current, items)
+	if len(diff.Reserve) == 0 && len(diff.Release) == 0 {
+		return order, nil
+	}
+	// остатки в другой базе и меняются до записи позиций: склады новых позиций выбирает резерв
+	reserved, err := reserve(ctx, diff)
+	if err != nil {
+		return model.Order{}, fmt.Errorf("reserve: %w", err)
+	}
+	order.Items = applyDiff(current, diff.Release, reserved)
+	if err := replaceItems(ctx, qtx, orderID, order.Items); err != nil {
+		return model.Order{}, err
+	}
+
+	ctxTx := context.WithValue(ctx, repository.CtxTxKey{}, tx)
+	for _, f := range inTx {
+		if err := f(ctxTx, order); err != nil {
+			return model.Order{}, fmt.Errorf("inTx: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.Order{}, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return order, nil
+}
@@ -0,0 +1,204 @@
+package orderrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"route256/loms/internal/pkg/model"
+	"route256/loms/internal/pkg/repository"
+	"route256/loms/internal/pkg/repository/order_repository/sqlc_order"
+	"route256/loms/pkg/tracing"
+	"time"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgxpool"
+)
+
+// AddReturn записывает возврат позиций заказа и переводит заказ в partially_returned или returned.
+// Заказ блокируется на время транзакции, поэтому одновременные возвраты не вернут больше заказанного.
+// inTx выполняются в той же транзакции, например для записи события в outbox.
+func (r *DbOrderRepository) AddReturn(ctx context.Context, orderID model.OrderID, items []model.OrderItem, inTx ...func(context.Context, model.OrderReturn) error) (_ model.OrderReturn, err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.AddReturn")
+	defer tracing.EndWithCheckError(span, &err)
+
+	db, err := r.sm.Pick(r.sm.GetShardIndexFromID(int64(orderID)))
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("r.sm.Pick: %w", err)
+	}
+
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	order, err := qtx.LockOrder(ctx, int64(orderID))
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.OrderReturn{}, fmt.Errorf("%w: invalid order id: %d", model.ErrOrderNotFound, orderID)
+	}
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("qtx.LockOrder: %w", err)
+	}
+	ordered, err := getItems(ctx, qtx, orderID)
+	if err != nil {
+		return model.OrderReturn{}, err
+	}
+	returnedRows, err := qtx.GetReturnedItems(ctx, int64(orderID))
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("qtx.GetReturnedItems: %w", err)
+	}
+	returned := make([]model.OrderItem, 0, len(returnedRows))
+	for _, row := range returnedRows {
+		returned = append(returned, model.OrderItem{
+			Sku:       model.ProductSku(row.Sku),
+			Count:     uint16(row.Count),
+			Warehouse: model.WarehouseID(row.WarehouseID),
+		})
+	}
+
+	from := model.OrderStatus(order.Status)
+	allocated, to, err := planReturn(from, ordered, returned, items)
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("orderID %d: %w", orderID, err)
+	}
+
+	row, err := qtx.AddReturn(ctx, int64(orderID))
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("qtx.AddReturn: %w", err)
+	}
+	for _, item := range allocated {
+		err := qtx.AddReturnItem(ctx, sqlc_order.AddReturnItemParams{
+			ReturnID:    row.ID,
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+			WarehouseID: int64(item.Warehouse),
+		})
+		if err != nil {
+			return model.OrderReturn{}, fmt.Errorf("qtx.AddReturnItem: %w", err)
+		}
+	}
+	// заказ заблокирован, условие на статус здесь всегда выполняется
+	if _, err := qtx.SetStatus(ctx, sqlc_order.SetStatusParams{
+		ID:                    int64(orderID),
+		FromStatus:            string(from),
+		ToStatus:              string(to),
+		AwaitingPaymentStatus: string(model.OrderStatusAwaitingPayment),
+	}); err != nil {
+		return model.OrderReturn{}, fmt.Errorf("qtx.SetStatus: %w", err)
+	}
+	err = qtx.AddStatusHistory(ctx, sqlc_order.AddStatusHistoryParams{
+		OrderID:    int64(orderID),
+		FromStatus: string(from),
+		ToStatus:   string(to),
+		Reason:     model.EventReasonFromContext(ctx),
+	})
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("qtx.AddStatusHistory: %w", err)
+	}
+
+	orderReturn := model.OrderReturn{
+		ID:        model.ReturnID(row.ID),
+		OrderID:   orderID,
+		Items:     allocated,
+		Status:    to,
+		CreatedAt: row.CreatedAt.Time,
+	}
+	ctxTx := context.WithValue(ctx, repository.CtxTxKey{}, tx)
+	for _, f := range inTx {
+		if err := f(ctxTx, orderReturn); err != nil {
+			return model.OrderReturn{}, fmt.Errorf("inTx: %w", err)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return model.OrderReturn{}, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return orderReturn, nil
+}
+
+// SetReturnRestocked отмечает, что позиции возврата вернулись на склады.
+func (r *DbOrderRepository) SetReturnRestocked(ctx context.Context, orderID model.OrderID, returnID model.ReturnID) (err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.SetReturnRestocked")
+	defer tracing.EndWithCheckError(span, &err)
+
+	db, err := r.sm.Pick(r.sm.GetShardIndexFromID(int64(orderID)))
+	if err != nil {
+		return fmt.Errorf("r.sm.Pick: %w", err)
+	}
+	if err := sqlc_order.New(db).SetReturnRestocked(ctx, int64(returnID)); err != nil {
+		return fmt.Errorf("queries.SetReturnRestocked: %w", err)
+	}
+	return nil
+}
+
+// restockLockKey - ключ advisory-блокировки шарда на время дополнения остатков по возвратам
+const restockLockKey = 20240906
+
+// HandleNotRestocked передает в handle возвраты старше delay, остатки по которым еще не пополнены,
+// по limit с каждого шарда. Шард обрабатывает одна реплика, как в HandleUnpaid.
+func (r *DbOrderRepository) HandleNotRestocked(ctx context.Context, delay time.Duration, limit int, handle func(context.Context, []model.OrderReturn) error) (err error) {
+	ctx, span := tracing.Start(ctx, "DbOrderRepository.HandleNotRestocked")
+	defer tracing.EndWithCheckError(span, &err)
+
+	var errs []error
+	for i, db := range r.sm.GetShards() {
+		if err := r.handleNotRestockedShard(ctx, db, delay, limit, handle); err != nil {
+			errs = append(errs, fmt.Errorf("shard %d: %w", i, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func (r *DbOrderRepository) handleNotRestockedShard(ctx context.Context, db *pgxpool.Pool, delay time.Duration, limit int, handle func(context.Context, []model.OrderReturn) error) error {
+	tx, err := db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := sqlc_order.New(tx)
+
+	locked, err := qtx.TryLockShard(ctx, restockLockKey)
+	if err != nil {
+		return fmt.Errorf("qtx.TryLockShard: %w", err)
+	}
+	if !locked {
+		return nil
+	}
+	rows, err := qtx.GetNotRestockedReturns(ctx, sqlc_order.GetNotRestockedReturnsParams{
+		DelaySeconds: int32(delay.Seconds()),
+		MaxCount:     int32(limit),
+	})
+	if err != nil {
+		return fmt.Errorf("qtx.GetNotRestockedReturns: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+	returns := make([]model.OrderReturn, 0, len(rows))
+	for _, row := range rows {
+		itemRows, err := qtx.GetReturnItems(ctx, row.ID)
+		if err != nil {
+			return fmt.Errorf("qtx.GetReturnItems: %w", err)
+		}
+		items := make([]model.OrderItem, 0, len(itemRows))
+		for _, item := range itemRows {
+			items = append(items, model.OrderItem{
+				Sku:       model.ProductSku(item.Sku),
+				Count:     uint16(item.Count),
+				Warehouse: model.WarehouseID(item.WarehouseID),
+			})
+		}
+		returns = append(returns, model.OrderReturn{
+			ID:        model.ReturnID(row.ID),
+			OrderID:   model.OrderID(row.OrderID),
+			Items:     items,
+			CreatedAt: row.CreatedAt.Time,
+		})
+	}
+	// пополнение идет мимо транзакции блокировки, через обычные методы репозиториев
+	if err := handle(ctx, returns); err != nil {
+		return fmt.Errorf("handle: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,63 @@
+package orderrepository
+
+import (
+	"context"
+	"errors"
+	"route256/loms/internal/pkg/inrfa/shard_manager"
+	"route256/loms/internal/pkg/model"
+	"testing"
+
+	"github.com/jackc/pgx/v5/pgxpool"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var errPick = errors.New("pick")
+
+// shardManagerStub запоминает, какой шард выбрал репозиторий, и не ходит в базу.
+type shardManagerStub struct {
+	fn     shard_manager.ShardFn
+	keys   []shard_manager.ShardKey
+	picked []shard_manager.ShardIndex
+}
+
+func (m *shardManagerStub) GetShardIndex(key shard_manager.ShardKey) shard_manager.ShardIndex {
+	m.keys = append(m.keys, key)
+	return m.fn(key)
+}
+
+func (m *shardManagerStub) GetShardIndexFromID(id int64) shard_manager.ShardIndex {
+	return shard_manager.ShardIndex(id % 1000)
+}
+
+func (m *shardManagerStub) Pick(index shard_manager.ShardIndex) (*pgxpool.Pool, error) {
+	m.picked = append(m.picked, index)
+	return nil, errPick
+}
+
+func (m *shardManagerStub) GetShards() []*pgxpool.Pool {
+	return nil
+}
+
+func TestListByUserPicksUserShard(t *testing.T) {
+	ctx := context.Background()
+	fn := shard_manager.GetMurmur3ShardFn(2)
+	sm := &shardManagerStub{fn: fn}
+	repository := NewDbOrderRepository(sm)
+
+	for _, user := range []model.UserID{1, 2, 3, 42, 1000} {
+		_, err := repository.ListByUser(ctx, model.OrderFilter{User: user}, nil, 10)
+		require.ErrorIs(t, err, errPick)
+		// список читается из того же шарда, куда Create пишет заказы пользователя
+		_, err = repository.Create(ctx, model.Order{User: user})
+		require.ErrorIs(t, err, errPick)
+	}
+
+	require.Len(t, sm.picked, 10)
+	for i := 0; i < len(sm.picked); i += 2 {
+		assert.Equal(t, sm.keys[i], sm.keys[i+1])
+		assert.Equal(t, fn(sm.keys[i]), sm.picked[i])
+		assert.Equal(t, sm.picked[i], sm.picked[i+1])
+	}
+	assert.Equal(t, shard_manager.ShardKey("42"), sm.keys[6])
+}
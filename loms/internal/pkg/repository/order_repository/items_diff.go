@@ -0,0 +1,104 @@
+package orderrepository
+
+import (
+	"route256/loms/internal/pkg/model"
+	"sort"
+)
+
+type itemLine struct {
+	sku       model.ProductSku
+	warehouse model.WarehouseID
+}
+
+// diffItems сравнивает текущие позиции заказа с новыми по sku. Увеличение резервируется
+// заново, уменьшение освобождается с конца - со складов с большим id.
+// sku, которых нет в новых позициях, освобождаются целиком.
+func diffItems(current, requested []model.OrderItem) model.OrderItemsDiff {
+	have := make(map[itemLine]int, len(current))
+	lines := make([]itemLine, 0, len(current))
+	haveBySku := make(map[model.ProductSku]int, len(current))
+	for _, item := range current {
+		l := itemLine{item.Sku, item.Warehouse}
+		if _, ok := have[l]; !ok {
+			lines = append(lines, l)
+		}
+		have[l] += int(item.Count)
+		haveBySku[item.Sku] += int(item.Count)
+	}
+	sortLines(lines)
+
+	want := make(map[model.ProductSku]int, len(requested))
+	for _, item := range requested {
+		want[item.Sku] += int(item.Count)
+	}
+	skus := make([]model.ProductSku, 0, len(want)+len(haveBySku))
+	for sku := range want {
+		skus = append(skus, sku)
+	}
+	for sku := range haveBySku {
+		if _, ok := want[sku]; !ok {
+			skus = append(skus, sku)
+		}
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i] < skus[j] })
+
+	var diff model.OrderItemsDiff
+	for _, sku := range skus {
+		delta := want[sku] - haveBySku[sku]
+		if delta > 0 {
+			diff.Reserve = append(diff.Reserve, model.OrderItem{Sku: sku, Count: uint16(delta)})
+			continue
+		}
+		for i := len(lines) - 1; i >= 0 && delta < 0; i-- {
+			l := lines[i]
+			if l.sku != sku {
+				continue
+			}
+			n := min(-delta, have[l])
+			diff.Release = append(diff.Release, model.OrderItem{Sku: sku, Count: uint16(n), Warehouse: l.warehouse})
+			delta += n
+		}
+	}
+	return diff
+}
+
+// applyDiff возвращает позиции заказа после изменения: из current вычитаются released
+// и добавляются reserved, позиции с одинаковыми sku и складом складываются.
+func applyDiff(current, released, reserved []model.OrderItem) []model.OrderItem {
+	counts := make(map[itemLine]int, len(current)+len(reserved))
+	lines := make([]itemLine, 0, len(current)+len(reserved))
+	add := func(item model.OrderItem, sign int) {
+		l := itemLine{item.Sku, item.Warehouse}
+		if _, ok := counts[l]; !ok {
+			lines = append(lines, l)
+		}
+		counts[l] += sign * int(item.Count)
+	}
+	for _, item := range current {
+		add(item, 1)
+	}
+	for _, item := range released {
+		add(item, -1)
+	}
+	for _, item := range reserved {
+		add(item, 1)
+	}
+	sortLines(lines)
+
+	items := make([]model.OrderItem, 0, len(lines))
+	for _, l := range lines {
+		if counts[l] > 0 {
+			items = append(items, model.OrderItem{Sku: l.sku, Count: uint16(counts[l]), Warehouse: l.warehouse})
+		}
+	}
+	return items
+}
+
+func sortLines(lines []itemLine) {
+	sort.Slice(lines, func(i, j int) bool {
+		if lines[i].sku != lines[j].sku {
+			return lines[i].sku < lines[j].sku
+		}
+		return lines[i].warehouse < lines[j].warehouse
+	})
+}
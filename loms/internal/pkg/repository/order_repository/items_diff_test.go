@@ -0,0 +1,83 @@
+package orderrepository
+
+import (
+	"route256/loms/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestDiffItems(t *testing.T) {
+	t.Parallel()
+
+	// sku 1 зарезервирован на двух складах
+	current := []model.OrderItem{
+		{Sku: 1, Count: 2, Warehouse: 1},
+		{Sku: 1, Count: 3, Warehouse: 2},
+		{Sku: 2, Count: 1, Warehouse: 1},
+	}
+	testData := []struct {
+		name      string
+		requested []model.OrderItem
+		diff      model.OrderItemsDiff
+	}{
+		{
+			name:      "no changes",
+			requested: []model.OrderItem{{Sku: 2, Count: 1}, {Sku: 1, Count: 5}},
+		},
+		{
+			name:      "increase",
+			requested: []model.OrderItem{{Sku: 1, Count: 7}, {Sku: 2, Count: 1}},
+			diff:      model.OrderItemsDiff{Reserve: []model.OrderItem{{Sku: 1, Count: 2}}},
+		},
+		{
+			name:      "decrease from last warehouse",
+			requested: []model.OrderItem{{Sku: 1, Count: 4}, {Sku: 2, Count: 1}},
+			diff:      model.OrderItemsDiff{Release: []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 2}}},
+		},
+		{
+			name:      "decrease across warehouses",
+			requested: []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 2, Count: 1}},
+			diff: model.OrderItemsDiff{Release: []model.OrderItem{
+				{Sku: 1, Count: 3, Warehouse: 2},
+				{Sku: 1, Count: 1, Warehouse: 1},
+			}},
+		},
+		{
+			name:      "removed and added sku",
+			requested: []model.OrderItem{{Sku: 1, Count: 5}, {Sku: 3, Count: 2}},
+			diff: model.OrderItemsDiff{
+				Reserve: []model.OrderItem{{Sku: 3, Count: 2}},
+				Release: []model.OrderItem{{Sku: 2, Count: 1, Warehouse: 1}},
+			},
+		},
+		{
+			name:      "same sku twice",
+			requested: []model.OrderItem{{Sku: 1, Count: 3}, {Sku: 1, Count: 3}, {Sku: 2, Count: 1}},
+			diff:      model.OrderItemsDiff{Reserve: []model.OrderItem{{Sku: 1, Count: 1}}},
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.diff, diffItems(current, tt.requested))
+		})
+	}
+}
+
+func TestApplyDiff(t *testing.T) {
+	t.Parallel()
+
+	current := []model.OrderItem{
+		{Sku: 1, Count: 2, Warehouse: 1},
+		{Sku: 1, Count: 3, Warehouse: 2},
+		{Sku: 2, Count: 1, Warehouse: 1},
+	}
+	items := applyDiff(current,
+		[]model.OrderItem{{Sku: 1, Count: 3, Warehouse: 2}, {Sku: 2, Count: 1, Warehouse: 1}},
+		[]model.OrderItem{{Sku: 3, Count: 2, Warehouse: 2}, {Sku: 1, Count: 1, Warehouse: 1}},
+	)
+	assert.Equal(t, []model.OrderItem{
+		{Sku: 1, Count: 3, Warehouse: 1},
+		{Sku: 3, Count: 2, Warehouse: 2},
+	}, items)
+}
@@ -0,0 +1,107 @@
+package orderrepository
+
+import (
+	"container/heap"
+	"context"
+	"fmt"
+	"route256/loms/internal/pkg/model"
+	"route256/loms/internal/pkg/repository/order_repository/sqlc_order"
+)
+
+// orderSource отдает заказы одного шарда по возрастанию ID.
+type orderSource interface {
+	// next возвращает следующий заказ или false, если заказы закончились.
+	next(ctx context.Context) (model.Order, bool, error)
+}
+
+// shardSource читает шард пачками по batchSize заказов, следующая пачка запрашивается, когда кончилась текущая.
+type shardSource struct {
+	queries   *sqlc_order.Queries
+	afterID   int64
+	batchSize int
+	batch     []model.Order
+	done      bool
+}
+
+func (s *shardSource) next(ctx context.Context) (model.Order, bool, error) {
+	if len(s.batch) == 0 {
+		if s.done {
+			return model.Order{}, false, nil
+		}
+		rows, err := s.queries.ListAfterID(ctx, sqlc_order.ListAfterIDParams{
+			AfterID:  s.afterID,
+			MaxCount: int32(s.batchSize),
+		})
+		if err != nil {
+			return model.Order{}, false, fmt.Errorf("queries.ListAfterID: %w", err)
+		}
+		s.done = len(rows) < s.batchSize
+		if len(rows) == 0 {
+			return model.Order{}, false, nil
+		}
+		if s.batch, err = withItems(ctx, s.queries, rows); err != nil {
+			return model.Order{}, false, err
+		}
+		s.afterID = rows[len(rows)-1].ID
+	}
+	order := s.batch[0]
+	s.batch = s.batch[1:]
+	return order, true, nil
+}
+
+type mergeItem struct {
+	order  model.Order
+	source int
+}
+
+// mergeHeap - min-heap текущих заказов источников по ID.
+type mergeHeap []mergeItem
+
+func (h mergeHeap) Len() int           { return len(h) }
+func (h mergeHeap) Less(i, j int) bool { return h[i].order.ID < h[j].order.ID }
+func (h mergeHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *mergeHeap) Push(x any)        { *h = append(*h, x.(mergeItem)) }
+func (h *mergeHeap) Pop() any {
+	old := *h
+	item := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return item
+}
+
+// mergeByID передает в fn заказы всех источников по возрастанию ID.
+// В памяти держится по одному заказу из каждого источника плюс их пачки.
+// Ошибка fn или отмена ctx останавливает чтение всех источников.
+func mergeByID(ctx context.Context, sources []orderSource, fn func(model.Order) error) error {
+	h := make(mergeHeap, 0, len(sources))
+	for i, source := range sources {
+		order, ok, err := source.next(ctx)
+		if err != nil {
+			return fmt.Errorf("source %d: %w", i, err)
+		}
+		if ok {
+			h = append(h, mergeItem{order: order, source: i})
+		}
+	}
+	heap.Init(&h)
+
+	for h.Len() > 0 {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		item := h[0]
+		if err := fn(item.order); err != nil {
+			return err
+		}
+		order, ok, err := sources[item.source].next(ctx)
+		if err != nil {
+			return fmt.Errorf("source %d: %w", item.source, err)
+		}
+		if ok {
+			h[0].order = order
+			heap.Fix(&h, 0)
+		} else {
+			heap.Pop(&h)
+		}
+	}
+	return nil
+}
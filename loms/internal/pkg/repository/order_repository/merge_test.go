@@ -0,0 +1,74 @@
+package orderrepository
+
+import (
+	"context"
+	"errors"
+	"route256/loms/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// sliceSource - шард в памяти, считает обращения.
+type sliceSource struct {
+	ids   []model.OrderID
+	calls int
+}
+
+func (s *sliceSource) next(context.Context) (model.Order, bool, error) {
+	s.calls++
+	if len(s.ids) == 0 {
+		return model.Order{}, false, nil
+	}
+	order := model.Order{ID: s.ids[0]}
+	s.ids = s.ids[1:]
+	return order, true, nil
+}
+
+func TestMergeByID(t *testing.T) {
+	ctx := context.Background()
+	sources := []orderSource{
+		&sliceSource{ids: []model.OrderID{1000, 3000, 4000}},
+		&sliceSource{},
+		&sliceSource{ids: []model.OrderID{1001, 2001, 5001}},
+	}
+
+	var ids []model.OrderID
+	err := mergeByID(ctx, sources, func(order model.Order) error {
+		ids = append(ids, order.ID)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []model.OrderID{1000, 1001, 2001, 3000, 4000, 5001}, ids)
+}
+
+func TestMergeByIDStops(t *testing.T) {
+	errStop := errors.New("stop")
+	first := &sliceSource{ids: []model.OrderID{1000, 2000, 3000}}
+	second := &sliceSource{ids: []model.OrderID{1001, 2001, 3001}}
+
+	err := mergeByID(context.Background(), []orderSource{first, second}, func(order model.Order) error {
+		if order.ID == 2000 {
+			return errStop
+		}
+		return nil
+	})
+	require.ErrorIs(t, err, errStop)
+	// после ошибки источники больше не читаются
+	assert.Equal(t, 2, first.calls)
+	assert.Equal(t, 2, second.calls)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	first = &sliceSource{ids: []model.OrderID{1000, 2000, 3000}}
+	second = &sliceSource{ids: []model.OrderID{1001, 2001, 3001}}
+	var sent int
+	err = mergeByID(ctx, []orderSource{first, second}, func(order model.Order) error {
+		sent++
+		cancel()
+		return nil
+	})
+	require.ErrorIs(t, err, context.Canceled)
+	assert.Equal(t, 1, sent)
+	assert.Equal(t, 3, first.calls+second.calls)
+}
@@ -0,0 +1,69 @@
+package orderrepository
+
+import (
+	"fmt"
+	"route256/loms/internal/pkg/model"
+	"sort"
+)
+
+// planReturn раскладывает позиции возврата по складам, с которых они были отгружены,
+// с учетом уже возвращенного, и определяет статус заказа после возврата.
+func planReturn(status model.OrderStatus, ordered, returned, requested []model.OrderItem) ([]model.OrderItem, model.OrderStatus, error) {
+	if !status.CanTransitionTo(model.OrderStatusReturned) {
+		return nil, model.OrderStatusNone, fmt.Errorf("%w: %s -> %s", model.ErrInvalidStatusTransition, status, model.OrderStatusReturned)
+	}
+	if len(requested) == 0 {
+		return nil, model.OrderStatusNone, fmt.Errorf("%w: no items", model.ErrInvalidReturn)
+	}
+
+	// сколько еще можно вернуть на каждый склад
+	left := make(map[itemLine]int, len(ordered))
+	lines := make([]itemLine, 0, len(ordered))
+	leftTotal := 0
+	for _, item := range ordered {
+		l := itemLine{item.Sku, item.Warehouse}
+		if _, ok := left[l]; !ok {
+			lines = append(lines, l)
+		}
+		left[l] += int(item.Count)
+		leftTotal += int(item.Count)
+	}
+	for _, item := range returned {
+		left[itemLine{item.Sku, item.Warehouse}] -= int(item.Count)
+		leftTotal -= int(item.Count)
+	}
+	sortLines(lines)
+
+	wanted := make(map[model.ProductSku]int, len(requested))
+	skus := make([]model.ProductSku, 0, len(requested))
+	for _, item := range requested {
+		if _, ok := wanted[item.Sku]; !ok {
+			skus = append(skus, item.Sku)
+		}
+		wanted[item.Sku] += int(item.Count)
+	}
+	sort.Slice(skus, func(i, j int) bool { return skus[i] < skus[j] })
+
+	allocated := make([]model.OrderItem, 0, len(requested))
+	for _, sku := range skus {
+		count := wanted[sku]
+		for _, l := range lines {
+			if l.sku != sku || left[l] <= 0 || count == 0 {
+				continue
+			}
+			n := min(count, left[l])
+			allocated = append(allocated, model.OrderItem{Sku: sku, Count: uint16(n), Warehouse: l.warehouse})
+			left[l] -= n
+			leftTotal -= n
+			count -= n
+		}
+		if count > 0 {
+			return nil, model.OrderStatusNone, fmt.Errorf("%w: sku %d: %d more than left to return", model.ErrInvalidReturn, sku, count)
+		}
+	}
+
+	if leftTotal > 0 {
+		return allocated, model.OrderStatusPartiallyReturned, nil
+	}
+	return allocated, model.OrderStatusReturned, nil
+}
@@ -0,0 +1,94 @@
+package orderrepository
+
+import (
+	"route256/loms/internal/pkg/model"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPlanReturn(t *testing.T) {
+	t.Parallel()
+
+	// sku 1 отгружен с двух складов
+	ordered := []model.OrderItem{
+		{Sku: 1, Count: 2, Warehouse: 2},
+		{Sku: 1, Count: 3, Warehouse: 1},
+		{Sku: 2, Count: 1, Warehouse: 1},
+	}
+	testData := []struct {
+		name      string
+		status    model.OrderStatus
+		returned  []model.OrderItem
+		requested []model.OrderItem
+		allocated []model.OrderItem
+		to        model.OrderStatus
+		err       error
+	}{
+		{
+			name:      "partial",
+			status:    model.OrderStatusPaid,
+			requested: []model.OrderItem{{Sku: 1, Count: 4}},
+			allocated: []model.OrderItem{{Sku: 1, Count: 3, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 2}},
+			to:        model.OrderStatusPartiallyReturned,
+		},
+		{
+			name:      "rest after partial",
+			status:    model.OrderStatusPartiallyReturned,
+			returned:  []model.OrderItem{{Sku: 1, Count: 3, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 2}},
+			requested: []model.OrderItem{{Sku: 2, Count: 1}, {Sku: 1, Count: 1}},
+			allocated: []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 2}, {Sku: 2, Count: 1, Warehouse: 1}},
+			to:        model.OrderStatusReturned,
+		},
+		{
+			name:      "same sku twice",
+			status:    model.OrderStatusPaid,
+			requested: []model.OrderItem{{Sku: 1, Count: 2}, {Sku: 1, Count: 3}, {Sku: 2, Count: 1}},
+			allocated: []model.OrderItem{{Sku: 1, Count: 3, Warehouse: 1}, {Sku: 1, Count: 2, Warehouse: 2}, {Sku: 2, Count: 1, Warehouse: 1}},
+			to:        model.OrderStatusReturned,
+		},
+		{
+			name:      "more than ordered",
+			status:    model.OrderStatusPaid,
+			requested: []model.OrderItem{{Sku: 1, Count: 6}},
+			err:       model.ErrInvalidReturn,
+		},
+		{
+			name:      "already returned",
+			status:    model.OrderStatusPartiallyReturned,
+			returned:  []model.OrderItem{{Sku: 2, Count: 1, Warehouse: 1}},
+			requested: []model.OrderItem{{Sku: 2, Count: 1}},
+			err:       model.ErrInvalidReturn,
+		},
+		{
+			name:      "sku not in order",
+			status:    model.OrderStatusPaid,
+			requested: []model.OrderItem{{Sku: 3, Count: 1}},
+			err:       model.ErrInvalidReturn,
+		},
+		{
+			name:      "not paid",
+			status:    model.OrderStatusAwaitingPayment,
+			requested: []model.OrderItem{{Sku: 1, Count: 1}},
+			err:       model.ErrInvalidStatusTransition,
+		},
+		{
+			name:      "returned",
+			status:    model.OrderStatusReturned,
+			requested: []model.OrderItem{{Sku: 1, Count: 1}},
+			err:       model.ErrInvalidStatusTransition,
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			allocated, to, err := planReturn(tt.status, ordered, tt.returned, tt.requested)
+			if tt.err != nil {
+				assert.ErrorIs(t, err, tt.err)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.allocated, allocated)
+			assert.Equal(t, tt.to, to)
+		})
+	}
+}
@@ -9,19 +9,45 @@ import (
 )
 
 type Order struct {
-	ID        int64
-	UserID    int64
-	Status    string
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
+	ID                   int64
+	UserID               int64
+	Status               string
+	CreatedAt            pgtype.Timestamp
+	UpdatedAt            pgtype.Timestamp
+	RequestID            pgtype.Text
+	AwaitingPaymentSince pgtype.Timestamp
 }
 
 type OrderItem struct {
-	OrderID   int64
-	Sku       int64
-	Count     int32
-	CreatedAt pgtype.Timestamp
-	UpdatedAt pgtype.Timestamp
+	OrderID     int64
+	Sku         int64
+	Count       int32
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	WarehouseID int64
+}
+
+type OrderReturn struct {
+	ID          int64
+	OrderID     int64
+	CreatedAt   pgtype.Timestamp
+	RestockedAt pgtype.Timestamp
+}
+
+type OrderReturnItem struct {
+	ReturnID    int64
+	Sku         int64
+	Count       int32
+	WarehouseID int64
+}
+
+type OrderStatusHistory struct {
+	ID         int64
+	OrderID    int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+	CreatedAt  pgtype.Timestamp
 }
 
 type Outbox struct {
@@ -34,9 +60,41 @@ type Outbox struct {
 }
 
 type Stock struct {
-	Sku        int64
-	TotalCount int32
-	Reserved   int32
-	CreatedAt  pgtype.Timestamp
-	UpdatedAt  pgtype.Timestamp
+	Sku         int64
+	TotalCount  int32
+	Reserved    int32
+	CreatedAt   pgtype.Timestamp
+	UpdatedAt   pgtype.Timestamp
+	WarehouseID int64
+}
+
+type StockHold struct {
+	ID        int64
+	Sku       int64
+	UserID    int64
+	Count     int32
+	ExpiresAt pgtype.Timestamptz
+	CreatedAt pgtype.Timestamp
+}
+
+type StockMovement struct {
+	ID          int64
+	Sku         int64
+	Operation   string
+	Delta       int32
+	TotalCount  int32
+	Reason      string
+	Actor       string
+	OrderID     pgtype.Int8
+	CreatedAt   pgtype.Timestamptz
+	WarehouseID int64
+	ReturnID    pgtype.Int8
+}
+
+type Warehouse struct {
+	ID        int64
+	Name      string
+	Latitude  float64
+	Longitude float64
+	CreatedAt pgtype.Timestamp
 }
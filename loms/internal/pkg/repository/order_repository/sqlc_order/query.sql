@@ -1,28 +1,133 @@
 -- name: Create :one
 INSERT INTO orders
-    (id, user_id, status)
+    (id, user_id, status, request_id)
 VALUES
-    (nextval('order_id_manual_seq') + @shard_id::int, $1, $2)
+    (nextval('order_id_manual_seq') + @shard_id::int, $1, $2, sqlc.narg(request_id))
 RETURNING id;
 
 -- name: AddItem :exec
 INSERT INTO order_items
-    (order_id, sku, count)
+    (order_id, sku, count, warehouse_id)
 VALUES
-    ($1, $2, $3);
+    ($1, $2, $3, $4);
 
--- name: GetById :many
-SELECT sqlc.embed(orders), sqlc.embed(order_items)
+-- name: DeleteItems :exec
+DELETE FROM order_items
+WHERE order_id = $1;
+
+-- name: GetById :one
+SELECT *
+FROM orders
+WHERE orders.id = $1;
+
+-- name: GetByRequestId :one
+SELECT *
 FROM orders
-LEFT JOIN order_items ON orders.id = order_items.order_id
-WHERE id = $1;
+WHERE orders.user_id = @user_id::BIGINT
+  AND orders.request_id = @request_id::TEXT;
 
--- name: SetStatus :exec
+-- name: SetStatus :execrows
 UPDATE orders
-SET status = $2
-WHERE id = $1;
+SET status = @to_status::TEXT, updated_at = now(),
+    -- ставится один раз при первом переходе, откат статуса не перезапускает срок оплаты
+    awaiting_payment_since = CASE
+        WHEN @to_status::TEXT = @awaiting_payment_status::TEXT THEN COALESCE(orders.awaiting_payment_since, now())
+        ELSE orders.awaiting_payment_since
+    END
+WHERE orders.id = @id::BIGINT
+  AND orders.status = @from_status::TEXT;
+
+-- name: AddStatusHistory :exec
+INSERT INTO order_status_history
+    (order_id, from_status, to_status, reason)
+VALUES
+    ($1, $2, $3, $4);
+
+-- name: GetStatusHistory :many
+SELECT *
+FROM order_status_history
+WHERE order_id = $1
+ORDER BY id;
+
+-- name: ListAfterID :many
+SELECT *
+FROM orders
+WHERE orders.id > @after_id::BIGINT
+ORDER BY orders.id
+LIMIT @max_count::INT;
+
+-- name: TryLockShard :one
+SELECT pg_try_advisory_xact_lock(@lock_key::BIGINT)::BOOLEAN AS locked;
+
+-- name: GetUnpaid :many
+SELECT orders.id
+FROM orders
+WHERE orders.status = @status::TEXT
+  AND orders.awaiting_payment_since < now() - make_interval(0, 0, 0, 0, 0, 0, @timeout_seconds::INT)
+ORDER BY orders.awaiting_payment_since
+LIMIT @max_count::INT;
 
--- name: GetAll :many
-SELECT sqlc.embed(orders), sqlc.embed(order_items)
+-- name: ListByUser :many
+SELECT *
 FROM orders
-LEFT JOIN order_items ON orders.id = order_items.order_id;
+WHERE orders.user_id = @user_id::BIGINT
+  AND (cardinality(@statuses::TEXT[]) = 0 OR orders.status = ANY(@statuses::TEXT[]))
+  AND (sqlc.narg('created_from')::TIMESTAMP IS NULL OR orders.created_at >= sqlc.narg('created_from')::TIMESTAMP)
+  AND (sqlc.narg('created_to')::TIMESTAMP IS NULL OR orders.created_at < sqlc.narg('created_to')::TIMESTAMP)
+  AND (sqlc.narg('after_id')::BIGINT IS NULL
+    OR (orders.created_at, orders.id) < (sqlc.narg('after_created_at')::TIMESTAMP, sqlc.narg('after_id')::BIGINT))
+ORDER BY orders.created_at DESC, orders.id DESC
+LIMIT @max_count::INT;
+
+-- name: GetItemsByOrderIds :many
+SELECT *
+FROM order_items
+WHERE order_items.order_id = ANY(@order_ids::BIGINT[])
+ORDER BY order_items.order_id, order_items.sku, order_items.warehouse_id;
+
+-- name: LockOrder :one
+SELECT *
+FROM orders
+WHERE orders.id = $1
+FOR UPDATE;
+
+-- name: AddReturn :one
+INSERT INTO order_returns
+    (order_id)
+VALUES
+    ($1)
+RETURNING id, created_at;
+
+-- name: AddReturnItem :exec
+INSERT INTO order_return_items
+    (return_id, sku, count, warehouse_id)
+VALUES
+    ($1, $2, $3, $4);
+
+-- name: SetReturnRestocked :exec
+UPDATE order_returns
+SET restocked_at = now()
+WHERE order_returns.id = @id::BIGINT
+  AND order_returns.restocked_at IS NULL;
+
+-- name: GetNotRestockedReturns :many
+SELECT order_returns.id, order_returns.order_id, order_returns.created_at
+FROM order_returns
+WHERE order_returns.restocked_at IS NULL
+  AND order_returns.created_at < now() - make_interval(0, 0, 0, 0, 0, 0, @delay_seconds::INT)
+ORDER BY order_returns.id
+LIMIT @max_count::INT;
+
+-- name: GetReturnItems :many
+SELECT order_return_items.sku, order_return_items.count, order_return_items.warehouse_id
+FROM order_return_items
+WHERE order_return_items.return_id = @return_id::BIGINT
+ORDER BY order_return_items.sku, order_return_items.warehouse_id;
+
+-- name: GetReturnedItems :many
+SELECT order_return_items.sku, order_return_items.warehouse_id, sum(order_return_items.count)::INT AS count
+FROM order_returns
+JOIN order_return_items ON order_return_items.return_id = order_returns.id
+WHERE order_returns.order_id = $1
+GROUP BY order_return_items.sku, order_return_items.warehouse_id
+ORDER BY order_return_items.sku, order_return_items.warehouse_id;
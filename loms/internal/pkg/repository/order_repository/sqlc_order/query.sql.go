@@ -7,78 +7,428 @@ package sqlc_order
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
 const addItem = `-- name: AddItem :exec
 INSERT INTO order_items
-    (order_id, sku, count)
+    (order_id, sku, count, warehouse_id)
 VALUES
-    ($1, $2, $3)
+    ($1, $2, $3, $4)
 `
 
 type AddItemParams struct {
-	OrderID int64
-	Sku     int64
-	Count   int32
+	OrderID     int64
+	Sku         int64
+	Count       int32
+	WarehouseID int64
 }
 
 func (q *Queries) AddItem(ctx context.Context, arg AddItemParams) error {
-	_, err := q.db.Exec(ctx, addItem, arg.OrderID, arg.Sku, arg.Count)
+	_, err := q.db.Exec(ctx, addItem,
+		arg.OrderID,
+		arg.Sku,
+		arg.Count,
+		arg.WarehouseID,
+	)
+	return err
+}
+
+const addReturn = `-- name: AddReturn :one
+INSERT INTO order_returns
+    (order_id)
+VALUES
+    ($1)
+RETURNING id, created_at
+`
+
+type AddReturnRow struct {
+	ID        int64
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) AddReturn(ctx context.Context, orderID int64) (AddReturnRow, error) {
+	row := q.db.QueryRow(ctx, addReturn, orderID)
+	var i AddReturnRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const addReturnItem = `-- name: AddReturnItem :exec
+INSERT INTO order_return_items
+    (return_id, sku, count, warehouse_id)
+VALUES
+    ($1, $2, $3, $4)
+`
+
+type AddReturnItemParams struct {
+	ReturnID    int64
+	Sku         int64
+	Count       int32
+	WarehouseID int64
+}
+
+func (q *Queries) AddReturnItem(ctx context.Context, arg AddReturnItemParams) error {
+	_, err := q.db.Exec(ctx, addReturnItem,
+		arg.ReturnID,
+		arg.Sku,
+		arg.Count,
+		arg.WarehouseID,
+	)
+	return err
+}
+
+const addStatusHistory = `-- name: AddStatusHistory :exec
+INSERT INTO order_status_history
+    (order_id, from_status, to_status, reason)
+VALUES
+    ($1, $2, $3, $4)
+`
+
+type AddStatusHistoryParams struct {
+	OrderID    int64
+	FromStatus string
+	ToStatus   string
+	Reason     string
+}
+
+func (q *Queries) AddStatusHistory(ctx context.Context, arg AddStatusHistoryParams) error {
+	_, err := q.db.Exec(ctx, addStatusHistory,
+		arg.OrderID,
+		arg.FromStatus,
+		arg.ToStatus,
+		arg.Reason,
+	)
 	return err
 }
 
 const create = `-- name: Create :one
 INSERT INTO orders
-    (id, user_id, status)
+    (id, user_id, status, request_id)
 VALUES
-    (nextval('order_id_manual_seq') + $3::int, $1, $2)
+    (nextval('order_id_manual_seq') + $3::int, $1, $2, $4)
 RETURNING id
 `
 
 type CreateParams struct {
-	UserID  int64
-	Status  string
-	ShardID int32
+	UserID    int64
+	Status    string
+	ShardID   int32
+	RequestID pgtype.Text
 }
 
 func (q *Queries) Create(ctx context.Context, arg CreateParams) (int64, error) {
-	row := q.db.QueryRow(ctx, create, arg.UserID, arg.Status, arg.ShardID)
+	row := q.db.QueryRow(ctx, create,
+		arg.UserID,
+		arg.Status,
+		arg.ShardID,
+		arg.RequestID,
+	)
 	var id int64
 	err := row.Scan(&id)
 	return id, err
 }
 
-const getAll = `-- name: GetAll :many
-SELECT orders.id, orders.user_id, orders.status, orders.created_at, orders.updated_at, order_items.order_id, order_items.sku, order_items.count, order_items.created_at, order_items.updated_at
+const deleteItems = `-- name: DeleteItems :exec
+DELETE FROM order_items
+WHERE order_id = $1
+`
+
+func (q *Queries) DeleteItems(ctx context.Context, orderID int64) error {
+	_, err := q.db.Exec(ctx, deleteItems, orderID)
+	return err
+}
+
+const getById = `-- name: GetById :one
+SELECT id, user_id, status, created_at, updated_at, request_id, awaiting_payment_since
+FROM orders
+WHERE orders.id = $1
+`
+
+func (q *Queries) GetById(ctx context.Context, id int64) (Order, error) {
+	row := q.db.QueryRow(ctx, getById, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AwaitingPaymentSince,
+	)
+	return i, err
+}
+
+const getByRequestId = `-- name: GetByRequestId :one
+SELECT id, user_id, status, created_at, updated_at, request_id, awaiting_payment_since
+FROM orders
+WHERE orders.user_id = $1::BIGINT
+  AND orders.request_id = $2::TEXT
+`
+
+type GetByRequestIdParams struct {
+	UserID    int64
+	RequestID string
+}
+
+func (q *Queries) GetByRequestId(ctx context.Context, arg GetByRequestIdParams) (Order, error) {
+	row := q.db.QueryRow(ctx, getByRequestId, arg.UserID, arg.RequestID)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AwaitingPaymentSince,
+	)
+	return i, err
+}
+
+const getItemsByOrderIds = `-- name: GetItemsByOrderIds :many
+SELECT order_id, sku, count, created_at, updated_at, warehouse_id
+FROM order_items
+WHERE order_items.order_id = ANY($1::BIGINT[])
+ORDER BY order_items.order_id, order_items.sku, order_items.warehouse_id
+`
+
+func (q *Queries) GetItemsByOrderIds(ctx context.Context, orderIds []int64) ([]OrderItem, error) {
+	rows, err := q.db.Query(ctx, getItemsByOrderIds, orderIds)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderItem
+	for rows.Next() {
+		var i OrderItem
+		if err := rows.Scan(
+			&i.OrderID,
+			&i.Sku,
+			&i.Count,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.WarehouseID,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getNotRestockedReturns = `-- name: GetNotRestockedReturns :many
+SELECT order_returns.id, order_returns.order_id, order_returns.created_at
+FROM order_returns
+WHERE order_returns.restocked_at IS NULL
+  AND order_returns.created_at < now() - make_interval(0, 0, 0, 0, 0, 0, $1::INT)
+ORDER BY order_returns.id
+LIMIT $2::INT
+`
+
+type GetNotRestockedReturnsParams struct {
+	DelaySeconds int32
+	MaxCount     int32
+}
+
+type GetNotRestockedReturnsRow struct {
+	ID        int64
+	OrderID   int64
+	CreatedAt pgtype.Timestamp
+}
+
+func (q *Queries) GetNotRestockedReturns(ctx context.Context, arg GetNotRestockedReturnsParams) ([]GetNotRestockedReturnsRow, error) {
+	rows, err := q.db.Query(ctx, getNotRestockedReturns, arg.DelaySeconds, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetNotRestockedReturnsRow
+	for rows.Next() {
+		var i GetNotRestockedReturnsRow
+		if err := rows.Scan(&i.ID, &i.OrderID, &i.CreatedAt); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReturnItems = `-- name: GetReturnItems :many
+SELECT order_return_items.sku, order_return_items.count, order_return_items.warehouse_id
+FROM order_return_items
+WHERE order_return_items.return_id = $1::BIGINT
+ORDER BY order_return_items.sku, order_return_items.warehouse_id
+`
+
+type GetReturnItemsRow struct {
+	Sku         int64
+	Count       int32
+	WarehouseID int64
+}
+
+func (q *Queries) GetReturnItems(ctx context.Context, returnID int64) ([]GetReturnItemsRow, error) {
+	rows, err := q.db.Query(ctx, getReturnItems, returnID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReturnItemsRow
+	for rows.Next() {
+		var i GetReturnItemsRow
+		if err := rows.Scan(&i.Sku, &i.Count, &i.WarehouseID); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getReturnedItems = `-- name: GetReturnedItems :many
+SELECT order_return_items.sku, order_return_items.warehouse_id, sum(order_return_items.count)::INT AS count
+FROM order_returns
+JOIN order_return_items ON order_return_items.return_id = order_returns.id
+WHERE order_returns.order_id = $1
+GROUP BY order_return_items.sku, order_return_items.warehouse_id
+ORDER BY order_return_items.sku, order_return_items.warehouse_id
+`
+
+type GetReturnedItemsRow struct {
+	Sku         int64
+	WarehouseID int64
+	Count       int32
+}
+
+func (q *Queries) GetReturnedItems(ctx context.Context, orderID int64) ([]GetReturnedItemsRow, error) {
+	rows, err := q.db.Query(ctx, getReturnedItems, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetReturnedItemsRow
+	for rows.Next() {
+		var i GetReturnedItemsRow
+		if err := rows.Scan(&i.Sku, &i.WarehouseID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStatusHistory = `-- name: GetStatusHistory :many
+SELECT id, order_id, from_status, to_status, reason, created_at
+FROM order_status_history
+WHERE order_id = $1
+ORDER BY id
+`
+
+func (q *Queries) GetStatusHistory(ctx context.Context, orderID int64) ([]OrderStatusHistory, error) {
+	rows, err := q.db.Query(ctx, getStatusHistory, orderID)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []OrderStatusHistory
+	for rows.Next() {
+		var i OrderStatusHistory
+		if err := rows.Scan(
+			&i.ID,
+			&i.OrderID,
+			&i.FromStatus,
+			&i.ToStatus,
+			&i.Reason,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUnpaid = `-- name: GetUnpaid :many
+SELECT orders.id
 FROM orders
-LEFT JOIN order_items ON orders.id = order_items.order_id
+WHERE orders.status = $1::TEXT
+  AND orders.awaiting_payment_since < now() - make_interval(0, 0, 0, 0, 0, 0, $2::INT)
+ORDER BY orders.awaiting_payment_since
+LIMIT $3::INT
 `
 
-type GetAllRow struct {
-	Order     Order
-	OrderItem OrderItem
+type GetUnpaidParams struct {
+	Status         string
+	TimeoutSeconds int32
+	MaxCount       int32
 }
 
-func (q *Queries) GetAll(ctx context.Context) ([]GetAllRow, error) {
-	rows, err := q.db.Query(ctx, getAll)
+func (q *Queries) GetUnpaid(ctx context.Context, arg GetUnpaidParams) ([]int64, error) {
+	rows, err := q.db.Query(ctx, getUnpaid, arg.Status, arg.TimeoutSeconds, arg.MaxCount)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetAllRow
+	var items []int64
 	for rows.Next() {
-		var i GetAllRow
+		var id int64
+		if err := rows.Scan(&id); err != nil {
+			return nil, err
+		}
+		items = append(items, id)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const listAfterID = `-- name: ListAfterID :many
+SELECT id, user_id, status, created_at, updated_at, request_id, awaiting_payment_since
+FROM orders
+WHERE orders.id > $1::BIGINT
+ORDER BY orders.id
+LIMIT $2::INT
+`
+
+type ListAfterIDParams struct {
+	AfterID  int64
+	MaxCount int32
+}
+
+func (q *Queries) ListAfterID(ctx context.Context, arg ListAfterIDParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listAfterID, arg.AfterID, arg.MaxCount)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []Order
+	for rows.Next() {
+		var i Order
 		if err := rows.Scan(
-			&i.Order.ID,
-			&i.Order.UserID,
-			&i.Order.Status,
-			&i.Order.CreatedAt,
-			&i.Order.UpdatedAt,
-			&i.OrderItem.OrderID,
-			&i.OrderItem.Sku,
-			&i.OrderItem.Count,
-			&i.OrderItem.CreatedAt,
-			&i.OrderItem.UpdatedAt,
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AwaitingPaymentSince,
 		); err != nil {
 			return nil, err
 		}
@@ -90,38 +440,54 @@ func (q *Queries) GetAll(ctx context.Context) ([]GetAllRow, error) {
 	return items, nil
 }
 
-const getById = `-- name: GetById :many
-SELECT orders.id, orders.user_id, orders.status, orders.created_at, orders.updated_at, order_items.order_id, order_items.sku, order_items.count, order_items.created_at, order_items.updated_at
+const listByUser = `-- name: ListByUser :many
+SELECT id, user_id, status, created_at, updated_at, request_id, awaiting_payment_since
 FROM orders
-LEFT JOIN order_items ON orders.id = order_items.order_id
-WHERE id = $1
+WHERE orders.user_id = $1::BIGINT
+  AND (cardinality($2::TEXT[]) = 0 OR orders.status = ANY($2::TEXT[]))
+  AND ($3::TIMESTAMP IS NULL OR orders.created_at >= $3::TIMESTAMP)
+  AND ($4::TIMESTAMP IS NULL OR orders.created_at < $4::TIMESTAMP)
+  AND ($5::BIGINT IS NULL
+    OR (orders.created_at, orders.id) < ($6::TIMESTAMP, $5::BIGINT))
+ORDER BY orders.created_at DESC, orders.id DESC
+LIMIT $7::INT
 `
 
-type GetByIdRow struct {
-	Order     Order
-	OrderItem OrderItem
+type ListByUserParams struct {
+	UserID         int64
+	Statuses       []string
+	CreatedFrom    pgtype.Timestamp
+	CreatedTo      pgtype.Timestamp
+	AfterID        pgtype.Int8
+	AfterCreatedAt pgtype.Timestamp
+	MaxCount       int32
 }
 
-func (q *Queries) GetById(ctx context.Context, id int64) ([]GetByIdRow, error) {
-	rows, err := q.db.Query(ctx, getById, id)
+func (q *Queries) ListByUser(ctx context.Context, arg ListByUserParams) ([]Order, error) {
+	rows, err := q.db.Query(ctx, listByUser,
+		arg.UserID,
+		arg.Statuses,
+		arg.CreatedFrom,
+		arg.CreatedTo,
+		arg.AfterID,
+		arg.AfterCreatedAt,
+		arg.MaxCount,
+	)
 	if err != nil {
 		return nil, err
 	}
 	defer rows.Close()
-	var items []GetByIdRow
+	var items []Order
 	for rows.Next() {
-		var i GetByIdRow
+		var i Order
 		if err := rows.Scan(
-			&i.Order.ID,
-			&i.Order.UserID,
-			&i.Order.Status,
-			&i.Order.CreatedAt,
-			&i.Order.UpdatedAt,
-			&i.OrderItem.OrderID,
-			&i.OrderItem.Sku,
-			&i.OrderItem.Count,
-			&i.OrderItem.CreatedAt,
-			&i.OrderItem.UpdatedAt,
+			&i.ID,
+			&i.UserID,
+			&i.Status,
+			&i.CreatedAt,
+			&i.UpdatedAt,
+			&i.RequestID,
+			&i.AwaitingPaymentSince,
 		); err != nil {
 			return nil, err
 		}
@@ -133,18 +499,79 @@ func (q *Queries) GetById(ctx context.Context, id int64) ([]GetByIdRow, error) {
 	return items, nil
 }
 
-const setStatus = `-- name: SetStatus :exec
+const lockOrder = `-- name: LockOrder :one
+SELECT id, user_id, status, created_at, updated_at, request_id, awaiting_payment_since
+FROM orders
+WHERE orders.id = $1
+FOR UPDATE
+`
+
+func (q *Queries) LockOrder(ctx context.Context, id int64) (Order, error) {
+	row := q.db.QueryRow(ctx, lockOrder, id)
+	var i Order
+	err := row.Scan(
+		&i.ID,
+		&i.UserID,
+		&i.Status,
+		&i.CreatedAt,
+		&i.UpdatedAt,
+		&i.RequestID,
+		&i.AwaitingPaymentSince,
+	)
+	return i, err
+}
+
+const setReturnRestocked = `-- name: SetReturnRestocked :exec
+UPDATE order_returns
+SET restocked_at = now()
+WHERE order_returns.id = $1::BIGINT
+  AND order_returns.restocked_at IS NULL
+`
+
+func (q *Queries) SetReturnRestocked(ctx context.Context, id int64) error {
+	_, err := q.db.Exec(ctx, setReturnRestocked, id)
+	return err
+}
+
+const setStatus = `-- name: SetStatus :execrows
 UPDATE orders
-SET status = $2
-WHERE id = $1
+SET status = $1::TEXT, updated_at = now(),
+    -- ставится один раз при первом переходе, откат статуса не перезапускает срок оплаты
+    awaiting_payment_since = CASE
+        WHEN $1::TEXT = $2::TEXT THEN COALESCE(orders.awaiting_payment_since, now())
+        ELSE orders.awaiting_payment_since
+    END
+WHERE orders.id = $3::BIGINT
+  AND orders.status = $4::TEXT
 `
 
 type SetStatusParams struct {
-	ID     int64
-	Status string
+	ToStatus              string
+	AwaitingPaymentStatus string
+	ID                    int64
+	FromStatus            string
 }
 
-func (q *Queries) SetStatus(ctx context.Context, arg SetStatusParams) error {
-	_, err := q.db.Exec(ctx, setStatus, arg.ID, arg.Status)
-	return err
+func (q *Queries) SetStatus(ctx context.Context, arg SetStatusParams) (int64, error) {
+	result, err := q.db.Exec(ctx, setStatus,
+		arg.ToStatus,
+		arg.AwaitingPaymentStatus,
+		arg.ID,
+		arg.FromStatus,
+	)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const tryLockShard = `-- name: TryLockShard :one
+SELECT pg_try_advisory_xact_lock($1::BIGINT)::BOOLEAN AS locked
+`
+
+func (q *Queries) TryLockShard(ctx context.Context, lockKey int64) (bool, error) {
+	row := q.db.QueryRow(ctx, tryLockShard, lockKey)
+	var locked bool
+	err := row.Scan(&locked)
+	return locked, err
 }
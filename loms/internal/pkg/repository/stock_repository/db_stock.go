@@ -2,10 +2,13 @@ package stockrepository
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/model"
 	"route256/loms/internal/pkg/repository/stock_repository/sqlc_stock"
 	"route256/loms/pkg/tracing"
+	"time"
 
 	"github.com/jackc/pgx/v5"
 	"github.com/jackc/pgx/v5/pgconn"
@@ -19,52 +22,205 @@ type DB interface {
 }
 
 type DbStockRepository struct {
+	db      DB
 	queries *sqlc_stock.Queries
 }
 
 func NewDbStockRepository(db DB) (*DbStockRepository, error) {
 	return &DbStockRepository{
+		db:      db,
 		queries: sqlc_stock.New(db),
 	}, nil
 }
 
-func (r *DbStockRepository) Reserve(ctx context.Context, sku model.ProductSku, count uint16) (err error) {
+// Reserve выбирает склады по strategy и резервирует все позиции заказа в одной транзакции:
+// либо все, либо ничего. Строки остатка блокируются в порядке sku и склада до выбора складов,
+// поэтому параллельные заказы не продают лишнего и не ловят дедлок.
+// Если задан user, его холды на эти sku снимаются в той же транзакции и переходят в резерв:
+// между снятием холдов и резервом остаток не может забрать другой покупатель.
+func (r *DbStockRepository) Reserve(ctx context.Context, user model.UserID, items []model.OrderItem, strategy allocation.Strategy, delivery *model.Location) (_ []model.OrderItem, err error) {
 	ctx, span := tracing.Start(ctx, "DbStockRepository.Reserve")
 	defer tracing.EndWithCheckError(span, &err)
 
-	stockCount, err := r.GetStocksBySku(ctx, sku)
+	tx, err := r.db.Begin(ctx)
 	if err != nil {
-		return fmt.Errorf("r.GetStocksBySku: %w", err)
+		return nil, fmt.Errorf("r.db.Begin: %w", err)
 	}
-	if stockCount < uint64(count) {
-		return fmt.Errorf("not enough products in stock")
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	skus := make([]int64, 0, len(items))
+	for _, item := range items {
+		skus = append(skus, int64(item.Sku))
 	}
-	return r.queries.Reserve(ctx, sqlc_stock.ReserveParams{
-		Sku:   int64(sku),
-		Count: int32(count),
-	})
+	stock, err := lockAvailable(ctx, qtx, user, skus)
+	if err != nil {
+		return nil, err
+	}
+	warehouses, err := getWarehouses(ctx, qtx)
+	if err != nil {
+		return nil, err
+	}
+	allocated, err := allocation.Allocate(strategy, items, stock, warehouses, delivery)
+	if err != nil {
+		return nil, fmt.Errorf("allocation.Allocate: %w", err)
+	}
+
+	for _, item := range mergeItems(allocated) {
+		reserved, err := qtx.Reserve(ctx, sqlc_stock.ReserveParams{
+			WarehouseID: int64(item.Warehouse),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+		})
+		if err != nil {
+			return nil, fmt.Errorf("qtx.Reserve: %w", err)
+		}
+		if reserved == 0 {
+			return nil, fmt.Errorf("%w: sku %d, warehouse %d", model.ErrNotEnoughStock, item.Sku, item.Warehouse)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return allocated, nil
+}
+
+// lockAvailable блокирует строки остатка skus, снимает холды user на них (если user задан)
+// и возвращает свободный остаток по складам за вычетом оставшихся холдов.
+func lockAvailable(ctx context.Context, qtx *sqlc_stock.Queries, user model.UserID, skus []int64) (allocation.Stock, error) {
+	rows, err := qtx.LockStocksBySkus(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("qtx.LockStocksBySkus: %w", err)
+	}
+	if user != 0 {
+		err := qtx.HoldReleaseByUser(ctx, sqlc_stock.HoldReleaseByUserParams{
+			UserID: int64(user),
+			Skus:   skus,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("qtx.HoldReleaseByUser: %w", err)
+		}
+	}
+	stock := make(allocation.Stock, len(skus))
+	for _, row := range rows {
+		sku := model.ProductSku(row.Sku)
+		if stock[sku] == nil {
+			stock[sku] = make(map[model.WarehouseID]uint64)
+		}
+		stock[sku][model.WarehouseID(row.WarehouseID)] = uint64(max(row.Count, 0))
+	}
+
+	holds, err := getHolds(ctx, qtx, skus)
+	if err != nil {
+		return nil, err
+	}
+	deductHolds(stock, holds)
+	return stock, nil
+}
+
+func getHolds(ctx context.Context, queries *sqlc_stock.Queries, skus []int64) (map[model.ProductSku]uint64, error) {
+	rows, err := queries.GetHoldsBySkus(ctx, skus)
+	if err != nil {
+		return nil, fmt.Errorf("queries.GetHoldsBySkus: %w", err)
+	}
+	holds := make(map[model.ProductSku]uint64, len(rows))
+	for _, row := range rows {
+		holds[model.ProductSku(row.Sku)] = uint64(row.Count)
+	}
+	return holds, nil
 }
 
-func (r *DbStockRepository) ReserveRemove(ctx context.Context, sku model.ProductSku, count uint16) (err error) {
+func getWarehouses(ctx context.Context, queries *sqlc_stock.Queries) ([]model.Warehouse, error) {
+	rows, err := queries.GetWarehouses(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("queries.GetWarehouses: %w", err)
+	}
+	warehouses := make([]model.Warehouse, 0, len(rows))
+	for _, row := range rows {
+		warehouses = append(warehouses, model.Warehouse{
+			ID:   model.WarehouseID(row.ID),
+			Name: row.Name,
+			Location: model.Location{
+				Latitude:  row.Latitude,
+				Longitude: row.Longitude,
+			},
+		})
+	}
+	return warehouses, nil
+}
+
+// ReserveRemove списывает резерв оплаченного заказа со складов позиций в одной транзакции.
+func (r *DbStockRepository) ReserveRemove(ctx context.Context, items []model.OrderItem) (err error) {
 	ctx, span := tracing.Start(ctx, "DbStockRepository.ReserveRemove")
 	defer tracing.EndWithCheckError(span, &err)
 
-	return r.queries.ReserveRemove(ctx, sqlc_stock.ReserveRemoveParams{
-		Sku:   int64(sku),
-		Count: int32(count),
+	return r.updateReserved(ctx, items, func(qtx *sqlc_stock.Queries, item mergedItem) (int64, error) {
+		return qtx.ReserveRemove(ctx, sqlc_stock.ReserveRemoveParams{
+			WarehouseID: int64(item.Warehouse),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+		})
 	})
 }
 
-func (r *DbStockRepository) ReserveCancel(ctx context.Context, sku model.ProductSku, count uint16) (err error) {
+// ReserveCancel возвращает резерв отмененного заказа на склады позиций в одной транзакции.
+func (r *DbStockRepository) ReserveCancel(ctx context.Context, items []model.OrderItem) (err error) {
 	ctx, span := tracing.Start(ctx, "DbStockRepository.ReserveCancel")
 	defer tracing.EndWithCheckError(span, &err)
 
-	return r.queries.ReserveCancel(ctx, sqlc_stock.ReserveCancelParams{
-		Sku:   int64(sku),
-		Count: int32(count),
+	return r.updateReserved(ctx, items, func(qtx *sqlc_stock.Queries, item mergedItem) (int64, error) {
+		return qtx.ReserveCancel(ctx, sqlc_stock.ReserveCancelParams{
+			WarehouseID: int64(item.Warehouse),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+		})
 	})
 }
 
+// ReserveAt резервирует позиции на указанных в них складах, без выбора складов и холдов.
+// Нужен, чтобы вернуть резерв, освобожденный изменением заказа, которое затем не сохранилось.
+func (r *DbStockRepository) ReserveAt(ctx context.Context, items []model.OrderItem) (err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.ReserveAt")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return r.updateReserved(ctx, items, func(qtx *sqlc_stock.Queries, item mergedItem) (int64, error) {
+		reserved, err := qtx.Reserve(ctx, sqlc_stock.ReserveParams{
+			WarehouseID: int64(item.Warehouse),
+			Sku:         int64(item.Sku),
+			Count:       int32(item.Count),
+		})
+		if err == nil && reserved == 0 {
+			return 0, fmt.Errorf("%w: sku %d, warehouse %d", model.ErrNotEnoughStock, item.Sku, item.Warehouse)
+		}
+		return reserved, err
+	})
+}
+
+func (r *DbStockRepository) updateReserved(ctx context.Context, items []model.OrderItem, update func(*sqlc_stock.Queries, mergedItem) (int64, error)) error {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	for _, item := range mergeItems(items) {
+		updated, err := update(qtx, item)
+		if err != nil {
+			return fmt.Errorf("update reserved: %w", err)
+		}
+		if updated == 0 {
+			return fmt.Errorf("not enough reserved products: sku %d, warehouse %d", item.Sku, item.Warehouse)
+		}
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return fmt.Errorf("tx.Commit: %w", err)
+	}
+	return nil
+}
+
 func (r *DbStockRepository) GetStocksBySku(ctx context.Context, sku model.ProductSku) (_ uint64, err error) {
 	ctx, span := tracing.Start(ctx, "DbStockRepository.GetStocksBySku")
 	defer tracing.EndWithCheckError(span, &err)
@@ -75,3 +231,142 @@ func (r *DbStockRepository) GetStocksBySku(ctx context.Context, sku model.Produc
 	}
 	return uint64(count), nil
 }
+
+// GetStocksBySkus возвращает остатки одним запросом, неизвестных sku в результате нет.
+func (r *DbStockRepository) GetStocksBySkus(ctx context.Context, skus []model.ProductSku) (_ map[model.ProductSku]uint64, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.GetStocksBySkus")
+	defer tracing.EndWithCheckError(span, &err)
+
+	params := make([]int64, 0, len(skus))
+	for _, sku := range skus {
+		params = append(params, int64(sku))
+	}
+	rows, err := r.queries.GetStocksBySkus(ctx, params)
+	if err != nil {
+		return nil, fmt.Errorf("r.queries.GetStocksBySkus: %w", err)
+	}
+	stocks := make(map[model.ProductSku]uint64, len(rows))
+	for _, row := range rows {
+		stocks[model.ProductSku(row.Sku)] = uint64(row.Count)
+	}
+	return stocks, nil
+}
+
+// GetStocksByWarehouse возвращает свободный остаток sku по складам, сумма совпадает с GetStocksBySku.
+func (r *DbStockRepository) GetStocksByWarehouse(ctx context.Context, sku model.ProductSku) (_ []model.WarehouseStock, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.GetStocksByWarehouse")
+	defer tracing.EndWithCheckError(span, &err)
+
+	rows, err := r.queries.GetStocksByWarehouse(ctx, int64(sku))
+	if err != nil {
+		return nil, fmt.Errorf("r.queries.GetStocksByWarehouse: %w", err)
+	}
+	if len(rows) == 0 {
+		return nil, fmt.Errorf("%w: sku %d", model.ErrStockNotFound, sku)
+	}
+	stock := allocation.Stock{sku: make(map[model.WarehouseID]uint64, len(rows))}
+	for _, row := range rows {
+		stock[sku][model.WarehouseID(row.WarehouseID)] = uint64(max(row.Count, 0))
+	}
+
+	holds, err := getHolds(ctx, r.queries, []int64{int64(sku)})
+	if err != nil {
+		return nil, err
+	}
+	deductHolds(stock, holds)
+
+	stocks := make([]model.WarehouseStock, 0, len(rows))
+	for _, row := range rows {
+		warehouse := model.WarehouseID(row.WarehouseID)
+		stocks = append(stocks, model.WarehouseStock{Warehouse: warehouse, Count: stock[sku][warehouse]})
+	}
+	return stocks, nil
+}
+
+// HoldCreate создает или обновляет холд пользователя на sku.
+// Холды самого пользователя при проверке остатка не учитываются.
+func (r *DbStockRepository) HoldCreate(ctx context.Context, user model.UserID, sku model.ProductSku, count uint16, ttl time.Duration) (_ model.StockHold, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.HoldCreate")
+	defer tracing.EndWithCheckError(span, &err)
+
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return model.StockHold{}, fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+
+	// холды читаются после блокировки строк остатка, чтобы параллельные HoldCreate/Reserve их видели
+	rows, err := qtx.LockStocksBySkus(ctx, []int64{int64(sku)})
+	if err != nil {
+		return model.StockHold{}, fmt.Errorf("qtx.LockStocksBySkus: %w", err)
+	}
+	var available int64
+	for _, row := range rows {
+		available += int64(max(row.Count, 0))
+	}
+	holds, err := getHolds(ctx, qtx, []int64{int64(sku)})
+	if err != nil {
+		return model.StockHold{}, err
+	}
+	own, err := qtx.GetUserHoldCount(ctx, sqlc_stock.GetUserHoldCountParams{
+		UserID: int64(user),
+		Sku:    int64(sku),
+	})
+	if err != nil {
+		return model.StockHold{}, fmt.Errorf("qtx.GetUserHoldCount: %w", err)
+	}
+	if available-int64(holds[sku])+int64(own) < int64(count) {
+		return model.StockHold{}, model.ErrNotEnoughStock
+	}
+
+	row, err := qtx.HoldCreate(ctx, sqlc_stock.HoldCreateParams{
+		Sku:        int64(sku),
+		UserID:     int64(user),
+		Count:      int32(count),
+		TtlSeconds: int32(ttl.Seconds()),
+	})
+	if err != nil {
+		return model.StockHold{}, fmt.Errorf("qtx.HoldCreate: %w", err)
+	}
+	if err := tx.Commit(ctx); err != nil {
+		return model.StockHold{}, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return model.StockHold{
+		ID:        model.HoldID(row.ID),
+		User:      user,
+		Sku:       sku,
+		Count:     count,
+		ExpiresAt: row.ExpiresAt.Time,
+	}, nil
+}
+
+// HoldExtend продлевает холд id, если он принадлежит user.
+func (r *DbStockRepository) HoldExtend(ctx context.Context, user model.UserID, id model.HoldID, ttl time.Duration) (_ time.Time, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.HoldExtend")
+	defer tracing.EndWithCheckError(span, &err)
+
+	expiresAt, err := r.queries.HoldExtend(ctx, sqlc_stock.HoldExtendParams{
+		TtlSeconds: int32(ttl.Seconds()),
+		ID:         int64(id),
+		UserID:     int64(user),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return time.Time{}, model.ErrHoldNotFound
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("r.queries.HoldExtend: %w", err)
+	}
+	return expiresAt.Time, nil
+}
+
+// HoldRelease снимает холд id, если он принадлежит user.
+func (r *DbStockRepository) HoldRelease(ctx context.Context, user model.UserID, id model.HoldID) (err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.HoldRelease")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return r.queries.HoldRelease(ctx, sqlc_stock.HoldReleaseParams{
+		ID:     int64(id),
+		UserID: int64(user),
+	})
+}
@@ -0,0 +1,205 @@
+package stockrepository
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math"
+	"route256/loms/internal/pkg/model"
+	"route256/loms/internal/pkg/repository"
+	"route256/loms/internal/pkg/repository/stock_repository/sqlc_stock"
+	"route256/loms/pkg/tracing"
+
+	"github.com/jackc/pgx/v5"
+	"github.com/jackc/pgx/v5/pgconn"
+	"github.com/jackc/pgx/v5/pgtype"
+)
+
+const (
+	// foreignKeyViolation - код ошибки postgres при вставке остатка на несуществующий склад
+	foreignKeyViolation = "23503"
+	// uniqueViolation - код ошибки postgres при повторном движении по тому же возврату
+	uniqueViolation = "23505"
+)
+
+// errReturnRestocked - возврат уже пополнил остатки
+var errReturnRestocked = errors.New("order return already restocked")
+
+// ChangeStock меняет total_count и пишет движение в stock_movements в одной транзакции.
+// inTx выполняются в той же транзакции, например для записи события в outbox.
+func (r *DbStockRepository) ChangeStock(ctx context.Context, change model.StockChange, inTx ...func(context.Context, model.StockMovement) error) (_ model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.ChangeStock")
+	defer tracing.EndWithCheckError(span, &err)
+
+	movements, err := r.changeStocks(ctx, []model.StockChange{change}, inTx)
+	if err != nil {
+		return model.StockMovement{}, err
+	}
+	if len(movements) == 0 {
+		// повтор по уже пополнившему остатки возврату
+		return model.StockMovement{}, nil
+	}
+	return movements[0], nil
+}
+
+// ChangeStocks применяет все изменения в одной транзакции: либо все, либо ни одного.
+// inTx выполняются для каждого движения. Повтор изменений с тем же ReturnID
+// ничего не меняет и возвращает пустой список движений.
+func (r *DbStockRepository) ChangeStocks(ctx context.Context, changes []model.StockChange, inTx ...func(context.Context, model.StockMovement) error) (_ []model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.ChangeStocks")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return r.changeStocks(ctx, changes, inTx)
+}
+
+func (r *DbStockRepository) changeStocks(ctx context.Context, changes []model.StockChange, inTx []func(context.Context, model.StockMovement) error) ([]model.StockMovement, error) {
+	tx, err := r.db.Begin(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("r.db.Begin: %w", err)
+	}
+	defer tx.Rollback(ctx)
+	qtx := r.queries.WithTx(tx)
+	ctxTx := context.WithValue(ctx, repository.CtxTxKey{}, tx)
+
+	movements := make([]model.StockMovement, 0, len(changes))
+	for _, change := range changes {
+		movement, err := changeStock(ctx, qtx, change)
+		if errors.Is(err, errReturnRestocked) {
+			return nil, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for _, f := range inTx {
+			if err := f(ctxTx, movement); err != nil {
+				return nil, fmt.Errorf("inTx: %w", err)
+			}
+		}
+		movements = append(movements, movement)
+	}
+
+	if err := tx.Commit(ctx); err != nil {
+		return nil, fmt.Errorf("tx.Commit: %w", err)
+	}
+	return movements, nil
+}
+
+func changeStock(ctx context.Context, qtx *sqlc_stock.Queries, change model.StockChange) (model.StockMovement, error) {
+	// add, set и return могут завести новый sku, adjust - только существующий
+	if change.Operation != model.StockOperationAdjust {
+		err := qtx.EnsureStock(ctx, sqlc_stock.EnsureStockParams{
+			WarehouseID: int64(change.Warehouse),
+			Sku:         int64(change.Sku),
+		})
+		var pgErr *pgconn.PgError
+		if errors.As(err, &pgErr) && pgErr.Code == foreignKeyViolation {
+			return model.StockMovement{}, fmt.Errorf("%w: %d", model.ErrWarehouseNotFound, change.Warehouse)
+		}
+		if err != nil {
+			return model.StockMovement{}, fmt.Errorf("qtx.EnsureStock: %w", err)
+		}
+	}
+	stock, err := qtx.LockStock(ctx, sqlc_stock.LockStockParams{
+		WarehouseID: int64(change.Warehouse),
+		Sku:         int64(change.Sku),
+	})
+	if errors.Is(err, pgx.ErrNoRows) {
+		return model.StockMovement{}, fmt.Errorf("%w: sku %d, warehouse %d", model.ErrStockNotFound, change.Sku, change.Warehouse)
+	}
+	if err != nil {
+		return model.StockMovement{}, fmt.Errorf("qtx.LockStock: %w", err)
+	}
+
+	totalCount, err := newTotalCount(change, int64(stock.TotalCount), int64(stock.Reserved))
+	if err != nil {
+		return model.StockMovement{}, err
+	}
+	if err := qtx.SetTotalCount(ctx, sqlc_stock.SetTotalCountParams{
+		WarehouseID: int64(change.Warehouse),
+		Sku:         int64(change.Sku),
+		TotalCount:  int32(totalCount),
+	}); err != nil {
+		return model.StockMovement{}, fmt.Errorf("qtx.SetTotalCount: %w", err)
+	}
+
+	movement := model.StockMovement{
+		Warehouse:  change.Warehouse,
+		Sku:        change.Sku,
+		Operation:  change.Operation,
+		Delta:      totalCount - int64(stock.TotalCount),
+		TotalCount: uint64(totalCount),
+		Reason:     change.Reason,
+		Actor:      change.Actor,
+		OrderID:    change.OrderID,
+	}
+	row, err := qtx.AddMovement(ctx, sqlc_stock.AddMovementParams{
+		WarehouseID: int64(movement.Warehouse),
+		Sku:         int64(movement.Sku),
+		Operation:   string(movement.Operation),
+		Delta:       int32(movement.Delta),
+		TotalCount:  int32(movement.TotalCount),
+		Reason:      movement.Reason,
+		Actor:       movement.Actor,
+		OrderID:     pgtype.Int8{Int64: int64(movement.OrderID), Valid: movement.OrderID != 0},
+		ReturnID:    pgtype.Int8{Int64: int64(change.ReturnID), Valid: change.ReturnID != 0},
+	})
+	var pgErr *pgconn.PgError
+	if errors.As(err, &pgErr) && pgErr.Code == uniqueViolation {
+		return model.StockMovement{}, fmt.Errorf("%w: order %d, return %d", errReturnRestocked, change.OrderID, change.ReturnID)
+	}
+	if err != nil {
+		return model.StockMovement{}, fmt.Errorf("qtx.AddMovement: %w", err)
+	}
+	movement.ID = model.StockMovementID(row.ID)
+	movement.CreatedAt = row.CreatedAt.Time
+	return movement, nil
+}
+
+func newTotalCount(change model.StockChange, totalCount, reserved int64) (int64, error) {
+	switch change.Operation {
+	case model.StockOperationAdd, model.StockOperationAdjust, model.StockOperationReturn:
+		totalCount += change.Count
+	case model.StockOperationSet:
+		totalCount = change.Count
+	default:
+		return 0, fmt.Errorf("unknown stock operation: %q", change.Operation)
+	}
+	if totalCount < reserved {
+		return 0, fmt.Errorf("%w: sku %d, total %d, reserved %d", model.ErrStockBelowReserved, change.Sku, totalCount, reserved)
+	}
+	if totalCount > math.MaxInt32 {
+		return 0, fmt.Errorf("total count is too large: sku %d, total %d", change.Sku, totalCount)
+	}
+	return totalCount, nil
+}
+
+// GetMovements возвращает движения по sku от новых к старым, beforeID - курсор предыдущей страницы.
+func (r *DbStockRepository) GetMovements(ctx context.Context, sku model.ProductSku, beforeID model.StockMovementID, limit int) (_ []model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "DbStockRepository.GetMovements")
+	defer tracing.EndWithCheckError(span, &err)
+
+	rows, err := r.queries.GetMovements(ctx, sqlc_stock.GetMovementsParams{
+		Sku:      int64(sku),
+		BeforeID: int64(beforeID),
+		PageSize: int32(limit),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("r.queries.GetMovements: %w", err)
+	}
+	movements := make([]model.StockMovement, 0, len(rows))
+	for _, row := range rows {
+		movements = append(movements, model.StockMovement{
+			ID:         model.StockMovementID(row.ID),
+			Warehouse:  model.WarehouseID(row.WarehouseID),
+			Sku:        model.ProductSku(row.Sku),
+			Operation:  model.StockOperation(row.Operation),
+			Delta:      int64(row.Delta),
+			TotalCount: uint64(row.TotalCount),
+			Reason:     row.Reason,
+			Actor:      row.Actor,
+			OrderID:    model.OrderID(row.OrderID.Int64),
+			CreatedAt:  row.CreatedAt.Time,
+		})
+	}
+	return movements, nil
+}
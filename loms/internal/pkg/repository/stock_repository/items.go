@@ -0,0 +1,57 @@
+package stockrepository
+
+import (
+	"route256/loms/internal/pkg/allocation"
+	"route256/loms/internal/pkg/model"
+	"sort"
+)
+
+type mergedItem struct {
+	Warehouse model.WarehouseID
+	Sku       model.ProductSku
+	Count     uint64
+}
+
+// mergeItems складывает позиции с одинаковыми sku и складом и сортирует их по sku и складу,
+// чтобы все транзакции брали блокировки строк в одном порядке.
+func mergeItems(items []model.OrderItem) []mergedItem {
+	type key struct {
+		warehouse model.WarehouseID
+		sku       model.ProductSku
+	}
+	counts := make(map[key]uint64, len(items))
+	for _, item := range items {
+		counts[key{item.Warehouse, item.Sku}] += uint64(item.Count)
+	}
+	merged := make([]mergedItem, 0, len(counts))
+	for k, count := range counts {
+		merged = append(merged, mergedItem{Warehouse: k.warehouse, Sku: k.sku, Count: count})
+	}
+	sort.Slice(merged, func(i, j int) bool {
+		if merged[i].Sku != merged[j].Sku {
+			return merged[i].Sku < merged[j].Sku
+		}
+		return merged[i].Warehouse < merged[j].Warehouse
+	})
+	return merged
+}
+
+// deductHolds вычитает холды из остатка. Холды не привязаны к складу,
+// поэтому списываются с конца - со складов с большим id.
+func deductHolds(stock allocation.Stock, holds map[model.ProductSku]uint64) {
+	for sku, held := range holds {
+		warehouses := make([]model.WarehouseID, 0, len(stock[sku]))
+		for warehouse := range stock[sku] {
+			warehouses = append(warehouses, warehouse)
+		}
+		sort.Slice(warehouses, func(i, j int) bool { return warehouses[i] > warehouses[j] })
+		for _, warehouse := range warehouses {
+			if held == 0 {
+				break
+			}
+			take := min(held, stock[sku][warehouse])
+			stock[sku][warehouse] -= take
+			held -= take
+		}
+	}
+}
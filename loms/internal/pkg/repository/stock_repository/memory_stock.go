@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"route256/loms/internal/pkg/model"
+	"sync"
 )
 
 //go:embed stock-data.json
@@ -17,6 +18,7 @@ type storage map[model.ProductSku]struct {
 }
 
 type stockMemoryRepository struct {
+	mx      sync.Mutex
 	storage storage
 }
 
@@ -32,20 +34,33 @@ func NewStockMemoryRepository() (*stockMemoryRepository, error) {
 	}, nil
 }
 
-func (r *stockMemoryRepository) Reserve(_ context.Context, sku model.ProductSku, count uint16) error {
-	product, ok := r.storage[sku]
-	if !ok {
-		return fmt.Errorf("invalid sku: %d", sku)
+// Reserve резервирует все позиции или ни одной.
+func (r *stockMemoryRepository) Reserve(_ context.Context, items []model.OrderItem) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	merged := mergeItems(items)
+	for _, item := range merged {
+		product, ok := r.storage[item.Sku]
+		if !ok {
+			return fmt.Errorf("invalid sku: %d", item.Sku)
+		}
+		if product.TotalCount-product.Reserved < item.Count {
+			return fmt.Errorf("%w: sku %d", model.ErrNotEnoughStock, item.Sku)
+		}
 	}
-	if product.TotalCount-product.Reserved < uint64(count) {
-		return fmt.Errorf("not enough products by sku: %d", sku)
+	for _, item := range merged {
+		product := r.storage[item.Sku]
+		product.Reserved += item.Count
+		r.storage[item.Sku] = product
 	}
-	product.Reserved += uint64(count)
-	r.storage[sku] = product
 	return nil
 }
 
 func (r *stockMemoryRepository) ReserveRemove(_ context.Context, sku model.ProductSku, count uint16) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
 	product, ok := r.storage[sku]
 	if !ok {
 		return fmt.Errorf("invalid sku: %d", sku)
@@ -60,6 +75,9 @@ func (r *stockMemoryRepository) ReserveRemove(_ context.Context, sku model.Produ
 }
 
 func (r *stockMemoryRepository) ReserveCancel(_ context.Context, sku model.ProductSku, count uint16) error {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
 	product, ok := r.storage[sku]
 	if !ok {
 		return fmt.Errorf("invalid sku: %d", sku)
@@ -73,9 +91,25 @@ func (r *stockMemoryRepository) ReserveCancel(_ context.Context, sku model.Produ
 }
 
 func (r *stockMemoryRepository) GetStocksBySku(_ context.Context, sku model.ProductSku) (uint64, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
 	product, ok := r.storage[sku]
 	if !ok {
 		return 0, nil
 	}
 	return product.TotalCount - product.Reserved, nil
 }
+
+func (r *stockMemoryRepository) GetStocksBySkus(_ context.Context, skus []model.ProductSku) (map[model.ProductSku]uint64, error) {
+	r.mx.Lock()
+	defer r.mx.Unlock()
+
+	stocks := make(map[model.ProductSku]uint64, len(skus))
+	for _, sku := range skus {
+		if product, ok := r.storage[sku]; ok {
+			stocks[sku] = product.TotalCount - product.Reserved
+		}
+	}
+	return stocks, nil
+}
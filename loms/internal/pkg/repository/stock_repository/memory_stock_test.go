@@ -0,0 +1,123 @@
+package stockrepository
+
+import (
+	"context"
+	"route256/loms/internal/pkg/model"
+	"sync"
+	"sync/atomic"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMergeItems(t *testing.T) {
+	t.Parallel()
+
+	merged := mergeItems([]model.OrderItem{
+		{Sku: 3, Count: 1},
+		{Sku: 1, Count: 2},
+		{Sku: 3, Count: 65535},
+	})
+	assert.Equal(t, []mergedItem{
+		{Sku: 1, Count: 2},
+		{Sku: 3, Count: 65536},
+	}, merged)
+}
+
+func TestMemoryReserveAllOrNothing(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, err := NewStockMemoryRepository()
+	require.NoError(t, err)
+
+	err = repo.Reserve(ctx, []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 3, Count: 3}})
+	assert.ErrorIs(t, err, model.ErrNotEnoughStock)
+
+	stocks, err := repo.GetStocksBySkus(ctx, []model.ProductSku{1, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[model.ProductSku]uint64{1: 4, 3: 2}, stocks)
+}
+
+func TestMemoryReserveConcurrent(t *testing.T) {
+	t.Parallel()
+
+	ctx := context.Background()
+	repo, err := NewStockMemoryRepository()
+	require.NoError(t, err)
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int32
+	)
+	for i := 0; i < 50; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if repo.Reserve(ctx, []model.OrderItem{{Sku: 3, Count: 1}, {Sku: 2, Count: 1}}) == nil {
+				reserved.Add(1)
+			}
+		}()
+	}
+	wg.Wait()
+
+	assert.Equal(t, int32(2), reserved.Load())
+	stocks, err := repo.GetStocksBySkus(ctx, []model.ProductSku{2, 3})
+	require.NoError(t, err)
+	assert.Equal(t, map[model.ProductSku]uint64{2: 1, 3: 0}, stocks)
+}
+
+func TestNewTotalCount(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name    string
+		change  model.StockChange
+		want    int64
+		wantErr error
+	}{
+		{
+			name:   "add",
+			change: model.StockChange{Operation: model.StockOperationAdd, Count: 5},
+			want:   15,
+		},
+		{
+			name:   "adjust down to reserved",
+			change: model.StockChange{Operation: model.StockOperationAdjust, Count: -7},
+			want:   3,
+		},
+		{
+			name:    "adjust below reserved",
+			change:  model.StockChange{Operation: model.StockOperationAdjust, Count: -8},
+			wantErr: model.ErrStockBelowReserved,
+		},
+		{
+			name:   "return",
+			change: model.StockChange{Operation: model.StockOperationReturn, Count: 2},
+			want:   12,
+		},
+		{
+			name:   "set",
+			change: model.StockChange{Operation: model.StockOperationSet, Count: 4},
+			want:   4,
+		},
+		{
+			name:    "set below reserved",
+			change:  model.StockChange{Operation: model.StockOperationSet, Count: 2},
+			wantErr: model.ErrStockBelowReserved,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			got, err := newTotalCount(tt.change, 10, 3)
+			if tt.wantErr != nil {
+				assert.ErrorIs(t, err, tt.wantErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tt.want, got)
+		})
+	}
+}
@@ -1,22 +1,133 @@
--- name: Reserve :exec
+-- name: LockStocksBySkus :many
+SELECT stock.warehouse_id, stock.sku, stock.total_count - stock.reserved AS count
+FROM stock
+WHERE stock.sku = ANY(@skus::BIGINT[])
+ORDER BY stock.sku, stock.warehouse_id
+FOR UPDATE;
+
+-- name: GetHoldsBySkus :many
+SELECT stock_holds.sku, SUM(stock_holds.count)::INT AS count
+FROM stock_holds
+WHERE stock_holds.sku = ANY(@skus::BIGINT[]) AND stock_holds.expires_at > NOW()
+GROUP BY stock_holds.sku;
+
+-- name: GetWarehouses :many
+SELECT id, name, latitude, longitude
+FROM warehouses
+ORDER BY id;
+
+-- name: Reserve :execrows
 UPDATE stock
-SET reserved = reserved + @count
-WHERE sku = $1;
+SET reserved = stock.reserved + @count::INT
+WHERE stock.warehouse_id = @warehouse_id::BIGINT
+    AND stock.sku = @sku::BIGINT
+    AND stock.total_count - stock.reserved >= @count::INT;
 
--- name: ReserveRemove :exec
+-- name: ReserveRemove :execrows
 UPDATE stock
 SET
-    total_count = total_count - @count,
-    reserved = reserved - @count
-WHERE sku = $1;
+    total_count = stock.total_count - @count::INT,
+    reserved = stock.reserved - @count::INT
+WHERE stock.warehouse_id = @warehouse_id::BIGINT
+    AND stock.sku = @sku::BIGINT
+    AND stock.reserved >= @count::INT;
 
--- name: ReserveCancel :exec
+-- name: ReserveCancel :execrows
 UPDATE stock
-SET reserved = reserved - @count
-WHERE sku = $1;
+SET reserved = stock.reserved - @count::INT
+WHERE stock.warehouse_id = @warehouse_id::BIGINT
+    AND stock.sku = @sku::BIGINT
+    AND stock.reserved >= @count::INT;
 
 -- name: GetStocksBySku :one
-SELECT total_count - reserved AS count
+SELECT GREATEST(SUM(stock.total_count - stock.reserved) - COALESCE((
+        SELECT SUM(stock_holds.count)
+        FROM stock_holds
+        WHERE stock_holds.sku = @sku::BIGINT AND stock_holds.expires_at > NOW()
+    ), 0), 0)::INT AS count
+FROM stock
+WHERE stock.sku = @sku::BIGINT
+GROUP BY stock.sku;
+
+-- name: GetStocksBySkus :many
+SELECT stock.sku, GREATEST(SUM(stock.total_count - stock.reserved) - COALESCE((
+        SELECT SUM(stock_holds.count)
+        FROM stock_holds
+        WHERE stock_holds.sku = stock.sku AND stock_holds.expires_at > NOW()
+    ), 0), 0)::INT AS count
+FROM stock
+WHERE stock.sku = ANY(@skus::BIGINT[])
+GROUP BY stock.sku;
+
+-- name: GetStocksByWarehouse :many
+SELECT stock.warehouse_id, stock.total_count - stock.reserved AS count
+FROM stock
+WHERE stock.sku = @sku::BIGINT
+ORDER BY stock.warehouse_id;
+
+-- name: GetUserHoldCount :one
+SELECT COALESCE(SUM(stock_holds.count), 0)::INT AS count
+FROM stock_holds
+WHERE stock_holds.user_id = @user_id::BIGINT
+    AND stock_holds.sku = @sku::BIGINT
+    AND stock_holds.expires_at > NOW();
+
+-- name: HoldCreate :one
+INSERT INTO stock_holds
+    (sku, user_id, count, expires_at)
+VALUES
+    (@sku::BIGINT, @user_id::BIGINT, @count::INT, NOW() + make_interval(secs => @ttl_seconds::INT))
+ON CONFLICT (user_id, sku) DO UPDATE
+SET
+    count = EXCLUDED.count,
+    expires_at = EXCLUDED.expires_at
+RETURNING id, expires_at;
+
+-- name: HoldExtend :one
+UPDATE stock_holds
+SET expires_at = NOW() + make_interval(secs => @ttl_seconds::INT)
+WHERE id = @id AND user_id = @user_id AND expires_at > NOW()
+RETURNING expires_at;
+
+-- name: HoldRelease :exec
+DELETE FROM stock_holds
+WHERE id = @id AND user_id = @user_id;
+
+-- name: HoldReleaseByUser :exec
+DELETE FROM stock_holds
+WHERE user_id = @user_id AND sku = ANY(@skus::BIGINT[]);
+
+-- name: LockStock :one
+SELECT stock.total_count, stock.reserved
 FROM stock
-WHERE sku = $1
-LIMIT 1;
+WHERE stock.warehouse_id = @warehouse_id::BIGINT AND stock.sku = @sku::BIGINT
+FOR UPDATE;
+
+-- name: EnsureStock :exec
+INSERT INTO stock
+    (warehouse_id, sku)
+VALUES
+    (@warehouse_id::BIGINT, @sku::BIGINT)
+ON CONFLICT (warehouse_id, sku) DO NOTHING;
+
+-- name: SetTotalCount :exec
+UPDATE stock
+SET
+    total_count = @total_count::INT,
+    updated_at = NOW()
+WHERE stock.warehouse_id = @warehouse_id::BIGINT AND stock.sku = @sku::BIGINT;
+
+-- name: AddMovement :one
+INSERT INTO stock_movements
+    (warehouse_id, sku, operation, delta, total_count, reason, actor, order_id, return_id)
+VALUES
+    (@warehouse_id::BIGINT, @sku::BIGINT, @operation::TEXT, @delta::INT, @total_count::INT, @reason::TEXT, @actor::TEXT, sqlc.narg(order_id)::BIGINT, sqlc.narg(return_id)::BIGINT)
+RETURNING id, created_at;
+
+-- name: GetMovements :many
+SELECT id, warehouse_id, sku, operation, delta, total_count, reason, actor, order_id, created_at
+FROM stock_movements
+WHERE stock_movements.sku = @sku::BIGINT
+    AND (@before_id::BIGINT = 0 OR stock_movements.id < @before_id::BIGINT)
+ORDER BY stock_movements.id DESC
+LIMIT @page_size::INT;
@@ -7,13 +7,170 @@ package sqlc_stock
 
 import (
 	"context"
+
+	"github.com/jackc/pgx/v5/pgtype"
 )
 
+const addMovement = `-- name: AddMovement :one
+INSERT INTO stock_movements
+    (warehouse_id, sku, operation, delta, total_count, reason, actor, order_id, return_id)
+VALUES
+    ($1::BIGINT, $2::BIGINT, $3::TEXT, $4::INT, $5::INT, $6::TEXT, $7::TEXT, $8::BIGINT, $9::BIGINT)
+RETURNING id, created_at
+`
+
+type AddMovementParams struct {
+	WarehouseID int64
+	Sku         int64
+	Operation   string
+	Delta       int32
+	TotalCount  int32
+	Reason      string
+	Actor       string
+	OrderID     pgtype.Int8
+	ReturnID    pgtype.Int8
+}
+
+type AddMovementRow struct {
+	ID        int64
+	CreatedAt pgtype.Timestamptz
+}
+
+func (q *Queries) AddMovement(ctx context.Context, arg AddMovementParams) (AddMovementRow, error) {
+	row := q.db.QueryRow(ctx, addMovement,
+		arg.WarehouseID,
+		arg.Sku,
+		arg.Operation,
+		arg.Delta,
+		arg.TotalCount,
+		arg.Reason,
+		arg.Actor,
+		arg.OrderID,
+		arg.ReturnID,
+	)
+	var i AddMovementRow
+	err := row.Scan(&i.ID, &i.CreatedAt)
+	return i, err
+}
+
+const ensureStock = `-- name: EnsureStock :exec
+INSERT INTO stock
+    (warehouse_id, sku)
+VALUES
+    ($1::BIGINT, $2::BIGINT)
+ON CONFLICT (warehouse_id, sku) DO NOTHING
+`
+
+type EnsureStockParams struct {
+	WarehouseID int64
+	Sku         int64
+}
+
+func (q *Queries) EnsureStock(ctx context.Context, arg EnsureStockParams) error {
+	_, err := q.db.Exec(ctx, ensureStock, arg.WarehouseID, arg.Sku)
+	return err
+}
+
+const getHoldsBySkus = `-- name: GetHoldsBySkus :many
+SELECT stock_holds.sku, SUM(stock_holds.count)::INT AS count
+FROM stock_holds
+WHERE stock_holds.sku = ANY($1::BIGINT[]) AND stock_holds.expires_at > NOW()
+GROUP BY stock_holds.sku
+`
+
+type GetHoldsBySkusRow struct {
+	Sku   int64
+	Count int32
+}
+
+func (q *Queries) GetHoldsBySkus(ctx context.Context, skus []int64) ([]GetHoldsBySkusRow, error) {
+	rows, err := q.db.Query(ctx, getHoldsBySkus, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetHoldsBySkusRow
+	for rows.Next() {
+		var i GetHoldsBySkusRow
+		if err := rows.Scan(&i.Sku, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getMovements = `-- name: GetMovements :many
+SELECT id, warehouse_id, sku, operation, delta, total_count, reason, actor, order_id, created_at
+FROM stock_movements
+WHERE stock_movements.sku = $1::BIGINT
+    AND ($2::BIGINT = 0 OR stock_movements.id < $2::BIGINT)
+ORDER BY stock_movements.id DESC
+LIMIT $3::INT
+`
+
+type GetMovementsParams struct {
+	Sku      int64
+	BeforeID int64
+	PageSize int32
+}
+
+type GetMovementsRow struct {
+	ID          int64
+	WarehouseID int64
+	Sku         int64
+	Operation   string
+	Delta       int32
+	TotalCount  int32
+	Reason      string
+	Actor       string
+	OrderID     pgtype.Int8
+	CreatedAt   pgtype.Timestamptz
+}
+
+func (q *Queries) GetMovements(ctx context.Context, arg GetMovementsParams) ([]GetMovementsRow, error) {
+	rows, err := q.db.Query(ctx, getMovements, arg.Sku, arg.BeforeID, arg.PageSize)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetMovementsRow
+	for rows.Next() {
+		var i GetMovementsRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.WarehouseID,
+			&i.Sku,
+			&i.Operation,
+			&i.Delta,
+			&i.TotalCount,
+			&i.Reason,
+			&i.Actor,
+			&i.OrderID,
+			&i.CreatedAt,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
 const getStocksBySku = `-- name: GetStocksBySku :one
-SELECT total_count - reserved AS count
+SELECT GREATEST(SUM(stock.total_count - stock.reserved) - COALESCE((
+        SELECT SUM(stock_holds.count)
+        FROM stock_holds
+        WHERE stock_holds.sku = $1::BIGINT AND stock_holds.expires_at > NOW()
+    ), 0), 0)::INT AS count
 FROM stock
-WHERE sku = $1
-LIMIT 1
+WHERE stock.sku = $1::BIGINT
+GROUP BY stock.sku
 `
 
 func (q *Queries) GetStocksBySku(ctx context.Context, sku int64) (int32, error) {
@@ -23,52 +180,359 @@ func (q *Queries) GetStocksBySku(ctx context.Context, sku int64) (int32, error)
 	return count, err
 }
 
-const reserve = `-- name: Reserve :exec
-UPDATE stock
-SET reserved = reserved + $2
-WHERE sku = $1
+const getStocksBySkus = `-- name: GetStocksBySkus :many
+SELECT stock.sku, GREATEST(SUM(stock.total_count - stock.reserved) - COALESCE((
+        SELECT SUM(stock_holds.count)
+        FROM stock_holds
+        WHERE stock_holds.sku = stock.sku AND stock_holds.expires_at > NOW()
+    ), 0), 0)::INT AS count
+FROM stock
+WHERE stock.sku = ANY($1::BIGINT[])
+GROUP BY stock.sku
 `
 
-type ReserveParams struct {
+type GetStocksBySkusRow struct {
 	Sku   int64
 	Count int32
 }
 
-func (q *Queries) Reserve(ctx context.Context, arg ReserveParams) error {
-	_, err := q.db.Exec(ctx, reserve, arg.Sku, arg.Count)
+func (q *Queries) GetStocksBySkus(ctx context.Context, skus []int64) ([]GetStocksBySkusRow, error) {
+	rows, err := q.db.Query(ctx, getStocksBySkus, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStocksBySkusRow
+	for rows.Next() {
+		var i GetStocksBySkusRow
+		if err := rows.Scan(&i.Sku, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getStocksByWarehouse = `-- name: GetStocksByWarehouse :many
+SELECT stock.warehouse_id, stock.total_count - stock.reserved AS count
+FROM stock
+WHERE stock.sku = $1::BIGINT
+ORDER BY stock.warehouse_id
+`
+
+type GetStocksByWarehouseRow struct {
+	WarehouseID int64
+	Count       int32
+}
+
+func (q *Queries) GetStocksByWarehouse(ctx context.Context, sku int64) ([]GetStocksByWarehouseRow, error) {
+	rows, err := q.db.Query(ctx, getStocksByWarehouse, sku)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetStocksByWarehouseRow
+	for rows.Next() {
+		var i GetStocksByWarehouseRow
+		if err := rows.Scan(&i.WarehouseID, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const getUserHoldCount = `-- name: GetUserHoldCount :one
+SELECT COALESCE(SUM(stock_holds.count), 0)::INT AS count
+FROM stock_holds
+WHERE stock_holds.user_id = $1::BIGINT
+    AND stock_holds.sku = $2::BIGINT
+    AND stock_holds.expires_at > NOW()
+`
+
+type GetUserHoldCountParams struct {
+	UserID int64
+	Sku    int64
+}
+
+func (q *Queries) GetUserHoldCount(ctx context.Context, arg GetUserHoldCountParams) (int32, error) {
+	row := q.db.QueryRow(ctx, getUserHoldCount, arg.UserID, arg.Sku)
+	var count int32
+	err := row.Scan(&count)
+	return count, err
+}
+
+const getWarehouses = `-- name: GetWarehouses :many
+SELECT id, name, latitude, longitude
+FROM warehouses
+ORDER BY id
+`
+
+type GetWarehousesRow struct {
+	ID        int64
+	Name      string
+	Latitude  float64
+	Longitude float64
+}
+
+func (q *Queries) GetWarehouses(ctx context.Context) ([]GetWarehousesRow, error) {
+	rows, err := q.db.Query(ctx, getWarehouses)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []GetWarehousesRow
+	for rows.Next() {
+		var i GetWarehousesRow
+		if err := rows.Scan(
+			&i.ID,
+			&i.Name,
+			&i.Latitude,
+			&i.Longitude,
+		); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const holdCreate = `-- name: HoldCreate :one
+INSERT INTO stock_holds
+    (sku, user_id, count, expires_at)
+VALUES
+    ($1::BIGINT, $2::BIGINT, $3::INT, NOW() + make_interval(secs => $4::INT))
+ON CONFLICT (user_id, sku) DO UPDATE
+SET
+    count = EXCLUDED.count,
+    expires_at = EXCLUDED.expires_at
+RETURNING id, expires_at
+`
+
+type HoldCreateParams struct {
+	Sku        int64
+	UserID     int64
+	Count      int32
+	TtlSeconds int32
+}
+
+type HoldCreateRow struct {
+	ID        int64
+	ExpiresAt pgtype.Timestamptz
+}
+
+func (q *Queries) HoldCreate(ctx context.Context, arg HoldCreateParams) (HoldCreateRow, error) {
+	row := q.db.QueryRow(ctx, holdCreate,
+		arg.Sku,
+		arg.UserID,
+		arg.Count,
+		arg.TtlSeconds,
+	)
+	var i HoldCreateRow
+	err := row.Scan(&i.ID, &i.ExpiresAt)
+	return i, err
+}
+
+const holdExtend = `-- name: HoldExtend :one
+UPDATE stock_holds
+SET expires_at = NOW() + make_interval(secs => $1::INT)
+WHERE id = $2 AND user_id = $3 AND expires_at > NOW()
+RETURNING expires_at
+`
+
+type HoldExtendParams struct {
+	TtlSeconds int32
+	ID         int64
+	UserID     int64
+}
+
+func (q *Queries) HoldExtend(ctx context.Context, arg HoldExtendParams) (pgtype.Timestamptz, error) {
+	row := q.db.QueryRow(ctx, holdExtend, arg.TtlSeconds, arg.ID, arg.UserID)
+	var expires_at pgtype.Timestamptz
+	err := row.Scan(&expires_at)
+	return expires_at, err
+}
+
+const holdRelease = `-- name: HoldRelease :exec
+DELETE FROM stock_holds
+WHERE id = $1 AND user_id = $2
+`
+
+type HoldReleaseParams struct {
+	ID     int64
+	UserID int64
+}
+
+func (q *Queries) HoldRelease(ctx context.Context, arg HoldReleaseParams) error {
+	_, err := q.db.Exec(ctx, holdRelease, arg.ID, arg.UserID)
+	return err
+}
+
+const holdReleaseByUser = `-- name: HoldReleaseByUser :exec
+DELETE FROM stock_holds
+WHERE user_id = $1 AND sku = ANY($2::BIGINT[])
+`
+
+type HoldReleaseByUserParams struct {
+	UserID int64
+	Skus   []int64
+}
+
+func (q *Queries) HoldReleaseByUser(ctx context.Context, arg HoldReleaseByUserParams) error {
+	_, err := q.db.Exec(ctx, holdReleaseByUser, arg.UserID, arg.Skus)
 	return err
 }
 
-const reserveCancel = `-- name: ReserveCancel :exec
+const lockStock = `-- name: LockStock :one
+SELECT stock.total_count, stock.reserved
+FROM stock
+WHERE stock.warehouse_id = $1::BIGINT AND stock.sku = $2::BIGINT
+FOR UPDATE
+`
+
+type LockStockParams struct {
+	WarehouseID int64
+	Sku         int64
+}
+
+type LockStockRow struct {
+	TotalCount int32
+	Reserved   int32
+}
+
+func (q *Queries) LockStock(ctx context.Context, arg LockStockParams) (LockStockRow, error) {
+	row := q.db.QueryRow(ctx, lockStock, arg.WarehouseID, arg.Sku)
+	var i LockStockRow
+	err := row.Scan(&i.TotalCount, &i.Reserved)
+	return i, err
+}
+
+const lockStocksBySkus = `-- name: LockStocksBySkus :many
+SELECT stock.warehouse_id, stock.sku, stock.total_count - stock.reserved AS count
+FROM stock
+WHERE stock.sku = ANY($1::BIGINT[])
+ORDER BY stock.sku, stock.warehouse_id
+FOR UPDATE
+`
+
+type LockStocksBySkusRow struct {
+	WarehouseID int64
+	Sku         int64
+	Count       int32
+}
+
+func (q *Queries) LockStocksBySkus(ctx context.Context, skus []int64) ([]LockStocksBySkusRow, error) {
+	rows, err := q.db.Query(ctx, lockStocksBySkus, skus)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	var items []LockStocksBySkusRow
+	for rows.Next() {
+		var i LockStocksBySkusRow
+		if err := rows.Scan(&i.WarehouseID, &i.Sku, &i.Count); err != nil {
+			return nil, err
+		}
+		items = append(items, i)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+const reserve = `-- name: Reserve :execrows
+UPDATE stock
+SET reserved = stock.reserved + $1::INT
+WHERE stock.warehouse_id = $2::BIGINT
+    AND stock.sku = $3::BIGINT
+    AND stock.total_count - stock.reserved >= $1::INT
+`
+
+type ReserveParams struct {
+	Count       int32
+	WarehouseID int64
+	Sku         int64
+}
+
+func (q *Queries) Reserve(ctx context.Context, arg ReserveParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reserve, arg.Count, arg.WarehouseID, arg.Sku)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const reserveCancel = `-- name: ReserveCancel :execrows
 UPDATE stock
-SET reserved = reserved - $2
-WHERE sku = $1
+SET reserved = stock.reserved - $1::INT
+WHERE stock.warehouse_id = $2::BIGINT
+    AND stock.sku = $3::BIGINT
+    AND stock.reserved >= $1::INT
 `
 
 type ReserveCancelParams struct {
-	Sku   int64
-	Count int32
+	Count       int32
+	WarehouseID int64
+	Sku         int64
 }
 
-func (q *Queries) ReserveCancel(ctx context.Context, arg ReserveCancelParams) error {
-	_, err := q.db.Exec(ctx, reserveCancel, arg.Sku, arg.Count)
-	return err
+func (q *Queries) ReserveCancel(ctx context.Context, arg ReserveCancelParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reserveCancel, arg.Count, arg.WarehouseID, arg.Sku)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
 }
 
-const reserveRemove = `-- name: ReserveRemove :exec
+const reserveRemove = `-- name: ReserveRemove :execrows
 UPDATE stock
 SET
-    total_count = total_count - $2,
-    reserved = reserved - $2
-WHERE sku = $1
+    total_count = stock.total_count - $1::INT,
+    reserved = stock.reserved - $1::INT
+WHERE stock.warehouse_id = $2::BIGINT
+    AND stock.sku = $3::BIGINT
+    AND stock.reserved >= $1::INT
 `
 
 type ReserveRemoveParams struct {
-	Sku   int64
-	Count int32
+	Count       int32
+	WarehouseID int64
+	Sku         int64
+}
+
+func (q *Queries) ReserveRemove(ctx context.Context, arg ReserveRemoveParams) (int64, error) {
+	result, err := q.db.Exec(ctx, reserveRemove, arg.Count, arg.WarehouseID, arg.Sku)
+	if err != nil {
+		return 0, err
+	}
+	return result.RowsAffected(), nil
+}
+
+const setTotalCount = `-- name: SetTotalCount :exec
+UPDATE stock
+SET
+    total_count = $1::INT,
+    updated_at = NOW()
+WHERE stock.warehouse_id = $2::BIGINT AND stock.sku = $3::BIGINT
+`
+
+type SetTotalCountParams struct {
+	TotalCount  int32
+	WarehouseID int64
+	Sku         int64
 }
 
-func (q *Queries) ReserveRemove(ctx context.Context, arg ReserveRemoveParams) error {
-	_, err := q.db.Exec(ctx, reserveRemove, arg.Sku, arg.Count)
+func (q *Queries) SetTotalCount(ctx context.Context, arg SetTotalCountParams) error {
+	_, err := q.db.Exec(ctx, setTotalCount, arg.TotalCount, arg.WarehouseID, arg.Sku)
 	return err
 }
@@ -2,64 +2,161 @@ package service
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/model"
+	"route256/loms/pkg/logger"
 	"route256/loms/pkg/tracing"
+	"time"
 )
 
 type stockRepository interface {
-	Reserve(context.Context, model.ProductSku, uint16) error
-	ReserveRemove(context.Context, model.ProductSku, uint16) error
-	ReserveCancel(context.Context, model.ProductSku, uint16) error
+	Reserve(context.Context, model.UserID, []model.OrderItem, allocation.Strategy, *model.Location) ([]model.OrderItem, error)
+	ReserveRemove(context.Context, []model.OrderItem) error
+	ReserveCancel(context.Context, []model.OrderItem) error
+	ReserveAt(context.Context, []model.OrderItem) error
 	GetStocksBySku(context.Context, model.ProductSku) (uint64, error)
+	GetStocksByWarehouse(context.Context, model.ProductSku) ([]model.WarehouseStock, error)
+	GetStocksBySkus(context.Context, []model.ProductSku) (map[model.ProductSku]uint64, error)
+	HoldCreate(context.Context, model.UserID, model.ProductSku, uint16, time.Duration) (model.StockHold, error)
+	HoldExtend(context.Context, model.UserID, model.HoldID, time.Duration) (time.Time, error)
+	HoldRelease(context.Context, model.UserID, model.HoldID) error
+	ChangeStock(context.Context, model.StockChange) (model.StockMovement, error)
+	ChangeStocks(context.Context, []model.StockChange) ([]model.StockMovement, error)
+	GetMovements(context.Context, model.ProductSku, model.StockMovementID, int) ([]model.StockMovement, error)
 }
 
 type orderRepository interface {
 	Create(context.Context, model.Order) (model.OrderID, error)
 	GetById(context.Context, model.OrderID) (model.Order, error)
-	SetStatus(context.Context, model.OrderID, model.OrderStatus) error
+	GetByRequestID(context.Context, model.UserID, string) (model.Order, error)
+	SetStatus(context.Context, model.OrderID, model.OrderStatus, model.OrderStatus) ([]model.OrderItem, error)
+	SetItems(context.Context, model.OrderID, []model.OrderItem) error
+	UpdateItems(context.Context, model.OrderID, []model.OrderItem, func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), ...func(context.Context, model.Order) error) (model.Order, error)
 	GetAll(context.Context) ([]model.Order, error)
+	GetStatusHistory(context.Context, model.OrderID) ([]model.OrderStatusChange, error)
+	AddReturn(context.Context, model.OrderID, []model.OrderItem, ...func(context.Context, model.OrderReturn) error) (model.OrderReturn, error)
+	ListByUser(context.Context, model.OrderFilter, *model.OrderCursor, int) ([]model.Order, error)
+	StreamAll(context.Context, model.OrderID, int, func(model.Order) error) error
+	HandleUnpaid(context.Context, time.Duration, int, func(context.Context, []model.OrderID) error) error
+	SetReturnRestocked(context.Context, model.OrderID, model.ReturnID) error
+	HandleNotRestocked(context.Context, time.Duration, int, func(context.Context, []model.OrderReturn) error) error
 }
 
 type LomsService struct {
-	stockRepository stockRepository
-	orderRepository orderRepository
+	stockRepository    stockRepository
+	orderRepository    orderRepository
+	allocationStrategy allocation.Strategy
 }
 
-func NewLomsService(stockRepository stockRepository, orderRepository orderRepository) *LomsService {
-	return &LomsService{
-		stockRepository: stockRepository,
-		orderRepository: orderRepository,
+type Option interface {
+	apply(*LomsService)
+}
+
+type optionFn func(*LomsService)
+
+func (fn optionFn) apply(s *LomsService) {
+	fn(s)
+}
+
+// WithAllocationStrategy задает выбор складов при резерве заказа, по умолчанию single_first.
+func WithAllocationStrategy(strategy allocation.Strategy) Option {
+	return optionFn(func(s *LomsService) {
+		s.allocationStrategy = strategy
+	})
+}
+
+func NewLomsService(stockRepository stockRepository, orderRepository orderRepository, opts ...Option) *LomsService {
+	s := &LomsService{
+		stockRepository:    stockRepository,
+		orderRepository:    orderRepository,
+		allocationStrategy: allocation.StrategySingleFirst,
 	}
+	for _, opt := range opts {
+		opt.apply(s)
+	}
+	return s
 }
 
-func (s *LomsService) OrderCreate(ctx context.Context, order model.Order) (_ model.OrderID, err error) {
+// OrderCreate создает заказ и резервирует его позиции. Повтор запроса с тем же
+// order.RequestID возвращает уже созданный заказ в его текущем статусе.
+func (s *LomsService) OrderCreate(ctx context.Context, order model.Order) (_ model.Order, err error) {
 	ctx, span := tracing.Start(ctx, "LomsService.OrderCreate")
 	defer tracing.EndWithCheckError(span, &err)
 
+	if order.RequestID != "" {
+		created, err := s.orderRepository.GetByRequestID(ctx, order.User, order.RequestID)
+		if err == nil {
+			return created, nil
+		}
+		if !errors.Is(err, model.ErrOrderNotFound) {
+			return model.Order{}, fmt.Errorf("orderRepository.GetByRequestID: %w", err)
+		}
+	}
+	// повтор после очистки корзины приходит без позиций, но его заказ уже найден выше
+	if len(order.Items) == 0 {
+		return model.Order{}, model.ErrEmptyOrder
+	}
 	orderID, err := s.orderRepository.Create(ctx, order)
+	if errors.Is(err, model.ErrDuplicateOrderRequest) {
+		// одновременный повтор успел создать заказ, резервирует его он
+		created, err := s.orderRepository.GetByRequestID(ctx, order.User, order.RequestID)
+		if err != nil {
+			return model.Order{}, fmt.Errorf("orderRepository.GetByRequestID: %w", err)
+		}
+		return created, nil
+	}
 	if err != nil {
-		return 0, fmt.Errorf("orderRepository.Create: %w", err)
+		return model.Order{}, fmt.Errorf("orderRepository.Create: %w", err)
 	}
-	reservedItems := make([]model.OrderItem, 0, len(order.Items))
-	for _, item := range order.Items {
-		if err := s.stockRepository.Reserve(ctx, item.Sku, item.Count); err != nil {
-			if err := s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusFailed); err != nil {
-				return 0, fmt.Errorf("orderRepository.SetStatus: %w; status: %s", err, model.OrderStatusFailed)
-			}
-			for i, reservedItem := range reservedItems {
-				if err := s.stockRepository.ReserveCancel(ctx, reservedItem.Sku, reservedItem.Count); err != nil {
-					return 0, fmt.Errorf("stockRepository.ReserveCancel: %w; not canceled reserved items: %v", err, reservedItems[i:])
-				}
-			}
-			return 0, fmt.Errorf("stockRepository.Reserve: %w", err)
+	order.ID, order.Status = orderID, model.OrderStatusNew
+	// резерв всего заказа атомарный, компенсировать частично зарезервированное не нужно.
+	// Холды пользователя переходят в резерв заказа в той же транзакции.
+	allocated, err := s.stockRepository.Reserve(ctx, order.User, order.Items, s.allocationStrategy, order.Delivery)
+	if err != nil {
+		if _, err := s.setStatus(ctx, order, model.OrderStatusFailed); err != nil {
+			return model.Order{}, err
 		}
-		reservedItems = append(reservedItems, item)
+		return model.Order{}, fmt.Errorf("stockRepository.Reserve: %w", err)
 	}
-	if err := s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusAwaitingPayment); err != nil {
-		return 0, fmt.Errorf("orderRepository.SetStatus: %w; status: %s", err, model.OrderStatusAwaitingPayment)
+	// заказ и остатки в разных базах: если склады не сохранились, резерв возвращаем
+	if err := s.orderRepository.SetItems(ctx, orderID, allocated); err != nil {
+		if err := s.stockRepository.ReserveCancel(ctx, allocated); err != nil {
+			return model.Order{}, fmt.Errorf("stockRepository.ReserveCancel: %w; not canceled items: %v", err, allocated)
+		}
+		if _, err := s.setStatus(ctx, order, model.OrderStatusFailed); err != nil {
+			return model.Order{}, err
+		}
+		return model.Order{}, fmt.Errorf("orderRepository.SetItems: %w", err)
+	}
+	if _, err := s.setStatus(ctx, order, model.OrderStatusAwaitingPayment); err != nil {
+		return model.Order{}, err
+	}
+	order.Status, order.Items = model.OrderStatusAwaitingPayment, allocated
+	return order, nil
+}
+
+// setStatus переводит заказ в статус to, если переход разрешен из текущего статуса, и возвращает
+// позиции заказа на момент перехода. Запись условная, поэтому из двух одновременных переходов пройдет только один.
+func (s *LomsService) setStatus(ctx context.Context, order model.Order, to model.OrderStatus) ([]model.OrderItem, error) {
+	if !order.Status.CanTransitionTo(to) {
+		return nil, fmt.Errorf("%w: %s -> %s; orderID: %d", model.ErrInvalidStatusTransition, order.Status, to, order.ID)
+	}
+	items, err := s.orderRepository.SetStatus(ctx, order.ID, order.Status, to)
+	if err != nil {
+		return nil, fmt.Errorf("orderRepository.SetStatus: %w; status: %s", err, to)
 	}
-	return orderID, nil
+	return items, nil
+}
+
+// rollbackStatus возвращает заказ из статуса from в прежний, если после перехода не удалось изменить остатки.
+func (s *LomsService) rollbackStatus(ctx context.Context, order model.Order, from model.OrderStatus) error {
+	ctx = model.WithEventReason(ctx, model.EventReasonRollback)
+	if _, err := s.orderRepository.SetStatus(ctx, order.ID, from, order.Status); err != nil {
+		return fmt.Errorf("orderRepository.SetStatus: %w; status: %s", err, order.Status)
+	}
+	return nil
 }
 
 func (s *LomsService) OrderInfo(ctx context.Context, orderID model.OrderID) (_ model.Order, err error) {
@@ -77,15 +174,19 @@ func (s *LomsService) OrderPay(ctx context.Context, orderID model.OrderID) (err
 	if err != nil {
 		return fmt.Errorf("orderRepository.GetById: %w", err)
 	}
-	if order.Status != model.OrderStatusAwaitingPayment {
-		return fmt.Errorf("invalid order status: %s; orderID: %d", order.Status, orderID)
+	// статус меняется до списания резерва: одновременная отмена получит конфликт и резерв не тронет.
+	// Списываются позиции, прочитанные под блокировкой заказа, а не из GetById: их мог изменить UpdateItems
+	items, err := s.setStatus(ctx, order, model.OrderStatusPaid)
+	if err != nil {
+		return err
 	}
-	for i, item := range order.Items {
-		if err := s.stockRepository.ReserveRemove(ctx, item.Sku, item.Count); err != nil {
-			return fmt.Errorf("stockRepository.ReserveRemove: %w; not removed items: %v", err, order.Items[i:])
+	if err := s.stockRepository.ReserveRemove(ctx, items); err != nil {
+		if err := s.rollbackStatus(ctx, order, model.OrderStatusPaid); err != nil {
+			return err
 		}
+		return fmt.Errorf("stockRepository.ReserveRemove: %w", err)
 	}
-	return s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusPaid)
+	return nil
 }
 
 func (s *LomsService) OrderCancel(ctx context.Context, orderID model.OrderID) (err error) {
@@ -96,15 +197,176 @@ func (s *LomsService) OrderCancel(ctx context.Context, orderID model.OrderID) (e
 	if err != nil {
 		return fmt.Errorf("orderRepository.GetById: %w", err)
 	}
-	if order.Status != model.OrderStatusAwaitingPayment {
-		return fmt.Errorf("invalid order status: %s; orderID: %d", order.Status, orderID)
+	items, err := s.setStatus(ctx, order, model.OrderStatusCancelled)
+	if err != nil {
+		return err
+	}
+	if err := s.stockRepository.ReserveCancel(ctx, items); err != nil {
+		if err := s.rollbackStatus(ctx, order, model.OrderStatusCancelled); err != nil {
+			return err
+		}
+		return fmt.Errorf("stockRepository.ReserveCancel: %w", err)
+	}
+	return nil
+}
+
+// OrderHistory возвращает все переходы статусов заказа.
+func (s *LomsService) OrderHistory(ctx context.Context, orderID model.OrderID) (_ []model.OrderStatusChange, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.OrderHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.orderRepository.GetStatusHistory(ctx, orderID)
+}
+
+// OrderUpdateItems меняет позиции заказа до оплаты. Резервируется только разница по sku:
+// увеличение дозарезервируется, уменьшение освобождается.
+func (s *LomsService) OrderUpdateItems(ctx context.Context, orderID model.OrderID, items []model.OrderItem) (_ model.Order, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.OrderUpdateItems")
+	defer tracing.EndWithCheckError(span, &err)
+
+	// reserved и released уже применены к остаткам: если позиции заказа не сохранятся, их откатываем
+	var reserved, released []model.OrderItem
+	reserve := func(ctx context.Context, diff model.OrderItemsDiff) ([]model.OrderItem, error) {
+		if len(diff.Reserve) > 0 {
+			// холды корзины к уже созданному заказу не относятся, их не трогаем
+			allocated, err := s.stockRepository.Reserve(ctx, 0, diff.Reserve, s.allocationStrategy, nil)
+			if err != nil {
+				return nil, fmt.Errorf("stockRepository.Reserve: %w", err)
+			}
+			reserved = allocated
+		}
+		if len(diff.Release) > 0 {
+			if err := s.stockRepository.ReserveCancel(ctx, diff.Release); err != nil {
+				return nil, fmt.Errorf("stockRepository.ReserveCancel: %w", err)
+			}
+			released = diff.Release
+		}
+		return reserved, nil
+	}
+	order, err := s.orderRepository.UpdateItems(ctx, orderID, items, reserve)
+	if err != nil {
+		// остатки в другой базе, транзакция заказа их изменения не откатывает
+		if err := s.undoReserveDiff(ctx, reserved, released); err != nil {
+			return model.Order{}, err
+		}
+		return model.Order{}, fmt.Errorf("orderRepository.UpdateItems: %w", err)
 	}
-	for i, item := range order.Items {
-		if err := s.stockRepository.ReserveCancel(ctx, item.Sku, item.Count); err != nil {
-			return fmt.Errorf("stockRepository.ReserveCancel: %w; not canceled items: %v", err, order.Items[i:])
+	return order, nil
+}
+
+// undoReserveDiff возвращает остатки к позициям заказа до изменения:
+// дозарезервированное освобождается, освобожденное резервируется на тех же складах.
+func (s *LomsService) undoReserveDiff(ctx context.Context, reserved, released []model.OrderItem) error {
+	if len(reserved) > 0 {
+		if err := s.stockRepository.ReserveCancel(ctx, reserved); err != nil {
+			return fmt.Errorf("stockRepository.ReserveCancel: %w; not canceled items: %v", err, reserved)
 		}
 	}
-	return s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusCancelled)
+	if len(released) > 0 {
+		if err := s.stockRepository.ReserveAt(ctx, released); err != nil {
+			return fmt.Errorf("stockRepository.ReserveAt: %w; not reserved items: %v", err, released)
+		}
+	}
+	return nil
+}
+
+// движения остатков при возврате заказа
+const (
+	returnStockReason = "order return"
+	returnStockActor  = "loms"
+)
+
+// OrderReturn возвращает часть позиций оплаченного заказа на склады, с которых они были отгружены.
+// Остатки в другой базе и пополняются после записи возврата. Если склад не принял возврат,
+// запрос все равно успешен: возврат уже записан, остатки по нему дополнит RestockReturns.
+func (s *LomsService) OrderReturn(ctx context.Context, orderID model.OrderID, items []model.OrderItem) (_ model.OrderReturn, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.OrderReturn")
+	defer tracing.EndWithCheckError(span, &err)
+
+	orderReturn, err := s.orderRepository.AddReturn(ctx, orderID, items)
+	if err != nil {
+		return model.OrderReturn{}, fmt.Errorf("orderRepository.AddReturn: %w", err)
+	}
+	// ошибка клиенту привела бы к повтору запроса и второму возврату тех же позиций
+	if err := s.restock(ctx, orderReturn); err != nil {
+		logger.Errorw(ctx, "restock order return", "err", err, "orderID", orderID, "returnID", orderReturn.ID)
+	}
+	return orderReturn, nil
+}
+
+// restock возвращает позиции возврата на склады. ReturnID - ключ идемпотентности,
+// поэтому повтор после сбоя между пополнением и отметкой в заказе не пополнит остатки дважды.
+func (s *LomsService) restock(ctx context.Context, orderReturn model.OrderReturn) error {
+	changes := make([]model.StockChange, 0, len(orderReturn.Items))
+	for _, item := range orderReturn.Items {
+		changes = append(changes, model.StockChange{
+			Warehouse: item.Warehouse,
+			Sku:       item.Sku,
+			Operation: model.StockOperationReturn,
+			Count:     int64(item.Count),
+			Reason:    returnStockReason,
+			Actor:     returnStockActor,
+			OrderID:   orderReturn.OrderID,
+			ReturnID:  orderReturn.ID,
+		})
+	}
+	if _, err := s.stockRepository.ChangeStocks(ctx, changes); err != nil {
+		return fmt.Errorf("stockRepository.ChangeStocks: %w", err)
+	}
+	if err := s.orderRepository.SetReturnRestocked(ctx, orderReturn.OrderID, orderReturn.ID); err != nil {
+		return fmt.Errorf("orderRepository.SetReturnRestocked: %w", err)
+	}
+	return nil
+}
+
+// RestockReturns дополняет остатки по возвратам старше delay, которые не удалось пополнить сразу,
+// и возвращает число пополненных.
+func (s *LomsService) RestockReturns(ctx context.Context, delay time.Duration, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.RestockReturns")
+	defer tracing.EndWithCheckError(span, &err)
+
+	restocked := 0
+	err = s.orderRepository.HandleNotRestocked(ctx, delay, limit, func(ctx context.Context, returns []model.OrderReturn) error {
+		var errs []error
+		for _, orderReturn := range returns {
+			if err := s.restock(ctx, orderReturn); err != nil {
+				errs = append(errs, fmt.Errorf("restock: %w; orderID: %d, returnID: %d", err, orderReturn.OrderID, orderReturn.ID))
+				continue
+			}
+			restocked++
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		return restocked, fmt.Errorf("orderRepository.HandleNotRestocked: %w", err)
+	}
+	return restocked, nil
+}
+
+// CancelUnpaidOrders отменяет заказы, которые ждут оплаты дольше timeout, и возвращает число отмененных.
+// События об отмене уходят с reason=timeout.
+func (s *LomsService) CancelUnpaidOrders(ctx context.Context, timeout time.Duration, limit int) (_ int, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.CancelUnpaidOrders")
+	defer tracing.EndWithCheckError(span, &err)
+
+	ctx = model.WithEventReason(ctx, model.EventReasonTimeout)
+	cancelled := 0
+	err = s.orderRepository.HandleUnpaid(ctx, timeout, limit, func(ctx context.Context, orderIDs []model.OrderID) error {
+		// заказ могли оплатить между выборкой и отменой, остальные отменяем все равно
+		var errs []error
+		for _, orderID := range orderIDs {
+			if err := s.OrderCancel(ctx, orderID); err != nil {
+				errs = append(errs, fmt.Errorf("OrderCancel: %w; orderID: %d", err, orderID))
+				continue
+			}
+			cancelled++
+		}
+		return errors.Join(errs...)
+	})
+	if err != nil {
+		return cancelled, fmt.Errorf("orderRepository.HandleUnpaid: %w", err)
+	}
+	return cancelled, nil
 }
 
 func (s *LomsService) StocksInfo(ctx context.Context, sku model.ProductSku) (_ uint64, err error) {
@@ -114,6 +376,117 @@ func (s *LomsService) StocksInfo(ctx context.Context, sku model.ProductSku) (_ u
 	return s.stockRepository.GetStocksBySku(ctx, sku)
 }
 
+func (s *LomsService) StocksInfoByWarehouse(ctx context.Context, sku model.ProductSku) (_ []model.WarehouseStock, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StocksInfoByWarehouse")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.GetStocksByWarehouse(ctx, sku)
+}
+
+func (s *LomsService) StocksInfoBatch(ctx context.Context, skus []model.ProductSku) (_ map[model.ProductSku]uint64, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StocksInfoBatch")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.GetStocksBySkus(ctx, skus)
+}
+
+func (s *LomsService) HoldCreate(ctx context.Context, user model.UserID, sku model.ProductSku, count uint16, ttl time.Duration) (_ model.StockHold, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldCreate")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.HoldCreate(ctx, user, sku, count, ttl)
+}
+
+func (s *LomsService) HoldExtend(ctx context.Context, user model.UserID, holdID model.HoldID, ttl time.Duration) (_ time.Time, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldExtend")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.HoldExtend(ctx, user, holdID, ttl)
+}
+
+func (s *LomsService) HoldRelease(ctx context.Context, user model.UserID, holdID model.HoldID) (err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.HoldRelease")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.HoldRelease(ctx, user, holdID)
+}
+
+// StockChange применяет ручное изменение остатка: поставку, корректировку или инвентаризацию.
+func (s *LomsService) StockChange(ctx context.Context, change model.StockChange) (_ model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StockChange")
+	defer tracing.EndWithCheckError(span, &err)
+
+	if err := validateStockChange(change); err != nil {
+		return model.StockMovement{}, err
+	}
+	if change.Warehouse == 0 {
+		change.Warehouse = model.DefaultWarehouseID
+	}
+	movement, err := s.stockRepository.ChangeStock(ctx, change)
+	if err != nil {
+		return model.StockMovement{}, fmt.Errorf("stockRepository.ChangeStock: %w", err)
+	}
+	return movement, nil
+}
+
+func validateStockChange(change model.StockChange) error {
+	switch {
+	case change.Reason == "" || change.Actor == "":
+		return fmt.Errorf("%w: reason and actor are required", model.ErrInvalidStockChange)
+	case change.Operation == model.StockOperationAdd && change.Count <= 0:
+		return fmt.Errorf("%w: add count must be positive, got %d", model.ErrInvalidStockChange, change.Count)
+	case change.Operation == model.StockOperationAdjust && change.Count == 0:
+		return fmt.Errorf("%w: adjust delta must not be zero", model.ErrInvalidStockChange)
+	case change.Operation == model.StockOperationSet && change.Count < 0:
+		return fmt.Errorf("%w: set count must not be negative, got %d", model.ErrInvalidStockChange, change.Count)
+	case change.Operation != model.StockOperationAdd && change.Operation != model.StockOperationAdjust && change.Operation != model.StockOperationSet:
+		return fmt.Errorf("%w: unknown operation %q", model.ErrInvalidStockChange, change.Operation)
+	}
+	return nil
+}
+
+func (s *LomsService) StockHistory(ctx context.Context, sku model.ProductSku, beforeID model.StockMovementID, limit int) (_ []model.StockMovement, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StockHistory")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.stockRepository.GetMovements(ctx, sku, beforeID, limit)
+}
+
+// ListOrders возвращает страницу заказов пользователя от новых к старым
+// и токен следующей страницы, пустой на последней.
+func (s *LomsService) ListOrders(ctx context.Context, filter model.OrderFilter, pageToken string, pageSize int) (_ []model.Order, _ string, err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.ListOrders")
+	defer tracing.EndWithCheckError(span, &err)
+
+	after, err := decodePageToken(pageToken)
+	if err != nil {
+		return nil, "", err
+	}
+	// лишний заказ показывает, есть ли следующая страница
+	orders, err := s.orderRepository.ListByUser(ctx, filter, after, pageSize+1)
+	if err != nil {
+		return nil, "", fmt.Errorf("orderRepository.ListByUser: %w", err)
+	}
+	if len(orders) <= pageSize {
+		return orders, "", nil
+	}
+	orders = orders[:pageSize]
+	last := orders[len(orders)-1]
+	return orders, encodePageToken(model.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}), nil
+}
+
+// streamOrdersBatchSize - по сколько заказов выгрузка читает из каждого шарда
+const streamOrdersBatchSize = 100
+
+// StreamOrders передает в send все заказы по возрастанию ID, начиная после afterID.
+// ID последнего отправленного заказа - точка, с которой выгрузку можно продолжить.
+func (s *LomsService) StreamOrders(ctx context.Context, afterID model.OrderID, send func(model.Order) error) (err error) {
+	ctx, span := tracing.Start(ctx, "LomsService.StreamOrders")
+	defer tracing.EndWithCheckError(span, &err)
+
+	return s.orderRepository.StreamAll(ctx, afterID, streamOrdersBatchSize, send)
+}
+
 func (s *LomsService) GetAllOrders(ctx context.Context) (_ []model.Order, err error) {
 	ctx, span := tracing.Start(ctx, "GetAllOrders")
 	defer tracing.EndWithCheckError(span, &err)
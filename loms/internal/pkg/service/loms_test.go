@@ -3,9 +3,12 @@ package service
 import (
 	"context"
 	"errors"
+	"fmt"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/model"
 	"route256/loms/internal/pkg/service/mock"
 	"testing"
+	"time"
 
 	"github.com/gojuno/minimock/v3"
 	"github.com/stretchr/testify/assert"
@@ -31,7 +34,7 @@ func TestOrderCreate(t *testing.T) {
 		name    string
 		order   model.Order
 		prepare func(mocks *mocks)
-		test    func(orderID model.OrderID, err error)
+		test    func(order model.Order, err error)
 	}{
 		{
 			name: "valid params",
@@ -46,12 +49,14 @@ func TestOrderCreate(t *testing.T) {
 			},
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.CreateMock.Return(1, nil)
-				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, 1).Return(nil)
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment).Return(nil)
+				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil).Return([]model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}, nil)
+				mocks.orderRepositoryMock.SetItemsMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}).Return(nil)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusNew, model.OrderStatusAwaitingPayment).Return(nil, nil)
 			},
-			test: func(orderID model.OrderID, err error) {
-				assert.Equal(t, model.OrderID(1), orderID)
+			test: func(order model.Order, err error) {
 				assert.NoError(t, err)
+				assert.Equal(t, model.OrderID(1), order.ID)
+				assert.Equal(t, model.OrderStatusAwaitingPayment, order.Status)
 			},
 		},
 		{
@@ -67,10 +72,10 @@ func TestOrderCreate(t *testing.T) {
 			},
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.CreateMock.Return(1, nil)
-				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, 1).Return(errors.New("reserve error"))
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusFailed).Return(nil)
+				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil).Return(nil, errors.New("reserve error"))
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusNew, model.OrderStatusFailed).Return(nil, nil)
 			},
-			test: func(orderID model.OrderID, err error) {
+			test: func(order model.Order, err error) {
 				assert.Error(t, err)
 			},
 		},
@@ -87,15 +92,16 @@ func TestOrderCreate(t *testing.T) {
 			},
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.CreateMock.Return(1, nil)
-				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, 1).Return(nil)
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment).Return(errors.New("set status error"))
+				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil).Return([]model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}, nil)
+				mocks.orderRepositoryMock.SetItemsMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}).Return(nil)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusNew, model.OrderStatusAwaitingPayment).Return(nil, errors.New("set status error"))
 			},
-			test: func(orderID model.OrderID, err error) {
+			test: func(order model.Order, err error) {
 				assert.Error(t, err)
 			},
 		},
 		{
-			name: "reserver cancel error",
+			name: "multi sku reserve error",
 			order: model.Order{
 				User: 1,
 				Items: []model.OrderItem{
@@ -111,21 +117,119 @@ func TestOrderCreate(t *testing.T) {
 			},
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.CreateMock.Return(1, nil)
-				mocks.stockRepositoryMock.ReserveMock.When(ctx, 1, 1).Then(nil)
-				mocks.stockRepositoryMock.ReserveMock.When(ctx, 2, 1).Then(errors.New("reserve error"))
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusFailed).Return(nil)
-				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, 1, 1).Return(errors.New("reserve cancel error"))
+				// резерв откатывается целиком в репозитории, ReserveCancel не вызывается
+				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 2, Count: 1}}, allocation.StrategySingleFirst, nil).Return(nil, model.ErrNotEnoughStock)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusNew, model.OrderStatusFailed).Return(nil, nil)
+			},
+			test: func(order model.Order, err error) {
+				assert.ErrorIs(t, err, model.ErrNotEnoughStock)
+			},
+		},
+		{
+			name: "set items error",
+			order: model.Order{
+				User: 1,
+				Items: []model.OrderItem{
+					{
+						Sku:   1,
+						Count: 1,
+					},
+				},
+			},
+			prepare: func(mocks *mocks) {
+				mocks.orderRepositoryMock.CreateMock.Return(1, nil)
+				mocks.stockRepositoryMock.ReserveMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil).Return([]model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}, nil)
+				mocks.orderRepositoryMock.SetItemsMock.Expect(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}).Return(errors.New("set items error"))
+				// склады не сохранились в заказе - резерв возвращается
+				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}).Return(nil)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusNew, model.OrderStatusFailed).Return(nil, nil)
 			},
-			test: func(orderID model.OrderID, err error) {
+			test: func(order model.Order, err error) {
 				assert.Error(t, err)
 			},
 		},
+		{
+			name: "repeated request",
+			order: model.Order{
+				User:      1,
+				Items:     []model.OrderItem{{Sku: 1, Count: 1}},
+				RequestID: "checkout-1",
+			},
+			prepare: func(mocks *mocks) {
+				// заказ по ключу уже создан - новый не создается и не резервируется
+				mocks.orderRepositoryMock.GetByRequestIDMock.Set(func(_ context.Context, user model.UserID, requestID string) (model.Order, error) {
+					assert.Equal(t, "checkout-1", requestID)
+					return model.Order{ID: 1, User: user, Status: model.OrderStatusPaid}, nil
+				})
+			},
+			test: func(order model.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.OrderID(1), order.ID)
+				assert.Equal(t, model.OrderStatusPaid, order.Status)
+			},
+		},
+		{
+			name: "concurrent repeated request",
+			order: model.Order{
+				User:      1,
+				Items:     []model.OrderItem{{Sku: 1, Count: 1}},
+				RequestID: "checkout-2",
+			},
+			prepare: func(mocks *mocks) {
+				calls := 0
+				mocks.orderRepositoryMock.GetByRequestIDMock.Set(func(_ context.Context, user model.UserID, requestID string) (model.Order, error) {
+					// первый поиск до создания, второй - после конфликта уникального ключа
+					calls++
+					if calls == 1 {
+						return model.Order{}, model.ErrOrderNotFound
+					}
+					return model.Order{ID: 2, User: user, Status: model.OrderStatusNew}, nil
+				})
+				mocks.orderRepositoryMock.CreateMock.Return(0, model.ErrDuplicateOrderRequest)
+			},
+			test: func(order model.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.OrderID(2), order.ID)
+				assert.Equal(t, model.OrderStatusNew, order.Status)
+			},
+		},
+		{
+			name: "repeated request after cart cleared",
+			order: model.Order{
+				User:      1,
+				RequestID: "checkout-3",
+			},
+			prepare: func(mocks *mocks) {
+				mocks.orderRepositoryMock.GetByRequestIDMock.Set(func(_ context.Context, user model.UserID, requestID string) (model.Order, error) {
+					return model.Order{ID: 3, User: user, Status: model.OrderStatusAwaitingPayment}, nil
+				})
+			},
+			test: func(order model.Order, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.OrderID(3), order.ID)
+			},
+		},
+		{
+			name: "empty order",
+			order: model.Order{
+				User:      1,
+				RequestID: "checkout-4",
+			},
+			prepare: func(mocks *mocks) {
+				mocks.orderRepositoryMock.GetByRequestIDMock.Set(func(context.Context, model.UserID, string) (model.Order, error) {
+					return model.Order{}, model.ErrOrderNotFound
+				})
+			},
+			test: func(order model.Order, err error) {
+				assert.ErrorIs(t, err, model.ErrEmptyOrder)
+			},
+		},
 	}
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			tt.prepare(&testMocks)
-			orderID, err := service.OrderCreate(ctx, tt.order)
-			tt.test(orderID, err)
+			order, err := service.OrderCreate(ctx, tt.order)
+			tt.test(order, err)
 		})
 	}
 }
@@ -226,6 +330,7 @@ func TestOrderPay(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{
@@ -235,8 +340,8 @@ func TestOrderPay(t *testing.T) {
 						},
 					},
 				}, nil)
-				mocks.stockRepositoryMock.ReserveRemoveMock.Expect(ctx, 1, 1).Return(nil)
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusPaid).Return(nil)
+				mocks.stockRepositoryMock.ReserveRemoveMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 1}}).Return(nil)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaid).Return([]model.OrderItem{{Sku: 1, Count: 1}}, nil)
 			},
 			test: func(err error) {
 				assert.NoError(t, err)
@@ -257,6 +362,7 @@ func TestOrderPay(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusFailed,
 					Items: []model.OrderItem{
@@ -268,7 +374,52 @@ func TestOrderPay(t *testing.T) {
 				}, nil)
 			},
 			test: func(err error) {
-				assert.Error(t, err)
+				assert.ErrorIs(t, err, model.ErrInvalidStatusTransition)
+			},
+		},
+		{
+			name:    "concurrent cancel",
+			orderID: 1,
+			prepare: func(mocks *mocks) {
+				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
+					User:   1,
+					Status: model.OrderStatusAwaitingPayment,
+					Items: []model.OrderItem{
+						{
+							Sku:   1,
+							Count: 1,
+						},
+					},
+				}, nil)
+				// заказ успели отменить после чтения - резерв не списывается
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaid).Return(nil, model.ErrOrderStatusConflict)
+			},
+			test: func(err error) {
+				assert.ErrorIs(t, err, model.ErrOrderStatusConflict)
+			},
+		},
+		{
+			name:    "items updated after read",
+			orderID: 1,
+			prepare: func(mocks *mocks) {
+				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
+					User:   1,
+					Status: model.OrderStatusAwaitingPayment,
+					Items: []model.OrderItem{
+						{
+							Sku:   1,
+							Count: 1,
+						},
+					},
+				}, nil)
+				// позиции изменили между GetById и оплатой - списываются позиции из транзакции смены статуса
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaid).Return([]model.OrderItem{{Sku: 1, Count: 3}}, nil)
+				mocks.stockRepositoryMock.ReserveRemoveMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 3}}).Return(nil)
+			},
+			test: func(err error) {
+				assert.NoError(t, err)
 			},
 		},
 		{
@@ -276,6 +427,7 @@ func TestOrderPay(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{
@@ -285,7 +437,10 @@ func TestOrderPay(t *testing.T) {
 						},
 					},
 				}, nil)
-				mocks.stockRepositoryMock.ReserveRemoveMock.Expect(ctx, 1, 1).Return(errors.New("reserve remove error"))
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusPaid).Return([]model.OrderItem{{Sku: 1, Count: 1}}, nil)
+				mocks.stockRepositoryMock.ReserveRemoveMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 1}}).Return(errors.New("reserve remove error"))
+				// резерв не изменился - заказ возвращается в ожидание оплаты
+				mocks.orderRepositoryMock.SetStatusMock.When(model.WithEventReason(ctx, model.EventReasonRollback), 1, model.OrderStatusPaid, model.OrderStatusAwaitingPayment).Then(nil, nil)
 			},
 			test: func(err error) {
 				assert.Error(t, err)
@@ -328,6 +483,7 @@ func TestOrderCancel(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{
@@ -337,8 +493,8 @@ func TestOrderCancel(t *testing.T) {
 						},
 					},
 				}, nil)
-				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, 1, 1).Return(nil)
-				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusCancelled).Return(nil)
+				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 1}}).Return(nil)
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusCancelled).Return([]model.OrderItem{{Sku: 1, Count: 1}}, nil)
 			},
 			test: func(err error) {
 				assert.NoError(t, err)
@@ -359,6 +515,7 @@ func TestOrderCancel(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusFailed,
 					Items: []model.OrderItem{
@@ -378,6 +535,7 @@ func TestOrderCancel(t *testing.T) {
 			orderID: 1,
 			prepare: func(mocks *mocks) {
 				mocks.orderRepositoryMock.GetByIdMock.Expect(ctx, 1).Return(model.Order{
+					ID:     1,
 					User:   1,
 					Status: model.OrderStatusAwaitingPayment,
 					Items: []model.OrderItem{
@@ -387,7 +545,10 @@ func TestOrderCancel(t *testing.T) {
 						},
 					},
 				}, nil)
-				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, 1, 1).Return(errors.New("reserve cancel error"))
+				mocks.orderRepositoryMock.SetStatusMock.Expect(ctx, 1, model.OrderStatusAwaitingPayment, model.OrderStatusCancelled).Return([]model.OrderItem{{Sku: 1, Count: 1}}, nil)
+				mocks.stockRepositoryMock.ReserveCancelMock.Expect(ctx, []model.OrderItem{{Sku: 1, Count: 1}}).Return(errors.New("reserve cancel error"))
+				// резерв не изменился - заказ возвращается в ожидание оплаты
+				mocks.orderRepositoryMock.SetStatusMock.When(model.WithEventReason(ctx, model.EventReasonRollback), 1, model.OrderStatusCancelled, model.OrderStatusAwaitingPayment).Then(nil, nil)
 			},
 			test: func(err error) {
 				assert.Error(t, err)
@@ -402,3 +563,427 @@ func TestOrderCancel(t *testing.T) {
 		})
 	}
 }
+
+func TestOrderUpdateItems(t *testing.T) {
+	ctx := context.Background()
+	requested := []model.OrderItem{{Sku: 1, Count: 3}, {Sku: 2, Count: 1}}
+	diff := model.OrderItemsDiff{
+		Reserve: []model.OrderItem{{Sku: 1, Count: 1}},
+		Release: []model.OrderItem{{Sku: 2, Count: 2, Warehouse: 1}},
+	}
+	reserved := []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 2}}
+
+	newService := func(t *testing.T) (*LomsService, *mock.StockRepositoryMock) {
+		ctrl := minimock.NewController(t)
+		stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+		orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+		// репозиторий считает разницу с текущими позициями и откатывает изменение, если reserve вернул ошибку
+		orderRepositoryMock.UpdateItemsMock.Set(func(ctx context.Context, orderID model.OrderID, items []model.OrderItem, reserve func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), _ ...func(context.Context, model.Order) error) (model.Order, error) {
+			assert.Equal(t, model.OrderID(10), orderID)
+			assert.Equal(t, requested, items)
+			allocated, err := reserve(ctx, diff)
+			if err != nil {
+				return model.Order{}, err
+			}
+			return model.Order{ID: orderID, Status: model.OrderStatusAwaitingPayment, Items: allocated}, nil
+		})
+		stockRepositoryMock.ReserveMock.Expect(ctx, 0, diff.Reserve, allocation.StrategySingleFirst, nil).Return(reserved, nil)
+		return NewLomsService(stockRepositoryMock, orderRepositoryMock), stockRepositoryMock
+	}
+
+	t.Run("reserve diff", func(t *testing.T) {
+		service, stockRepositoryMock := newService(t)
+		stockRepositoryMock.ReserveCancelMock.Expect(ctx, diff.Release).Return(nil)
+
+		order, err := service.OrderUpdateItems(ctx, 10, requested)
+		assert.NoError(t, err)
+		assert.Equal(t, reserved, order.Items)
+	})
+	t.Run("release error", func(t *testing.T) {
+		service, stockRepositoryMock := newService(t)
+		errRelease := errors.New("release error")
+		stockRepositoryMock.ReserveCancelMock.When(ctx, diff.Release).Then(errRelease)
+		// освободить не удалось - дозарезервированное возвращается
+		stockRepositoryMock.ReserveCancelMock.When(ctx, reserved).Then(nil)
+
+		_, err := service.OrderUpdateItems(ctx, 10, requested)
+		assert.ErrorIs(t, err, errRelease)
+	})
+	t.Run("inTx error", func(t *testing.T) {
+		ctrl := minimock.NewController(t)
+		stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+		orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+		service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+		errOutbox := errors.New("outbox error")
+		// остатки изменены, но транзакция заказа откатилась на записи события
+		orderRepositoryMock.UpdateItemsMock.Set(func(ctx context.Context, orderID model.OrderID, items []model.OrderItem, reserve func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), _ ...func(context.Context, model.Order) error) (model.Order, error) {
+			if _, err := reserve(ctx, diff); err != nil {
+				return model.Order{}, err
+			}
+			return model.Order{}, fmt.Errorf("inTx: %w", errOutbox)
+		})
+		stockRepositoryMock.ReserveMock.Expect(ctx, 0, diff.Reserve, allocation.StrategySingleFirst, nil).Return(reserved, nil)
+		stockRepositoryMock.ReserveCancelMock.When(ctx, diff.Release).Then(nil)
+		// разница откатывается: дозарезервированное освобождается, освобожденное резервируется обратно
+		stockRepositoryMock.ReserveCancelMock.When(ctx, reserved).Then(nil)
+		stockRepositoryMock.ReserveAtMock.Expect(ctx, diff.Release).Return(nil)
+
+		_, err := service.OrderUpdateItems(ctx, 10, requested)
+		assert.ErrorIs(t, err, errOutbox)
+		assert.Equal(t, uint64(2), stockRepositoryMock.ReserveCancelAfterCounter())
+	})
+}
+
+func TestOrderReturn(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	requested := []model.OrderItem{{Sku: 1, Count: 3}}
+	orderReturn := model.OrderReturn{
+		ID:      1,
+		OrderID: 10,
+		Items:   []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 2}},
+		Status:  model.OrderStatusPartiallyReturned,
+	}
+	orderRepositoryMock.AddReturnMock.Set(func(_ context.Context, orderID model.OrderID, items []model.OrderItem, inTx ...func(context.Context, model.OrderReturn) error) (model.OrderReturn, error) {
+		assert.Equal(t, model.OrderID(10), orderID)
+		assert.Equal(t, requested, items)
+		// остатки пополняются после записи возврата, а не в его транзакции
+		assert.Empty(t, inTx)
+		return orderReturn, nil
+	})
+
+	changes := []model.StockChange{
+		{Warehouse: 1, Sku: 1, Operation: model.StockOperationReturn, Count: 2, Reason: "order return", Actor: "loms", OrderID: 10, ReturnID: 1},
+		{Warehouse: 2, Sku: 1, Operation: model.StockOperationReturn, Count: 1, Reason: "order return", Actor: "loms", OrderID: 10, ReturnID: 1},
+	}
+	stockRepositoryMock.ChangeStocksMock.Expect(ctx, changes).Return(nil, nil)
+	orderRepositoryMock.SetReturnRestockedMock.Expect(ctx, 10, 1).Return(nil)
+	res, err := service.OrderReturn(ctx, 10, requested)
+	assert.NoError(t, err)
+	assert.Equal(t, orderReturn, res)
+	assert.Equal(t, uint64(1), orderRepositoryMock.SetReturnRestockedAfterCounter())
+
+	// возврат уже записан: ошибка склада не возвращается клиенту, остатки дополнит RestockReturns
+	stockRepositoryMock.ChangeStocksMock.Expect(ctx, changes).Return(nil, model.ErrWarehouseNotFound)
+	res, err = service.OrderReturn(ctx, 10, requested)
+	assert.NoError(t, err)
+	assert.Equal(t, orderReturn, res)
+	assert.Equal(t, uint64(1), orderRepositoryMock.SetReturnRestockedAfterCounter())
+}
+
+func TestRestockReturns(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	orderRepositoryMock.HandleNotRestockedMock.Set(func(ctx context.Context, delay time.Duration, limit int, handle func(context.Context, []model.OrderReturn) error) error {
+		assert.Equal(t, time.Minute, delay)
+		assert.Equal(t, 100, limit)
+		return handle(ctx, []model.OrderReturn{
+			{ID: 1, OrderID: 10, Items: []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}},
+			{ID: 2, OrderID: 20, Items: []model.OrderItem{{Sku: 2, Count: 1, Warehouse: 1}}},
+		})
+	})
+	stockRepositoryMock.ChangeStocksMock.Set(func(_ context.Context, changes []model.StockChange) ([]model.StockMovement, error) {
+		if changes[0].ReturnID == 2 {
+			return nil, model.ErrWarehouseNotFound
+		}
+		return nil, nil
+	})
+	orderRepositoryMock.SetReturnRestockedMock.Expect(ctx, 10, 1).Return(nil)
+
+	restocked, err := service.RestockReturns(ctx, time.Minute, 100)
+	assert.ErrorIs(t, err, model.ErrWarehouseNotFound)
+	assert.Equal(t, 1, restocked)
+}
+
+func TestCancelUnpaidOrders(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	orderRepositoryMock.HandleUnpaidMock.Set(func(ctx context.Context, timeout time.Duration, limit int, handle func(context.Context, []model.OrderID) error) error {
+		assert.Equal(t, 15*time.Minute, timeout)
+		assert.Equal(t, 100, limit)
+		return handle(ctx, []model.OrderID{1, 2, 3})
+	})
+	orderRepositoryMock.GetByIdMock.Set(func(_ context.Context, orderID model.OrderID) (model.Order, error) {
+		// заказ 2 успели оплатить после выборки
+		status := model.OrderStatusAwaitingPayment
+		if orderID == 2 {
+			status = model.OrderStatusPaid
+		}
+		return model.Order{ID: orderID, Status: status, Items: []model.OrderItem{{Sku: 1, Count: 1}}}, nil
+	})
+	stockRepositoryMock.ReserveCancelMock.Return(nil)
+	orderRepositoryMock.SetStatusMock.Set(func(ctx context.Context, orderID model.OrderID, from, to model.OrderStatus) ([]model.OrderItem, error) {
+		assert.Equal(t, model.OrderStatusAwaitingPayment, from)
+		assert.Equal(t, model.OrderStatusCancelled, to)
+		assert.Equal(t, model.EventReasonTimeout, model.EventReasonFromContext(ctx))
+		return []model.OrderItem{{Sku: 1, Count: 1}}, nil
+	})
+
+	cancelled, err := service.CancelUnpaidOrders(ctx, 15*time.Minute, 100)
+	assert.Error(t, err)
+	assert.Equal(t, 2, cancelled)
+	assert.Equal(t, uint64(2), orderRepositoryMock.SetStatusAfterCounter())
+}
+
+func TestStreamOrders(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	errSend := errors.New("send error")
+	orderRepositoryMock.StreamAllMock.Set(func(_ context.Context, afterID model.OrderID, batchSize int, fn func(model.Order) error) error {
+		assert.Equal(t, model.OrderID(1000), afterID)
+		assert.Equal(t, 100, batchSize)
+		for _, id := range []model.OrderID{1001, 1002, 2001} {
+			if err := fn(model.Order{ID: id}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+
+	var sent []model.OrderID
+	err := service.StreamOrders(ctx, 1000, func(order model.Order) error {
+		sent = append(sent, order.ID)
+		return nil
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, []model.OrderID{1001, 1002, 2001}, sent)
+
+	// ошибка отправки прерывает выгрузку
+	err = service.StreamOrders(ctx, 1000, func(order model.Order) error {
+		return errSend
+	})
+	assert.ErrorIs(t, err, errSend)
+}
+
+func TestStocksInfoBatch(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	testData := []struct {
+		name    string
+		skus    []model.ProductSku
+		prepare func()
+		test    func(stocks map[model.ProductSku]uint64, err error)
+	}{
+		{
+			name: "valid params",
+			skus: []model.ProductSku{1, 2, 888888},
+			prepare: func() {
+				stockRepositoryMock.GetStocksBySkusMock.Expect(ctx, []model.ProductSku{1, 2, 888888}).Return(map[model.ProductSku]uint64{
+					1: 4,
+					2: 3,
+				}, nil)
+			},
+			test: func(stocks map[model.ProductSku]uint64, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, map[model.ProductSku]uint64{1: 4, 2: 3}, stocks)
+			},
+		},
+		{
+			name: "repository error",
+			skus: []model.ProductSku{1},
+			prepare: func() {
+				stockRepositoryMock.GetStocksBySkusMock.Expect(ctx, []model.ProductSku{1}).Return(nil, errors.New("db error"))
+			},
+			test: func(stocks map[model.ProductSku]uint64, err error) {
+				assert.Error(t, err)
+			},
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			stocks, err := service.StocksInfoBatch(ctx, tt.skus)
+			tt.test(stocks, err)
+		})
+	}
+}
+
+func TestHoldCreate(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	expiresAt := time.Now().Add(time.Minute)
+	testData := []struct {
+		name    string
+		prepare func()
+		test    func(hold model.StockHold, err error)
+	}{
+		{
+			name: "valid params",
+			prepare: func() {
+				stockRepositoryMock.HoldCreateMock.Expect(ctx, 1, 1, 2, time.Minute).Return(model.StockHold{
+					ID:        1,
+					User:      1,
+					Sku:       1,
+					Count:     2,
+					ExpiresAt: expiresAt,
+				}, nil)
+			},
+			test: func(hold model.StockHold, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.HoldID(1), hold.ID)
+				assert.Equal(t, expiresAt, hold.ExpiresAt)
+			},
+		},
+		{
+			name: "not enough stock",
+			prepare: func() {
+				stockRepositoryMock.HoldCreateMock.Expect(ctx, 1, 1, 2, time.Minute).Return(model.StockHold{}, model.ErrNotEnoughStock)
+			},
+			test: func(hold model.StockHold, err error) {
+				assert.ErrorIs(t, err, model.ErrNotEnoughStock)
+			},
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare()
+			hold, err := service.HoldCreate(ctx, 1, 1, 2, time.Minute)
+			tt.test(hold, err)
+		})
+	}
+}
+
+func TestStockChange(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	testData := []struct {
+		name    string
+		change  model.StockChange
+		prepare func(change model.StockChange)
+		test    func(movement model.StockMovement, err error)
+	}{
+		{
+			name:   "add",
+			change: model.StockChange{Sku: 1, Operation: model.StockOperationAdd, Count: 10, Reason: "supply", Actor: "admin"},
+			prepare: func(change model.StockChange) {
+				// без склада изменение идет на основной
+				change.Warehouse = model.DefaultWarehouseID
+				stockRepositoryMock.ChangeStockMock.Expect(ctx, change).Return(model.StockMovement{ID: 1, Sku: 1, Delta: 10, TotalCount: 17}, nil)
+			},
+			test: func(movement model.StockMovement, err error) {
+				assert.NoError(t, err)
+				assert.Equal(t, model.StockMovement{ID: 1, Sku: 1, Delta: 10, TotalCount: 17}, movement)
+			},
+		},
+		{
+			name:   "set below reserved",
+			change: model.StockChange{Warehouse: 2, Sku: 1, Operation: model.StockOperationSet, Count: 1, Reason: "inventory", Actor: "admin"},
+			prepare: func(change model.StockChange) {
+				stockRepositoryMock.ChangeStockMock.Expect(ctx, change).Return(model.StockMovement{}, model.ErrStockBelowReserved)
+			},
+			test: func(movement model.StockMovement, err error) {
+				assert.ErrorIs(t, err, model.ErrStockBelowReserved)
+			},
+		},
+		{
+			name:    "add not positive",
+			change:  model.StockChange{Sku: 1, Operation: model.StockOperationAdd, Count: -1, Reason: "supply", Actor: "admin"},
+			prepare: func(model.StockChange) {},
+			test: func(movement model.StockMovement, err error) {
+				assert.ErrorIs(t, err, model.ErrInvalidStockChange)
+			},
+		},
+		{
+			name:    "zero adjust",
+			change:  model.StockChange{Sku: 1, Operation: model.StockOperationAdjust, Reason: "damaged", Actor: "admin"},
+			prepare: func(model.StockChange) {},
+			test: func(movement model.StockMovement, err error) {
+				assert.ErrorIs(t, err, model.ErrInvalidStockChange)
+			},
+		},
+		{
+			name:    "no actor",
+			change:  model.StockChange{Sku: 1, Operation: model.StockOperationSet, Count: 5, Reason: "inventory"},
+			prepare: func(model.StockChange) {},
+			test: func(movement model.StockMovement, err error) {
+				assert.ErrorIs(t, err, model.ErrInvalidStockChange)
+			},
+		},
+	}
+	for _, tt := range testData {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.prepare(tt.change)
+			movement, err := service.StockChange(ctx, tt.change)
+			tt.test(movement, err)
+		})
+	}
+}
+
+func TestPageToken(t *testing.T) {
+	cursor := model.OrderCursor{CreatedAt: time.Date(2024, 8, 26, 12, 0, 0, 123456000, time.UTC), ID: 2001}
+
+	token := encodePageToken(cursor)
+	decoded, err := decodePageToken(token)
+	assert.NoError(t, err)
+	assert.Equal(t, &cursor, decoded)
+
+	decoded, err = decodePageToken("")
+	assert.NoError(t, err)
+	assert.Nil(t, decoded)
+
+	for _, token := range []string{"not base64!", "bm90IGpzb24", "eyJjIjoxfQ"} {
+		_, err := decodePageToken(token)
+		assert.ErrorIs(t, err, model.ErrInvalidPageToken, token)
+	}
+}
+
+func TestListOrders(t *testing.T) {
+	ctx := context.Background()
+	ctrl := minimock.NewController(t)
+	stockRepositoryMock := mock.NewStockRepositoryMock(ctrl)
+	orderRepositoryMock := mock.NewOrderRepositoryMock(ctrl)
+	service := NewLomsService(stockRepositoryMock, orderRepositoryMock)
+
+	createdAt := time.Date(2024, 8, 26, 12, 0, 0, 0, time.UTC)
+	filter := model.OrderFilter{User: 1, Statuses: []model.OrderStatus{model.OrderStatusPaid}}
+	orders := []model.Order{
+		{ID: 3001, User: 1, CreatedAt: createdAt},
+		{ID: 2001, User: 1, CreatedAt: createdAt},
+		{ID: 1001, User: 1, CreatedAt: createdAt.Add(-time.Hour)},
+	}
+
+	// первая страница: запрашивается на один заказ больше, чтобы понять, есть ли следующая
+	orderRepositoryMock.ListByUserMock.Expect(ctx, filter, nil, 3).Return(orders, nil)
+	page, nextPageToken, err := service.ListOrders(ctx, filter, "", 2)
+	assert.NoError(t, err)
+	assert.Equal(t, orders[:2], page)
+	assert.NotEmpty(t, nextPageToken)
+
+	// следующая страница продолжается после последнего заказа предыдущей
+	orderRepositoryMock.ListByUserMock.Expect(ctx, filter, &model.OrderCursor{CreatedAt: createdAt, ID: 2001}, 3).Return(orders[2:], nil)
+	page, nextPageToken, err = service.ListOrders(ctx, filter, nextPageToken, 2)
+	assert.NoError(t, err)
+	assert.Equal(t, orders[2:], page)
+	assert.Empty(t, nextPageToken)
+
+	_, _, err = service.ListOrders(ctx, filter, "broken", 2)
+	assert.ErrorIs(t, err, model.ErrInvalidPageToken)
+}
@@ -1,4 +1,4 @@
-// Code generated by http://github.com/gojuno/minimock (v3.3.13). DO NOT EDIT.
+// Code generated by http://github.com/gojuno/minimock (v3.3.11). DO NOT EDIT.
 
 package mock
 
@@ -9,6 +9,7 @@ import (
 	"route256/loms/internal/pkg/model"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -19,6 +20,12 @@ type OrderRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
+	funcAddReturn          func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error) (o2 model.OrderReturn, err error)
+	inspectFuncAddReturn   func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error)
+	afterAddReturnCounter  uint64
+	beforeAddReturnCounter uint64
+	AddReturnMock          mOrderRepositoryMockAddReturn
+
 	funcCreate          func(ctx context.Context, o1 model.Order) (o2 model.OrderID, err error)
 	inspectFuncCreate   func(ctx context.Context, o1 model.Order)
 	afterCreateCounter  uint64
@@ -37,11 +44,65 @@ type OrderRepositoryMock struct {
 	beforeGetByIdCounter uint64
 	GetByIdMock          mOrderRepositoryMockGetById
 
-	funcSetStatus          func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus) (err error)
-	inspectFuncSetStatus   func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus)
+	funcGetByRequestID          func(ctx context.Context, u1 model.UserID, s1 string) (o1 model.Order, err error)
+	inspectFuncGetByRequestID   func(ctx context.Context, u1 model.UserID, s1 string)
+	afterGetByRequestIDCounter  uint64
+	beforeGetByRequestIDCounter uint64
+	GetByRequestIDMock          mOrderRepositoryMockGetByRequestID
+
+	funcGetStatusHistory          func(ctx context.Context, o1 model.OrderID) (oa1 []model.OrderStatusChange, err error)
+	inspectFuncGetStatusHistory   func(ctx context.Context, o1 model.OrderID)
+	afterGetStatusHistoryCounter  uint64
+	beforeGetStatusHistoryCounter uint64
+	GetStatusHistoryMock          mOrderRepositoryMockGetStatusHistory
+
+	funcHandleNotRestocked          func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error) (err error)
+	inspectFuncHandleNotRestocked   func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error)
+	afterHandleNotRestockedCounter  uint64
+	beforeHandleNotRestockedCounter uint64
+	HandleNotRestockedMock          mOrderRepositoryMockHandleNotRestocked
+
+	funcHandleUnpaid          func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error) (err error)
+	inspectFuncHandleUnpaid   func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error)
+	afterHandleUnpaidCounter  uint64
+	beforeHandleUnpaidCounter uint64
+	HandleUnpaidMock          mOrderRepositoryMockHandleUnpaid
+
+	funcListByUser          func(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int) (oa1 []model.Order, err error)
+	inspectFuncListByUser   func(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int)
+	afterListByUserCounter  uint64
+	beforeListByUserCounter uint64
+	ListByUserMock          mOrderRepositoryMockListByUser
+
+	funcSetItems          func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem) (err error)
+	inspectFuncSetItems   func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem)
+	afterSetItemsCounter  uint64
+	beforeSetItemsCounter uint64
+	SetItemsMock          mOrderRepositoryMockSetItems
+
+	funcSetReturnRestocked          func(ctx context.Context, o1 model.OrderID, r1 model.ReturnID) (err error)
+	inspectFuncSetReturnRestocked   func(ctx context.Context, o1 model.OrderID, r1 model.ReturnID)
+	afterSetReturnRestockedCounter  uint64
+	beforeSetReturnRestockedCounter uint64
+	SetReturnRestockedMock          mOrderRepositoryMockSetReturnRestocked
+
+	funcSetStatus          func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus) (oa1 []model.OrderItem, err error)
+	inspectFuncSetStatus   func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus)
 	afterSetStatusCounter  uint64
 	beforeSetStatusCounter uint64
 	SetStatusMock          mOrderRepositoryMockSetStatus
+
+	funcStreamAll          func(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error) (err error)
+	inspectFuncStreamAll   func(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error)
+	afterStreamAllCounter  uint64
+	beforeStreamAllCounter uint64
+	StreamAllMock          mOrderRepositoryMockStreamAll
+
+	funcUpdateItems          func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error) (o2 model.Order, err error)
+	inspectFuncUpdateItems   func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error)
+	afterUpdateItemsCounter  uint64
+	beforeUpdateItemsCounter uint64
+	UpdateItemsMock          mOrderRepositoryMockUpdateItems
 }
 
 // NewOrderRepositoryMock returns a mock for service.orderRepository
@@ -52,6 +113,9 @@ func NewOrderRepositoryMock(t minimock.Tester) *OrderRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.AddReturnMock = mOrderRepositoryMockAddReturn{mock: m}
+	m.AddReturnMock.callArgs = []*OrderRepositoryMockAddReturnParams{}
+
 	m.CreateMock = mOrderRepositoryMockCreate{mock: m}
 	m.CreateMock.callArgs = []*OrderRepositoryMockCreateParams{}
 
@@ -61,14 +125,418 @@ func NewOrderRepositoryMock(t minimock.Tester) *OrderRepositoryMock {
 	m.GetByIdMock = mOrderRepositoryMockGetById{mock: m}
 	m.GetByIdMock.callArgs = []*OrderRepositoryMockGetByIdParams{}
 
+	m.GetByRequestIDMock = mOrderRepositoryMockGetByRequestID{mock: m}
+	m.GetByRequestIDMock.callArgs = []*OrderRepositoryMockGetByRequestIDParams{}
+
+	m.GetStatusHistoryMock = mOrderRepositoryMockGetStatusHistory{mock: m}
+	m.GetStatusHistoryMock.callArgs = []*OrderRepositoryMockGetStatusHistoryParams{}
+
+	m.HandleNotRestockedMock = mOrderRepositoryMockHandleNotRestocked{mock: m}
+	m.HandleNotRestockedMock.callArgs = []*OrderRepositoryMockHandleNotRestockedParams{}
+
+	m.HandleUnpaidMock = mOrderRepositoryMockHandleUnpaid{mock: m}
+	m.HandleUnpaidMock.callArgs = []*OrderRepositoryMockHandleUnpaidParams{}
+
+	m.ListByUserMock = mOrderRepositoryMockListByUser{mock: m}
+	m.ListByUserMock.callArgs = []*OrderRepositoryMockListByUserParams{}
+
+	m.SetItemsMock = mOrderRepositoryMockSetItems{mock: m}
+	m.SetItemsMock.callArgs = []*OrderRepositoryMockSetItemsParams{}
+
+	m.SetReturnRestockedMock = mOrderRepositoryMockSetReturnRestocked{mock: m}
+	m.SetReturnRestockedMock.callArgs = []*OrderRepositoryMockSetReturnRestockedParams{}
+
 	m.SetStatusMock = mOrderRepositoryMockSetStatus{mock: m}
 	m.SetStatusMock.callArgs = []*OrderRepositoryMockSetStatusParams{}
 
+	m.StreamAllMock = mOrderRepositoryMockStreamAll{mock: m}
+	m.StreamAllMock.callArgs = []*OrderRepositoryMockStreamAllParams{}
+
+	m.UpdateItemsMock = mOrderRepositoryMockUpdateItems{mock: m}
+	m.UpdateItemsMock.callArgs = []*OrderRepositoryMockUpdateItemsParams{}
+
 	t.Cleanup(m.MinimockFinish)
 
 	return m
 }
 
+type mOrderRepositoryMockAddReturn struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockAddReturnExpectation
+	expectations       []*OrderRepositoryMockAddReturnExpectation
+
+	callArgs []*OrderRepositoryMockAddReturnParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockAddReturnExpectation specifies expectation struct of the orderRepository.AddReturn
+type OrderRepositoryMockAddReturnExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockAddReturnParams
+	paramPtrs *OrderRepositoryMockAddReturnParamPtrs
+	results   *OrderRepositoryMockAddReturnResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockAddReturnParams contains parameters of the orderRepository.AddReturn
+type OrderRepositoryMockAddReturnParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	oa1 []model.OrderItem
+	p1  []func(context.Context, model.OrderReturn) error
+}
+
+// OrderRepositoryMockAddReturnParamPtrs contains pointers to parameters of the orderRepository.AddReturn
+type OrderRepositoryMockAddReturnParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	oa1 *[]model.OrderItem
+	p1  *[]func(context.Context, model.OrderReturn) error
+}
+
+// OrderRepositoryMockAddReturnResults contains results of the orderRepository.AddReturn
+type OrderRepositoryMockAddReturnResults struct {
+	o2  model.OrderReturn
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Optional() *mOrderRepositoryMockAddReturn {
+	mmAddReturn.optional = true
+	return mmAddReturn
+}
+
+// Expect sets up expected params for orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Expect(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{}
+	}
+
+	if mmAddReturn.defaultExpectation.paramPtrs != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by ExpectParams functions")
+	}
+
+	mmAddReturn.defaultExpectation.params = &OrderRepositoryMockAddReturnParams{ctx, o1, oa1, p1}
+	for _, e := range mmAddReturn.expectations {
+		if minimock.Equal(e.params, mmAddReturn.defaultExpectation.params) {
+			mmAddReturn.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmAddReturn.defaultExpectation.params)
+		}
+	}
+
+	return mmAddReturn
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{}
+	}
+
+	if mmAddReturn.defaultExpectation.params != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Expect")
+	}
+
+	if mmAddReturn.defaultExpectation.paramPtrs == nil {
+		mmAddReturn.defaultExpectation.paramPtrs = &OrderRepositoryMockAddReturnParamPtrs{}
+	}
+	mmAddReturn.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmAddReturn
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{}
+	}
+
+	if mmAddReturn.defaultExpectation.params != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Expect")
+	}
+
+	if mmAddReturn.defaultExpectation.paramPtrs == nil {
+		mmAddReturn.defaultExpectation.paramPtrs = &OrderRepositoryMockAddReturnParamPtrs{}
+	}
+	mmAddReturn.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmAddReturn
+}
+
+// ExpectOa1Param3 sets up expected param oa1 for orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) ExpectOa1Param3(oa1 []model.OrderItem) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{}
+	}
+
+	if mmAddReturn.defaultExpectation.params != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Expect")
+	}
+
+	if mmAddReturn.defaultExpectation.paramPtrs == nil {
+		mmAddReturn.defaultExpectation.paramPtrs = &OrderRepositoryMockAddReturnParamPtrs{}
+	}
+	mmAddReturn.defaultExpectation.paramPtrs.oa1 = &oa1
+
+	return mmAddReturn
+}
+
+// ExpectP1Param4 sets up expected param p1 for orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) ExpectP1Param4(p1 ...func(context.Context, model.OrderReturn) error) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{}
+	}
+
+	if mmAddReturn.defaultExpectation.params != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Expect")
+	}
+
+	if mmAddReturn.defaultExpectation.paramPtrs == nil {
+		mmAddReturn.defaultExpectation.paramPtrs = &OrderRepositoryMockAddReturnParamPtrs{}
+	}
+	mmAddReturn.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmAddReturn
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Inspect(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error)) *mOrderRepositoryMockAddReturn {
+	if mmAddReturn.mock.inspectFuncAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.AddReturn")
+	}
+
+	mmAddReturn.mock.inspectFuncAddReturn = f
+
+	return mmAddReturn
+}
+
+// Return sets up results that will be returned by orderRepository.AddReturn
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Return(o2 model.OrderReturn, err error) *OrderRepositoryMock {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	if mmAddReturn.defaultExpectation == nil {
+		mmAddReturn.defaultExpectation = &OrderRepositoryMockAddReturnExpectation{mock: mmAddReturn.mock}
+	}
+	mmAddReturn.defaultExpectation.results = &OrderRepositoryMockAddReturnResults{o2, err}
+	return mmAddReturn.mock
+}
+
+// Set uses given function f to mock the orderRepository.AddReturn method
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Set(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error) (o2 model.OrderReturn, err error)) *OrderRepositoryMock {
+	if mmAddReturn.defaultExpectation != nil {
+		mmAddReturn.mock.t.Fatalf("Default expectation is already set for the orderRepository.AddReturn method")
+	}
+
+	if len(mmAddReturn.expectations) > 0 {
+		mmAddReturn.mock.t.Fatalf("Some expectations are already set for the orderRepository.AddReturn method")
+	}
+
+	mmAddReturn.mock.funcAddReturn = f
+	return mmAddReturn.mock
+}
+
+// When sets expectation for the orderRepository.AddReturn which will trigger the result defined by the following
+// Then helper
+func (mmAddReturn *mOrderRepositoryMockAddReturn) When(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error) *OrderRepositoryMockAddReturnExpectation {
+	if mmAddReturn.mock.funcAddReturn != nil {
+		mmAddReturn.mock.t.Fatalf("OrderRepositoryMock.AddReturn mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockAddReturnExpectation{
+		mock:   mmAddReturn.mock,
+		params: &OrderRepositoryMockAddReturnParams{ctx, o1, oa1, p1},
+	}
+	mmAddReturn.expectations = append(mmAddReturn.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.AddReturn return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockAddReturnExpectation) Then(o2 model.OrderReturn, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockAddReturnResults{o2, err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.AddReturn should be invoked
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Times(n uint64) *mOrderRepositoryMockAddReturn {
+	if n == 0 {
+		mmAddReturn.mock.t.Fatalf("Times of OrderRepositoryMock.AddReturn mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmAddReturn.expectedInvocations, n)
+	return mmAddReturn
+}
+
+func (mmAddReturn *mOrderRepositoryMockAddReturn) invocationsDone() bool {
+	if len(mmAddReturn.expectations) == 0 && mmAddReturn.defaultExpectation == nil && mmAddReturn.mock.funcAddReturn == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmAddReturn.mock.afterAddReturnCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmAddReturn.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// AddReturn implements service.orderRepository
+func (mmAddReturn *OrderRepositoryMock) AddReturn(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, p1 ...func(context.Context, model.OrderReturn) error) (o2 model.OrderReturn, err error) {
+	mm_atomic.AddUint64(&mmAddReturn.beforeAddReturnCounter, 1)
+	defer mm_atomic.AddUint64(&mmAddReturn.afterAddReturnCounter, 1)
+
+	if mmAddReturn.inspectFuncAddReturn != nil {
+		mmAddReturn.inspectFuncAddReturn(ctx, o1, oa1, p1...)
+	}
+
+	mm_params := OrderRepositoryMockAddReturnParams{ctx, o1, oa1, p1}
+
+	// Record call args
+	mmAddReturn.AddReturnMock.mutex.Lock()
+	mmAddReturn.AddReturnMock.callArgs = append(mmAddReturn.AddReturnMock.callArgs, &mm_params)
+	mmAddReturn.AddReturnMock.mutex.Unlock()
+
+	for _, e := range mmAddReturn.AddReturnMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.o2, e.results.err
+		}
+	}
+
+	if mmAddReturn.AddReturnMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmAddReturn.AddReturnMock.defaultExpectation.Counter, 1)
+		mm_want := mmAddReturn.AddReturnMock.defaultExpectation.params
+		mm_want_ptrs := mmAddReturn.AddReturnMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockAddReturnParams{ctx, o1, oa1, p1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmAddReturn.t.Errorf("OrderRepositoryMock.AddReturn got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmAddReturn.t.Errorf("OrderRepositoryMock.AddReturn got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmAddReturn.t.Errorf("OrderRepositoryMock.AddReturn got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmAddReturn.t.Errorf("OrderRepositoryMock.AddReturn got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmAddReturn.t.Errorf("OrderRepositoryMock.AddReturn got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmAddReturn.AddReturnMock.defaultExpectation.results
+		if mm_results == nil {
+			mmAddReturn.t.Fatal("No results are set for the OrderRepositoryMock.AddReturn")
+		}
+		return (*mm_results).o2, (*mm_results).err
+	}
+	if mmAddReturn.funcAddReturn != nil {
+		return mmAddReturn.funcAddReturn(ctx, o1, oa1, p1...)
+	}
+	mmAddReturn.t.Fatalf("Unexpected call to OrderRepositoryMock.AddReturn. %v %v %v %v", ctx, o1, oa1, p1)
+	return
+}
+
+// AddReturnAfterCounter returns a count of finished OrderRepositoryMock.AddReturn invocations
+func (mmAddReturn *OrderRepositoryMock) AddReturnAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddReturn.afterAddReturnCounter)
+}
+
+// AddReturnBeforeCounter returns a count of OrderRepositoryMock.AddReturn invocations
+func (mmAddReturn *OrderRepositoryMock) AddReturnBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmAddReturn.beforeAddReturnCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.AddReturn.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmAddReturn *mOrderRepositoryMockAddReturn) Calls() []*OrderRepositoryMockAddReturnParams {
+	mmAddReturn.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockAddReturnParams, len(mmAddReturn.callArgs))
+	copy(argCopy, mmAddReturn.callArgs)
+
+	mmAddReturn.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockAddReturnDone returns true if the count of the AddReturn invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockAddReturnDone() bool {
+	if m.AddReturnMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.AddReturnMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.AddReturnMock.invocationsDone()
+}
+
+// MinimockAddReturnInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockAddReturnInspect() {
+	for _, e := range m.AddReturnMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.AddReturn with params: %#v", *e.params)
+		}
+	}
+
+	afterAddReturnCounter := mm_atomic.LoadUint64(&m.afterAddReturnCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.AddReturnMock.defaultExpectation != nil && afterAddReturnCounter < 1 {
+		if m.AddReturnMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.AddReturn")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.AddReturn with params: %#v", *m.AddReturnMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcAddReturn != nil && afterAddReturnCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.AddReturn")
+	}
+
+	if !m.AddReturnMock.invocationsDone() && afterAddReturnCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.AddReturn but found %d calls",
+			mm_atomic.LoadUint64(&m.AddReturnMock.expectedInvocations), afterAddReturnCounter)
+	}
+}
+
 type mOrderRepositoryMockCreate struct {
 	optional           bool
 	mock               *OrderRepositoryMock
@@ -111,7 +579,7 @@ type OrderRepositoryMockCreateResults struct {
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
 // the test will fail minimock's automatic final call check if the mocked method was not called at least once.
 // Optional() makes method check to work in '0 or more' mode.
-// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
 func (mmCreate *mOrderRepositoryMockCreate) Optional() *mOrderRepositoryMockCreate {
 	mmCreate.optional = true
@@ -430,7 +898,7 @@ type OrderRepositoryMockGetAllResults struct {
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
 // the test will fail minimock's automatic final call check if the mocked method was not called at least once.
 // Optional() makes method check to work in '0 or more' mode.
-// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
 func (mmGetAll *mOrderRepositoryMockGetAll) Optional() *mOrderRepositoryMockGetAll {
 	mmGetAll.optional = true
@@ -725,7 +1193,7 @@ type OrderRepositoryMockGetByIdResults struct {
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
 // the test will fail minimock's automatic final call check if the mocked method was not called at least once.
 // Optional() makes method check to work in '0 or more' mode.
-// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
 func (mmGetById *mOrderRepositoryMockGetById) Optional() *mOrderRepositoryMockGetById {
 	mmGetById.optional = true
@@ -1004,351 +1472,3656 @@ func (m *OrderRepositoryMock) MinimockGetByIdInspect() {
 	}
 }
 
-type mOrderRepositoryMockSetStatus struct {
+type mOrderRepositoryMockGetByRequestID struct {
 	optional           bool
 	mock               *OrderRepositoryMock
-	defaultExpectation *OrderRepositoryMockSetStatusExpectation
-	expectations       []*OrderRepositoryMockSetStatusExpectation
+	defaultExpectation *OrderRepositoryMockGetByRequestIDExpectation
+	expectations       []*OrderRepositoryMockGetByRequestIDExpectation
 
-	callArgs []*OrderRepositoryMockSetStatusParams
+	callArgs []*OrderRepositoryMockGetByRequestIDParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// OrderRepositoryMockSetStatusExpectation specifies expectation struct of the orderRepository.SetStatus
-type OrderRepositoryMockSetStatusExpectation struct {
+// OrderRepositoryMockGetByRequestIDExpectation specifies expectation struct of the orderRepository.GetByRequestID
+type OrderRepositoryMockGetByRequestIDExpectation struct {
 	mock      *OrderRepositoryMock
-	params    *OrderRepositoryMockSetStatusParams
-	paramPtrs *OrderRepositoryMockSetStatusParamPtrs
-	results   *OrderRepositoryMockSetStatusResults
+	params    *OrderRepositoryMockGetByRequestIDParams
+	paramPtrs *OrderRepositoryMockGetByRequestIDParamPtrs
+	results   *OrderRepositoryMockGetByRequestIDResults
 	Counter   uint64
 }
 
-// OrderRepositoryMockSetStatusParams contains parameters of the orderRepository.SetStatus
-type OrderRepositoryMockSetStatusParams struct {
+// OrderRepositoryMockGetByRequestIDParams contains parameters of the orderRepository.GetByRequestID
+type OrderRepositoryMockGetByRequestIDParams struct {
 	ctx context.Context
-	o1  model.OrderID
-	o2  model.OrderStatus
+	u1  model.UserID
+	s1  string
 }
 
-// OrderRepositoryMockSetStatusParamPtrs contains pointers to parameters of the orderRepository.SetStatus
-type OrderRepositoryMockSetStatusParamPtrs struct {
+// OrderRepositoryMockGetByRequestIDParamPtrs contains pointers to parameters of the orderRepository.GetByRequestID
+type OrderRepositoryMockGetByRequestIDParamPtrs struct {
 	ctx *context.Context
-	o1  *model.OrderID
-	o2  *model.OrderStatus
+	u1  *model.UserID
+	s1  *string
 }
 
-// OrderRepositoryMockSetStatusResults contains results of the orderRepository.SetStatus
-type OrderRepositoryMockSetStatusResults struct {
+// OrderRepositoryMockGetByRequestIDResults contains results of the orderRepository.GetByRequestID
+type OrderRepositoryMockGetByRequestIDResults struct {
+	o1  model.Order
 	err error
 }
 
 // Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
 // the test will fail minimock's automatic final call check if the mocked method was not called at least once.
 // Optional() makes method check to work in '0 or more' mode.
-// It is NOT RECOMMENDED to use this option unless you really need it, as default behaviour helps to
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Optional() *mOrderRepositoryMockSetStatus {
-	mmSetStatus.optional = true
-	return mmSetStatus
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Optional() *mOrderRepositoryMockGetByRequestID {
+	mmGetByRequestID.optional = true
+	return mmGetByRequestID
 }
 
-// Expect sets up expected params for orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Expect(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus) *mOrderRepositoryMockSetStatus {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+// Expect sets up expected params for orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Expect(ctx context.Context, u1 model.UserID, s1 string) *mOrderRepositoryMockGetByRequestID {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
 	}
 
-	if mmSetStatus.defaultExpectation == nil {
-		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	if mmGetByRequestID.defaultExpectation == nil {
+		mmGetByRequestID.defaultExpectation = &OrderRepositoryMockGetByRequestIDExpectation{}
 	}
 
-	if mmSetStatus.defaultExpectation.paramPtrs != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by ExpectParams functions")
+	if mmGetByRequestID.defaultExpectation.paramPtrs != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by ExpectParams functions")
 	}
 
-	mmSetStatus.defaultExpectation.params = &OrderRepositoryMockSetStatusParams{ctx, o1, o2}
-	for _, e := range mmSetStatus.expectations {
-		if minimock.Equal(e.params, mmSetStatus.defaultExpectation.params) {
-			mmSetStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetStatus.defaultExpectation.params)
+	mmGetByRequestID.defaultExpectation.params = &OrderRepositoryMockGetByRequestIDParams{ctx, u1, s1}
+	for _, e := range mmGetByRequestID.expectations {
+		if minimock.Equal(e.params, mmGetByRequestID.defaultExpectation.params) {
+			mmGetByRequestID.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetByRequestID.defaultExpectation.params)
 		}
 	}
 
-	return mmSetStatus
+	return mmGetByRequestID
 }
 
-// ExpectCtxParam1 sets up expected param ctx for orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockSetStatus {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockGetByRequestID {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
 	}
 
-	if mmSetStatus.defaultExpectation == nil {
-		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	if mmGetByRequestID.defaultExpectation == nil {
+		mmGetByRequestID.defaultExpectation = &OrderRepositoryMockGetByRequestIDExpectation{}
 	}
 
-	if mmSetStatus.defaultExpectation.params != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
+	if mmGetByRequestID.defaultExpectation.params != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Expect")
 	}
 
-	if mmSetStatus.defaultExpectation.paramPtrs == nil {
-		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	if mmGetByRequestID.defaultExpectation.paramPtrs == nil {
+		mmGetByRequestID.defaultExpectation.paramPtrs = &OrderRepositoryMockGetByRequestIDParamPtrs{}
 	}
-	mmSetStatus.defaultExpectation.paramPtrs.ctx = &ctx
+	mmGetByRequestID.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmSetStatus
+	return mmGetByRequestID
 }
 
-// ExpectO1Param2 sets up expected param o1 for orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockSetStatus {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+// ExpectU1Param2 sets up expected param u1 for orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) ExpectU1Param2(u1 model.UserID) *mOrderRepositoryMockGetByRequestID {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
 	}
 
-	if mmSetStatus.defaultExpectation == nil {
-		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	if mmGetByRequestID.defaultExpectation == nil {
+		mmGetByRequestID.defaultExpectation = &OrderRepositoryMockGetByRequestIDExpectation{}
 	}
 
-	if mmSetStatus.defaultExpectation.params != nil {
+	if mmGetByRequestID.defaultExpectation.params != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Expect")
+	}
+
+	if mmGetByRequestID.defaultExpectation.paramPtrs == nil {
+		mmGetByRequestID.defaultExpectation.paramPtrs = &OrderRepositoryMockGetByRequestIDParamPtrs{}
+	}
+	mmGetByRequestID.defaultExpectation.paramPtrs.u1 = &u1
+
+	return mmGetByRequestID
+}
+
+// ExpectS1Param3 sets up expected param s1 for orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) ExpectS1Param3(s1 string) *mOrderRepositoryMockGetByRequestID {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
+	}
+
+	if mmGetByRequestID.defaultExpectation == nil {
+		mmGetByRequestID.defaultExpectation = &OrderRepositoryMockGetByRequestIDExpectation{}
+	}
+
+	if mmGetByRequestID.defaultExpectation.params != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Expect")
+	}
+
+	if mmGetByRequestID.defaultExpectation.paramPtrs == nil {
+		mmGetByRequestID.defaultExpectation.paramPtrs = &OrderRepositoryMockGetByRequestIDParamPtrs{}
+	}
+	mmGetByRequestID.defaultExpectation.paramPtrs.s1 = &s1
+
+	return mmGetByRequestID
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Inspect(f func(ctx context.Context, u1 model.UserID, s1 string)) *mOrderRepositoryMockGetByRequestID {
+	if mmGetByRequestID.mock.inspectFuncGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.GetByRequestID")
+	}
+
+	mmGetByRequestID.mock.inspectFuncGetByRequestID = f
+
+	return mmGetByRequestID
+}
+
+// Return sets up results that will be returned by orderRepository.GetByRequestID
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Return(o1 model.Order, err error) *OrderRepositoryMock {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
+	}
+
+	if mmGetByRequestID.defaultExpectation == nil {
+		mmGetByRequestID.defaultExpectation = &OrderRepositoryMockGetByRequestIDExpectation{mock: mmGetByRequestID.mock}
+	}
+	mmGetByRequestID.defaultExpectation.results = &OrderRepositoryMockGetByRequestIDResults{o1, err}
+	return mmGetByRequestID.mock
+}
+
+// Set uses given function f to mock the orderRepository.GetByRequestID method
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Set(f func(ctx context.Context, u1 model.UserID, s1 string) (o1 model.Order, err error)) *OrderRepositoryMock {
+	if mmGetByRequestID.defaultExpectation != nil {
+		mmGetByRequestID.mock.t.Fatalf("Default expectation is already set for the orderRepository.GetByRequestID method")
+	}
+
+	if len(mmGetByRequestID.expectations) > 0 {
+		mmGetByRequestID.mock.t.Fatalf("Some expectations are already set for the orderRepository.GetByRequestID method")
+	}
+
+	mmGetByRequestID.mock.funcGetByRequestID = f
+	return mmGetByRequestID.mock
+}
+
+// When sets expectation for the orderRepository.GetByRequestID which will trigger the result defined by the following
+// Then helper
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) When(ctx context.Context, u1 model.UserID, s1 string) *OrderRepositoryMockGetByRequestIDExpectation {
+	if mmGetByRequestID.mock.funcGetByRequestID != nil {
+		mmGetByRequestID.mock.t.Fatalf("OrderRepositoryMock.GetByRequestID mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockGetByRequestIDExpectation{
+		mock:   mmGetByRequestID.mock,
+		params: &OrderRepositoryMockGetByRequestIDParams{ctx, u1, s1},
+	}
+	mmGetByRequestID.expectations = append(mmGetByRequestID.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.GetByRequestID return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockGetByRequestIDExpectation) Then(o1 model.Order, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockGetByRequestIDResults{o1, err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.GetByRequestID should be invoked
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Times(n uint64) *mOrderRepositoryMockGetByRequestID {
+	if n == 0 {
+		mmGetByRequestID.mock.t.Fatalf("Times of OrderRepositoryMock.GetByRequestID mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetByRequestID.expectedInvocations, n)
+	return mmGetByRequestID
+}
+
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) invocationsDone() bool {
+	if len(mmGetByRequestID.expectations) == 0 && mmGetByRequestID.defaultExpectation == nil && mmGetByRequestID.mock.funcGetByRequestID == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetByRequestID.mock.afterGetByRequestIDCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetByRequestID.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetByRequestID implements service.orderRepository
+func (mmGetByRequestID *OrderRepositoryMock) GetByRequestID(ctx context.Context, u1 model.UserID, s1 string) (o1 model.Order, err error) {
+	mm_atomic.AddUint64(&mmGetByRequestID.beforeGetByRequestIDCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetByRequestID.afterGetByRequestIDCounter, 1)
+
+	if mmGetByRequestID.inspectFuncGetByRequestID != nil {
+		mmGetByRequestID.inspectFuncGetByRequestID(ctx, u1, s1)
+	}
+
+	mm_params := OrderRepositoryMockGetByRequestIDParams{ctx, u1, s1}
+
+	// Record call args
+	mmGetByRequestID.GetByRequestIDMock.mutex.Lock()
+	mmGetByRequestID.GetByRequestIDMock.callArgs = append(mmGetByRequestID.GetByRequestIDMock.callArgs, &mm_params)
+	mmGetByRequestID.GetByRequestIDMock.mutex.Unlock()
+
+	for _, e := range mmGetByRequestID.GetByRequestIDMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.o1, e.results.err
+		}
+	}
+
+	if mmGetByRequestID.GetByRequestIDMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetByRequestID.GetByRequestIDMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetByRequestID.GetByRequestIDMock.defaultExpectation.params
+		mm_want_ptrs := mmGetByRequestID.GetByRequestIDMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockGetByRequestIDParams{ctx, u1, s1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetByRequestID.t.Errorf("OrderRepositoryMock.GetByRequestID got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
+				mmGetByRequestID.t.Errorf("OrderRepositoryMock.GetByRequestID got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			}
+
+			if mm_want_ptrs.s1 != nil && !minimock.Equal(*mm_want_ptrs.s1, mm_got.s1) {
+				mmGetByRequestID.t.Errorf("OrderRepositoryMock.GetByRequestID got unexpected parameter s1, want: %#v, got: %#v%s\n", *mm_want_ptrs.s1, mm_got.s1, minimock.Diff(*mm_want_ptrs.s1, mm_got.s1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetByRequestID.t.Errorf("OrderRepositoryMock.GetByRequestID got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetByRequestID.GetByRequestIDMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetByRequestID.t.Fatal("No results are set for the OrderRepositoryMock.GetByRequestID")
+		}
+		return (*mm_results).o1, (*mm_results).err
+	}
+	if mmGetByRequestID.funcGetByRequestID != nil {
+		return mmGetByRequestID.funcGetByRequestID(ctx, u1, s1)
+	}
+	mmGetByRequestID.t.Fatalf("Unexpected call to OrderRepositoryMock.GetByRequestID. %v %v %v", ctx, u1, s1)
+	return
+}
+
+// GetByRequestIDAfterCounter returns a count of finished OrderRepositoryMock.GetByRequestID invocations
+func (mmGetByRequestID *OrderRepositoryMock) GetByRequestIDAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetByRequestID.afterGetByRequestIDCounter)
+}
+
+// GetByRequestIDBeforeCounter returns a count of OrderRepositoryMock.GetByRequestID invocations
+func (mmGetByRequestID *OrderRepositoryMock) GetByRequestIDBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetByRequestID.beforeGetByRequestIDCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.GetByRequestID.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetByRequestID *mOrderRepositoryMockGetByRequestID) Calls() []*OrderRepositoryMockGetByRequestIDParams {
+	mmGetByRequestID.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockGetByRequestIDParams, len(mmGetByRequestID.callArgs))
+	copy(argCopy, mmGetByRequestID.callArgs)
+
+	mmGetByRequestID.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetByRequestIDDone returns true if the count of the GetByRequestID invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockGetByRequestIDDone() bool {
+	if m.GetByRequestIDMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetByRequestIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetByRequestIDMock.invocationsDone()
+}
+
+// MinimockGetByRequestIDInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockGetByRequestIDInspect() {
+	for _, e := range m.GetByRequestIDMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetByRequestID with params: %#v", *e.params)
+		}
+	}
+
+	afterGetByRequestIDCounter := mm_atomic.LoadUint64(&m.afterGetByRequestIDCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetByRequestIDMock.defaultExpectation != nil && afterGetByRequestIDCounter < 1 {
+		if m.GetByRequestIDMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.GetByRequestID")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetByRequestID with params: %#v", *m.GetByRequestIDMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetByRequestID != nil && afterGetByRequestIDCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.GetByRequestID")
+	}
+
+	if !m.GetByRequestIDMock.invocationsDone() && afterGetByRequestIDCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.GetByRequestID but found %d calls",
+			mm_atomic.LoadUint64(&m.GetByRequestIDMock.expectedInvocations), afterGetByRequestIDCounter)
+	}
+}
+
+type mOrderRepositoryMockGetStatusHistory struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockGetStatusHistoryExpectation
+	expectations       []*OrderRepositoryMockGetStatusHistoryExpectation
+
+	callArgs []*OrderRepositoryMockGetStatusHistoryParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockGetStatusHistoryExpectation specifies expectation struct of the orderRepository.GetStatusHistory
+type OrderRepositoryMockGetStatusHistoryExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockGetStatusHistoryParams
+	paramPtrs *OrderRepositoryMockGetStatusHistoryParamPtrs
+	results   *OrderRepositoryMockGetStatusHistoryResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockGetStatusHistoryParams contains parameters of the orderRepository.GetStatusHistory
+type OrderRepositoryMockGetStatusHistoryParams struct {
+	ctx context.Context
+	o1  model.OrderID
+}
+
+// OrderRepositoryMockGetStatusHistoryParamPtrs contains pointers to parameters of the orderRepository.GetStatusHistory
+type OrderRepositoryMockGetStatusHistoryParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+}
+
+// OrderRepositoryMockGetStatusHistoryResults contains results of the orderRepository.GetStatusHistory
+type OrderRepositoryMockGetStatusHistoryResults struct {
+	oa1 []model.OrderStatusChange
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Optional() *mOrderRepositoryMockGetStatusHistory {
+	mmGetStatusHistory.optional = true
+	return mmGetStatusHistory
+}
+
+// Expect sets up expected params for orderRepository.GetStatusHistory
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Expect(ctx context.Context, o1 model.OrderID) *mOrderRepositoryMockGetStatusHistory {
+	if mmGetStatusHistory.mock.funcGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Set")
+	}
+
+	if mmGetStatusHistory.defaultExpectation == nil {
+		mmGetStatusHistory.defaultExpectation = &OrderRepositoryMockGetStatusHistoryExpectation{}
+	}
+
+	if mmGetStatusHistory.defaultExpectation.paramPtrs != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by ExpectParams functions")
+	}
+
+	mmGetStatusHistory.defaultExpectation.params = &OrderRepositoryMockGetStatusHistoryParams{ctx, o1}
+	for _, e := range mmGetStatusHistory.expectations {
+		if minimock.Equal(e.params, mmGetStatusHistory.defaultExpectation.params) {
+			mmGetStatusHistory.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStatusHistory.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStatusHistory
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.GetStatusHistory
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockGetStatusHistory {
+	if mmGetStatusHistory.mock.funcGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Set")
+	}
+
+	if mmGetStatusHistory.defaultExpectation == nil {
+		mmGetStatusHistory.defaultExpectation = &OrderRepositoryMockGetStatusHistoryExpectation{}
+	}
+
+	if mmGetStatusHistory.defaultExpectation.params != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Expect")
+	}
+
+	if mmGetStatusHistory.defaultExpectation.paramPtrs == nil {
+		mmGetStatusHistory.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStatusHistoryParamPtrs{}
+	}
+	mmGetStatusHistory.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetStatusHistory
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.GetStatusHistory
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockGetStatusHistory {
+	if mmGetStatusHistory.mock.funcGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Set")
+	}
+
+	if mmGetStatusHistory.defaultExpectation == nil {
+		mmGetStatusHistory.defaultExpectation = &OrderRepositoryMockGetStatusHistoryExpectation{}
+	}
+
+	if mmGetStatusHistory.defaultExpectation.params != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Expect")
+	}
+
+	if mmGetStatusHistory.defaultExpectation.paramPtrs == nil {
+		mmGetStatusHistory.defaultExpectation.paramPtrs = &OrderRepositoryMockGetStatusHistoryParamPtrs{}
+	}
+	mmGetStatusHistory.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmGetStatusHistory
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.GetStatusHistory
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Inspect(f func(ctx context.Context, o1 model.OrderID)) *mOrderRepositoryMockGetStatusHistory {
+	if mmGetStatusHistory.mock.inspectFuncGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.GetStatusHistory")
+	}
+
+	mmGetStatusHistory.mock.inspectFuncGetStatusHistory = f
+
+	return mmGetStatusHistory
+}
+
+// Return sets up results that will be returned by orderRepository.GetStatusHistory
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Return(oa1 []model.OrderStatusChange, err error) *OrderRepositoryMock {
+	if mmGetStatusHistory.mock.funcGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Set")
+	}
+
+	if mmGetStatusHistory.defaultExpectation == nil {
+		mmGetStatusHistory.defaultExpectation = &OrderRepositoryMockGetStatusHistoryExpectation{mock: mmGetStatusHistory.mock}
+	}
+	mmGetStatusHistory.defaultExpectation.results = &OrderRepositoryMockGetStatusHistoryResults{oa1, err}
+	return mmGetStatusHistory.mock
+}
+
+// Set uses given function f to mock the orderRepository.GetStatusHistory method
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Set(f func(ctx context.Context, o1 model.OrderID) (oa1 []model.OrderStatusChange, err error)) *OrderRepositoryMock {
+	if mmGetStatusHistory.defaultExpectation != nil {
+		mmGetStatusHistory.mock.t.Fatalf("Default expectation is already set for the orderRepository.GetStatusHistory method")
+	}
+
+	if len(mmGetStatusHistory.expectations) > 0 {
+		mmGetStatusHistory.mock.t.Fatalf("Some expectations are already set for the orderRepository.GetStatusHistory method")
+	}
+
+	mmGetStatusHistory.mock.funcGetStatusHistory = f
+	return mmGetStatusHistory.mock
+}
+
+// When sets expectation for the orderRepository.GetStatusHistory which will trigger the result defined by the following
+// Then helper
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) When(ctx context.Context, o1 model.OrderID) *OrderRepositoryMockGetStatusHistoryExpectation {
+	if mmGetStatusHistory.mock.funcGetStatusHistory != nil {
+		mmGetStatusHistory.mock.t.Fatalf("OrderRepositoryMock.GetStatusHistory mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockGetStatusHistoryExpectation{
+		mock:   mmGetStatusHistory.mock,
+		params: &OrderRepositoryMockGetStatusHistoryParams{ctx, o1},
+	}
+	mmGetStatusHistory.expectations = append(mmGetStatusHistory.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.GetStatusHistory return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockGetStatusHistoryExpectation) Then(oa1 []model.OrderStatusChange, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockGetStatusHistoryResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.GetStatusHistory should be invoked
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Times(n uint64) *mOrderRepositoryMockGetStatusHistory {
+	if n == 0 {
+		mmGetStatusHistory.mock.t.Fatalf("Times of OrderRepositoryMock.GetStatusHistory mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStatusHistory.expectedInvocations, n)
+	return mmGetStatusHistory
+}
+
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) invocationsDone() bool {
+	if len(mmGetStatusHistory.expectations) == 0 && mmGetStatusHistory.defaultExpectation == nil && mmGetStatusHistory.mock.funcGetStatusHistory == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStatusHistory.mock.afterGetStatusHistoryCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStatusHistory.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStatusHistory implements service.orderRepository
+func (mmGetStatusHistory *OrderRepositoryMock) GetStatusHistory(ctx context.Context, o1 model.OrderID) (oa1 []model.OrderStatusChange, err error) {
+	mm_atomic.AddUint64(&mmGetStatusHistory.beforeGetStatusHistoryCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStatusHistory.afterGetStatusHistoryCounter, 1)
+
+	if mmGetStatusHistory.inspectFuncGetStatusHistory != nil {
+		mmGetStatusHistory.inspectFuncGetStatusHistory(ctx, o1)
+	}
+
+	mm_params := OrderRepositoryMockGetStatusHistoryParams{ctx, o1}
+
+	// Record call args
+	mmGetStatusHistory.GetStatusHistoryMock.mutex.Lock()
+	mmGetStatusHistory.GetStatusHistoryMock.callArgs = append(mmGetStatusHistory.GetStatusHistoryMock.callArgs, &mm_params)
+	mmGetStatusHistory.GetStatusHistoryMock.mutex.Unlock()
+
+	for _, e := range mmGetStatusHistory.GetStatusHistoryMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmGetStatusHistory.GetStatusHistoryMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStatusHistory.GetStatusHistoryMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStatusHistory.GetStatusHistoryMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStatusHistory.GetStatusHistoryMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockGetStatusHistoryParams{ctx, o1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStatusHistory.t.Errorf("OrderRepositoryMock.GetStatusHistory got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmGetStatusHistory.t.Errorf("OrderRepositoryMock.GetStatusHistory got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStatusHistory.t.Errorf("OrderRepositoryMock.GetStatusHistory got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStatusHistory.GetStatusHistoryMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStatusHistory.t.Fatal("No results are set for the OrderRepositoryMock.GetStatusHistory")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmGetStatusHistory.funcGetStatusHistory != nil {
+		return mmGetStatusHistory.funcGetStatusHistory(ctx, o1)
+	}
+	mmGetStatusHistory.t.Fatalf("Unexpected call to OrderRepositoryMock.GetStatusHistory. %v %v", ctx, o1)
+	return
+}
+
+// GetStatusHistoryAfterCounter returns a count of finished OrderRepositoryMock.GetStatusHistory invocations
+func (mmGetStatusHistory *OrderRepositoryMock) GetStatusHistoryAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStatusHistory.afterGetStatusHistoryCounter)
+}
+
+// GetStatusHistoryBeforeCounter returns a count of OrderRepositoryMock.GetStatusHistory invocations
+func (mmGetStatusHistory *OrderRepositoryMock) GetStatusHistoryBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStatusHistory.beforeGetStatusHistoryCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.GetStatusHistory.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStatusHistory *mOrderRepositoryMockGetStatusHistory) Calls() []*OrderRepositoryMockGetStatusHistoryParams {
+	mmGetStatusHistory.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockGetStatusHistoryParams, len(mmGetStatusHistory.callArgs))
+	copy(argCopy, mmGetStatusHistory.callArgs)
+
+	mmGetStatusHistory.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStatusHistoryDone returns true if the count of the GetStatusHistory invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockGetStatusHistoryDone() bool {
+	if m.GetStatusHistoryMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStatusHistoryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStatusHistoryMock.invocationsDone()
+}
+
+// MinimockGetStatusHistoryInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockGetStatusHistoryInspect() {
+	for _, e := range m.GetStatusHistoryMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetStatusHistory with params: %#v", *e.params)
+		}
+	}
+
+	afterGetStatusHistoryCounter := mm_atomic.LoadUint64(&m.afterGetStatusHistoryCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStatusHistoryMock.defaultExpectation != nil && afterGetStatusHistoryCounter < 1 {
+		if m.GetStatusHistoryMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.GetStatusHistory")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.GetStatusHistory with params: %#v", *m.GetStatusHistoryMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStatusHistory != nil && afterGetStatusHistoryCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.GetStatusHistory")
+	}
+
+	if !m.GetStatusHistoryMock.invocationsDone() && afterGetStatusHistoryCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.GetStatusHistory but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStatusHistoryMock.expectedInvocations), afterGetStatusHistoryCounter)
+	}
+}
+
+type mOrderRepositoryMockHandleNotRestocked struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockHandleNotRestockedExpectation
+	expectations       []*OrderRepositoryMockHandleNotRestockedExpectation
+
+	callArgs []*OrderRepositoryMockHandleNotRestockedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockHandleNotRestockedExpectation specifies expectation struct of the orderRepository.HandleNotRestocked
+type OrderRepositoryMockHandleNotRestockedExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockHandleNotRestockedParams
+	paramPtrs *OrderRepositoryMockHandleNotRestockedParamPtrs
+	results   *OrderRepositoryMockHandleNotRestockedResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockHandleNotRestockedParams contains parameters of the orderRepository.HandleNotRestocked
+type OrderRepositoryMockHandleNotRestockedParams struct {
+	ctx context.Context
+	d1  time.Duration
+	i1  int
+	f1  func(context.Context, []model.OrderReturn) error
+}
+
+// OrderRepositoryMockHandleNotRestockedParamPtrs contains pointers to parameters of the orderRepository.HandleNotRestocked
+type OrderRepositoryMockHandleNotRestockedParamPtrs struct {
+	ctx *context.Context
+	d1  *time.Duration
+	i1  *int
+	f1  *func(context.Context, []model.OrderReturn) error
+}
+
+// OrderRepositoryMockHandleNotRestockedResults contains results of the orderRepository.HandleNotRestocked
+type OrderRepositoryMockHandleNotRestockedResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Optional() *mOrderRepositoryMockHandleNotRestocked {
+	mmHandleNotRestocked.optional = true
+	return mmHandleNotRestocked
+}
+
+// Expect sets up expected params for orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Expect(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{}
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.paramPtrs != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by ExpectParams functions")
+	}
+
+	mmHandleNotRestocked.defaultExpectation.params = &OrderRepositoryMockHandleNotRestockedParams{ctx, d1, i1, f1}
+	for _, e := range mmHandleNotRestocked.expectations {
+		if minimock.Equal(e.params, mmHandleNotRestocked.defaultExpectation.params) {
+			mmHandleNotRestocked.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHandleNotRestocked.defaultExpectation.params)
+		}
+	}
+
+	return mmHandleNotRestocked
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{}
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.params != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Expect")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.paramPtrs == nil {
+		mmHandleNotRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleNotRestockedParamPtrs{}
+	}
+	mmHandleNotRestocked.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHandleNotRestocked
+}
+
+// ExpectD1Param2 sets up expected param d1 for orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) ExpectD1Param2(d1 time.Duration) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{}
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.params != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Expect")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.paramPtrs == nil {
+		mmHandleNotRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleNotRestockedParamPtrs{}
+	}
+	mmHandleNotRestocked.defaultExpectation.paramPtrs.d1 = &d1
+
+	return mmHandleNotRestocked
+}
+
+// ExpectI1Param3 sets up expected param i1 for orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) ExpectI1Param3(i1 int) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{}
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.params != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Expect")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.paramPtrs == nil {
+		mmHandleNotRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleNotRestockedParamPtrs{}
+	}
+	mmHandleNotRestocked.defaultExpectation.paramPtrs.i1 = &i1
+
+	return mmHandleNotRestocked
+}
+
+// ExpectF1Param4 sets up expected param f1 for orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) ExpectF1Param4(f1 func(context.Context, []model.OrderReturn) error) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{}
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.params != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Expect")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation.paramPtrs == nil {
+		mmHandleNotRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleNotRestockedParamPtrs{}
+	}
+	mmHandleNotRestocked.defaultExpectation.paramPtrs.f1 = &f1
+
+	return mmHandleNotRestocked
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Inspect(f func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error)) *mOrderRepositoryMockHandleNotRestocked {
+	if mmHandleNotRestocked.mock.inspectFuncHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.HandleNotRestocked")
+	}
+
+	mmHandleNotRestocked.mock.inspectFuncHandleNotRestocked = f
+
+	return mmHandleNotRestocked
+}
+
+// Return sets up results that will be returned by orderRepository.HandleNotRestocked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Return(err error) *OrderRepositoryMock {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	if mmHandleNotRestocked.defaultExpectation == nil {
+		mmHandleNotRestocked.defaultExpectation = &OrderRepositoryMockHandleNotRestockedExpectation{mock: mmHandleNotRestocked.mock}
+	}
+	mmHandleNotRestocked.defaultExpectation.results = &OrderRepositoryMockHandleNotRestockedResults{err}
+	return mmHandleNotRestocked.mock
+}
+
+// Set uses given function f to mock the orderRepository.HandleNotRestocked method
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Set(f func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error) (err error)) *OrderRepositoryMock {
+	if mmHandleNotRestocked.defaultExpectation != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("Default expectation is already set for the orderRepository.HandleNotRestocked method")
+	}
+
+	if len(mmHandleNotRestocked.expectations) > 0 {
+		mmHandleNotRestocked.mock.t.Fatalf("Some expectations are already set for the orderRepository.HandleNotRestocked method")
+	}
+
+	mmHandleNotRestocked.mock.funcHandleNotRestocked = f
+	return mmHandleNotRestocked.mock
+}
+
+// When sets expectation for the orderRepository.HandleNotRestocked which will trigger the result defined by the following
+// Then helper
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) When(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error) *OrderRepositoryMockHandleNotRestockedExpectation {
+	if mmHandleNotRestocked.mock.funcHandleNotRestocked != nil {
+		mmHandleNotRestocked.mock.t.Fatalf("OrderRepositoryMock.HandleNotRestocked mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockHandleNotRestockedExpectation{
+		mock:   mmHandleNotRestocked.mock,
+		params: &OrderRepositoryMockHandleNotRestockedParams{ctx, d1, i1, f1},
+	}
+	mmHandleNotRestocked.expectations = append(mmHandleNotRestocked.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.HandleNotRestocked return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockHandleNotRestockedExpectation) Then(err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockHandleNotRestockedResults{err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.HandleNotRestocked should be invoked
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Times(n uint64) *mOrderRepositoryMockHandleNotRestocked {
+	if n == 0 {
+		mmHandleNotRestocked.mock.t.Fatalf("Times of OrderRepositoryMock.HandleNotRestocked mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHandleNotRestocked.expectedInvocations, n)
+	return mmHandleNotRestocked
+}
+
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) invocationsDone() bool {
+	if len(mmHandleNotRestocked.expectations) == 0 && mmHandleNotRestocked.defaultExpectation == nil && mmHandleNotRestocked.mock.funcHandleNotRestocked == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHandleNotRestocked.mock.afterHandleNotRestockedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHandleNotRestocked.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HandleNotRestocked implements service.orderRepository
+func (mmHandleNotRestocked *OrderRepositoryMock) HandleNotRestocked(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderReturn) error) (err error) {
+	mm_atomic.AddUint64(&mmHandleNotRestocked.beforeHandleNotRestockedCounter, 1)
+	defer mm_atomic.AddUint64(&mmHandleNotRestocked.afterHandleNotRestockedCounter, 1)
+
+	if mmHandleNotRestocked.inspectFuncHandleNotRestocked != nil {
+		mmHandleNotRestocked.inspectFuncHandleNotRestocked(ctx, d1, i1, f1)
+	}
+
+	mm_params := OrderRepositoryMockHandleNotRestockedParams{ctx, d1, i1, f1}
+
+	// Record call args
+	mmHandleNotRestocked.HandleNotRestockedMock.mutex.Lock()
+	mmHandleNotRestocked.HandleNotRestockedMock.callArgs = append(mmHandleNotRestocked.HandleNotRestockedMock.callArgs, &mm_params)
+	mmHandleNotRestocked.HandleNotRestockedMock.mutex.Unlock()
+
+	for _, e := range mmHandleNotRestocked.HandleNotRestockedMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHandleNotRestocked.HandleNotRestockedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHandleNotRestocked.HandleNotRestockedMock.defaultExpectation.Counter, 1)
+		mm_want := mmHandleNotRestocked.HandleNotRestockedMock.defaultExpectation.params
+		mm_want_ptrs := mmHandleNotRestocked.HandleNotRestockedMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockHandleNotRestockedParams{ctx, d1, i1, f1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHandleNotRestocked.t.Errorf("OrderRepositoryMock.HandleNotRestocked got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.d1 != nil && !minimock.Equal(*mm_want_ptrs.d1, mm_got.d1) {
+				mmHandleNotRestocked.t.Errorf("OrderRepositoryMock.HandleNotRestocked got unexpected parameter d1, want: %#v, got: %#v%s\n", *mm_want_ptrs.d1, mm_got.d1, minimock.Diff(*mm_want_ptrs.d1, mm_got.d1))
+			}
+
+			if mm_want_ptrs.i1 != nil && !minimock.Equal(*mm_want_ptrs.i1, mm_got.i1) {
+				mmHandleNotRestocked.t.Errorf("OrderRepositoryMock.HandleNotRestocked got unexpected parameter i1, want: %#v, got: %#v%s\n", *mm_want_ptrs.i1, mm_got.i1, minimock.Diff(*mm_want_ptrs.i1, mm_got.i1))
+			}
+
+			if mm_want_ptrs.f1 != nil && !minimock.Equal(*mm_want_ptrs.f1, mm_got.f1) {
+				mmHandleNotRestocked.t.Errorf("OrderRepositoryMock.HandleNotRestocked got unexpected parameter f1, want: %#v, got: %#v%s\n", *mm_want_ptrs.f1, mm_got.f1, minimock.Diff(*mm_want_ptrs.f1, mm_got.f1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHandleNotRestocked.t.Errorf("OrderRepositoryMock.HandleNotRestocked got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHandleNotRestocked.HandleNotRestockedMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHandleNotRestocked.t.Fatal("No results are set for the OrderRepositoryMock.HandleNotRestocked")
+		}
+		return (*mm_results).err
+	}
+	if mmHandleNotRestocked.funcHandleNotRestocked != nil {
+		return mmHandleNotRestocked.funcHandleNotRestocked(ctx, d1, i1, f1)
+	}
+	mmHandleNotRestocked.t.Fatalf("Unexpected call to OrderRepositoryMock.HandleNotRestocked. %v %v %v %v", ctx, d1, i1, f1)
+	return
+}
+
+// HandleNotRestockedAfterCounter returns a count of finished OrderRepositoryMock.HandleNotRestocked invocations
+func (mmHandleNotRestocked *OrderRepositoryMock) HandleNotRestockedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHandleNotRestocked.afterHandleNotRestockedCounter)
+}
+
+// HandleNotRestockedBeforeCounter returns a count of OrderRepositoryMock.HandleNotRestocked invocations
+func (mmHandleNotRestocked *OrderRepositoryMock) HandleNotRestockedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHandleNotRestocked.beforeHandleNotRestockedCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.HandleNotRestocked.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHandleNotRestocked *mOrderRepositoryMockHandleNotRestocked) Calls() []*OrderRepositoryMockHandleNotRestockedParams {
+	mmHandleNotRestocked.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockHandleNotRestockedParams, len(mmHandleNotRestocked.callArgs))
+	copy(argCopy, mmHandleNotRestocked.callArgs)
+
+	mmHandleNotRestocked.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHandleNotRestockedDone returns true if the count of the HandleNotRestocked invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockHandleNotRestockedDone() bool {
+	if m.HandleNotRestockedMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HandleNotRestockedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HandleNotRestockedMock.invocationsDone()
+}
+
+// MinimockHandleNotRestockedInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockHandleNotRestockedInspect() {
+	for _, e := range m.HandleNotRestockedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.HandleNotRestocked with params: %#v", *e.params)
+		}
+	}
+
+	afterHandleNotRestockedCounter := mm_atomic.LoadUint64(&m.afterHandleNotRestockedCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HandleNotRestockedMock.defaultExpectation != nil && afterHandleNotRestockedCounter < 1 {
+		if m.HandleNotRestockedMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.HandleNotRestocked")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.HandleNotRestocked with params: %#v", *m.HandleNotRestockedMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHandleNotRestocked != nil && afterHandleNotRestockedCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.HandleNotRestocked")
+	}
+
+	if !m.HandleNotRestockedMock.invocationsDone() && afterHandleNotRestockedCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.HandleNotRestocked but found %d calls",
+			mm_atomic.LoadUint64(&m.HandleNotRestockedMock.expectedInvocations), afterHandleNotRestockedCounter)
+	}
+}
+
+type mOrderRepositoryMockHandleUnpaid struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockHandleUnpaidExpectation
+	expectations       []*OrderRepositoryMockHandleUnpaidExpectation
+
+	callArgs []*OrderRepositoryMockHandleUnpaidParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockHandleUnpaidExpectation specifies expectation struct of the orderRepository.HandleUnpaid
+type OrderRepositoryMockHandleUnpaidExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockHandleUnpaidParams
+	paramPtrs *OrderRepositoryMockHandleUnpaidParamPtrs
+	results   *OrderRepositoryMockHandleUnpaidResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockHandleUnpaidParams contains parameters of the orderRepository.HandleUnpaid
+type OrderRepositoryMockHandleUnpaidParams struct {
+	ctx context.Context
+	d1  time.Duration
+	i1  int
+	f1  func(context.Context, []model.OrderID) error
+}
+
+// OrderRepositoryMockHandleUnpaidParamPtrs contains pointers to parameters of the orderRepository.HandleUnpaid
+type OrderRepositoryMockHandleUnpaidParamPtrs struct {
+	ctx *context.Context
+	d1  *time.Duration
+	i1  *int
+	f1  *func(context.Context, []model.OrderID) error
+}
+
+// OrderRepositoryMockHandleUnpaidResults contains results of the orderRepository.HandleUnpaid
+type OrderRepositoryMockHandleUnpaidResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Optional() *mOrderRepositoryMockHandleUnpaid {
+	mmHandleUnpaid.optional = true
+	return mmHandleUnpaid
+}
+
+// Expect sets up expected params for orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Expect(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{}
+	}
+
+	if mmHandleUnpaid.defaultExpectation.paramPtrs != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by ExpectParams functions")
+	}
+
+	mmHandleUnpaid.defaultExpectation.params = &OrderRepositoryMockHandleUnpaidParams{ctx, d1, i1, f1}
+	for _, e := range mmHandleUnpaid.expectations {
+		if minimock.Equal(e.params, mmHandleUnpaid.defaultExpectation.params) {
+			mmHandleUnpaid.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHandleUnpaid.defaultExpectation.params)
+		}
+	}
+
+	return mmHandleUnpaid
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{}
+	}
+
+	if mmHandleUnpaid.defaultExpectation.params != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Expect")
+	}
+
+	if mmHandleUnpaid.defaultExpectation.paramPtrs == nil {
+		mmHandleUnpaid.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleUnpaidParamPtrs{}
+	}
+	mmHandleUnpaid.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHandleUnpaid
+}
+
+// ExpectD1Param2 sets up expected param d1 for orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) ExpectD1Param2(d1 time.Duration) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{}
+	}
+
+	if mmHandleUnpaid.defaultExpectation.params != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Expect")
+	}
+
+	if mmHandleUnpaid.defaultExpectation.paramPtrs == nil {
+		mmHandleUnpaid.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleUnpaidParamPtrs{}
+	}
+	mmHandleUnpaid.defaultExpectation.paramPtrs.d1 = &d1
+
+	return mmHandleUnpaid
+}
+
+// ExpectI1Param3 sets up expected param i1 for orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) ExpectI1Param3(i1 int) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{}
+	}
+
+	if mmHandleUnpaid.defaultExpectation.params != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Expect")
+	}
+
+	if mmHandleUnpaid.defaultExpectation.paramPtrs == nil {
+		mmHandleUnpaid.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleUnpaidParamPtrs{}
+	}
+	mmHandleUnpaid.defaultExpectation.paramPtrs.i1 = &i1
+
+	return mmHandleUnpaid
+}
+
+// ExpectF1Param4 sets up expected param f1 for orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) ExpectF1Param4(f1 func(context.Context, []model.OrderID) error) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{}
+	}
+
+	if mmHandleUnpaid.defaultExpectation.params != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Expect")
+	}
+
+	if mmHandleUnpaid.defaultExpectation.paramPtrs == nil {
+		mmHandleUnpaid.defaultExpectation.paramPtrs = &OrderRepositoryMockHandleUnpaidParamPtrs{}
+	}
+	mmHandleUnpaid.defaultExpectation.paramPtrs.f1 = &f1
+
+	return mmHandleUnpaid
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Inspect(f func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error)) *mOrderRepositoryMockHandleUnpaid {
+	if mmHandleUnpaid.mock.inspectFuncHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.HandleUnpaid")
+	}
+
+	mmHandleUnpaid.mock.inspectFuncHandleUnpaid = f
+
+	return mmHandleUnpaid
+}
+
+// Return sets up results that will be returned by orderRepository.HandleUnpaid
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Return(err error) *OrderRepositoryMock {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	if mmHandleUnpaid.defaultExpectation == nil {
+		mmHandleUnpaid.defaultExpectation = &OrderRepositoryMockHandleUnpaidExpectation{mock: mmHandleUnpaid.mock}
+	}
+	mmHandleUnpaid.defaultExpectation.results = &OrderRepositoryMockHandleUnpaidResults{err}
+	return mmHandleUnpaid.mock
+}
+
+// Set uses given function f to mock the orderRepository.HandleUnpaid method
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Set(f func(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error) (err error)) *OrderRepositoryMock {
+	if mmHandleUnpaid.defaultExpectation != nil {
+		mmHandleUnpaid.mock.t.Fatalf("Default expectation is already set for the orderRepository.HandleUnpaid method")
+	}
+
+	if len(mmHandleUnpaid.expectations) > 0 {
+		mmHandleUnpaid.mock.t.Fatalf("Some expectations are already set for the orderRepository.HandleUnpaid method")
+	}
+
+	mmHandleUnpaid.mock.funcHandleUnpaid = f
+	return mmHandleUnpaid.mock
+}
+
+// When sets expectation for the orderRepository.HandleUnpaid which will trigger the result defined by the following
+// Then helper
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) When(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error) *OrderRepositoryMockHandleUnpaidExpectation {
+	if mmHandleUnpaid.mock.funcHandleUnpaid != nil {
+		mmHandleUnpaid.mock.t.Fatalf("OrderRepositoryMock.HandleUnpaid mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockHandleUnpaidExpectation{
+		mock:   mmHandleUnpaid.mock,
+		params: &OrderRepositoryMockHandleUnpaidParams{ctx, d1, i1, f1},
+	}
+	mmHandleUnpaid.expectations = append(mmHandleUnpaid.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.HandleUnpaid return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockHandleUnpaidExpectation) Then(err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockHandleUnpaidResults{err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.HandleUnpaid should be invoked
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Times(n uint64) *mOrderRepositoryMockHandleUnpaid {
+	if n == 0 {
+		mmHandleUnpaid.mock.t.Fatalf("Times of OrderRepositoryMock.HandleUnpaid mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHandleUnpaid.expectedInvocations, n)
+	return mmHandleUnpaid
+}
+
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) invocationsDone() bool {
+	if len(mmHandleUnpaid.expectations) == 0 && mmHandleUnpaid.defaultExpectation == nil && mmHandleUnpaid.mock.funcHandleUnpaid == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHandleUnpaid.mock.afterHandleUnpaidCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHandleUnpaid.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HandleUnpaid implements service.orderRepository
+func (mmHandleUnpaid *OrderRepositoryMock) HandleUnpaid(ctx context.Context, d1 time.Duration, i1 int, f1 func(context.Context, []model.OrderID) error) (err error) {
+	mm_atomic.AddUint64(&mmHandleUnpaid.beforeHandleUnpaidCounter, 1)
+	defer mm_atomic.AddUint64(&mmHandleUnpaid.afterHandleUnpaidCounter, 1)
+
+	if mmHandleUnpaid.inspectFuncHandleUnpaid != nil {
+		mmHandleUnpaid.inspectFuncHandleUnpaid(ctx, d1, i1, f1)
+	}
+
+	mm_params := OrderRepositoryMockHandleUnpaidParams{ctx, d1, i1, f1}
+
+	// Record call args
+	mmHandleUnpaid.HandleUnpaidMock.mutex.Lock()
+	mmHandleUnpaid.HandleUnpaidMock.callArgs = append(mmHandleUnpaid.HandleUnpaidMock.callArgs, &mm_params)
+	mmHandleUnpaid.HandleUnpaidMock.mutex.Unlock()
+
+	for _, e := range mmHandleUnpaid.HandleUnpaidMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHandleUnpaid.HandleUnpaidMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHandleUnpaid.HandleUnpaidMock.defaultExpectation.Counter, 1)
+		mm_want := mmHandleUnpaid.HandleUnpaidMock.defaultExpectation.params
+		mm_want_ptrs := mmHandleUnpaid.HandleUnpaidMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockHandleUnpaidParams{ctx, d1, i1, f1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHandleUnpaid.t.Errorf("OrderRepositoryMock.HandleUnpaid got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.d1 != nil && !minimock.Equal(*mm_want_ptrs.d1, mm_got.d1) {
+				mmHandleUnpaid.t.Errorf("OrderRepositoryMock.HandleUnpaid got unexpected parameter d1, want: %#v, got: %#v%s\n", *mm_want_ptrs.d1, mm_got.d1, minimock.Diff(*mm_want_ptrs.d1, mm_got.d1))
+			}
+
+			if mm_want_ptrs.i1 != nil && !minimock.Equal(*mm_want_ptrs.i1, mm_got.i1) {
+				mmHandleUnpaid.t.Errorf("OrderRepositoryMock.HandleUnpaid got unexpected parameter i1, want: %#v, got: %#v%s\n", *mm_want_ptrs.i1, mm_got.i1, minimock.Diff(*mm_want_ptrs.i1, mm_got.i1))
+			}
+
+			if mm_want_ptrs.f1 != nil && !minimock.Equal(*mm_want_ptrs.f1, mm_got.f1) {
+				mmHandleUnpaid.t.Errorf("OrderRepositoryMock.HandleUnpaid got unexpected parameter f1, want: %#v, got: %#v%s\n", *mm_want_ptrs.f1, mm_got.f1, minimock.Diff(*mm_want_ptrs.f1, mm_got.f1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHandleUnpaid.t.Errorf("OrderRepositoryMock.HandleUnpaid got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHandleUnpaid.HandleUnpaidMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHandleUnpaid.t.Fatal("No results are set for the OrderRepositoryMock.HandleUnpaid")
+		}
+		return (*mm_results).err
+	}
+	if mmHandleUnpaid.funcHandleUnpaid != nil {
+		return mmHandleUnpaid.funcHandleUnpaid(ctx, d1, i1, f1)
+	}
+	mmHandleUnpaid.t.Fatalf("Unexpected call to OrderRepositoryMock.HandleUnpaid. %v %v %v %v", ctx, d1, i1, f1)
+	return
+}
+
+// HandleUnpaidAfterCounter returns a count of finished OrderRepositoryMock.HandleUnpaid invocations
+func (mmHandleUnpaid *OrderRepositoryMock) HandleUnpaidAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHandleUnpaid.afterHandleUnpaidCounter)
+}
+
+// HandleUnpaidBeforeCounter returns a count of OrderRepositoryMock.HandleUnpaid invocations
+func (mmHandleUnpaid *OrderRepositoryMock) HandleUnpaidBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHandleUnpaid.beforeHandleUnpaidCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.HandleUnpaid.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHandleUnpaid *mOrderRepositoryMockHandleUnpaid) Calls() []*OrderRepositoryMockHandleUnpaidParams {
+	mmHandleUnpaid.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockHandleUnpaidParams, len(mmHandleUnpaid.callArgs))
+	copy(argCopy, mmHandleUnpaid.callArgs)
+
+	mmHandleUnpaid.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHandleUnpaidDone returns true if the count of the HandleUnpaid invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockHandleUnpaidDone() bool {
+	if m.HandleUnpaidMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HandleUnpaidMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HandleUnpaidMock.invocationsDone()
+}
+
+// MinimockHandleUnpaidInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockHandleUnpaidInspect() {
+	for _, e := range m.HandleUnpaidMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.HandleUnpaid with params: %#v", *e.params)
+		}
+	}
+
+	afterHandleUnpaidCounter := mm_atomic.LoadUint64(&m.afterHandleUnpaidCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HandleUnpaidMock.defaultExpectation != nil && afterHandleUnpaidCounter < 1 {
+		if m.HandleUnpaidMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.HandleUnpaid")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.HandleUnpaid with params: %#v", *m.HandleUnpaidMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHandleUnpaid != nil && afterHandleUnpaidCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.HandleUnpaid")
+	}
+
+	if !m.HandleUnpaidMock.invocationsDone() && afterHandleUnpaidCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.HandleUnpaid but found %d calls",
+			mm_atomic.LoadUint64(&m.HandleUnpaidMock.expectedInvocations), afterHandleUnpaidCounter)
+	}
+}
+
+type mOrderRepositoryMockListByUser struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockListByUserExpectation
+	expectations       []*OrderRepositoryMockListByUserExpectation
+
+	callArgs []*OrderRepositoryMockListByUserParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockListByUserExpectation specifies expectation struct of the orderRepository.ListByUser
+type OrderRepositoryMockListByUserExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockListByUserParams
+	paramPtrs *OrderRepositoryMockListByUserParamPtrs
+	results   *OrderRepositoryMockListByUserResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockListByUserParams contains parameters of the orderRepository.ListByUser
+type OrderRepositoryMockListByUserParams struct {
+	ctx context.Context
+	o1  model.OrderFilter
+	op1 *model.OrderCursor
+	i1  int
+}
+
+// OrderRepositoryMockListByUserParamPtrs contains pointers to parameters of the orderRepository.ListByUser
+type OrderRepositoryMockListByUserParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderFilter
+	op1 **model.OrderCursor
+	i1  *int
+}
+
+// OrderRepositoryMockListByUserResults contains results of the orderRepository.ListByUser
+type OrderRepositoryMockListByUserResults struct {
+	oa1 []model.Order
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmListByUser *mOrderRepositoryMockListByUser) Optional() *mOrderRepositoryMockListByUser {
+	mmListByUser.optional = true
+	return mmListByUser
+}
+
+// Expect sets up expected params for orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) Expect(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{}
+	}
+
+	if mmListByUser.defaultExpectation.paramPtrs != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by ExpectParams functions")
+	}
+
+	mmListByUser.defaultExpectation.params = &OrderRepositoryMockListByUserParams{ctx, o1, op1, i1}
+	for _, e := range mmListByUser.expectations {
+		if minimock.Equal(e.params, mmListByUser.defaultExpectation.params) {
+			mmListByUser.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmListByUser.defaultExpectation.params)
+		}
+	}
+
+	return mmListByUser
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{}
+	}
+
+	if mmListByUser.defaultExpectation.params != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Expect")
+	}
+
+	if mmListByUser.defaultExpectation.paramPtrs == nil {
+		mmListByUser.defaultExpectation.paramPtrs = &OrderRepositoryMockListByUserParamPtrs{}
+	}
+	mmListByUser.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmListByUser
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) ExpectO1Param2(o1 model.OrderFilter) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{}
+	}
+
+	if mmListByUser.defaultExpectation.params != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Expect")
+	}
+
+	if mmListByUser.defaultExpectation.paramPtrs == nil {
+		mmListByUser.defaultExpectation.paramPtrs = &OrderRepositoryMockListByUserParamPtrs{}
+	}
+	mmListByUser.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmListByUser
+}
+
+// ExpectOp1Param3 sets up expected param op1 for orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) ExpectOp1Param3(op1 *model.OrderCursor) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{}
+	}
+
+	if mmListByUser.defaultExpectation.params != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Expect")
+	}
+
+	if mmListByUser.defaultExpectation.paramPtrs == nil {
+		mmListByUser.defaultExpectation.paramPtrs = &OrderRepositoryMockListByUserParamPtrs{}
+	}
+	mmListByUser.defaultExpectation.paramPtrs.op1 = &op1
+
+	return mmListByUser
+}
+
+// ExpectI1Param4 sets up expected param i1 for orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) ExpectI1Param4(i1 int) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{}
+	}
+
+	if mmListByUser.defaultExpectation.params != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Expect")
+	}
+
+	if mmListByUser.defaultExpectation.paramPtrs == nil {
+		mmListByUser.defaultExpectation.paramPtrs = &OrderRepositoryMockListByUserParamPtrs{}
+	}
+	mmListByUser.defaultExpectation.paramPtrs.i1 = &i1
+
+	return mmListByUser
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) Inspect(f func(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int)) *mOrderRepositoryMockListByUser {
+	if mmListByUser.mock.inspectFuncListByUser != nil {
+		mmListByUser.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.ListByUser")
+	}
+
+	mmListByUser.mock.inspectFuncListByUser = f
+
+	return mmListByUser
+}
+
+// Return sets up results that will be returned by orderRepository.ListByUser
+func (mmListByUser *mOrderRepositoryMockListByUser) Return(oa1 []model.Order, err error) *OrderRepositoryMock {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	if mmListByUser.defaultExpectation == nil {
+		mmListByUser.defaultExpectation = &OrderRepositoryMockListByUserExpectation{mock: mmListByUser.mock}
+	}
+	mmListByUser.defaultExpectation.results = &OrderRepositoryMockListByUserResults{oa1, err}
+	return mmListByUser.mock
+}
+
+// Set uses given function f to mock the orderRepository.ListByUser method
+func (mmListByUser *mOrderRepositoryMockListByUser) Set(f func(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int) (oa1 []model.Order, err error)) *OrderRepositoryMock {
+	if mmListByUser.defaultExpectation != nil {
+		mmListByUser.mock.t.Fatalf("Default expectation is already set for the orderRepository.ListByUser method")
+	}
+
+	if len(mmListByUser.expectations) > 0 {
+		mmListByUser.mock.t.Fatalf("Some expectations are already set for the orderRepository.ListByUser method")
+	}
+
+	mmListByUser.mock.funcListByUser = f
+	return mmListByUser.mock
+}
+
+// When sets expectation for the orderRepository.ListByUser which will trigger the result defined by the following
+// Then helper
+func (mmListByUser *mOrderRepositoryMockListByUser) When(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int) *OrderRepositoryMockListByUserExpectation {
+	if mmListByUser.mock.funcListByUser != nil {
+		mmListByUser.mock.t.Fatalf("OrderRepositoryMock.ListByUser mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockListByUserExpectation{
+		mock:   mmListByUser.mock,
+		params: &OrderRepositoryMockListByUserParams{ctx, o1, op1, i1},
+	}
+	mmListByUser.expectations = append(mmListByUser.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.ListByUser return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockListByUserExpectation) Then(oa1 []model.Order, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockListByUserResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.ListByUser should be invoked
+func (mmListByUser *mOrderRepositoryMockListByUser) Times(n uint64) *mOrderRepositoryMockListByUser {
+	if n == 0 {
+		mmListByUser.mock.t.Fatalf("Times of OrderRepositoryMock.ListByUser mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmListByUser.expectedInvocations, n)
+	return mmListByUser
+}
+
+func (mmListByUser *mOrderRepositoryMockListByUser) invocationsDone() bool {
+	if len(mmListByUser.expectations) == 0 && mmListByUser.defaultExpectation == nil && mmListByUser.mock.funcListByUser == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmListByUser.mock.afterListByUserCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmListByUser.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ListByUser implements service.orderRepository
+func (mmListByUser *OrderRepositoryMock) ListByUser(ctx context.Context, o1 model.OrderFilter, op1 *model.OrderCursor, i1 int) (oa1 []model.Order, err error) {
+	mm_atomic.AddUint64(&mmListByUser.beforeListByUserCounter, 1)
+	defer mm_atomic.AddUint64(&mmListByUser.afterListByUserCounter, 1)
+
+	if mmListByUser.inspectFuncListByUser != nil {
+		mmListByUser.inspectFuncListByUser(ctx, o1, op1, i1)
+	}
+
+	mm_params := OrderRepositoryMockListByUserParams{ctx, o1, op1, i1}
+
+	// Record call args
+	mmListByUser.ListByUserMock.mutex.Lock()
+	mmListByUser.ListByUserMock.callArgs = append(mmListByUser.ListByUserMock.callArgs, &mm_params)
+	mmListByUser.ListByUserMock.mutex.Unlock()
+
+	for _, e := range mmListByUser.ListByUserMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmListByUser.ListByUserMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmListByUser.ListByUserMock.defaultExpectation.Counter, 1)
+		mm_want := mmListByUser.ListByUserMock.defaultExpectation.params
+		mm_want_ptrs := mmListByUser.ListByUserMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockListByUserParams{ctx, o1, op1, i1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmListByUser.t.Errorf("OrderRepositoryMock.ListByUser got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmListByUser.t.Errorf("OrderRepositoryMock.ListByUser got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.op1 != nil && !minimock.Equal(*mm_want_ptrs.op1, mm_got.op1) {
+				mmListByUser.t.Errorf("OrderRepositoryMock.ListByUser got unexpected parameter op1, want: %#v, got: %#v%s\n", *mm_want_ptrs.op1, mm_got.op1, minimock.Diff(*mm_want_ptrs.op1, mm_got.op1))
+			}
+
+			if mm_want_ptrs.i1 != nil && !minimock.Equal(*mm_want_ptrs.i1, mm_got.i1) {
+				mmListByUser.t.Errorf("OrderRepositoryMock.ListByUser got unexpected parameter i1, want: %#v, got: %#v%s\n", *mm_want_ptrs.i1, mm_got.i1, minimock.Diff(*mm_want_ptrs.i1, mm_got.i1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmListByUser.t.Errorf("OrderRepositoryMock.ListByUser got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmListByUser.ListByUserMock.defaultExpectation.results
+		if mm_results == nil {
+			mmListByUser.t.Fatal("No results are set for the OrderRepositoryMock.ListByUser")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmListByUser.funcListByUser != nil {
+		return mmListByUser.funcListByUser(ctx, o1, op1, i1)
+	}
+	mmListByUser.t.Fatalf("Unexpected call to OrderRepositoryMock.ListByUser. %v %v %v %v", ctx, o1, op1, i1)
+	return
+}
+
+// ListByUserAfterCounter returns a count of finished OrderRepositoryMock.ListByUser invocations
+func (mmListByUser *OrderRepositoryMock) ListByUserAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListByUser.afterListByUserCounter)
+}
+
+// ListByUserBeforeCounter returns a count of OrderRepositoryMock.ListByUser invocations
+func (mmListByUser *OrderRepositoryMock) ListByUserBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmListByUser.beforeListByUserCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.ListByUser.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmListByUser *mOrderRepositoryMockListByUser) Calls() []*OrderRepositoryMockListByUserParams {
+	mmListByUser.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockListByUserParams, len(mmListByUser.callArgs))
+	copy(argCopy, mmListByUser.callArgs)
+
+	mmListByUser.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockListByUserDone returns true if the count of the ListByUser invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockListByUserDone() bool {
+	if m.ListByUserMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ListByUserMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ListByUserMock.invocationsDone()
+}
+
+// MinimockListByUserInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockListByUserInspect() {
+	for _, e := range m.ListByUserMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.ListByUser with params: %#v", *e.params)
+		}
+	}
+
+	afterListByUserCounter := mm_atomic.LoadUint64(&m.afterListByUserCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ListByUserMock.defaultExpectation != nil && afterListByUserCounter < 1 {
+		if m.ListByUserMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.ListByUser")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.ListByUser with params: %#v", *m.ListByUserMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcListByUser != nil && afterListByUserCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.ListByUser")
+	}
+
+	if !m.ListByUserMock.invocationsDone() && afterListByUserCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.ListByUser but found %d calls",
+			mm_atomic.LoadUint64(&m.ListByUserMock.expectedInvocations), afterListByUserCounter)
+	}
+}
+
+type mOrderRepositoryMockSetItems struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockSetItemsExpectation
+	expectations       []*OrderRepositoryMockSetItemsExpectation
+
+	callArgs []*OrderRepositoryMockSetItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockSetItemsExpectation specifies expectation struct of the orderRepository.SetItems
+type OrderRepositoryMockSetItemsExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockSetItemsParams
+	paramPtrs *OrderRepositoryMockSetItemsParamPtrs
+	results   *OrderRepositoryMockSetItemsResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockSetItemsParams contains parameters of the orderRepository.SetItems
+type OrderRepositoryMockSetItemsParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	oa1 []model.OrderItem
+}
+
+// OrderRepositoryMockSetItemsParamPtrs contains pointers to parameters of the orderRepository.SetItems
+type OrderRepositoryMockSetItemsParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	oa1 *[]model.OrderItem
+}
+
+// OrderRepositoryMockSetItemsResults contains results of the orderRepository.SetItems
+type OrderRepositoryMockSetItemsResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetItems *mOrderRepositoryMockSetItems) Optional() *mOrderRepositoryMockSetItems {
+	mmSetItems.optional = true
+	return mmSetItems
+}
+
+// Expect sets up expected params for orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) Expect(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem) *mOrderRepositoryMockSetItems {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	if mmSetItems.defaultExpectation == nil {
+		mmSetItems.defaultExpectation = &OrderRepositoryMockSetItemsExpectation{}
+	}
+
+	if mmSetItems.defaultExpectation.paramPtrs != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by ExpectParams functions")
+	}
+
+	mmSetItems.defaultExpectation.params = &OrderRepositoryMockSetItemsParams{ctx, o1, oa1}
+	for _, e := range mmSetItems.expectations {
+		if minimock.Equal(e.params, mmSetItems.defaultExpectation.params) {
+			mmSetItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetItems.defaultExpectation.params)
+		}
+	}
+
+	return mmSetItems
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockSetItems {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	if mmSetItems.defaultExpectation == nil {
+		mmSetItems.defaultExpectation = &OrderRepositoryMockSetItemsExpectation{}
+	}
+
+	if mmSetItems.defaultExpectation.params != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Expect")
+	}
+
+	if mmSetItems.defaultExpectation.paramPtrs == nil {
+		mmSetItems.defaultExpectation.paramPtrs = &OrderRepositoryMockSetItemsParamPtrs{}
+	}
+	mmSetItems.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmSetItems
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockSetItems {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	if mmSetItems.defaultExpectation == nil {
+		mmSetItems.defaultExpectation = &OrderRepositoryMockSetItemsExpectation{}
+	}
+
+	if mmSetItems.defaultExpectation.params != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Expect")
+	}
+
+	if mmSetItems.defaultExpectation.paramPtrs == nil {
+		mmSetItems.defaultExpectation.paramPtrs = &OrderRepositoryMockSetItemsParamPtrs{}
+	}
+	mmSetItems.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmSetItems
+}
+
+// ExpectOa1Param3 sets up expected param oa1 for orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) ExpectOa1Param3(oa1 []model.OrderItem) *mOrderRepositoryMockSetItems {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	if mmSetItems.defaultExpectation == nil {
+		mmSetItems.defaultExpectation = &OrderRepositoryMockSetItemsExpectation{}
+	}
+
+	if mmSetItems.defaultExpectation.params != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Expect")
+	}
+
+	if mmSetItems.defaultExpectation.paramPtrs == nil {
+		mmSetItems.defaultExpectation.paramPtrs = &OrderRepositoryMockSetItemsParamPtrs{}
+	}
+	mmSetItems.defaultExpectation.paramPtrs.oa1 = &oa1
+
+	return mmSetItems
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) Inspect(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem)) *mOrderRepositoryMockSetItems {
+	if mmSetItems.mock.inspectFuncSetItems != nil {
+		mmSetItems.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.SetItems")
+	}
+
+	mmSetItems.mock.inspectFuncSetItems = f
+
+	return mmSetItems
+}
+
+// Return sets up results that will be returned by orderRepository.SetItems
+func (mmSetItems *mOrderRepositoryMockSetItems) Return(err error) *OrderRepositoryMock {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	if mmSetItems.defaultExpectation == nil {
+		mmSetItems.defaultExpectation = &OrderRepositoryMockSetItemsExpectation{mock: mmSetItems.mock}
+	}
+	mmSetItems.defaultExpectation.results = &OrderRepositoryMockSetItemsResults{err}
+	return mmSetItems.mock
+}
+
+// Set uses given function f to mock the orderRepository.SetItems method
+func (mmSetItems *mOrderRepositoryMockSetItems) Set(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem) (err error)) *OrderRepositoryMock {
+	if mmSetItems.defaultExpectation != nil {
+		mmSetItems.mock.t.Fatalf("Default expectation is already set for the orderRepository.SetItems method")
+	}
+
+	if len(mmSetItems.expectations) > 0 {
+		mmSetItems.mock.t.Fatalf("Some expectations are already set for the orderRepository.SetItems method")
+	}
+
+	mmSetItems.mock.funcSetItems = f
+	return mmSetItems.mock
+}
+
+// When sets expectation for the orderRepository.SetItems which will trigger the result defined by the following
+// Then helper
+func (mmSetItems *mOrderRepositoryMockSetItems) When(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem) *OrderRepositoryMockSetItemsExpectation {
+	if mmSetItems.mock.funcSetItems != nil {
+		mmSetItems.mock.t.Fatalf("OrderRepositoryMock.SetItems mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockSetItemsExpectation{
+		mock:   mmSetItems.mock,
+		params: &OrderRepositoryMockSetItemsParams{ctx, o1, oa1},
+	}
+	mmSetItems.expectations = append(mmSetItems.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.SetItems return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockSetItemsExpectation) Then(err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockSetItemsResults{err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.SetItems should be invoked
+func (mmSetItems *mOrderRepositoryMockSetItems) Times(n uint64) *mOrderRepositoryMockSetItems {
+	if n == 0 {
+		mmSetItems.mock.t.Fatalf("Times of OrderRepositoryMock.SetItems mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetItems.expectedInvocations, n)
+	return mmSetItems
+}
+
+func (mmSetItems *mOrderRepositoryMockSetItems) invocationsDone() bool {
+	if len(mmSetItems.expectations) == 0 && mmSetItems.defaultExpectation == nil && mmSetItems.mock.funcSetItems == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetItems.mock.afterSetItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetItems.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetItems implements service.orderRepository
+func (mmSetItems *OrderRepositoryMock) SetItems(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem) (err error) {
+	mm_atomic.AddUint64(&mmSetItems.beforeSetItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetItems.afterSetItemsCounter, 1)
+
+	if mmSetItems.inspectFuncSetItems != nil {
+		mmSetItems.inspectFuncSetItems(ctx, o1, oa1)
+	}
+
+	mm_params := OrderRepositoryMockSetItemsParams{ctx, o1, oa1}
+
+	// Record call args
+	mmSetItems.SetItemsMock.mutex.Lock()
+	mmSetItems.SetItemsMock.callArgs = append(mmSetItems.SetItemsMock.callArgs, &mm_params)
+	mmSetItems.SetItemsMock.mutex.Unlock()
+
+	for _, e := range mmSetItems.SetItemsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSetItems.SetItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetItems.SetItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetItems.SetItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmSetItems.SetItemsMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockSetItemsParams{ctx, o1, oa1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetItems.t.Errorf("OrderRepositoryMock.SetItems got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmSetItems.t.Errorf("OrderRepositoryMock.SetItems got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmSetItems.t.Errorf("OrderRepositoryMock.SetItems got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetItems.t.Errorf("OrderRepositoryMock.SetItems got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetItems.SetItemsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetItems.t.Fatal("No results are set for the OrderRepositoryMock.SetItems")
+		}
+		return (*mm_results).err
+	}
+	if mmSetItems.funcSetItems != nil {
+		return mmSetItems.funcSetItems(ctx, o1, oa1)
+	}
+	mmSetItems.t.Fatalf("Unexpected call to OrderRepositoryMock.SetItems. %v %v %v", ctx, o1, oa1)
+	return
+}
+
+// SetItemsAfterCounter returns a count of finished OrderRepositoryMock.SetItems invocations
+func (mmSetItems *OrderRepositoryMock) SetItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItems.afterSetItemsCounter)
+}
+
+// SetItemsBeforeCounter returns a count of OrderRepositoryMock.SetItems invocations
+func (mmSetItems *OrderRepositoryMock) SetItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetItems.beforeSetItemsCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.SetItems.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetItems *mOrderRepositoryMockSetItems) Calls() []*OrderRepositoryMockSetItemsParams {
+	mmSetItems.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockSetItemsParams, len(mmSetItems.callArgs))
+	copy(argCopy, mmSetItems.callArgs)
+
+	mmSetItems.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetItemsDone returns true if the count of the SetItems invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockSetItemsDone() bool {
+	if m.SetItemsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetItemsMock.invocationsDone()
+}
+
+// MinimockSetItemsInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockSetItemsInspect() {
+	for _, e := range m.SetItemsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetItems with params: %#v", *e.params)
+		}
+	}
+
+	afterSetItemsCounter := mm_atomic.LoadUint64(&m.afterSetItemsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetItemsMock.defaultExpectation != nil && afterSetItemsCounter < 1 {
+		if m.SetItemsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.SetItems")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetItems with params: %#v", *m.SetItemsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetItems != nil && afterSetItemsCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.SetItems")
+	}
+
+	if !m.SetItemsMock.invocationsDone() && afterSetItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.SetItems but found %d calls",
+			mm_atomic.LoadUint64(&m.SetItemsMock.expectedInvocations), afterSetItemsCounter)
+	}
+}
+
+type mOrderRepositoryMockSetReturnRestocked struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockSetReturnRestockedExpectation
+	expectations       []*OrderRepositoryMockSetReturnRestockedExpectation
+
+	callArgs []*OrderRepositoryMockSetReturnRestockedParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockSetReturnRestockedExpectation specifies expectation struct of the orderRepository.SetReturnRestocked
+type OrderRepositoryMockSetReturnRestockedExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockSetReturnRestockedParams
+	paramPtrs *OrderRepositoryMockSetReturnRestockedParamPtrs
+	results   *OrderRepositoryMockSetReturnRestockedResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockSetReturnRestockedParams contains parameters of the orderRepository.SetReturnRestocked
+type OrderRepositoryMockSetReturnRestockedParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	r1  model.ReturnID
+}
+
+// OrderRepositoryMockSetReturnRestockedParamPtrs contains pointers to parameters of the orderRepository.SetReturnRestocked
+type OrderRepositoryMockSetReturnRestockedParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	r1  *model.ReturnID
+}
+
+// OrderRepositoryMockSetReturnRestockedResults contains results of the orderRepository.SetReturnRestocked
+type OrderRepositoryMockSetReturnRestockedResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Optional() *mOrderRepositoryMockSetReturnRestocked {
+	mmSetReturnRestocked.optional = true
+	return mmSetReturnRestocked
+}
+
+// Expect sets up expected params for orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Expect(ctx context.Context, o1 model.OrderID, r1 model.ReturnID) *mOrderRepositoryMockSetReturnRestocked {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation == nil {
+		mmSetReturnRestocked.defaultExpectation = &OrderRepositoryMockSetReturnRestockedExpectation{}
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.paramPtrs != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by ExpectParams functions")
+	}
+
+	mmSetReturnRestocked.defaultExpectation.params = &OrderRepositoryMockSetReturnRestockedParams{ctx, o1, r1}
+	for _, e := range mmSetReturnRestocked.expectations {
+		if minimock.Equal(e.params, mmSetReturnRestocked.defaultExpectation.params) {
+			mmSetReturnRestocked.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetReturnRestocked.defaultExpectation.params)
+		}
+	}
+
+	return mmSetReturnRestocked
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockSetReturnRestocked {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation == nil {
+		mmSetReturnRestocked.defaultExpectation = &OrderRepositoryMockSetReturnRestockedExpectation{}
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.params != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Expect")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.paramPtrs == nil {
+		mmSetReturnRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockSetReturnRestockedParamPtrs{}
+	}
+	mmSetReturnRestocked.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmSetReturnRestocked
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockSetReturnRestocked {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation == nil {
+		mmSetReturnRestocked.defaultExpectation = &OrderRepositoryMockSetReturnRestockedExpectation{}
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.params != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Expect")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.paramPtrs == nil {
+		mmSetReturnRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockSetReturnRestockedParamPtrs{}
+	}
+	mmSetReturnRestocked.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmSetReturnRestocked
+}
+
+// ExpectR1Param3 sets up expected param r1 for orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) ExpectR1Param3(r1 model.ReturnID) *mOrderRepositoryMockSetReturnRestocked {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation == nil {
+		mmSetReturnRestocked.defaultExpectation = &OrderRepositoryMockSetReturnRestockedExpectation{}
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.params != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Expect")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation.paramPtrs == nil {
+		mmSetReturnRestocked.defaultExpectation.paramPtrs = &OrderRepositoryMockSetReturnRestockedParamPtrs{}
+	}
+	mmSetReturnRestocked.defaultExpectation.paramPtrs.r1 = &r1
+
+	return mmSetReturnRestocked
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Inspect(f func(ctx context.Context, o1 model.OrderID, r1 model.ReturnID)) *mOrderRepositoryMockSetReturnRestocked {
+	if mmSetReturnRestocked.mock.inspectFuncSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.SetReturnRestocked")
+	}
+
+	mmSetReturnRestocked.mock.inspectFuncSetReturnRestocked = f
+
+	return mmSetReturnRestocked
+}
+
+// Return sets up results that will be returned by orderRepository.SetReturnRestocked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Return(err error) *OrderRepositoryMock {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	if mmSetReturnRestocked.defaultExpectation == nil {
+		mmSetReturnRestocked.defaultExpectation = &OrderRepositoryMockSetReturnRestockedExpectation{mock: mmSetReturnRestocked.mock}
+	}
+	mmSetReturnRestocked.defaultExpectation.results = &OrderRepositoryMockSetReturnRestockedResults{err}
+	return mmSetReturnRestocked.mock
+}
+
+// Set uses given function f to mock the orderRepository.SetReturnRestocked method
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Set(f func(ctx context.Context, o1 model.OrderID, r1 model.ReturnID) (err error)) *OrderRepositoryMock {
+	if mmSetReturnRestocked.defaultExpectation != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("Default expectation is already set for the orderRepository.SetReturnRestocked method")
+	}
+
+	if len(mmSetReturnRestocked.expectations) > 0 {
+		mmSetReturnRestocked.mock.t.Fatalf("Some expectations are already set for the orderRepository.SetReturnRestocked method")
+	}
+
+	mmSetReturnRestocked.mock.funcSetReturnRestocked = f
+	return mmSetReturnRestocked.mock
+}
+
+// When sets expectation for the orderRepository.SetReturnRestocked which will trigger the result defined by the following
+// Then helper
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) When(ctx context.Context, o1 model.OrderID, r1 model.ReturnID) *OrderRepositoryMockSetReturnRestockedExpectation {
+	if mmSetReturnRestocked.mock.funcSetReturnRestocked != nil {
+		mmSetReturnRestocked.mock.t.Fatalf("OrderRepositoryMock.SetReturnRestocked mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockSetReturnRestockedExpectation{
+		mock:   mmSetReturnRestocked.mock,
+		params: &OrderRepositoryMockSetReturnRestockedParams{ctx, o1, r1},
+	}
+	mmSetReturnRestocked.expectations = append(mmSetReturnRestocked.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.SetReturnRestocked return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockSetReturnRestockedExpectation) Then(err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockSetReturnRestockedResults{err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.SetReturnRestocked should be invoked
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Times(n uint64) *mOrderRepositoryMockSetReturnRestocked {
+	if n == 0 {
+		mmSetReturnRestocked.mock.t.Fatalf("Times of OrderRepositoryMock.SetReturnRestocked mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetReturnRestocked.expectedInvocations, n)
+	return mmSetReturnRestocked
+}
+
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) invocationsDone() bool {
+	if len(mmSetReturnRestocked.expectations) == 0 && mmSetReturnRestocked.defaultExpectation == nil && mmSetReturnRestocked.mock.funcSetReturnRestocked == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetReturnRestocked.mock.afterSetReturnRestockedCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetReturnRestocked.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetReturnRestocked implements service.orderRepository
+func (mmSetReturnRestocked *OrderRepositoryMock) SetReturnRestocked(ctx context.Context, o1 model.OrderID, r1 model.ReturnID) (err error) {
+	mm_atomic.AddUint64(&mmSetReturnRestocked.beforeSetReturnRestockedCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetReturnRestocked.afterSetReturnRestockedCounter, 1)
+
+	if mmSetReturnRestocked.inspectFuncSetReturnRestocked != nil {
+		mmSetReturnRestocked.inspectFuncSetReturnRestocked(ctx, o1, r1)
+	}
+
+	mm_params := OrderRepositoryMockSetReturnRestockedParams{ctx, o1, r1}
+
+	// Record call args
+	mmSetReturnRestocked.SetReturnRestockedMock.mutex.Lock()
+	mmSetReturnRestocked.SetReturnRestockedMock.callArgs = append(mmSetReturnRestocked.SetReturnRestockedMock.callArgs, &mm_params)
+	mmSetReturnRestocked.SetReturnRestockedMock.mutex.Unlock()
+
+	for _, e := range mmSetReturnRestocked.SetReturnRestockedMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmSetReturnRestocked.SetReturnRestockedMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetReturnRestocked.SetReturnRestockedMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetReturnRestocked.SetReturnRestockedMock.defaultExpectation.params
+		mm_want_ptrs := mmSetReturnRestocked.SetReturnRestockedMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockSetReturnRestockedParams{ctx, o1, r1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetReturnRestocked.t.Errorf("OrderRepositoryMock.SetReturnRestocked got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmSetReturnRestocked.t.Errorf("OrderRepositoryMock.SetReturnRestocked got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.r1 != nil && !minimock.Equal(*mm_want_ptrs.r1, mm_got.r1) {
+				mmSetReturnRestocked.t.Errorf("OrderRepositoryMock.SetReturnRestocked got unexpected parameter r1, want: %#v, got: %#v%s\n", *mm_want_ptrs.r1, mm_got.r1, minimock.Diff(*mm_want_ptrs.r1, mm_got.r1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetReturnRestocked.t.Errorf("OrderRepositoryMock.SetReturnRestocked got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetReturnRestocked.SetReturnRestockedMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetReturnRestocked.t.Fatal("No results are set for the OrderRepositoryMock.SetReturnRestocked")
+		}
+		return (*mm_results).err
+	}
+	if mmSetReturnRestocked.funcSetReturnRestocked != nil {
+		return mmSetReturnRestocked.funcSetReturnRestocked(ctx, o1, r1)
+	}
+	mmSetReturnRestocked.t.Fatalf("Unexpected call to OrderRepositoryMock.SetReturnRestocked. %v %v %v", ctx, o1, r1)
+	return
+}
+
+// SetReturnRestockedAfterCounter returns a count of finished OrderRepositoryMock.SetReturnRestocked invocations
+func (mmSetReturnRestocked *OrderRepositoryMock) SetReturnRestockedAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetReturnRestocked.afterSetReturnRestockedCounter)
+}
+
+// SetReturnRestockedBeforeCounter returns a count of OrderRepositoryMock.SetReturnRestocked invocations
+func (mmSetReturnRestocked *OrderRepositoryMock) SetReturnRestockedBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetReturnRestocked.beforeSetReturnRestockedCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.SetReturnRestocked.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetReturnRestocked *mOrderRepositoryMockSetReturnRestocked) Calls() []*OrderRepositoryMockSetReturnRestockedParams {
+	mmSetReturnRestocked.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockSetReturnRestockedParams, len(mmSetReturnRestocked.callArgs))
+	copy(argCopy, mmSetReturnRestocked.callArgs)
+
+	mmSetReturnRestocked.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetReturnRestockedDone returns true if the count of the SetReturnRestocked invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockSetReturnRestockedDone() bool {
+	if m.SetReturnRestockedMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetReturnRestockedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetReturnRestockedMock.invocationsDone()
+}
+
+// MinimockSetReturnRestockedInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockSetReturnRestockedInspect() {
+	for _, e := range m.SetReturnRestockedMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetReturnRestocked with params: %#v", *e.params)
+		}
+	}
+
+	afterSetReturnRestockedCounter := mm_atomic.LoadUint64(&m.afterSetReturnRestockedCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetReturnRestockedMock.defaultExpectation != nil && afterSetReturnRestockedCounter < 1 {
+		if m.SetReturnRestockedMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.SetReturnRestocked")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetReturnRestocked with params: %#v", *m.SetReturnRestockedMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetReturnRestocked != nil && afterSetReturnRestockedCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.SetReturnRestocked")
+	}
+
+	if !m.SetReturnRestockedMock.invocationsDone() && afterSetReturnRestockedCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.SetReturnRestocked but found %d calls",
+			mm_atomic.LoadUint64(&m.SetReturnRestockedMock.expectedInvocations), afterSetReturnRestockedCounter)
+	}
+}
+
+type mOrderRepositoryMockSetStatus struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockSetStatusExpectation
+	expectations       []*OrderRepositoryMockSetStatusExpectation
+
+	callArgs []*OrderRepositoryMockSetStatusParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockSetStatusExpectation specifies expectation struct of the orderRepository.SetStatus
+type OrderRepositoryMockSetStatusExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockSetStatusParams
+	paramPtrs *OrderRepositoryMockSetStatusParamPtrs
+	results   *OrderRepositoryMockSetStatusResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockSetStatusParams contains parameters of the orderRepository.SetStatus
+type OrderRepositoryMockSetStatusParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	o2  model.OrderStatus
+	o3  model.OrderStatus
+}
+
+// OrderRepositoryMockSetStatusParamPtrs contains pointers to parameters of the orderRepository.SetStatus
+type OrderRepositoryMockSetStatusParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	o2  *model.OrderStatus
+	o3  *model.OrderStatus
+}
+
+// OrderRepositoryMockSetStatusResults contains results of the orderRepository.SetStatus
+type OrderRepositoryMockSetStatusResults struct {
+	oa1 []model.OrderItem
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Optional() *mOrderRepositoryMockSetStatus {
+	mmSetStatus.optional = true
+	return mmSetStatus
+}
+
+// Expect sets up expected params for orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Expect(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.paramPtrs != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by ExpectParams functions")
+	}
+
+	mmSetStatus.defaultExpectation.params = &OrderRepositoryMockSetStatusParams{ctx, o1, o2, o3}
+	for _, e := range mmSetStatus.expectations {
+		if minimock.Equal(e.params, mmSetStatus.defaultExpectation.params) {
+			mmSetStatus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmSetStatus.defaultExpectation.params)
+		}
+	}
+
+	return mmSetStatus
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.params != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
+	}
+
+	if mmSetStatus.defaultExpectation.paramPtrs == nil {
+		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	}
+	mmSetStatus.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmSetStatus
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.params != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
+	}
+
+	if mmSetStatus.defaultExpectation.paramPtrs == nil {
+		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	}
+	mmSetStatus.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmSetStatus
+}
+
+// ExpectO2Param3 sets up expected param o2 for orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectO2Param3(o2 model.OrderStatus) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.params != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
+	}
+
+	if mmSetStatus.defaultExpectation.paramPtrs == nil {
+		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	}
+	mmSetStatus.defaultExpectation.paramPtrs.o2 = &o2
+
+	return mmSetStatus
+}
+
+// ExpectO3Param4 sets up expected param o3 for orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectO3Param4(o3 model.OrderStatus) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	}
+
+	if mmSetStatus.defaultExpectation.params != nil {
 		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
 	}
 
-	if mmSetStatus.defaultExpectation.paramPtrs == nil {
-		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	if mmSetStatus.defaultExpectation.paramPtrs == nil {
+		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+	}
+	mmSetStatus.defaultExpectation.paramPtrs.o3 = &o3
+
+	return mmSetStatus
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Inspect(f func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus)) *mOrderRepositoryMockSetStatus {
+	if mmSetStatus.mock.inspectFuncSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.SetStatus")
+	}
+
+	mmSetStatus.mock.inspectFuncSetStatus = f
+
+	return mmSetStatus
+}
+
+// Return sets up results that will be returned by orderRepository.SetStatus
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Return(oa1 []model.OrderItem, err error) *OrderRepositoryMock {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	if mmSetStatus.defaultExpectation == nil {
+		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{mock: mmSetStatus.mock}
+	}
+	mmSetStatus.defaultExpectation.results = &OrderRepositoryMockSetStatusResults{oa1, err}
+	return mmSetStatus.mock
+}
+
+// Set uses given function f to mock the orderRepository.SetStatus method
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Set(f func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus) (oa1 []model.OrderItem, err error)) *OrderRepositoryMock {
+	if mmSetStatus.defaultExpectation != nil {
+		mmSetStatus.mock.t.Fatalf("Default expectation is already set for the orderRepository.SetStatus method")
+	}
+
+	if len(mmSetStatus.expectations) > 0 {
+		mmSetStatus.mock.t.Fatalf("Some expectations are already set for the orderRepository.SetStatus method")
+	}
+
+	mmSetStatus.mock.funcSetStatus = f
+	return mmSetStatus.mock
+}
+
+// When sets expectation for the orderRepository.SetStatus which will trigger the result defined by the following
+// Then helper
+func (mmSetStatus *mOrderRepositoryMockSetStatus) When(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus) *OrderRepositoryMockSetStatusExpectation {
+	if mmSetStatus.mock.funcSetStatus != nil {
+		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockSetStatusExpectation{
+		mock:   mmSetStatus.mock,
+		params: &OrderRepositoryMockSetStatusParams{ctx, o1, o2, o3},
+	}
+	mmSetStatus.expectations = append(mmSetStatus.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.SetStatus return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockSetStatusExpectation) Then(oa1 []model.OrderItem, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockSetStatusResults{oa1, err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.SetStatus should be invoked
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Times(n uint64) *mOrderRepositoryMockSetStatus {
+	if n == 0 {
+		mmSetStatus.mock.t.Fatalf("Times of OrderRepositoryMock.SetStatus mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmSetStatus.expectedInvocations, n)
+	return mmSetStatus
+}
+
+func (mmSetStatus *mOrderRepositoryMockSetStatus) invocationsDone() bool {
+	if len(mmSetStatus.expectations) == 0 && mmSetStatus.defaultExpectation == nil && mmSetStatus.mock.funcSetStatus == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmSetStatus.mock.afterSetStatusCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmSetStatus.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// SetStatus implements service.orderRepository
+func (mmSetStatus *OrderRepositoryMock) SetStatus(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus, o3 model.OrderStatus) (oa1 []model.OrderItem, err error) {
+	mm_atomic.AddUint64(&mmSetStatus.beforeSetStatusCounter, 1)
+	defer mm_atomic.AddUint64(&mmSetStatus.afterSetStatusCounter, 1)
+
+	if mmSetStatus.inspectFuncSetStatus != nil {
+		mmSetStatus.inspectFuncSetStatus(ctx, o1, o2, o3)
+	}
+
+	mm_params := OrderRepositoryMockSetStatusParams{ctx, o1, o2, o3}
+
+	// Record call args
+	mmSetStatus.SetStatusMock.mutex.Lock()
+	mmSetStatus.SetStatusMock.callArgs = append(mmSetStatus.SetStatusMock.callArgs, &mm_params)
+	mmSetStatus.SetStatusMock.mutex.Unlock()
+
+	for _, e := range mmSetStatus.SetStatusMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.oa1, e.results.err
+		}
+	}
+
+	if mmSetStatus.SetStatusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmSetStatus.SetStatusMock.defaultExpectation.Counter, 1)
+		mm_want := mmSetStatus.SetStatusMock.defaultExpectation.params
+		mm_want_ptrs := mmSetStatus.SetStatusMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockSetStatusParams{ctx, o1, o2, o3}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.o2 != nil && !minimock.Equal(*mm_want_ptrs.o2, mm_got.o2) {
+				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter o2, want: %#v, got: %#v%s\n", *mm_want_ptrs.o2, mm_got.o2, minimock.Diff(*mm_want_ptrs.o2, mm_got.o2))
+			}
+
+			if mm_want_ptrs.o3 != nil && !minimock.Equal(*mm_want_ptrs.o3, mm_got.o3) {
+				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter o3, want: %#v, got: %#v%s\n", *mm_want_ptrs.o3, mm_got.o3, minimock.Diff(*mm_want_ptrs.o3, mm_got.o3))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmSetStatus.SetStatusMock.defaultExpectation.results
+		if mm_results == nil {
+			mmSetStatus.t.Fatal("No results are set for the OrderRepositoryMock.SetStatus")
+		}
+		return (*mm_results).oa1, (*mm_results).err
+	}
+	if mmSetStatus.funcSetStatus != nil {
+		return mmSetStatus.funcSetStatus(ctx, o1, o2, o3)
+	}
+	mmSetStatus.t.Fatalf("Unexpected call to OrderRepositoryMock.SetStatus. %v %v %v %v", ctx, o1, o2, o3)
+	return
+}
+
+// SetStatusAfterCounter returns a count of finished OrderRepositoryMock.SetStatus invocations
+func (mmSetStatus *OrderRepositoryMock) SetStatusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetStatus.afterSetStatusCounter)
+}
+
+// SetStatusBeforeCounter returns a count of OrderRepositoryMock.SetStatus invocations
+func (mmSetStatus *OrderRepositoryMock) SetStatusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmSetStatus.beforeSetStatusCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.SetStatus.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmSetStatus *mOrderRepositoryMockSetStatus) Calls() []*OrderRepositoryMockSetStatusParams {
+	mmSetStatus.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockSetStatusParams, len(mmSetStatus.callArgs))
+	copy(argCopy, mmSetStatus.callArgs)
+
+	mmSetStatus.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockSetStatusDone returns true if the count of the SetStatus invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockSetStatusDone() bool {
+	if m.SetStatusMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.SetStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.SetStatusMock.invocationsDone()
+}
+
+// MinimockSetStatusInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockSetStatusInspect() {
+	for _, e := range m.SetStatusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetStatus with params: %#v", *e.params)
+		}
+	}
+
+	afterSetStatusCounter := mm_atomic.LoadUint64(&m.afterSetStatusCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.SetStatusMock.defaultExpectation != nil && afterSetStatusCounter < 1 {
+		if m.SetStatusMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.SetStatus")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.SetStatus with params: %#v", *m.SetStatusMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcSetStatus != nil && afterSetStatusCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.SetStatus")
+	}
+
+	if !m.SetStatusMock.invocationsDone() && afterSetStatusCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.SetStatus but found %d calls",
+			mm_atomic.LoadUint64(&m.SetStatusMock.expectedInvocations), afterSetStatusCounter)
+	}
+}
+
+type mOrderRepositoryMockStreamAll struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockStreamAllExpectation
+	expectations       []*OrderRepositoryMockStreamAllExpectation
+
+	callArgs []*OrderRepositoryMockStreamAllParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockStreamAllExpectation specifies expectation struct of the orderRepository.StreamAll
+type OrderRepositoryMockStreamAllExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockStreamAllParams
+	paramPtrs *OrderRepositoryMockStreamAllParamPtrs
+	results   *OrderRepositoryMockStreamAllResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockStreamAllParams contains parameters of the orderRepository.StreamAll
+type OrderRepositoryMockStreamAllParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	i1  int
+	f1  func(model.Order) error
+}
+
+// OrderRepositoryMockStreamAllParamPtrs contains pointers to parameters of the orderRepository.StreamAll
+type OrderRepositoryMockStreamAllParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	i1  *int
+	f1  *func(model.Order) error
+}
+
+// OrderRepositoryMockStreamAllResults contains results of the orderRepository.StreamAll
+type OrderRepositoryMockStreamAllResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Optional() *mOrderRepositoryMockStreamAll {
+	mmStreamAll.optional = true
+	return mmStreamAll
+}
+
+// Expect sets up expected params for orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Expect(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{}
+	}
+
+	if mmStreamAll.defaultExpectation.paramPtrs != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by ExpectParams functions")
+	}
+
+	mmStreamAll.defaultExpectation.params = &OrderRepositoryMockStreamAllParams{ctx, o1, i1, f1}
+	for _, e := range mmStreamAll.expectations {
+		if minimock.Equal(e.params, mmStreamAll.defaultExpectation.params) {
+			mmStreamAll.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmStreamAll.defaultExpectation.params)
+		}
+	}
+
+	return mmStreamAll
+}
+
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{}
+	}
+
+	if mmStreamAll.defaultExpectation.params != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Expect")
+	}
+
+	if mmStreamAll.defaultExpectation.paramPtrs == nil {
+		mmStreamAll.defaultExpectation.paramPtrs = &OrderRepositoryMockStreamAllParamPtrs{}
+	}
+	mmStreamAll.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmStreamAll
+}
+
+// ExpectO1Param2 sets up expected param o1 for orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{}
+	}
+
+	if mmStreamAll.defaultExpectation.params != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Expect")
+	}
+
+	if mmStreamAll.defaultExpectation.paramPtrs == nil {
+		mmStreamAll.defaultExpectation.paramPtrs = &OrderRepositoryMockStreamAllParamPtrs{}
+	}
+	mmStreamAll.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmStreamAll
+}
+
+// ExpectI1Param3 sets up expected param i1 for orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) ExpectI1Param3(i1 int) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{}
+	}
+
+	if mmStreamAll.defaultExpectation.params != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Expect")
+	}
+
+	if mmStreamAll.defaultExpectation.paramPtrs == nil {
+		mmStreamAll.defaultExpectation.paramPtrs = &OrderRepositoryMockStreamAllParamPtrs{}
+	}
+	mmStreamAll.defaultExpectation.paramPtrs.i1 = &i1
+
+	return mmStreamAll
+}
+
+// ExpectF1Param4 sets up expected param f1 for orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) ExpectF1Param4(f1 func(model.Order) error) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{}
+	}
+
+	if mmStreamAll.defaultExpectation.params != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Expect")
+	}
+
+	if mmStreamAll.defaultExpectation.paramPtrs == nil {
+		mmStreamAll.defaultExpectation.paramPtrs = &OrderRepositoryMockStreamAllParamPtrs{}
+	}
+	mmStreamAll.defaultExpectation.paramPtrs.f1 = &f1
+
+	return mmStreamAll
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Inspect(f func(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error)) *mOrderRepositoryMockStreamAll {
+	if mmStreamAll.mock.inspectFuncStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.StreamAll")
+	}
+
+	mmStreamAll.mock.inspectFuncStreamAll = f
+
+	return mmStreamAll
+}
+
+// Return sets up results that will be returned by orderRepository.StreamAll
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Return(err error) *OrderRepositoryMock {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	if mmStreamAll.defaultExpectation == nil {
+		mmStreamAll.defaultExpectation = &OrderRepositoryMockStreamAllExpectation{mock: mmStreamAll.mock}
+	}
+	mmStreamAll.defaultExpectation.results = &OrderRepositoryMockStreamAllResults{err}
+	return mmStreamAll.mock
+}
+
+// Set uses given function f to mock the orderRepository.StreamAll method
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Set(f func(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error) (err error)) *OrderRepositoryMock {
+	if mmStreamAll.defaultExpectation != nil {
+		mmStreamAll.mock.t.Fatalf("Default expectation is already set for the orderRepository.StreamAll method")
+	}
+
+	if len(mmStreamAll.expectations) > 0 {
+		mmStreamAll.mock.t.Fatalf("Some expectations are already set for the orderRepository.StreamAll method")
+	}
+
+	mmStreamAll.mock.funcStreamAll = f
+	return mmStreamAll.mock
+}
+
+// When sets expectation for the orderRepository.StreamAll which will trigger the result defined by the following
+// Then helper
+func (mmStreamAll *mOrderRepositoryMockStreamAll) When(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error) *OrderRepositoryMockStreamAllExpectation {
+	if mmStreamAll.mock.funcStreamAll != nil {
+		mmStreamAll.mock.t.Fatalf("OrderRepositoryMock.StreamAll mock is already set by Set")
+	}
+
+	expectation := &OrderRepositoryMockStreamAllExpectation{
+		mock:   mmStreamAll.mock,
+		params: &OrderRepositoryMockStreamAllParams{ctx, o1, i1, f1},
+	}
+	mmStreamAll.expectations = append(mmStreamAll.expectations, expectation)
+	return expectation
+}
+
+// Then sets up orderRepository.StreamAll return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockStreamAllExpectation) Then(err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockStreamAllResults{err}
+	return e.mock
+}
+
+// Times sets number of times orderRepository.StreamAll should be invoked
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Times(n uint64) *mOrderRepositoryMockStreamAll {
+	if n == 0 {
+		mmStreamAll.mock.t.Fatalf("Times of OrderRepositoryMock.StreamAll mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmStreamAll.expectedInvocations, n)
+	return mmStreamAll
+}
+
+func (mmStreamAll *mOrderRepositoryMockStreamAll) invocationsDone() bool {
+	if len(mmStreamAll.expectations) == 0 && mmStreamAll.defaultExpectation == nil && mmStreamAll.mock.funcStreamAll == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmStreamAll.mock.afterStreamAllCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmStreamAll.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// StreamAll implements service.orderRepository
+func (mmStreamAll *OrderRepositoryMock) StreamAll(ctx context.Context, o1 model.OrderID, i1 int, f1 func(model.Order) error) (err error) {
+	mm_atomic.AddUint64(&mmStreamAll.beforeStreamAllCounter, 1)
+	defer mm_atomic.AddUint64(&mmStreamAll.afterStreamAllCounter, 1)
+
+	if mmStreamAll.inspectFuncStreamAll != nil {
+		mmStreamAll.inspectFuncStreamAll(ctx, o1, i1, f1)
+	}
+
+	mm_params := OrderRepositoryMockStreamAllParams{ctx, o1, i1, f1}
+
+	// Record call args
+	mmStreamAll.StreamAllMock.mutex.Lock()
+	mmStreamAll.StreamAllMock.callArgs = append(mmStreamAll.StreamAllMock.callArgs, &mm_params)
+	mmStreamAll.StreamAllMock.mutex.Unlock()
+
+	for _, e := range mmStreamAll.StreamAllMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmStreamAll.StreamAllMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmStreamAll.StreamAllMock.defaultExpectation.Counter, 1)
+		mm_want := mmStreamAll.StreamAllMock.defaultExpectation.params
+		mm_want_ptrs := mmStreamAll.StreamAllMock.defaultExpectation.paramPtrs
+
+		mm_got := OrderRepositoryMockStreamAllParams{ctx, o1, i1, f1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmStreamAll.t.Errorf("OrderRepositoryMock.StreamAll got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
+				mmStreamAll.t.Errorf("OrderRepositoryMock.StreamAll got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+			}
+
+			if mm_want_ptrs.i1 != nil && !minimock.Equal(*mm_want_ptrs.i1, mm_got.i1) {
+				mmStreamAll.t.Errorf("OrderRepositoryMock.StreamAll got unexpected parameter i1, want: %#v, got: %#v%s\n", *mm_want_ptrs.i1, mm_got.i1, minimock.Diff(*mm_want_ptrs.i1, mm_got.i1))
+			}
+
+			if mm_want_ptrs.f1 != nil && !minimock.Equal(*mm_want_ptrs.f1, mm_got.f1) {
+				mmStreamAll.t.Errorf("OrderRepositoryMock.StreamAll got unexpected parameter f1, want: %#v, got: %#v%s\n", *mm_want_ptrs.f1, mm_got.f1, minimock.Diff(*mm_want_ptrs.f1, mm_got.f1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmStreamAll.t.Errorf("OrderRepositoryMock.StreamAll got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmStreamAll.StreamAllMock.defaultExpectation.results
+		if mm_results == nil {
+			mmStreamAll.t.Fatal("No results are set for the OrderRepositoryMock.StreamAll")
+		}
+		return (*mm_results).err
+	}
+	if mmStreamAll.funcStreamAll != nil {
+		return mmStreamAll.funcStreamAll(ctx, o1, i1, f1)
+	}
+	mmStreamAll.t.Fatalf("Unexpected call to OrderRepositoryMock.StreamAll. %v %v %v %v", ctx, o1, i1, f1)
+	return
+}
+
+// StreamAllAfterCounter returns a count of finished OrderRepositoryMock.StreamAll invocations
+func (mmStreamAll *OrderRepositoryMock) StreamAllAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStreamAll.afterStreamAllCounter)
+}
+
+// StreamAllBeforeCounter returns a count of OrderRepositoryMock.StreamAll invocations
+func (mmStreamAll *OrderRepositoryMock) StreamAllBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmStreamAll.beforeStreamAllCounter)
+}
+
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.StreamAll.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmStreamAll *mOrderRepositoryMockStreamAll) Calls() []*OrderRepositoryMockStreamAllParams {
+	mmStreamAll.mutex.RLock()
+
+	argCopy := make([]*OrderRepositoryMockStreamAllParams, len(mmStreamAll.callArgs))
+	copy(argCopy, mmStreamAll.callArgs)
+
+	mmStreamAll.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockStreamAllDone returns true if the count of the StreamAll invocations corresponds
+// the number of defined expectations
+func (m *OrderRepositoryMock) MinimockStreamAllDone() bool {
+	if m.StreamAllMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
 	}
-	mmSetStatus.defaultExpectation.paramPtrs.o1 = &o1
 
-	return mmSetStatus
+	for _, e := range m.StreamAllMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.StreamAllMock.invocationsDone()
 }
 
-// ExpectO2Param3 sets up expected param o2 for orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) ExpectO2Param3(o2 model.OrderStatus) *mOrderRepositoryMockSetStatus {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+// MinimockStreamAllInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockStreamAllInspect() {
+	for _, e := range m.StreamAllMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to OrderRepositoryMock.StreamAll with params: %#v", *e.params)
+		}
 	}
 
-	if mmSetStatus.defaultExpectation == nil {
-		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{}
+	afterStreamAllCounter := mm_atomic.LoadUint64(&m.afterStreamAllCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.StreamAllMock.defaultExpectation != nil && afterStreamAllCounter < 1 {
+		if m.StreamAllMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.StreamAll")
+		} else {
+			m.t.Errorf("Expected call to OrderRepositoryMock.StreamAll with params: %#v", *m.StreamAllMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcStreamAll != nil && afterStreamAllCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.StreamAll")
 	}
 
-	if mmSetStatus.defaultExpectation.params != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Expect")
+	if !m.StreamAllMock.invocationsDone() && afterStreamAllCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.StreamAll but found %d calls",
+			mm_atomic.LoadUint64(&m.StreamAllMock.expectedInvocations), afterStreamAllCounter)
 	}
+}
 
-	if mmSetStatus.defaultExpectation.paramPtrs == nil {
-		mmSetStatus.defaultExpectation.paramPtrs = &OrderRepositoryMockSetStatusParamPtrs{}
+type mOrderRepositoryMockUpdateItems struct {
+	optional           bool
+	mock               *OrderRepositoryMock
+	defaultExpectation *OrderRepositoryMockUpdateItemsExpectation
+	expectations       []*OrderRepositoryMockUpdateItemsExpectation
+
+	callArgs []*OrderRepositoryMockUpdateItemsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// OrderRepositoryMockUpdateItemsExpectation specifies expectation struct of the orderRepository.UpdateItems
+type OrderRepositoryMockUpdateItemsExpectation struct {
+	mock      *OrderRepositoryMock
+	params    *OrderRepositoryMockUpdateItemsParams
+	paramPtrs *OrderRepositoryMockUpdateItemsParamPtrs
+	results   *OrderRepositoryMockUpdateItemsResults
+	Counter   uint64
+}
+
+// OrderRepositoryMockUpdateItemsParams contains parameters of the orderRepository.UpdateItems
+type OrderRepositoryMockUpdateItemsParams struct {
+	ctx context.Context
+	o1  model.OrderID
+	oa1 []model.OrderItem
+	f1  func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error)
+	p1  []func(context.Context, model.Order) error
+}
+
+// OrderRepositoryMockUpdateItemsParamPtrs contains pointers to parameters of the orderRepository.UpdateItems
+type OrderRepositoryMockUpdateItemsParamPtrs struct {
+	ctx *context.Context
+	o1  *model.OrderID
+	oa1 *[]model.OrderItem
+	f1  *func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error)
+	p1  *[]func(context.Context, model.Order) error
+}
+
+// OrderRepositoryMockUpdateItemsResults contains results of the orderRepository.UpdateItems
+type OrderRepositoryMockUpdateItemsResults struct {
+	o2  model.Order
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Optional() *mOrderRepositoryMockUpdateItems {
+	mmUpdateItems.optional = true
+	return mmUpdateItems
+}
+
+// Expect sets up expected params for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Expect(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
 	}
-	mmSetStatus.defaultExpectation.paramPtrs.o2 = &o2
 
-	return mmSetStatus
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by ExpectParams functions")
+	}
+
+	mmUpdateItems.defaultExpectation.params = &OrderRepositoryMockUpdateItemsParams{ctx, o1, oa1, f1, p1}
+	for _, e := range mmUpdateItems.expectations {
+		if minimock.Equal(e.params, mmUpdateItems.defaultExpectation.params) {
+			mmUpdateItems.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmUpdateItems.defaultExpectation.params)
+		}
+	}
+
+	return mmUpdateItems
 }
 
-// Inspect accepts an inspector function that has same arguments as the orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Inspect(f func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus)) *mOrderRepositoryMockSetStatus {
-	if mmSetStatus.mock.inspectFuncSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.SetStatus")
+// ExpectCtxParam1 sets up expected param ctx for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) ExpectCtxParam1(ctx context.Context) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
 	}
 
-	mmSetStatus.mock.inspectFuncSetStatus = f
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
+	}
 
-	return mmSetStatus
+	if mmUpdateItems.defaultExpectation.params != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Expect")
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs == nil {
+		mmUpdateItems.defaultExpectation.paramPtrs = &OrderRepositoryMockUpdateItemsParamPtrs{}
+	}
+	mmUpdateItems.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmUpdateItems
 }
 
-// Return sets up results that will be returned by orderRepository.SetStatus
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Return(err error) *OrderRepositoryMock {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+// ExpectO1Param2 sets up expected param o1 for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) ExpectO1Param2(o1 model.OrderID) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
 	}
 
-	if mmSetStatus.defaultExpectation == nil {
-		mmSetStatus.defaultExpectation = &OrderRepositoryMockSetStatusExpectation{mock: mmSetStatus.mock}
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
 	}
-	mmSetStatus.defaultExpectation.results = &OrderRepositoryMockSetStatusResults{err}
-	return mmSetStatus.mock
+
+	if mmUpdateItems.defaultExpectation.params != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Expect")
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs == nil {
+		mmUpdateItems.defaultExpectation.paramPtrs = &OrderRepositoryMockUpdateItemsParamPtrs{}
+	}
+	mmUpdateItems.defaultExpectation.paramPtrs.o1 = &o1
+
+	return mmUpdateItems
 }
 
-// Set uses given function f to mock the orderRepository.SetStatus method
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Set(f func(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus) (err error)) *OrderRepositoryMock {
-	if mmSetStatus.defaultExpectation != nil {
-		mmSetStatus.mock.t.Fatalf("Default expectation is already set for the orderRepository.SetStatus method")
+// ExpectOa1Param3 sets up expected param oa1 for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) ExpectOa1Param3(oa1 []model.OrderItem) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
 	}
 
-	if len(mmSetStatus.expectations) > 0 {
-		mmSetStatus.mock.t.Fatalf("Some expectations are already set for the orderRepository.SetStatus method")
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
 	}
 
-	mmSetStatus.mock.funcSetStatus = f
-	return mmSetStatus.mock
+	if mmUpdateItems.defaultExpectation.params != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Expect")
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs == nil {
+		mmUpdateItems.defaultExpectation.paramPtrs = &OrderRepositoryMockUpdateItemsParamPtrs{}
+	}
+	mmUpdateItems.defaultExpectation.paramPtrs.oa1 = &oa1
+
+	return mmUpdateItems
 }
 
-// When sets expectation for the orderRepository.SetStatus which will trigger the result defined by the following
+// ExpectF1Param4 sets up expected param f1 for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) ExpectF1Param4(f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error)) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
+	}
+
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
+	}
+
+	if mmUpdateItems.defaultExpectation.params != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Expect")
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs == nil {
+		mmUpdateItems.defaultExpectation.paramPtrs = &OrderRepositoryMockUpdateItemsParamPtrs{}
+	}
+	mmUpdateItems.defaultExpectation.paramPtrs.f1 = &f1
+
+	return mmUpdateItems
+}
+
+// ExpectP1Param5 sets up expected param p1 for orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) ExpectP1Param5(p1 ...func(context.Context, model.Order) error) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
+	}
+
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{}
+	}
+
+	if mmUpdateItems.defaultExpectation.params != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Expect")
+	}
+
+	if mmUpdateItems.defaultExpectation.paramPtrs == nil {
+		mmUpdateItems.defaultExpectation.paramPtrs = &OrderRepositoryMockUpdateItemsParamPtrs{}
+	}
+	mmUpdateItems.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmUpdateItems
+}
+
+// Inspect accepts an inspector function that has same arguments as the orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Inspect(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error)) *mOrderRepositoryMockUpdateItems {
+	if mmUpdateItems.mock.inspectFuncUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("Inspect function is already set for OrderRepositoryMock.UpdateItems")
+	}
+
+	mmUpdateItems.mock.inspectFuncUpdateItems = f
+
+	return mmUpdateItems
+}
+
+// Return sets up results that will be returned by orderRepository.UpdateItems
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Return(o2 model.Order, err error) *OrderRepositoryMock {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
+	}
+
+	if mmUpdateItems.defaultExpectation == nil {
+		mmUpdateItems.defaultExpectation = &OrderRepositoryMockUpdateItemsExpectation{mock: mmUpdateItems.mock}
+	}
+	mmUpdateItems.defaultExpectation.results = &OrderRepositoryMockUpdateItemsResults{o2, err}
+	return mmUpdateItems.mock
+}
+
+// Set uses given function f to mock the orderRepository.UpdateItems method
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Set(f func(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error) (o2 model.Order, err error)) *OrderRepositoryMock {
+	if mmUpdateItems.defaultExpectation != nil {
+		mmUpdateItems.mock.t.Fatalf("Default expectation is already set for the orderRepository.UpdateItems method")
+	}
+
+	if len(mmUpdateItems.expectations) > 0 {
+		mmUpdateItems.mock.t.Fatalf("Some expectations are already set for the orderRepository.UpdateItems method")
+	}
+
+	mmUpdateItems.mock.funcUpdateItems = f
+	return mmUpdateItems.mock
+}
+
+// When sets expectation for the orderRepository.UpdateItems which will trigger the result defined by the following
 // Then helper
-func (mmSetStatus *mOrderRepositoryMockSetStatus) When(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus) *OrderRepositoryMockSetStatusExpectation {
-	if mmSetStatus.mock.funcSetStatus != nil {
-		mmSetStatus.mock.t.Fatalf("OrderRepositoryMock.SetStatus mock is already set by Set")
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) When(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error) *OrderRepositoryMockUpdateItemsExpectation {
+	if mmUpdateItems.mock.funcUpdateItems != nil {
+		mmUpdateItems.mock.t.Fatalf("OrderRepositoryMock.UpdateItems mock is already set by Set")
 	}
 
-	expectation := &OrderRepositoryMockSetStatusExpectation{
-		mock:   mmSetStatus.mock,
-		params: &OrderRepositoryMockSetStatusParams{ctx, o1, o2},
+	expectation := &OrderRepositoryMockUpdateItemsExpectation{
+		mock:   mmUpdateItems.mock,
+		params: &OrderRepositoryMockUpdateItemsParams{ctx, o1, oa1, f1, p1},
 	}
-	mmSetStatus.expectations = append(mmSetStatus.expectations, expectation)
+	mmUpdateItems.expectations = append(mmUpdateItems.expectations, expectation)
 	return expectation
 }
 
-// Then sets up orderRepository.SetStatus return parameters for the expectation previously defined by the When method
-func (e *OrderRepositoryMockSetStatusExpectation) Then(err error) *OrderRepositoryMock {
-	e.results = &OrderRepositoryMockSetStatusResults{err}
+// Then sets up orderRepository.UpdateItems return parameters for the expectation previously defined by the When method
+func (e *OrderRepositoryMockUpdateItemsExpectation) Then(o2 model.Order, err error) *OrderRepositoryMock {
+	e.results = &OrderRepositoryMockUpdateItemsResults{o2, err}
 	return e.mock
 }
 
-// Times sets number of times orderRepository.SetStatus should be invoked
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Times(n uint64) *mOrderRepositoryMockSetStatus {
+// Times sets number of times orderRepository.UpdateItems should be invoked
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Times(n uint64) *mOrderRepositoryMockUpdateItems {
 	if n == 0 {
-		mmSetStatus.mock.t.Fatalf("Times of OrderRepositoryMock.SetStatus mock can not be zero")
+		mmUpdateItems.mock.t.Fatalf("Times of OrderRepositoryMock.UpdateItems mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmSetStatus.expectedInvocations, n)
-	return mmSetStatus
+	mm_atomic.StoreUint64(&mmUpdateItems.expectedInvocations, n)
+	return mmUpdateItems
 }
 
-func (mmSetStatus *mOrderRepositoryMockSetStatus) invocationsDone() bool {
-	if len(mmSetStatus.expectations) == 0 && mmSetStatus.defaultExpectation == nil && mmSetStatus.mock.funcSetStatus == nil {
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) invocationsDone() bool {
+	if len(mmUpdateItems.expectations) == 0 && mmUpdateItems.defaultExpectation == nil && mmUpdateItems.mock.funcUpdateItems == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmSetStatus.mock.afterSetStatusCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmSetStatus.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmUpdateItems.mock.afterUpdateItemsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmUpdateItems.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// SetStatus implements service.orderRepository
-func (mmSetStatus *OrderRepositoryMock) SetStatus(ctx context.Context, o1 model.OrderID, o2 model.OrderStatus) (err error) {
-	mm_atomic.AddUint64(&mmSetStatus.beforeSetStatusCounter, 1)
-	defer mm_atomic.AddUint64(&mmSetStatus.afterSetStatusCounter, 1)
+// UpdateItems implements service.orderRepository
+func (mmUpdateItems *OrderRepositoryMock) UpdateItems(ctx context.Context, o1 model.OrderID, oa1 []model.OrderItem, f1 func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error), p1 ...func(context.Context, model.Order) error) (o2 model.Order, err error) {
+	mm_atomic.AddUint64(&mmUpdateItems.beforeUpdateItemsCounter, 1)
+	defer mm_atomic.AddUint64(&mmUpdateItems.afterUpdateItemsCounter, 1)
 
-	if mmSetStatus.inspectFuncSetStatus != nil {
-		mmSetStatus.inspectFuncSetStatus(ctx, o1, o2)
+	if mmUpdateItems.inspectFuncUpdateItems != nil {
+		mmUpdateItems.inspectFuncUpdateItems(ctx, o1, oa1, f1, p1...)
 	}
 
-	mm_params := OrderRepositoryMockSetStatusParams{ctx, o1, o2}
+	mm_params := OrderRepositoryMockUpdateItemsParams{ctx, o1, oa1, f1, p1}
 
 	// Record call args
-	mmSetStatus.SetStatusMock.mutex.Lock()
-	mmSetStatus.SetStatusMock.callArgs = append(mmSetStatus.SetStatusMock.callArgs, &mm_params)
-	mmSetStatus.SetStatusMock.mutex.Unlock()
+	mmUpdateItems.UpdateItemsMock.mutex.Lock()
+	mmUpdateItems.UpdateItemsMock.callArgs = append(mmUpdateItems.UpdateItemsMock.callArgs, &mm_params)
+	mmUpdateItems.UpdateItemsMock.mutex.Unlock()
 
-	for _, e := range mmSetStatus.SetStatusMock.expectations {
+	for _, e := range mmUpdateItems.UpdateItemsMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.o2, e.results.err
 		}
 	}
 
-	if mmSetStatus.SetStatusMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmSetStatus.SetStatusMock.defaultExpectation.Counter, 1)
-		mm_want := mmSetStatus.SetStatusMock.defaultExpectation.params
-		mm_want_ptrs := mmSetStatus.SetStatusMock.defaultExpectation.paramPtrs
+	if mmUpdateItems.UpdateItemsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmUpdateItems.UpdateItemsMock.defaultExpectation.Counter, 1)
+		mm_want := mmUpdateItems.UpdateItemsMock.defaultExpectation.params
+		mm_want_ptrs := mmUpdateItems.UpdateItemsMock.defaultExpectation.paramPtrs
 
-		mm_got := OrderRepositoryMockSetStatusParams{ctx, o1, o2}
+		mm_got := OrderRepositoryMockUpdateItemsParams{ctx, o1, oa1, f1, p1}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
 			if mm_want_ptrs.o1 != nil && !minimock.Equal(*mm_want_ptrs.o1, mm_got.o1) {
-				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
+				mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameter o1, want: %#v, got: %#v%s\n", *mm_want_ptrs.o1, mm_got.o1, minimock.Diff(*mm_want_ptrs.o1, mm_got.o1))
 			}
 
-			if mm_want_ptrs.o2 != nil && !minimock.Equal(*mm_want_ptrs.o2, mm_got.o2) {
-				mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameter o2, want: %#v, got: %#v%s\n", *mm_want_ptrs.o2, mm_got.o2, minimock.Diff(*mm_want_ptrs.o2, mm_got.o2))
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
+			}
+
+			if mm_want_ptrs.f1 != nil && !minimock.Equal(*mm_want_ptrs.f1, mm_got.f1) {
+				mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameter f1, want: %#v, got: %#v%s\n", *mm_want_ptrs.f1, mm_got.f1, minimock.Diff(*mm_want_ptrs.f1, mm_got.f1))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmSetStatus.t.Errorf("OrderRepositoryMock.SetStatus got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmUpdateItems.t.Errorf("OrderRepositoryMock.UpdateItems got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmSetStatus.SetStatusMock.defaultExpectation.results
+		mm_results := mmUpdateItems.UpdateItemsMock.defaultExpectation.results
 		if mm_results == nil {
-			mmSetStatus.t.Fatal("No results are set for the OrderRepositoryMock.SetStatus")
+			mmUpdateItems.t.Fatal("No results are set for the OrderRepositoryMock.UpdateItems")
 		}
-		return (*mm_results).err
+		return (*mm_results).o2, (*mm_results).err
 	}
-	if mmSetStatus.funcSetStatus != nil {
-		return mmSetStatus.funcSetStatus(ctx, o1, o2)
+	if mmUpdateItems.funcUpdateItems != nil {
+		return mmUpdateItems.funcUpdateItems(ctx, o1, oa1, f1, p1...)
 	}
-	mmSetStatus.t.Fatalf("Unexpected call to OrderRepositoryMock.SetStatus. %v %v %v", ctx, o1, o2)
+	mmUpdateItems.t.Fatalf("Unexpected call to OrderRepositoryMock.UpdateItems. %v %v %v %v %v", ctx, o1, oa1, f1, p1)
 	return
 }
 
-// SetStatusAfterCounter returns a count of finished OrderRepositoryMock.SetStatus invocations
-func (mmSetStatus *OrderRepositoryMock) SetStatusAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmSetStatus.afterSetStatusCounter)
+// UpdateItemsAfterCounter returns a count of finished OrderRepositoryMock.UpdateItems invocations
+func (mmUpdateItems *OrderRepositoryMock) UpdateItemsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateItems.afterUpdateItemsCounter)
 }
 
-// SetStatusBeforeCounter returns a count of OrderRepositoryMock.SetStatus invocations
-func (mmSetStatus *OrderRepositoryMock) SetStatusBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmSetStatus.beforeSetStatusCounter)
+// UpdateItemsBeforeCounter returns a count of OrderRepositoryMock.UpdateItems invocations
+func (mmUpdateItems *OrderRepositoryMock) UpdateItemsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmUpdateItems.beforeUpdateItemsCounter)
 }
 
-// Calls returns a list of arguments used in each call to OrderRepositoryMock.SetStatus.
+// Calls returns a list of arguments used in each call to OrderRepositoryMock.UpdateItems.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmSetStatus *mOrderRepositoryMockSetStatus) Calls() []*OrderRepositoryMockSetStatusParams {
-	mmSetStatus.mutex.RLock()
+func (mmUpdateItems *mOrderRepositoryMockUpdateItems) Calls() []*OrderRepositoryMockUpdateItemsParams {
+	mmUpdateItems.mutex.RLock()
 
-	argCopy := make([]*OrderRepositoryMockSetStatusParams, len(mmSetStatus.callArgs))
-	copy(argCopy, mmSetStatus.callArgs)
+	argCopy := make([]*OrderRepositoryMockUpdateItemsParams, len(mmUpdateItems.callArgs))
+	copy(argCopy, mmUpdateItems.callArgs)
 
-	mmSetStatus.mutex.RUnlock()
+	mmUpdateItems.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockSetStatusDone returns true if the count of the SetStatus invocations corresponds
+// MinimockUpdateItemsDone returns true if the count of the UpdateItems invocations corresponds
 // the number of defined expectations
-func (m *OrderRepositoryMock) MinimockSetStatusDone() bool {
-	if m.SetStatusMock.optional {
+func (m *OrderRepositoryMock) MinimockUpdateItemsDone() bool {
+	if m.UpdateItemsMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.SetStatusMock.expectations {
+	for _, e := range m.UpdateItemsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.SetStatusMock.invocationsDone()
+	return m.UpdateItemsMock.invocationsDone()
 }
 
-// MinimockSetStatusInspect logs each unmet expectation
-func (m *OrderRepositoryMock) MinimockSetStatusInspect() {
-	for _, e := range m.SetStatusMock.expectations {
+// MinimockUpdateItemsInspect logs each unmet expectation
+func (m *OrderRepositoryMock) MinimockUpdateItemsInspect() {
+	for _, e := range m.UpdateItemsMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to OrderRepositoryMock.SetStatus with params: %#v", *e.params)
+			m.t.Errorf("Expected call to OrderRepositoryMock.UpdateItems with params: %#v", *e.params)
 		}
 	}
 
-	afterSetStatusCounter := mm_atomic.LoadUint64(&m.afterSetStatusCounter)
+	afterUpdateItemsCounter := mm_atomic.LoadUint64(&m.afterUpdateItemsCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.SetStatusMock.defaultExpectation != nil && afterSetStatusCounter < 1 {
-		if m.SetStatusMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to OrderRepositoryMock.SetStatus")
+	if m.UpdateItemsMock.defaultExpectation != nil && afterUpdateItemsCounter < 1 {
+		if m.UpdateItemsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to OrderRepositoryMock.UpdateItems")
 		} else {
-			m.t.Errorf("Expected call to OrderRepositoryMock.SetStatus with params: %#v", *m.SetStatusMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to OrderRepositoryMock.UpdateItems with params: %#v", *m.UpdateItemsMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcSetStatus != nil && afterSetStatusCounter < 1 {
-		m.t.Error("Expected call to OrderRepositoryMock.SetStatus")
+	if m.funcUpdateItems != nil && afterUpdateItemsCounter < 1 {
+		m.t.Error("Expected call to OrderRepositoryMock.UpdateItems")
 	}
 
-	if !m.SetStatusMock.invocationsDone() && afterSetStatusCounter > 0 {
-		m.t.Errorf("Expected %d calls to OrderRepositoryMock.SetStatus but found %d calls",
-			mm_atomic.LoadUint64(&m.SetStatusMock.expectedInvocations), afterSetStatusCounter)
+	if !m.UpdateItemsMock.invocationsDone() && afterUpdateItemsCounter > 0 {
+		m.t.Errorf("Expected %d calls to OrderRepositoryMock.UpdateItems but found %d calls",
+			mm_atomic.LoadUint64(&m.UpdateItemsMock.expectedInvocations), afterUpdateItemsCounter)
 	}
 }
 
@@ -1356,13 +5129,34 @@ func (m *OrderRepositoryMock) MinimockSetStatusInspect() {
 func (m *OrderRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
+			m.MinimockAddReturnInspect()
+
 			m.MinimockCreateInspect()
 
 			m.MinimockGetAllInspect()
 
 			m.MinimockGetByIdInspect()
 
+			m.MinimockGetByRequestIDInspect()
+
+			m.MinimockGetStatusHistoryInspect()
+
+			m.MinimockHandleNotRestockedInspect()
+
+			m.MinimockHandleUnpaidInspect()
+
+			m.MinimockListByUserInspect()
+
+			m.MinimockSetItemsInspect()
+
+			m.MinimockSetReturnRestockedInspect()
+
 			m.MinimockSetStatusInspect()
+
+			m.MinimockStreamAllInspect()
+
+			m.MinimockUpdateItemsInspect()
+			m.t.FailNow()
 		}
 	})
 }
@@ -1386,8 +5180,18 @@ func (m *OrderRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *OrderRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockAddReturnDone() &&
 		m.MinimockCreateDone() &&
 		m.MinimockGetAllDone() &&
 		m.MinimockGetByIdDone() &&
-		m.MinimockSetStatusDone()
+		m.MinimockGetByRequestIDDone() &&
+		m.MinimockGetStatusHistoryDone() &&
+		m.MinimockHandleNotRestockedDone() &&
+		m.MinimockHandleUnpaidDone() &&
+		m.MinimockListByUserDone() &&
+		m.MinimockSetItemsDone() &&
+		m.MinimockSetReturnRestockedDone() &&
+		m.MinimockSetStatusDone() &&
+		m.MinimockStreamAllDone() &&
+		m.MinimockUpdateItemsDone()
 }
@@ -6,9 +6,11 @@ package mock
 
 import (
 	"context"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/model"
 	"sync"
 	mm_atomic "sync/atomic"
+	"time"
 	mm_time "time"
 
 	"github.com/gojuno/minimock/v3"
@@ -19,26 +21,80 @@ type StockRepositoryMock struct {
 	t          minimock.Tester
 	finishOnce sync.Once
 
+	funcChangeStock          func(ctx context.Context, s1 model.StockChange) (s2 model.StockMovement, err error)
+	inspectFuncChangeStock   func(ctx context.Context, s1 model.StockChange)
+	afterChangeStockCounter  uint64
+	beforeChangeStockCounter uint64
+	ChangeStockMock          mStockRepositoryMockChangeStock
+
+	funcChangeStocks          func(ctx context.Context, sa1 []model.StockChange) (sa2 []model.StockMovement, err error)
+	inspectFuncChangeStocks   func(ctx context.Context, sa1 []model.StockChange)
+	afterChangeStocksCounter  uint64
+	beforeChangeStocksCounter uint64
+	ChangeStocksMock          mStockRepositoryMockChangeStocks
+
+	funcGetMovements          func(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int) (sa1 []model.StockMovement, err error)
+	inspectFuncGetMovements   func(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int)
+	afterGetMovementsCounter  uint64
+	beforeGetMovementsCounter uint64
+	GetMovementsMock          mStockRepositoryMockGetMovements
+
 	funcGetStocksBySku          func(ctx context.Context, p1 model.ProductSku) (u1 uint64, err error)
 	inspectFuncGetStocksBySku   func(ctx context.Context, p1 model.ProductSku)
 	afterGetStocksBySkuCounter  uint64
 	beforeGetStocksBySkuCounter uint64
 	GetStocksBySkuMock          mStockRepositoryMockGetStocksBySku
 
-	funcReserve          func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)
-	inspectFuncReserve   func(ctx context.Context, p1 model.ProductSku, u1 uint16)
+	funcGetStocksBySkus          func(ctx context.Context, pa1 []model.ProductSku) (m1 map[model.ProductSku]uint64, err error)
+	inspectFuncGetStocksBySkus   func(ctx context.Context, pa1 []model.ProductSku)
+	afterGetStocksBySkusCounter  uint64
+	beforeGetStocksBySkusCounter uint64
+	GetStocksBySkusMock          mStockRepositoryMockGetStocksBySkus
+
+	funcGetStocksByWarehouse          func(ctx context.Context, p1 model.ProductSku) (wa1 []model.WarehouseStock, err error)
+	inspectFuncGetStocksByWarehouse   func(ctx context.Context, p1 model.ProductSku)
+	afterGetStocksByWarehouseCounter  uint64
+	beforeGetStocksByWarehouseCounter uint64
+	GetStocksByWarehouseMock          mStockRepositoryMockGetStocksByWarehouse
+
+	funcHoldCreate          func(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration) (s1 model.StockHold, err error)
+	inspectFuncHoldCreate   func(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration)
+	afterHoldCreateCounter  uint64
+	beforeHoldCreateCounter uint64
+	HoldCreateMock          mStockRepositoryMockHoldCreate
+
+	funcHoldExtend          func(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration) (t1 time.Time, err error)
+	inspectFuncHoldExtend   func(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration)
+	afterHoldExtendCounter  uint64
+	beforeHoldExtendCounter uint64
+	HoldExtendMock          mStockRepositoryMockHoldExtend
+
+	funcHoldRelease          func(ctx context.Context, u1 model.UserID, h1 model.HoldID) (err error)
+	inspectFuncHoldRelease   func(ctx context.Context, u1 model.UserID, h1 model.HoldID)
+	afterHoldReleaseCounter  uint64
+	beforeHoldReleaseCounter uint64
+	HoldReleaseMock          mStockRepositoryMockHoldRelease
+
+	funcReserve          func(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location) (oa2 []model.OrderItem, err error)
+	inspectFuncReserve   func(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location)
 	afterReserveCounter  uint64
 	beforeReserveCounter uint64
 	ReserveMock          mStockRepositoryMockReserve
 
-	funcReserveCancel          func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)
-	inspectFuncReserveCancel   func(ctx context.Context, p1 model.ProductSku, u1 uint16)
+	funcReserveAt          func(ctx context.Context, oa1 []model.OrderItem) (err error)
+	inspectFuncReserveAt   func(ctx context.Context, oa1 []model.OrderItem)
+	afterReserveAtCounter  uint64
+	beforeReserveAtCounter uint64
+	ReserveAtMock          mStockRepositoryMockReserveAt
+
+	funcReserveCancel          func(ctx context.Context, oa1 []model.OrderItem) (err error)
+	inspectFuncReserveCancel   func(ctx context.Context, oa1 []model.OrderItem)
 	afterReserveCancelCounter  uint64
 	beforeReserveCancelCounter uint64
 	ReserveCancelMock          mStockRepositoryMockReserveCancel
 
-	funcReserveRemove          func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)
-	inspectFuncReserveRemove   func(ctx context.Context, p1 model.ProductSku, u1 uint16)
+	funcReserveRemove          func(ctx context.Context, oa1 []model.OrderItem) (err error)
+	inspectFuncReserveRemove   func(ctx context.Context, oa1 []model.OrderItem)
 	afterReserveRemoveCounter  uint64
 	beforeReserveRemoveCounter uint64
 	ReserveRemoveMock          mStockRepositoryMockReserveRemove
@@ -52,12 +108,39 @@ func NewStockRepositoryMock(t minimock.Tester) *StockRepositoryMock {
 		controller.RegisterMocker(m)
 	}
 
+	m.ChangeStockMock = mStockRepositoryMockChangeStock{mock: m}
+	m.ChangeStockMock.callArgs = []*StockRepositoryMockChangeStockParams{}
+
+	m.ChangeStocksMock = mStockRepositoryMockChangeStocks{mock: m}
+	m.ChangeStocksMock.callArgs = []*StockRepositoryMockChangeStocksParams{}
+
+	m.GetMovementsMock = mStockRepositoryMockGetMovements{mock: m}
+	m.GetMovementsMock.callArgs = []*StockRepositoryMockGetMovementsParams{}
+
 	m.GetStocksBySkuMock = mStockRepositoryMockGetStocksBySku{mock: m}
 	m.GetStocksBySkuMock.callArgs = []*StockRepositoryMockGetStocksBySkuParams{}
 
+	m.GetStocksBySkusMock = mStockRepositoryMockGetStocksBySkus{mock: m}
+	m.GetStocksBySkusMock.callArgs = []*StockRepositoryMockGetStocksBySkusParams{}
+
+	m.GetStocksByWarehouseMock = mStockRepositoryMockGetStocksByWarehouse{mock: m}
+	m.GetStocksByWarehouseMock.callArgs = []*StockRepositoryMockGetStocksByWarehouseParams{}
+
+	m.HoldCreateMock = mStockRepositoryMockHoldCreate{mock: m}
+	m.HoldCreateMock.callArgs = []*StockRepositoryMockHoldCreateParams{}
+
+	m.HoldExtendMock = mStockRepositoryMockHoldExtend{mock: m}
+	m.HoldExtendMock.callArgs = []*StockRepositoryMockHoldExtendParams{}
+
+	m.HoldReleaseMock = mStockRepositoryMockHoldRelease{mock: m}
+	m.HoldReleaseMock.callArgs = []*StockRepositoryMockHoldReleaseParams{}
+
 	m.ReserveMock = mStockRepositoryMockReserve{mock: m}
 	m.ReserveMock.callArgs = []*StockRepositoryMockReserveParams{}
 
+	m.ReserveAtMock = mStockRepositoryMockReserveAt{mock: m}
+	m.ReserveAtMock.callArgs = []*StockRepositoryMockReserveAtParams{}
+
 	m.ReserveCancelMock = mStockRepositoryMockReserveCancel{mock: m}
 	m.ReserveCancelMock.callArgs = []*StockRepositoryMockReserveCancelParams{}
 
@@ -69,42 +152,42 @@ func NewStockRepositoryMock(t minimock.Tester) *StockRepositoryMock {
 	return m
 }
 
-type mStockRepositoryMockGetStocksBySku struct {
+type mStockRepositoryMockChangeStock struct {
 	optional           bool
 	mock               *StockRepositoryMock
-	defaultExpectation *StockRepositoryMockGetStocksBySkuExpectation
-	expectations       []*StockRepositoryMockGetStocksBySkuExpectation
+	defaultExpectation *StockRepositoryMockChangeStockExpectation
+	expectations       []*StockRepositoryMockChangeStockExpectation
 
-	callArgs []*StockRepositoryMockGetStocksBySkuParams
+	callArgs []*StockRepositoryMockChangeStockParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// StockRepositoryMockGetStocksBySkuExpectation specifies expectation struct of the stockRepository.GetStocksBySku
-type StockRepositoryMockGetStocksBySkuExpectation struct {
+// StockRepositoryMockChangeStockExpectation specifies expectation struct of the stockRepository.ChangeStock
+type StockRepositoryMockChangeStockExpectation struct {
 	mock      *StockRepositoryMock
-	params    *StockRepositoryMockGetStocksBySkuParams
-	paramPtrs *StockRepositoryMockGetStocksBySkuParamPtrs
-	results   *StockRepositoryMockGetStocksBySkuResults
+	params    *StockRepositoryMockChangeStockParams
+	paramPtrs *StockRepositoryMockChangeStockParamPtrs
+	results   *StockRepositoryMockChangeStockResults
 	Counter   uint64
 }
 
-// StockRepositoryMockGetStocksBySkuParams contains parameters of the stockRepository.GetStocksBySku
-type StockRepositoryMockGetStocksBySkuParams struct {
+// StockRepositoryMockChangeStockParams contains parameters of the stockRepository.ChangeStock
+type StockRepositoryMockChangeStockParams struct {
 	ctx context.Context
-	p1  model.ProductSku
+	s1  model.StockChange
 }
 
-// StockRepositoryMockGetStocksBySkuParamPtrs contains pointers to parameters of the stockRepository.GetStocksBySku
-type StockRepositoryMockGetStocksBySkuParamPtrs struct {
+// StockRepositoryMockChangeStockParamPtrs contains pointers to parameters of the stockRepository.ChangeStock
+type StockRepositoryMockChangeStockParamPtrs struct {
 	ctx *context.Context
-	p1  *model.ProductSku
+	s1  *model.StockChange
 }
 
-// StockRepositoryMockGetStocksBySkuResults contains results of the stockRepository.GetStocksBySku
-type StockRepositoryMockGetStocksBySkuResults struct {
-	u1  uint64
+// StockRepositoryMockChangeStockResults contains results of the stockRepository.ChangeStock
+type StockRepositoryMockChangeStockResults struct {
+	s2  model.StockMovement
 	err error
 }
 
@@ -113,320 +196,319 @@ type StockRepositoryMockGetStocksBySkuResults struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Optional() *mStockRepositoryMockGetStocksBySku {
-	mmGetStocksBySku.optional = true
-	return mmGetStocksBySku
+func (mmChangeStock *mStockRepositoryMockChangeStock) Optional() *mStockRepositoryMockChangeStock {
+	mmChangeStock.optional = true
+	return mmChangeStock
 }
 
-// Expect sets up expected params for stockRepository.GetStocksBySku
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Expect(ctx context.Context, p1 model.ProductSku) *mStockRepositoryMockGetStocksBySku {
-	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+// Expect sets up expected params for stockRepository.ChangeStock
+func (mmChangeStock *mStockRepositoryMockChangeStock) Expect(ctx context.Context, s1 model.StockChange) *mStockRepositoryMockChangeStock {
+	if mmChangeStock.mock.funcChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Set")
 	}
 
-	if mmGetStocksBySku.defaultExpectation == nil {
-		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	if mmChangeStock.defaultExpectation == nil {
+		mmChangeStock.defaultExpectation = &StockRepositoryMockChangeStockExpectation{}
 	}
 
-	if mmGetStocksBySku.defaultExpectation.paramPtrs != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by ExpectParams functions")
+	if mmChangeStock.defaultExpectation.paramPtrs != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by ExpectParams functions")
 	}
 
-	mmGetStocksBySku.defaultExpectation.params = &StockRepositoryMockGetStocksBySkuParams{ctx, p1}
-	for _, e := range mmGetStocksBySku.expectations {
-		if minimock.Equal(e.params, mmGetStocksBySku.defaultExpectation.params) {
-			mmGetStocksBySku.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksBySku.defaultExpectation.params)
+	mmChangeStock.defaultExpectation.params = &StockRepositoryMockChangeStockParams{ctx, s1}
+	for _, e := range mmChangeStock.expectations {
+		if minimock.Equal(e.params, mmChangeStock.defaultExpectation.params) {
+			mmChangeStock.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmChangeStock.defaultExpectation.params)
 		}
 	}
 
-	return mmGetStocksBySku
+	return mmChangeStock
 }
 
-// ExpectCtxParam1 sets up expected param ctx for stockRepository.GetStocksBySku
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetStocksBySku {
-	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.ChangeStock
+func (mmChangeStock *mStockRepositoryMockChangeStock) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockChangeStock {
+	if mmChangeStock.mock.funcChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Set")
 	}
 
-	if mmGetStocksBySku.defaultExpectation == nil {
-		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	if mmChangeStock.defaultExpectation == nil {
+		mmChangeStock.defaultExpectation = &StockRepositoryMockChangeStockExpectation{}
 	}
 
-	if mmGetStocksBySku.defaultExpectation.params != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Expect")
+	if mmChangeStock.defaultExpectation.params != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Expect")
 	}
 
-	if mmGetStocksBySku.defaultExpectation.paramPtrs == nil {
-		mmGetStocksBySku.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkuParamPtrs{}
+	if mmChangeStock.defaultExpectation.paramPtrs == nil {
+		mmChangeStock.defaultExpectation.paramPtrs = &StockRepositoryMockChangeStockParamPtrs{}
 	}
-	mmGetStocksBySku.defaultExpectation.paramPtrs.ctx = &ctx
+	mmChangeStock.defaultExpectation.paramPtrs.ctx = &ctx
 
-	return mmGetStocksBySku
+	return mmChangeStock
 }
 
-// ExpectP1Param2 sets up expected param p1 for stockRepository.GetStocksBySku
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockGetStocksBySku {
-	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+// ExpectS1Param2 sets up expected param s1 for stockRepository.ChangeStock
+func (mmChangeStock *mStockRepositoryMockChangeStock) ExpectS1Param2(s1 model.StockChange) *mStockRepositoryMockChangeStock {
+	if mmChangeStock.mock.funcChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Set")
 	}
 
-	if mmGetStocksBySku.defaultExpectation == nil {
-		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	if mmChangeStock.defaultExpectation == nil {
+		mmChangeStock.defaultExpectation = &StockRepositoryMockChangeStockExpectation{}
 	}
 
-	if mmGetStocksBySku.defaultExpectation.params != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Expect")
+	if mmChangeStock.defaultExpectation.params != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Expect")
 	}
 
-	if mmGetStocksBySku.defaultExpectation.paramPtrs == nil {
-		mmGetStocksBySku.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkuParamPtrs{}
+	if mmChangeStock.defaultExpectation.paramPtrs == nil {
+		mmChangeStock.defaultExpectation.paramPtrs = &StockRepositoryMockChangeStockParamPtrs{}
 	}
-	mmGetStocksBySku.defaultExpectation.paramPtrs.p1 = &p1
+	mmChangeStock.defaultExpectation.paramPtrs.s1 = &s1
 
-	return mmGetStocksBySku
+	return mmChangeStock
 }
 
-// Inspect accepts an inspector function that has same arguments as the stockRepository.GetStocksBySku
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Inspect(f func(ctx context.Context, p1 model.ProductSku)) *mStockRepositoryMockGetStocksBySku {
-	if mmGetStocksBySku.mock.inspectFuncGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetStocksBySku")
+// Inspect accepts an inspector function that has same arguments as the stockRepository.ChangeStock
+func (mmChangeStock *mStockRepositoryMockChangeStock) Inspect(f func(ctx context.Context, s1 model.StockChange)) *mStockRepositoryMockChangeStock {
+	if mmChangeStock.mock.inspectFuncChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ChangeStock")
 	}
 
-	mmGetStocksBySku.mock.inspectFuncGetStocksBySku = f
+	mmChangeStock.mock.inspectFuncChangeStock = f
 
-	return mmGetStocksBySku
+	return mmChangeStock
 }
 
-// Return sets up results that will be returned by stockRepository.GetStocksBySku
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Return(u1 uint64, err error) *StockRepositoryMock {
-	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+// Return sets up results that will be returned by stockRepository.ChangeStock
+func (mmChangeStock *mStockRepositoryMockChangeStock) Return(s2 model.StockMovement, err error) *StockRepositoryMock {
+	if mmChangeStock.mock.funcChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Set")
 	}
 
-	if mmGetStocksBySku.defaultExpectation == nil {
-		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{mock: mmGetStocksBySku.mock}
+	if mmChangeStock.defaultExpectation == nil {
+		mmChangeStock.defaultExpectation = &StockRepositoryMockChangeStockExpectation{mock: mmChangeStock.mock}
 	}
-	mmGetStocksBySku.defaultExpectation.results = &StockRepositoryMockGetStocksBySkuResults{u1, err}
-	return mmGetStocksBySku.mock
+	mmChangeStock.defaultExpectation.results = &StockRepositoryMockChangeStockResults{s2, err}
+	return mmChangeStock.mock
 }
 
-// Set uses given function f to mock the stockRepository.GetStocksBySku method
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Set(f func(ctx context.Context, p1 model.ProductSku) (u1 uint64, err error)) *StockRepositoryMock {
-	if mmGetStocksBySku.defaultExpectation != nil {
-		mmGetStocksBySku.mock.t.Fatalf("Default expectation is already set for the stockRepository.GetStocksBySku method")
+// Set uses given function f to mock the stockRepository.ChangeStock method
+func (mmChangeStock *mStockRepositoryMockChangeStock) Set(f func(ctx context.Context, s1 model.StockChange) (s2 model.StockMovement, err error)) *StockRepositoryMock {
+	if mmChangeStock.defaultExpectation != nil {
+		mmChangeStock.mock.t.Fatalf("Default expectation is already set for the stockRepository.ChangeStock method")
 	}
 
-	if len(mmGetStocksBySku.expectations) > 0 {
-		mmGetStocksBySku.mock.t.Fatalf("Some expectations are already set for the stockRepository.GetStocksBySku method")
+	if len(mmChangeStock.expectations) > 0 {
+		mmChangeStock.mock.t.Fatalf("Some expectations are already set for the stockRepository.ChangeStock method")
 	}
 
-	mmGetStocksBySku.mock.funcGetStocksBySku = f
-	return mmGetStocksBySku.mock
+	mmChangeStock.mock.funcChangeStock = f
+	return mmChangeStock.mock
 }
 
-// When sets expectation for the stockRepository.GetStocksBySku which will trigger the result defined by the following
+// When sets expectation for the stockRepository.ChangeStock which will trigger the result defined by the following
 // Then helper
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) When(ctx context.Context, p1 model.ProductSku) *StockRepositoryMockGetStocksBySkuExpectation {
-	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
-		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+func (mmChangeStock *mStockRepositoryMockChangeStock) When(ctx context.Context, s1 model.StockChange) *StockRepositoryMockChangeStockExpectation {
+	if mmChangeStock.mock.funcChangeStock != nil {
+		mmChangeStock.mock.t.Fatalf("StockRepositoryMock.ChangeStock mock is already set by Set")
 	}
 
-	expectation := &StockRepositoryMockGetStocksBySkuExpectation{
-		mock:   mmGetStocksBySku.mock,
-		params: &StockRepositoryMockGetStocksBySkuParams{ctx, p1},
+	expectation := &StockRepositoryMockChangeStockExpectation{
+		mock:   mmChangeStock.mock,
+		params: &StockRepositoryMockChangeStockParams{ctx, s1},
 	}
-	mmGetStocksBySku.expectations = append(mmGetStocksBySku.expectations, expectation)
+	mmChangeStock.expectations = append(mmChangeStock.expectations, expectation)
 	return expectation
 }
 
-// Then sets up stockRepository.GetStocksBySku return parameters for the expectation previously defined by the When method
-func (e *StockRepositoryMockGetStocksBySkuExpectation) Then(u1 uint64, err error) *StockRepositoryMock {
-	e.results = &StockRepositoryMockGetStocksBySkuResults{u1, err}
+// Then sets up stockRepository.ChangeStock return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockChangeStockExpectation) Then(s2 model.StockMovement, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockChangeStockResults{s2, err}
 	return e.mock
 }
 
-// Times sets number of times stockRepository.GetStocksBySku should be invoked
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Times(n uint64) *mStockRepositoryMockGetStocksBySku {
+// Times sets number of times stockRepository.ChangeStock should be invoked
+func (mmChangeStock *mStockRepositoryMockChangeStock) Times(n uint64) *mStockRepositoryMockChangeStock {
 	if n == 0 {
-		mmGetStocksBySku.mock.t.Fatalf("Times of StockRepositoryMock.GetStocksBySku mock can not be zero")
+		mmChangeStock.mock.t.Fatalf("Times of StockRepositoryMock.ChangeStock mock can not be zero")
 	}
-	mm_atomic.StoreUint64(&mmGetStocksBySku.expectedInvocations, n)
-	return mmGetStocksBySku
+	mm_atomic.StoreUint64(&mmChangeStock.expectedInvocations, n)
+	return mmChangeStock
 }
 
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) invocationsDone() bool {
-	if len(mmGetStocksBySku.expectations) == 0 && mmGetStocksBySku.defaultExpectation == nil && mmGetStocksBySku.mock.funcGetStocksBySku == nil {
+func (mmChangeStock *mStockRepositoryMockChangeStock) invocationsDone() bool {
+	if len(mmChangeStock.expectations) == 0 && mmChangeStock.defaultExpectation == nil && mmChangeStock.mock.funcChangeStock == nil {
 		return true
 	}
 
-	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksBySku.mock.afterGetStocksBySkuCounter)
-	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksBySku.expectedInvocations)
+	totalInvocations := mm_atomic.LoadUint64(&mmChangeStock.mock.afterChangeStockCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmChangeStock.expectedInvocations)
 
 	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
 }
 
-// GetStocksBySku implements service.stockRepository
-func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySku(ctx context.Context, p1 model.ProductSku) (u1 uint64, err error) {
-	mm_atomic.AddUint64(&mmGetStocksBySku.beforeGetStocksBySkuCounter, 1)
-	defer mm_atomic.AddUint64(&mmGetStocksBySku.afterGetStocksBySkuCounter, 1)
+// ChangeStock implements service.stockRepository
+func (mmChangeStock *StockRepositoryMock) ChangeStock(ctx context.Context, s1 model.StockChange) (s2 model.StockMovement, err error) {
+	mm_atomic.AddUint64(&mmChangeStock.beforeChangeStockCounter, 1)
+	defer mm_atomic.AddUint64(&mmChangeStock.afterChangeStockCounter, 1)
 
-	if mmGetStocksBySku.inspectFuncGetStocksBySku != nil {
-		mmGetStocksBySku.inspectFuncGetStocksBySku(ctx, p1)
+	if mmChangeStock.inspectFuncChangeStock != nil {
+		mmChangeStock.inspectFuncChangeStock(ctx, s1)
 	}
 
-	mm_params := StockRepositoryMockGetStocksBySkuParams{ctx, p1}
+	mm_params := StockRepositoryMockChangeStockParams{ctx, s1}
 
 	// Record call args
-	mmGetStocksBySku.GetStocksBySkuMock.mutex.Lock()
-	mmGetStocksBySku.GetStocksBySkuMock.callArgs = append(mmGetStocksBySku.GetStocksBySkuMock.callArgs, &mm_params)
-	mmGetStocksBySku.GetStocksBySkuMock.mutex.Unlock()
+	mmChangeStock.ChangeStockMock.mutex.Lock()
+	mmChangeStock.ChangeStockMock.callArgs = append(mmChangeStock.ChangeStockMock.callArgs, &mm_params)
+	mmChangeStock.ChangeStockMock.mutex.Unlock()
 
-	for _, e := range mmGetStocksBySku.GetStocksBySkuMock.expectations {
+	for _, e := range mmChangeStock.ChangeStockMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.u1, e.results.err
+			return e.results.s2, e.results.err
 		}
 	}
 
-	if mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation != nil {
-		mm_atomic.AddUint64(&mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.Counter, 1)
-		mm_want := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.params
-		mm_want_ptrs := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.paramPtrs
+	if mmChangeStock.ChangeStockMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmChangeStock.ChangeStockMock.defaultExpectation.Counter, 1)
+		mm_want := mmChangeStock.ChangeStockMock.defaultExpectation.params
+		mm_want_ptrs := mmChangeStock.ChangeStockMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockGetStocksBySkuParams{ctx, p1}
+		mm_got := StockRepositoryMockChangeStockParams{ctx, s1}
 
 		if mm_want_ptrs != nil {
 
 			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
-				mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+				mmChangeStock.t.Errorf("StockRepositoryMock.ChangeStock got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
-				mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			if mm_want_ptrs.s1 != nil && !minimock.Equal(*mm_want_ptrs.s1, mm_got.s1) {
+				mmChangeStock.t.Errorf("StockRepositoryMock.ChangeStock got unexpected parameter s1, want: %#v, got: %#v%s\n", *mm_want_ptrs.s1, mm_got.s1, minimock.Diff(*mm_want_ptrs.s1, mm_got.s1))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
-			mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+			mmChangeStock.t.Errorf("StockRepositoryMock.ChangeStock got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
 
-		mm_results := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.results
+		mm_results := mmChangeStock.ChangeStockMock.defaultExpectation.results
 		if mm_results == nil {
-			mmGetStocksBySku.t.Fatal("No results are set for the StockRepositoryMock.GetStocksBySku")
+			mmChangeStock.t.Fatal("No results are set for the StockRepositoryMock.ChangeStock")
 		}
-		return (*mm_results).u1, (*mm_results).err
+		return (*mm_results).s2, (*mm_results).err
 	}
-	if mmGetStocksBySku.funcGetStocksBySku != nil {
-		return mmGetStocksBySku.funcGetStocksBySku(ctx, p1)
+	if mmChangeStock.funcChangeStock != nil {
+		return mmChangeStock.funcChangeStock(ctx, s1)
 	}
-	mmGetStocksBySku.t.Fatalf("Unexpected call to StockRepositoryMock.GetStocksBySku. %v %v", ctx, p1)
+	mmChangeStock.t.Fatalf("Unexpected call to StockRepositoryMock.ChangeStock. %v %v", ctx, s1)
 	return
 }
 
-// GetStocksBySkuAfterCounter returns a count of finished StockRepositoryMock.GetStocksBySku invocations
-func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySkuAfterCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetStocksBySku.afterGetStocksBySkuCounter)
+// ChangeStockAfterCounter returns a count of finished StockRepositoryMock.ChangeStock invocations
+func (mmChangeStock *StockRepositoryMock) ChangeStockAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeStock.afterChangeStockCounter)
 }
 
-// GetStocksBySkuBeforeCounter returns a count of StockRepositoryMock.GetStocksBySku invocations
-func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySkuBeforeCounter() uint64 {
-	return mm_atomic.LoadUint64(&mmGetStocksBySku.beforeGetStocksBySkuCounter)
+// ChangeStockBeforeCounter returns a count of StockRepositoryMock.ChangeStock invocations
+func (mmChangeStock *StockRepositoryMock) ChangeStockBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeStock.beforeChangeStockCounter)
 }
 
-// Calls returns a list of arguments used in each call to StockRepositoryMock.GetStocksBySku.
+// Calls returns a list of arguments used in each call to StockRepositoryMock.ChangeStock.
 // The list is in the same order as the calls were made (i.e. recent calls have a higher index)
-func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Calls() []*StockRepositoryMockGetStocksBySkuParams {
-	mmGetStocksBySku.mutex.RLock()
+func (mmChangeStock *mStockRepositoryMockChangeStock) Calls() []*StockRepositoryMockChangeStockParams {
+	mmChangeStock.mutex.RLock()
 
-	argCopy := make([]*StockRepositoryMockGetStocksBySkuParams, len(mmGetStocksBySku.callArgs))
-	copy(argCopy, mmGetStocksBySku.callArgs)
+	argCopy := make([]*StockRepositoryMockChangeStockParams, len(mmChangeStock.callArgs))
+	copy(argCopy, mmChangeStock.callArgs)
 
-	mmGetStocksBySku.mutex.RUnlock()
+	mmChangeStock.mutex.RUnlock()
 
 	return argCopy
 }
 
-// MinimockGetStocksBySkuDone returns true if the count of the GetStocksBySku invocations corresponds
+// MinimockChangeStockDone returns true if the count of the ChangeStock invocations corresponds
 // the number of defined expectations
-func (m *StockRepositoryMock) MinimockGetStocksBySkuDone() bool {
-	if m.GetStocksBySkuMock.optional {
+func (m *StockRepositoryMock) MinimockChangeStockDone() bool {
+	if m.ChangeStockMock.optional {
 		// Optional methods provide '0 or more' call count restriction.
 		return true
 	}
 
-	for _, e := range m.GetStocksBySkuMock.expectations {
+	for _, e := range m.ChangeStockMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
 			return false
 		}
 	}
 
-	return m.GetStocksBySkuMock.invocationsDone()
+	return m.ChangeStockMock.invocationsDone()
 }
 
-// MinimockGetStocksBySkuInspect logs each unmet expectation
-func (m *StockRepositoryMock) MinimockGetStocksBySkuInspect() {
-	for _, e := range m.GetStocksBySkuMock.expectations {
+// MinimockChangeStockInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockChangeStockInspect() {
+	for _, e := range m.ChangeStockMock.expectations {
 		if mm_atomic.LoadUint64(&e.Counter) < 1 {
-			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySku with params: %#v", *e.params)
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeStock with params: %#v", *e.params)
 		}
 	}
 
-	afterGetStocksBySkuCounter := mm_atomic.LoadUint64(&m.afterGetStocksBySkuCounter)
+	afterChangeStockCounter := mm_atomic.LoadUint64(&m.afterChangeStockCounter)
 	// if default expectation was set then invocations count should be greater than zero
-	if m.GetStocksBySkuMock.defaultExpectation != nil && afterGetStocksBySkuCounter < 1 {
-		if m.GetStocksBySkuMock.defaultExpectation.params == nil {
-			m.t.Error("Expected call to StockRepositoryMock.GetStocksBySku")
+	if m.ChangeStockMock.defaultExpectation != nil && afterChangeStockCounter < 1 {
+		if m.ChangeStockMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.ChangeStock")
 		} else {
-			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySku with params: %#v", *m.GetStocksBySkuMock.defaultExpectation.params)
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeStock with params: %#v", *m.ChangeStockMock.defaultExpectation.params)
 		}
 	}
 	// if func was set then invocations count should be greater than zero
-	if m.funcGetStocksBySku != nil && afterGetStocksBySkuCounter < 1 {
-		m.t.Error("Expected call to StockRepositoryMock.GetStocksBySku")
+	if m.funcChangeStock != nil && afterChangeStockCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.ChangeStock")
 	}
 
-	if !m.GetStocksBySkuMock.invocationsDone() && afterGetStocksBySkuCounter > 0 {
-		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetStocksBySku but found %d calls",
-			mm_atomic.LoadUint64(&m.GetStocksBySkuMock.expectedInvocations), afterGetStocksBySkuCounter)
+	if !m.ChangeStockMock.invocationsDone() && afterChangeStockCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.ChangeStock but found %d calls",
+			mm_atomic.LoadUint64(&m.ChangeStockMock.expectedInvocations), afterChangeStockCounter)
 	}
 }
 
-type mStockRepositoryMockReserve struct {
+type mStockRepositoryMockChangeStocks struct {
 	optional           bool
 	mock               *StockRepositoryMock
-	defaultExpectation *StockRepositoryMockReserveExpectation
-	expectations       []*StockRepositoryMockReserveExpectation
+	defaultExpectation *StockRepositoryMockChangeStocksExpectation
+	expectations       []*StockRepositoryMockChangeStocksExpectation
 
-	callArgs []*StockRepositoryMockReserveParams
+	callArgs []*StockRepositoryMockChangeStocksParams
 	mutex    sync.RWMutex
 
 	expectedInvocations uint64
 }
 
-// StockRepositoryMockReserveExpectation specifies expectation struct of the stockRepository.Reserve
-type StockRepositoryMockReserveExpectation struct {
+// StockRepositoryMockChangeStocksExpectation specifies expectation struct of the stockRepository.ChangeStocks
+type StockRepositoryMockChangeStocksExpectation struct {
 	mock      *StockRepositoryMock
-	params    *StockRepositoryMockReserveParams
-	paramPtrs *StockRepositoryMockReserveParamPtrs
-	results   *StockRepositoryMockReserveResults
+	params    *StockRepositoryMockChangeStocksParams
+	paramPtrs *StockRepositoryMockChangeStocksParamPtrs
+	results   *StockRepositoryMockChangeStocksResults
 	Counter   uint64
 }
 
-// StockRepositoryMockReserveParams contains parameters of the stockRepository.Reserve
-type StockRepositoryMockReserveParams struct {
+// StockRepositoryMockChangeStocksParams contains parameters of the stockRepository.ChangeStocks
+type StockRepositoryMockChangeStocksParams struct {
 	ctx context.Context
-	p1  model.ProductSku
-	u1  uint16
+	sa1 []model.StockChange
 }
 
-// StockRepositoryMockReserveParamPtrs contains pointers to parameters of the stockRepository.Reserve
-type StockRepositoryMockReserveParamPtrs struct {
+// StockRepositoryMockChangeStocksParamPtrs contains pointers to parameters of the stockRepository.ChangeStocks
+type StockRepositoryMockChangeStocksParamPtrs struct {
 	ctx *context.Context
-	p1  *model.ProductSku
-	u1  *uint16
+	sa1 *[]model.StockChange
 }
 
-// StockRepositoryMockReserveResults contains results of the stockRepository.Reserve
-type StockRepositoryMockReserveResults struct {
+// StockRepositoryMockChangeStocksResults contains results of the stockRepository.ChangeStocks
+type StockRepositoryMockChangeStocksResults struct {
+	sa2 []model.StockMovement
 	err error
 }
 
@@ -435,13 +517,2856 @@ type StockRepositoryMockReserveResults struct {
 // Optional() makes method check to work in '0 or more' mode.
 // It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
 // catch the problems when the expected method call is totally skipped during test run.
-func (mmReserve *mStockRepositoryMockReserve) Optional() *mStockRepositoryMockReserve {
-	mmReserve.optional = true
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Optional() *mStockRepositoryMockChangeStocks {
+	mmChangeStocks.optional = true
+	return mmChangeStocks
+}
+
+// Expect sets up expected params for stockRepository.ChangeStocks
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Expect(ctx context.Context, sa1 []model.StockChange) *mStockRepositoryMockChangeStocks {
+	if mmChangeStocks.mock.funcChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Set")
+	}
+
+	if mmChangeStocks.defaultExpectation == nil {
+		mmChangeStocks.defaultExpectation = &StockRepositoryMockChangeStocksExpectation{}
+	}
+
+	if mmChangeStocks.defaultExpectation.paramPtrs != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by ExpectParams functions")
+	}
+
+	mmChangeStocks.defaultExpectation.params = &StockRepositoryMockChangeStocksParams{ctx, sa1}
+	for _, e := range mmChangeStocks.expectations {
+		if minimock.Equal(e.params, mmChangeStocks.defaultExpectation.params) {
+			mmChangeStocks.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmChangeStocks.defaultExpectation.params)
+		}
+	}
+
+	return mmChangeStocks
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.ChangeStocks
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockChangeStocks {
+	if mmChangeStocks.mock.funcChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Set")
+	}
+
+	if mmChangeStocks.defaultExpectation == nil {
+		mmChangeStocks.defaultExpectation = &StockRepositoryMockChangeStocksExpectation{}
+	}
+
+	if mmChangeStocks.defaultExpectation.params != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Expect")
+	}
+
+	if mmChangeStocks.defaultExpectation.paramPtrs == nil {
+		mmChangeStocks.defaultExpectation.paramPtrs = &StockRepositoryMockChangeStocksParamPtrs{}
+	}
+	mmChangeStocks.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmChangeStocks
+}
+
+// ExpectSa1Param2 sets up expected param sa1 for stockRepository.ChangeStocks
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) ExpectSa1Param2(sa1 []model.StockChange) *mStockRepositoryMockChangeStocks {
+	if mmChangeStocks.mock.funcChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Set")
+	}
+
+	if mmChangeStocks.defaultExpectation == nil {
+		mmChangeStocks.defaultExpectation = &StockRepositoryMockChangeStocksExpectation{}
+	}
+
+	if mmChangeStocks.defaultExpectation.params != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Expect")
+	}
+
+	if mmChangeStocks.defaultExpectation.paramPtrs == nil {
+		mmChangeStocks.defaultExpectation.paramPtrs = &StockRepositoryMockChangeStocksParamPtrs{}
+	}
+	mmChangeStocks.defaultExpectation.paramPtrs.sa1 = &sa1
+
+	return mmChangeStocks
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.ChangeStocks
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Inspect(f func(ctx context.Context, sa1 []model.StockChange)) *mStockRepositoryMockChangeStocks {
+	if mmChangeStocks.mock.inspectFuncChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ChangeStocks")
+	}
+
+	mmChangeStocks.mock.inspectFuncChangeStocks = f
+
+	return mmChangeStocks
+}
+
+// Return sets up results that will be returned by stockRepository.ChangeStocks
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Return(sa2 []model.StockMovement, err error) *StockRepositoryMock {
+	if mmChangeStocks.mock.funcChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Set")
+	}
+
+	if mmChangeStocks.defaultExpectation == nil {
+		mmChangeStocks.defaultExpectation = &StockRepositoryMockChangeStocksExpectation{mock: mmChangeStocks.mock}
+	}
+	mmChangeStocks.defaultExpectation.results = &StockRepositoryMockChangeStocksResults{sa2, err}
+	return mmChangeStocks.mock
+}
+
+// Set uses given function f to mock the stockRepository.ChangeStocks method
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Set(f func(ctx context.Context, sa1 []model.StockChange) (sa2 []model.StockMovement, err error)) *StockRepositoryMock {
+	if mmChangeStocks.defaultExpectation != nil {
+		mmChangeStocks.mock.t.Fatalf("Default expectation is already set for the stockRepository.ChangeStocks method")
+	}
+
+	if len(mmChangeStocks.expectations) > 0 {
+		mmChangeStocks.mock.t.Fatalf("Some expectations are already set for the stockRepository.ChangeStocks method")
+	}
+
+	mmChangeStocks.mock.funcChangeStocks = f
+	return mmChangeStocks.mock
+}
+
+// When sets expectation for the stockRepository.ChangeStocks which will trigger the result defined by the following
+// Then helper
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) When(ctx context.Context, sa1 []model.StockChange) *StockRepositoryMockChangeStocksExpectation {
+	if mmChangeStocks.mock.funcChangeStocks != nil {
+		mmChangeStocks.mock.t.Fatalf("StockRepositoryMock.ChangeStocks mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockChangeStocksExpectation{
+		mock:   mmChangeStocks.mock,
+		params: &StockRepositoryMockChangeStocksParams{ctx, sa1},
+	}
+	mmChangeStocks.expectations = append(mmChangeStocks.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.ChangeStocks return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockChangeStocksExpectation) Then(sa2 []model.StockMovement, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockChangeStocksResults{sa2, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.ChangeStocks should be invoked
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Times(n uint64) *mStockRepositoryMockChangeStocks {
+	if n == 0 {
+		mmChangeStocks.mock.t.Fatalf("Times of StockRepositoryMock.ChangeStocks mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmChangeStocks.expectedInvocations, n)
+	return mmChangeStocks
+}
+
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) invocationsDone() bool {
+	if len(mmChangeStocks.expectations) == 0 && mmChangeStocks.defaultExpectation == nil && mmChangeStocks.mock.funcChangeStocks == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmChangeStocks.mock.afterChangeStocksCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmChangeStocks.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ChangeStocks implements service.stockRepository
+func (mmChangeStocks *StockRepositoryMock) ChangeStocks(ctx context.Context, sa1 []model.StockChange) (sa2 []model.StockMovement, err error) {
+	mm_atomic.AddUint64(&mmChangeStocks.beforeChangeStocksCounter, 1)
+	defer mm_atomic.AddUint64(&mmChangeStocks.afterChangeStocksCounter, 1)
+
+	if mmChangeStocks.inspectFuncChangeStocks != nil {
+		mmChangeStocks.inspectFuncChangeStocks(ctx, sa1)
+	}
+
+	mm_params := StockRepositoryMockChangeStocksParams{ctx, sa1}
+
+	// Record call args
+	mmChangeStocks.ChangeStocksMock.mutex.Lock()
+	mmChangeStocks.ChangeStocksMock.callArgs = append(mmChangeStocks.ChangeStocksMock.callArgs, &mm_params)
+	mmChangeStocks.ChangeStocksMock.mutex.Unlock()
+
+	for _, e := range mmChangeStocks.ChangeStocksMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa2, e.results.err
+		}
+	}
+
+	if mmChangeStocks.ChangeStocksMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmChangeStocks.ChangeStocksMock.defaultExpectation.Counter, 1)
+		mm_want := mmChangeStocks.ChangeStocksMock.defaultExpectation.params
+		mm_want_ptrs := mmChangeStocks.ChangeStocksMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockChangeStocksParams{ctx, sa1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmChangeStocks.t.Errorf("StockRepositoryMock.ChangeStocks got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.sa1 != nil && !minimock.Equal(*mm_want_ptrs.sa1, mm_got.sa1) {
+				mmChangeStocks.t.Errorf("StockRepositoryMock.ChangeStocks got unexpected parameter sa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.sa1, mm_got.sa1, minimock.Diff(*mm_want_ptrs.sa1, mm_got.sa1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmChangeStocks.t.Errorf("StockRepositoryMock.ChangeStocks got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmChangeStocks.ChangeStocksMock.defaultExpectation.results
+		if mm_results == nil {
+			mmChangeStocks.t.Fatal("No results are set for the StockRepositoryMock.ChangeStocks")
+		}
+		return (*mm_results).sa2, (*mm_results).err
+	}
+	if mmChangeStocks.funcChangeStocks != nil {
+		return mmChangeStocks.funcChangeStocks(ctx, sa1)
+	}
+	mmChangeStocks.t.Fatalf("Unexpected call to StockRepositoryMock.ChangeStocks. %v %v", ctx, sa1)
+	return
+}
+
+// ChangeStocksAfterCounter returns a count of finished StockRepositoryMock.ChangeStocks invocations
+func (mmChangeStocks *StockRepositoryMock) ChangeStocksAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeStocks.afterChangeStocksCounter)
+}
+
+// ChangeStocksBeforeCounter returns a count of StockRepositoryMock.ChangeStocks invocations
+func (mmChangeStocks *StockRepositoryMock) ChangeStocksBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmChangeStocks.beforeChangeStocksCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.ChangeStocks.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmChangeStocks *mStockRepositoryMockChangeStocks) Calls() []*StockRepositoryMockChangeStocksParams {
+	mmChangeStocks.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockChangeStocksParams, len(mmChangeStocks.callArgs))
+	copy(argCopy, mmChangeStocks.callArgs)
+
+	mmChangeStocks.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockChangeStocksDone returns true if the count of the ChangeStocks invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockChangeStocksDone() bool {
+	if m.ChangeStocksMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ChangeStocksMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ChangeStocksMock.invocationsDone()
+}
+
+// MinimockChangeStocksInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockChangeStocksInspect() {
+	for _, e := range m.ChangeStocksMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeStocks with params: %#v", *e.params)
+		}
+	}
+
+	afterChangeStocksCounter := mm_atomic.LoadUint64(&m.afterChangeStocksCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ChangeStocksMock.defaultExpectation != nil && afterChangeStocksCounter < 1 {
+		if m.ChangeStocksMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.ChangeStocks")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.ChangeStocks with params: %#v", *m.ChangeStocksMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcChangeStocks != nil && afterChangeStocksCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.ChangeStocks")
+	}
+
+	if !m.ChangeStocksMock.invocationsDone() && afterChangeStocksCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.ChangeStocks but found %d calls",
+			mm_atomic.LoadUint64(&m.ChangeStocksMock.expectedInvocations), afterChangeStocksCounter)
+	}
+}
+
+type mStockRepositoryMockGetMovements struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetMovementsExpectation
+	expectations       []*StockRepositoryMockGetMovementsExpectation
+
+	callArgs []*StockRepositoryMockGetMovementsParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockGetMovementsExpectation specifies expectation struct of the stockRepository.GetMovements
+type StockRepositoryMockGetMovementsExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockGetMovementsParams
+	paramPtrs *StockRepositoryMockGetMovementsParamPtrs
+	results   *StockRepositoryMockGetMovementsResults
+	Counter   uint64
+}
+
+// StockRepositoryMockGetMovementsParams contains parameters of the stockRepository.GetMovements
+type StockRepositoryMockGetMovementsParams struct {
+	ctx context.Context
+	p1  model.ProductSku
+	s1  model.StockMovementID
+	i1  int
+}
+
+// StockRepositoryMockGetMovementsParamPtrs contains pointers to parameters of the stockRepository.GetMovements
+type StockRepositoryMockGetMovementsParamPtrs struct {
+	ctx *context.Context
+	p1  *model.ProductSku
+	s1  *model.StockMovementID
+	i1  *int
+}
+
+// StockRepositoryMockGetMovementsResults contains results of the stockRepository.GetMovements
+type StockRepositoryMockGetMovementsResults struct {
+	sa1 []model.StockMovement
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetMovements *mStockRepositoryMockGetMovements) Optional() *mStockRepositoryMockGetMovements {
+	mmGetMovements.optional = true
+	return mmGetMovements
+}
+
+// Expect sets up expected params for stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) Expect(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{}
+	}
+
+	if mmGetMovements.defaultExpectation.paramPtrs != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by ExpectParams functions")
+	}
+
+	mmGetMovements.defaultExpectation.params = &StockRepositoryMockGetMovementsParams{ctx, p1, s1, i1}
+	for _, e := range mmGetMovements.expectations {
+		if minimock.Equal(e.params, mmGetMovements.defaultExpectation.params) {
+			mmGetMovements.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetMovements.defaultExpectation.params)
+		}
+	}
+
+	return mmGetMovements
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{}
+	}
+
+	if mmGetMovements.defaultExpectation.params != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Expect")
+	}
+
+	if mmGetMovements.defaultExpectation.paramPtrs == nil {
+		mmGetMovements.defaultExpectation.paramPtrs = &StockRepositoryMockGetMovementsParamPtrs{}
+	}
+	mmGetMovements.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetMovements
+}
+
+// ExpectP1Param2 sets up expected param p1 for stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{}
+	}
+
+	if mmGetMovements.defaultExpectation.params != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Expect")
+	}
+
+	if mmGetMovements.defaultExpectation.paramPtrs == nil {
+		mmGetMovements.defaultExpectation.paramPtrs = &StockRepositoryMockGetMovementsParamPtrs{}
+	}
+	mmGetMovements.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmGetMovements
+}
+
+// ExpectS1Param3 sets up expected param s1 for stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) ExpectS1Param3(s1 model.StockMovementID) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{}
+	}
+
+	if mmGetMovements.defaultExpectation.params != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Expect")
+	}
+
+	if mmGetMovements.defaultExpectation.paramPtrs == nil {
+		mmGetMovements.defaultExpectation.paramPtrs = &StockRepositoryMockGetMovementsParamPtrs{}
+	}
+	mmGetMovements.defaultExpectation.paramPtrs.s1 = &s1
+
+	return mmGetMovements
+}
+
+// ExpectI1Param4 sets up expected param i1 for stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) ExpectI1Param4(i1 int) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{}
+	}
+
+	if mmGetMovements.defaultExpectation.params != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Expect")
+	}
+
+	if mmGetMovements.defaultExpectation.paramPtrs == nil {
+		mmGetMovements.defaultExpectation.paramPtrs = &StockRepositoryMockGetMovementsParamPtrs{}
+	}
+	mmGetMovements.defaultExpectation.paramPtrs.i1 = &i1
+
+	return mmGetMovements
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) Inspect(f func(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int)) *mStockRepositoryMockGetMovements {
+	if mmGetMovements.mock.inspectFuncGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetMovements")
+	}
+
+	mmGetMovements.mock.inspectFuncGetMovements = f
+
+	return mmGetMovements
+}
+
+// Return sets up results that will be returned by stockRepository.GetMovements
+func (mmGetMovements *mStockRepositoryMockGetMovements) Return(sa1 []model.StockMovement, err error) *StockRepositoryMock {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	if mmGetMovements.defaultExpectation == nil {
+		mmGetMovements.defaultExpectation = &StockRepositoryMockGetMovementsExpectation{mock: mmGetMovements.mock}
+	}
+	mmGetMovements.defaultExpectation.results = &StockRepositoryMockGetMovementsResults{sa1, err}
+	return mmGetMovements.mock
+}
+
+// Set uses given function f to mock the stockRepository.GetMovements method
+func (mmGetMovements *mStockRepositoryMockGetMovements) Set(f func(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int) (sa1 []model.StockMovement, err error)) *StockRepositoryMock {
+	if mmGetMovements.defaultExpectation != nil {
+		mmGetMovements.mock.t.Fatalf("Default expectation is already set for the stockRepository.GetMovements method")
+	}
+
+	if len(mmGetMovements.expectations) > 0 {
+		mmGetMovements.mock.t.Fatalf("Some expectations are already set for the stockRepository.GetMovements method")
+	}
+
+	mmGetMovements.mock.funcGetMovements = f
+	return mmGetMovements.mock
+}
+
+// When sets expectation for the stockRepository.GetMovements which will trigger the result defined by the following
+// Then helper
+func (mmGetMovements *mStockRepositoryMockGetMovements) When(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int) *StockRepositoryMockGetMovementsExpectation {
+	if mmGetMovements.mock.funcGetMovements != nil {
+		mmGetMovements.mock.t.Fatalf("StockRepositoryMock.GetMovements mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetMovementsExpectation{
+		mock:   mmGetMovements.mock,
+		params: &StockRepositoryMockGetMovementsParams{ctx, p1, s1, i1},
+	}
+	mmGetMovements.expectations = append(mmGetMovements.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.GetMovements return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetMovementsExpectation) Then(sa1 []model.StockMovement, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetMovementsResults{sa1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.GetMovements should be invoked
+func (mmGetMovements *mStockRepositoryMockGetMovements) Times(n uint64) *mStockRepositoryMockGetMovements {
+	if n == 0 {
+		mmGetMovements.mock.t.Fatalf("Times of StockRepositoryMock.GetMovements mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetMovements.expectedInvocations, n)
+	return mmGetMovements
+}
+
+func (mmGetMovements *mStockRepositoryMockGetMovements) invocationsDone() bool {
+	if len(mmGetMovements.expectations) == 0 && mmGetMovements.defaultExpectation == nil && mmGetMovements.mock.funcGetMovements == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetMovements.mock.afterGetMovementsCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetMovements.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetMovements implements service.stockRepository
+func (mmGetMovements *StockRepositoryMock) GetMovements(ctx context.Context, p1 model.ProductSku, s1 model.StockMovementID, i1 int) (sa1 []model.StockMovement, err error) {
+	mm_atomic.AddUint64(&mmGetMovements.beforeGetMovementsCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetMovements.afterGetMovementsCounter, 1)
+
+	if mmGetMovements.inspectFuncGetMovements != nil {
+		mmGetMovements.inspectFuncGetMovements(ctx, p1, s1, i1)
+	}
+
+	mm_params := StockRepositoryMockGetMovementsParams{ctx, p1, s1, i1}
+
+	// Record call args
+	mmGetMovements.GetMovementsMock.mutex.Lock()
+	mmGetMovements.GetMovementsMock.callArgs = append(mmGetMovements.GetMovementsMock.callArgs, &mm_params)
+	mmGetMovements.GetMovementsMock.mutex.Unlock()
+
+	for _, e := range mmGetMovements.GetMovementsMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.sa1, e.results.err
+		}
+	}
+
+	if mmGetMovements.GetMovementsMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetMovements.GetMovementsMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetMovements.GetMovementsMock.defaultExpectation.params
+		mm_want_ptrs := mmGetMovements.GetMovementsMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetMovementsParams{ctx, p1, s1, i1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetMovements.t.Errorf("StockRepositoryMock.GetMovements got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmGetMovements.t.Errorf("StockRepositoryMock.GetMovements got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+			if mm_want_ptrs.s1 != nil && !minimock.Equal(*mm_want_ptrs.s1, mm_got.s1) {
+				mmGetMovements.t.Errorf("StockRepositoryMock.GetMovements got unexpected parameter s1, want: %#v, got: %#v%s\n", *mm_want_ptrs.s1, mm_got.s1, minimock.Diff(*mm_want_ptrs.s1, mm_got.s1))
+			}
+
+			if mm_want_ptrs.i1 != nil && !minimock.Equal(*mm_want_ptrs.i1, mm_got.i1) {
+				mmGetMovements.t.Errorf("StockRepositoryMock.GetMovements got unexpected parameter i1, want: %#v, got: %#v%s\n", *mm_want_ptrs.i1, mm_got.i1, minimock.Diff(*mm_want_ptrs.i1, mm_got.i1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetMovements.t.Errorf("StockRepositoryMock.GetMovements got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetMovements.GetMovementsMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetMovements.t.Fatal("No results are set for the StockRepositoryMock.GetMovements")
+		}
+		return (*mm_results).sa1, (*mm_results).err
+	}
+	if mmGetMovements.funcGetMovements != nil {
+		return mmGetMovements.funcGetMovements(ctx, p1, s1, i1)
+	}
+	mmGetMovements.t.Fatalf("Unexpected call to StockRepositoryMock.GetMovements. %v %v %v %v", ctx, p1, s1, i1)
+	return
+}
+
+// GetMovementsAfterCounter returns a count of finished StockRepositoryMock.GetMovements invocations
+func (mmGetMovements *StockRepositoryMock) GetMovementsAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetMovements.afterGetMovementsCounter)
+}
+
+// GetMovementsBeforeCounter returns a count of StockRepositoryMock.GetMovements invocations
+func (mmGetMovements *StockRepositoryMock) GetMovementsBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetMovements.beforeGetMovementsCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetMovements.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetMovements *mStockRepositoryMockGetMovements) Calls() []*StockRepositoryMockGetMovementsParams {
+	mmGetMovements.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetMovementsParams, len(mmGetMovements.callArgs))
+	copy(argCopy, mmGetMovements.callArgs)
+
+	mmGetMovements.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetMovementsDone returns true if the count of the GetMovements invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetMovementsDone() bool {
+	if m.GetMovementsMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetMovementsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetMovementsMock.invocationsDone()
+}
+
+// MinimockGetMovementsInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetMovementsInspect() {
+	for _, e := range m.GetMovementsMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetMovements with params: %#v", *e.params)
+		}
+	}
+
+	afterGetMovementsCounter := mm_atomic.LoadUint64(&m.afterGetMovementsCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetMovementsMock.defaultExpectation != nil && afterGetMovementsCounter < 1 {
+		if m.GetMovementsMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.GetMovements")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetMovements with params: %#v", *m.GetMovementsMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetMovements != nil && afterGetMovementsCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.GetMovements")
+	}
+
+	if !m.GetMovementsMock.invocationsDone() && afterGetMovementsCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetMovements but found %d calls",
+			mm_atomic.LoadUint64(&m.GetMovementsMock.expectedInvocations), afterGetMovementsCounter)
+	}
+}
+
+type mStockRepositoryMockGetStocksBySku struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetStocksBySkuExpectation
+	expectations       []*StockRepositoryMockGetStocksBySkuExpectation
+
+	callArgs []*StockRepositoryMockGetStocksBySkuParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockGetStocksBySkuExpectation specifies expectation struct of the stockRepository.GetStocksBySku
+type StockRepositoryMockGetStocksBySkuExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockGetStocksBySkuParams
+	paramPtrs *StockRepositoryMockGetStocksBySkuParamPtrs
+	results   *StockRepositoryMockGetStocksBySkuResults
+	Counter   uint64
+}
+
+// StockRepositoryMockGetStocksBySkuParams contains parameters of the stockRepository.GetStocksBySku
+type StockRepositoryMockGetStocksBySkuParams struct {
+	ctx context.Context
+	p1  model.ProductSku
+}
+
+// StockRepositoryMockGetStocksBySkuParamPtrs contains pointers to parameters of the stockRepository.GetStocksBySku
+type StockRepositoryMockGetStocksBySkuParamPtrs struct {
+	ctx *context.Context
+	p1  *model.ProductSku
+}
+
+// StockRepositoryMockGetStocksBySkuResults contains results of the stockRepository.GetStocksBySku
+type StockRepositoryMockGetStocksBySkuResults struct {
+	u1  uint64
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Optional() *mStockRepositoryMockGetStocksBySku {
+	mmGetStocksBySku.optional = true
+	return mmGetStocksBySku
+}
+
+// Expect sets up expected params for stockRepository.GetStocksBySku
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Expect(ctx context.Context, p1 model.ProductSku) *mStockRepositoryMockGetStocksBySku {
+	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+	}
+
+	if mmGetStocksBySku.defaultExpectation == nil {
+		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	}
+
+	if mmGetStocksBySku.defaultExpectation.paramPtrs != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by ExpectParams functions")
+	}
+
+	mmGetStocksBySku.defaultExpectation.params = &StockRepositoryMockGetStocksBySkuParams{ctx, p1}
+	for _, e := range mmGetStocksBySku.expectations {
+		if minimock.Equal(e.params, mmGetStocksBySku.defaultExpectation.params) {
+			mmGetStocksBySku.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksBySku.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStocksBySku
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.GetStocksBySku
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetStocksBySku {
+	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+	}
+
+	if mmGetStocksBySku.defaultExpectation == nil {
+		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	}
+
+	if mmGetStocksBySku.defaultExpectation.params != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Expect")
+	}
+
+	if mmGetStocksBySku.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySku.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkuParamPtrs{}
+	}
+	mmGetStocksBySku.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetStocksBySku
+}
+
+// ExpectP1Param2 sets up expected param p1 for stockRepository.GetStocksBySku
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockGetStocksBySku {
+	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+	}
+
+	if mmGetStocksBySku.defaultExpectation == nil {
+		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{}
+	}
+
+	if mmGetStocksBySku.defaultExpectation.params != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Expect")
+	}
+
+	if mmGetStocksBySku.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySku.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkuParamPtrs{}
+	}
+	mmGetStocksBySku.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmGetStocksBySku
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.GetStocksBySku
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Inspect(f func(ctx context.Context, p1 model.ProductSku)) *mStockRepositoryMockGetStocksBySku {
+	if mmGetStocksBySku.mock.inspectFuncGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetStocksBySku")
+	}
+
+	mmGetStocksBySku.mock.inspectFuncGetStocksBySku = f
+
+	return mmGetStocksBySku
+}
+
+// Return sets up results that will be returned by stockRepository.GetStocksBySku
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Return(u1 uint64, err error) *StockRepositoryMock {
+	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+	}
+
+	if mmGetStocksBySku.defaultExpectation == nil {
+		mmGetStocksBySku.defaultExpectation = &StockRepositoryMockGetStocksBySkuExpectation{mock: mmGetStocksBySku.mock}
+	}
+	mmGetStocksBySku.defaultExpectation.results = &StockRepositoryMockGetStocksBySkuResults{u1, err}
+	return mmGetStocksBySku.mock
+}
+
+// Set uses given function f to mock the stockRepository.GetStocksBySku method
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Set(f func(ctx context.Context, p1 model.ProductSku) (u1 uint64, err error)) *StockRepositoryMock {
+	if mmGetStocksBySku.defaultExpectation != nil {
+		mmGetStocksBySku.mock.t.Fatalf("Default expectation is already set for the stockRepository.GetStocksBySku method")
+	}
+
+	if len(mmGetStocksBySku.expectations) > 0 {
+		mmGetStocksBySku.mock.t.Fatalf("Some expectations are already set for the stockRepository.GetStocksBySku method")
+	}
+
+	mmGetStocksBySku.mock.funcGetStocksBySku = f
+	return mmGetStocksBySku.mock
+}
+
+// When sets expectation for the stockRepository.GetStocksBySku which will trigger the result defined by the following
+// Then helper
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) When(ctx context.Context, p1 model.ProductSku) *StockRepositoryMockGetStocksBySkuExpectation {
+	if mmGetStocksBySku.mock.funcGetStocksBySku != nil {
+		mmGetStocksBySku.mock.t.Fatalf("StockRepositoryMock.GetStocksBySku mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetStocksBySkuExpectation{
+		mock:   mmGetStocksBySku.mock,
+		params: &StockRepositoryMockGetStocksBySkuParams{ctx, p1},
+	}
+	mmGetStocksBySku.expectations = append(mmGetStocksBySku.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.GetStocksBySku return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetStocksBySkuExpectation) Then(u1 uint64, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetStocksBySkuResults{u1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.GetStocksBySku should be invoked
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Times(n uint64) *mStockRepositoryMockGetStocksBySku {
+	if n == 0 {
+		mmGetStocksBySku.mock.t.Fatalf("Times of StockRepositoryMock.GetStocksBySku mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStocksBySku.expectedInvocations, n)
+	return mmGetStocksBySku
+}
+
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) invocationsDone() bool {
+	if len(mmGetStocksBySku.expectations) == 0 && mmGetStocksBySku.defaultExpectation == nil && mmGetStocksBySku.mock.funcGetStocksBySku == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksBySku.mock.afterGetStocksBySkuCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksBySku.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStocksBySku implements service.stockRepository
+func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySku(ctx context.Context, p1 model.ProductSku) (u1 uint64, err error) {
+	mm_atomic.AddUint64(&mmGetStocksBySku.beforeGetStocksBySkuCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStocksBySku.afterGetStocksBySkuCounter, 1)
+
+	if mmGetStocksBySku.inspectFuncGetStocksBySku != nil {
+		mmGetStocksBySku.inspectFuncGetStocksBySku(ctx, p1)
+	}
+
+	mm_params := StockRepositoryMockGetStocksBySkuParams{ctx, p1}
+
+	// Record call args
+	mmGetStocksBySku.GetStocksBySkuMock.mutex.Lock()
+	mmGetStocksBySku.GetStocksBySkuMock.callArgs = append(mmGetStocksBySku.GetStocksBySkuMock.callArgs, &mm_params)
+	mmGetStocksBySku.GetStocksBySkuMock.mutex.Unlock()
+
+	for _, e := range mmGetStocksBySku.GetStocksBySkuMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.u1, e.results.err
+		}
+	}
+
+	if mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetStocksBySkuParams{ctx, p1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStocksBySku.t.Errorf("StockRepositoryMock.GetStocksBySku got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStocksBySku.GetStocksBySkuMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStocksBySku.t.Fatal("No results are set for the StockRepositoryMock.GetStocksBySku")
+		}
+		return (*mm_results).u1, (*mm_results).err
+	}
+	if mmGetStocksBySku.funcGetStocksBySku != nil {
+		return mmGetStocksBySku.funcGetStocksBySku(ctx, p1)
+	}
+	mmGetStocksBySku.t.Fatalf("Unexpected call to StockRepositoryMock.GetStocksBySku. %v %v", ctx, p1)
+	return
+}
+
+// GetStocksBySkuAfterCounter returns a count of finished StockRepositoryMock.GetStocksBySku invocations
+func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySkuAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySku.afterGetStocksBySkuCounter)
+}
+
+// GetStocksBySkuBeforeCounter returns a count of StockRepositoryMock.GetStocksBySku invocations
+func (mmGetStocksBySku *StockRepositoryMock) GetStocksBySkuBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySku.beforeGetStocksBySkuCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetStocksBySku.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStocksBySku *mStockRepositoryMockGetStocksBySku) Calls() []*StockRepositoryMockGetStocksBySkuParams {
+	mmGetStocksBySku.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetStocksBySkuParams, len(mmGetStocksBySku.callArgs))
+	copy(argCopy, mmGetStocksBySku.callArgs)
+
+	mmGetStocksBySku.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStocksBySkuDone returns true if the count of the GetStocksBySku invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetStocksBySkuDone() bool {
+	if m.GetStocksBySkuMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStocksBySkuMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStocksBySkuMock.invocationsDone()
+}
+
+// MinimockGetStocksBySkuInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetStocksBySkuInspect() {
+	for _, e := range m.GetStocksBySkuMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySku with params: %#v", *e.params)
+		}
+	}
+
+	afterGetStocksBySkuCounter := mm_atomic.LoadUint64(&m.afterGetStocksBySkuCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStocksBySkuMock.defaultExpectation != nil && afterGetStocksBySkuCounter < 1 {
+		if m.GetStocksBySkuMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.GetStocksBySku")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySku with params: %#v", *m.GetStocksBySkuMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStocksBySku != nil && afterGetStocksBySkuCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.GetStocksBySku")
+	}
+
+	if !m.GetStocksBySkuMock.invocationsDone() && afterGetStocksBySkuCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetStocksBySku but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStocksBySkuMock.expectedInvocations), afterGetStocksBySkuCounter)
+	}
+}
+
+type mStockRepositoryMockGetStocksBySkus struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetStocksBySkusExpectation
+	expectations       []*StockRepositoryMockGetStocksBySkusExpectation
+
+	callArgs []*StockRepositoryMockGetStocksBySkusParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockGetStocksBySkusExpectation specifies expectation struct of the stockRepository.GetStocksBySkus
+type StockRepositoryMockGetStocksBySkusExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockGetStocksBySkusParams
+	paramPtrs *StockRepositoryMockGetStocksBySkusParamPtrs
+	results   *StockRepositoryMockGetStocksBySkusResults
+	Counter   uint64
+}
+
+// StockRepositoryMockGetStocksBySkusParams contains parameters of the stockRepository.GetStocksBySkus
+type StockRepositoryMockGetStocksBySkusParams struct {
+	ctx context.Context
+	pa1 []model.ProductSku
+}
+
+// StockRepositoryMockGetStocksBySkusParamPtrs contains pointers to parameters of the stockRepository.GetStocksBySkus
+type StockRepositoryMockGetStocksBySkusParamPtrs struct {
+	ctx *context.Context
+	pa1 *[]model.ProductSku
+}
+
+// StockRepositoryMockGetStocksBySkusResults contains results of the stockRepository.GetStocksBySkus
+type StockRepositoryMockGetStocksBySkusResults struct {
+	m1  map[model.ProductSku]uint64
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Optional() *mStockRepositoryMockGetStocksBySkus {
+	mmGetStocksBySkus.optional = true
+	return mmGetStocksBySkus
+}
+
+// Expect sets up expected params for stockRepository.GetStocksBySkus
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Expect(ctx context.Context, pa1 []model.ProductSku) *mStockRepositoryMockGetStocksBySkus {
+	if mmGetStocksBySkus.mock.funcGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Set")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation == nil {
+		mmGetStocksBySkus.defaultExpectation = &StockRepositoryMockGetStocksBySkusExpectation{}
+	}
+
+	if mmGetStocksBySkus.defaultExpectation.paramPtrs != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by ExpectParams functions")
+	}
+
+	mmGetStocksBySkus.defaultExpectation.params = &StockRepositoryMockGetStocksBySkusParams{ctx, pa1}
+	for _, e := range mmGetStocksBySkus.expectations {
+		if minimock.Equal(e.params, mmGetStocksBySkus.defaultExpectation.params) {
+			mmGetStocksBySkus.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksBySkus.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStocksBySkus
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.GetStocksBySkus
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetStocksBySkus {
+	if mmGetStocksBySkus.mock.funcGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Set")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation == nil {
+		mmGetStocksBySkus.defaultExpectation = &StockRepositoryMockGetStocksBySkusExpectation{}
+	}
+
+	if mmGetStocksBySkus.defaultExpectation.params != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Expect")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySkus.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkusParamPtrs{}
+	}
+	mmGetStocksBySkus.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetStocksBySkus
+}
+
+// ExpectPa1Param2 sets up expected param pa1 for stockRepository.GetStocksBySkus
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) ExpectPa1Param2(pa1 []model.ProductSku) *mStockRepositoryMockGetStocksBySkus {
+	if mmGetStocksBySkus.mock.funcGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Set")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation == nil {
+		mmGetStocksBySkus.defaultExpectation = &StockRepositoryMockGetStocksBySkusExpectation{}
+	}
+
+	if mmGetStocksBySkus.defaultExpectation.params != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Expect")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation.paramPtrs == nil {
+		mmGetStocksBySkus.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksBySkusParamPtrs{}
+	}
+	mmGetStocksBySkus.defaultExpectation.paramPtrs.pa1 = &pa1
+
+	return mmGetStocksBySkus
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.GetStocksBySkus
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Inspect(f func(ctx context.Context, pa1 []model.ProductSku)) *mStockRepositoryMockGetStocksBySkus {
+	if mmGetStocksBySkus.mock.inspectFuncGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetStocksBySkus")
+	}
+
+	mmGetStocksBySkus.mock.inspectFuncGetStocksBySkus = f
+
+	return mmGetStocksBySkus
+}
+
+// Return sets up results that will be returned by stockRepository.GetStocksBySkus
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Return(m1 map[model.ProductSku]uint64, err error) *StockRepositoryMock {
+	if mmGetStocksBySkus.mock.funcGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Set")
+	}
+
+	if mmGetStocksBySkus.defaultExpectation == nil {
+		mmGetStocksBySkus.defaultExpectation = &StockRepositoryMockGetStocksBySkusExpectation{mock: mmGetStocksBySkus.mock}
+	}
+	mmGetStocksBySkus.defaultExpectation.results = &StockRepositoryMockGetStocksBySkusResults{m1, err}
+	return mmGetStocksBySkus.mock
+}
+
+// Set uses given function f to mock the stockRepository.GetStocksBySkus method
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Set(f func(ctx context.Context, pa1 []model.ProductSku) (m1 map[model.ProductSku]uint64, err error)) *StockRepositoryMock {
+	if mmGetStocksBySkus.defaultExpectation != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("Default expectation is already set for the stockRepository.GetStocksBySkus method")
+	}
+
+	if len(mmGetStocksBySkus.expectations) > 0 {
+		mmGetStocksBySkus.mock.t.Fatalf("Some expectations are already set for the stockRepository.GetStocksBySkus method")
+	}
+
+	mmGetStocksBySkus.mock.funcGetStocksBySkus = f
+	return mmGetStocksBySkus.mock
+}
+
+// When sets expectation for the stockRepository.GetStocksBySkus which will trigger the result defined by the following
+// Then helper
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) When(ctx context.Context, pa1 []model.ProductSku) *StockRepositoryMockGetStocksBySkusExpectation {
+	if mmGetStocksBySkus.mock.funcGetStocksBySkus != nil {
+		mmGetStocksBySkus.mock.t.Fatalf("StockRepositoryMock.GetStocksBySkus mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetStocksBySkusExpectation{
+		mock:   mmGetStocksBySkus.mock,
+		params: &StockRepositoryMockGetStocksBySkusParams{ctx, pa1},
+	}
+	mmGetStocksBySkus.expectations = append(mmGetStocksBySkus.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.GetStocksBySkus return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetStocksBySkusExpectation) Then(m1 map[model.ProductSku]uint64, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetStocksBySkusResults{m1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.GetStocksBySkus should be invoked
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Times(n uint64) *mStockRepositoryMockGetStocksBySkus {
+	if n == 0 {
+		mmGetStocksBySkus.mock.t.Fatalf("Times of StockRepositoryMock.GetStocksBySkus mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStocksBySkus.expectedInvocations, n)
+	return mmGetStocksBySkus
+}
+
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) invocationsDone() bool {
+	if len(mmGetStocksBySkus.expectations) == 0 && mmGetStocksBySkus.defaultExpectation == nil && mmGetStocksBySkus.mock.funcGetStocksBySkus == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksBySkus.mock.afterGetStocksBySkusCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksBySkus.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStocksBySkus implements service.stockRepository
+func (mmGetStocksBySkus *StockRepositoryMock) GetStocksBySkus(ctx context.Context, pa1 []model.ProductSku) (m1 map[model.ProductSku]uint64, err error) {
+	mm_atomic.AddUint64(&mmGetStocksBySkus.beforeGetStocksBySkusCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStocksBySkus.afterGetStocksBySkusCounter, 1)
+
+	if mmGetStocksBySkus.inspectFuncGetStocksBySkus != nil {
+		mmGetStocksBySkus.inspectFuncGetStocksBySkus(ctx, pa1)
+	}
+
+	mm_params := StockRepositoryMockGetStocksBySkusParams{ctx, pa1}
+
+	// Record call args
+	mmGetStocksBySkus.GetStocksBySkusMock.mutex.Lock()
+	mmGetStocksBySkus.GetStocksBySkusMock.callArgs = append(mmGetStocksBySkus.GetStocksBySkusMock.callArgs, &mm_params)
+	mmGetStocksBySkus.GetStocksBySkusMock.mutex.Unlock()
+
+	for _, e := range mmGetStocksBySkus.GetStocksBySkusMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.m1, e.results.err
+		}
+	}
+
+	if mmGetStocksBySkus.GetStocksBySkusMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStocksBySkus.GetStocksBySkusMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStocksBySkus.GetStocksBySkusMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStocksBySkus.GetStocksBySkusMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetStocksBySkusParams{ctx, pa1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStocksBySkus.t.Errorf("StockRepositoryMock.GetStocksBySkus got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.pa1 != nil && !minimock.Equal(*mm_want_ptrs.pa1, mm_got.pa1) {
+				mmGetStocksBySkus.t.Errorf("StockRepositoryMock.GetStocksBySkus got unexpected parameter pa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.pa1, mm_got.pa1, minimock.Diff(*mm_want_ptrs.pa1, mm_got.pa1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStocksBySkus.t.Errorf("StockRepositoryMock.GetStocksBySkus got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStocksBySkus.GetStocksBySkusMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStocksBySkus.t.Fatal("No results are set for the StockRepositoryMock.GetStocksBySkus")
+		}
+		return (*mm_results).m1, (*mm_results).err
+	}
+	if mmGetStocksBySkus.funcGetStocksBySkus != nil {
+		return mmGetStocksBySkus.funcGetStocksBySkus(ctx, pa1)
+	}
+	mmGetStocksBySkus.t.Fatalf("Unexpected call to StockRepositoryMock.GetStocksBySkus. %v %v", ctx, pa1)
+	return
+}
+
+// GetStocksBySkusAfterCounter returns a count of finished StockRepositoryMock.GetStocksBySkus invocations
+func (mmGetStocksBySkus *StockRepositoryMock) GetStocksBySkusAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySkus.afterGetStocksBySkusCounter)
+}
+
+// GetStocksBySkusBeforeCounter returns a count of StockRepositoryMock.GetStocksBySkus invocations
+func (mmGetStocksBySkus *StockRepositoryMock) GetStocksBySkusBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksBySkus.beforeGetStocksBySkusCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetStocksBySkus.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStocksBySkus *mStockRepositoryMockGetStocksBySkus) Calls() []*StockRepositoryMockGetStocksBySkusParams {
+	mmGetStocksBySkus.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetStocksBySkusParams, len(mmGetStocksBySkus.callArgs))
+	copy(argCopy, mmGetStocksBySkus.callArgs)
+
+	mmGetStocksBySkus.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStocksBySkusDone returns true if the count of the GetStocksBySkus invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetStocksBySkusDone() bool {
+	if m.GetStocksBySkusMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStocksBySkusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStocksBySkusMock.invocationsDone()
+}
+
+// MinimockGetStocksBySkusInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetStocksBySkusInspect() {
+	for _, e := range m.GetStocksBySkusMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySkus with params: %#v", *e.params)
+		}
+	}
+
+	afterGetStocksBySkusCounter := mm_atomic.LoadUint64(&m.afterGetStocksBySkusCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStocksBySkusMock.defaultExpectation != nil && afterGetStocksBySkusCounter < 1 {
+		if m.GetStocksBySkusMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.GetStocksBySkus")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksBySkus with params: %#v", *m.GetStocksBySkusMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStocksBySkus != nil && afterGetStocksBySkusCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.GetStocksBySkus")
+	}
+
+	if !m.GetStocksBySkusMock.invocationsDone() && afterGetStocksBySkusCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetStocksBySkus but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStocksBySkusMock.expectedInvocations), afterGetStocksBySkusCounter)
+	}
+}
+
+type mStockRepositoryMockGetStocksByWarehouse struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockGetStocksByWarehouseExpectation
+	expectations       []*StockRepositoryMockGetStocksByWarehouseExpectation
+
+	callArgs []*StockRepositoryMockGetStocksByWarehouseParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockGetStocksByWarehouseExpectation specifies expectation struct of the stockRepository.GetStocksByWarehouse
+type StockRepositoryMockGetStocksByWarehouseExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockGetStocksByWarehouseParams
+	paramPtrs *StockRepositoryMockGetStocksByWarehouseParamPtrs
+	results   *StockRepositoryMockGetStocksByWarehouseResults
+	Counter   uint64
+}
+
+// StockRepositoryMockGetStocksByWarehouseParams contains parameters of the stockRepository.GetStocksByWarehouse
+type StockRepositoryMockGetStocksByWarehouseParams struct {
+	ctx context.Context
+	p1  model.ProductSku
+}
+
+// StockRepositoryMockGetStocksByWarehouseParamPtrs contains pointers to parameters of the stockRepository.GetStocksByWarehouse
+type StockRepositoryMockGetStocksByWarehouseParamPtrs struct {
+	ctx *context.Context
+	p1  *model.ProductSku
+}
+
+// StockRepositoryMockGetStocksByWarehouseResults contains results of the stockRepository.GetStocksByWarehouse
+type StockRepositoryMockGetStocksByWarehouseResults struct {
+	wa1 []model.WarehouseStock
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Optional() *mStockRepositoryMockGetStocksByWarehouse {
+	mmGetStocksByWarehouse.optional = true
+	return mmGetStocksByWarehouse
+}
+
+// Expect sets up expected params for stockRepository.GetStocksByWarehouse
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Expect(ctx context.Context, p1 model.ProductSku) *mStockRepositoryMockGetStocksByWarehouse {
+	if mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Set")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation == nil {
+		mmGetStocksByWarehouse.defaultExpectation = &StockRepositoryMockGetStocksByWarehouseExpectation{}
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation.paramPtrs != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by ExpectParams functions")
+	}
+
+	mmGetStocksByWarehouse.defaultExpectation.params = &StockRepositoryMockGetStocksByWarehouseParams{ctx, p1}
+	for _, e := range mmGetStocksByWarehouse.expectations {
+		if minimock.Equal(e.params, mmGetStocksByWarehouse.defaultExpectation.params) {
+			mmGetStocksByWarehouse.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmGetStocksByWarehouse.defaultExpectation.params)
+		}
+	}
+
+	return mmGetStocksByWarehouse
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.GetStocksByWarehouse
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockGetStocksByWarehouse {
+	if mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Set")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation == nil {
+		mmGetStocksByWarehouse.defaultExpectation = &StockRepositoryMockGetStocksByWarehouseExpectation{}
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation.params != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Expect")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation.paramPtrs == nil {
+		mmGetStocksByWarehouse.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksByWarehouseParamPtrs{}
+	}
+	mmGetStocksByWarehouse.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmGetStocksByWarehouse
+}
+
+// ExpectP1Param2 sets up expected param p1 for stockRepository.GetStocksByWarehouse
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockGetStocksByWarehouse {
+	if mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Set")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation == nil {
+		mmGetStocksByWarehouse.defaultExpectation = &StockRepositoryMockGetStocksByWarehouseExpectation{}
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation.params != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Expect")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation.paramPtrs == nil {
+		mmGetStocksByWarehouse.defaultExpectation.paramPtrs = &StockRepositoryMockGetStocksByWarehouseParamPtrs{}
+	}
+	mmGetStocksByWarehouse.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmGetStocksByWarehouse
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.GetStocksByWarehouse
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Inspect(f func(ctx context.Context, p1 model.ProductSku)) *mStockRepositoryMockGetStocksByWarehouse {
+	if mmGetStocksByWarehouse.mock.inspectFuncGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.GetStocksByWarehouse")
+	}
+
+	mmGetStocksByWarehouse.mock.inspectFuncGetStocksByWarehouse = f
+
+	return mmGetStocksByWarehouse
+}
+
+// Return sets up results that will be returned by stockRepository.GetStocksByWarehouse
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Return(wa1 []model.WarehouseStock, err error) *StockRepositoryMock {
+	if mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Set")
+	}
+
+	if mmGetStocksByWarehouse.defaultExpectation == nil {
+		mmGetStocksByWarehouse.defaultExpectation = &StockRepositoryMockGetStocksByWarehouseExpectation{mock: mmGetStocksByWarehouse.mock}
+	}
+	mmGetStocksByWarehouse.defaultExpectation.results = &StockRepositoryMockGetStocksByWarehouseResults{wa1, err}
+	return mmGetStocksByWarehouse.mock
+}
+
+// Set uses given function f to mock the stockRepository.GetStocksByWarehouse method
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Set(f func(ctx context.Context, p1 model.ProductSku) (wa1 []model.WarehouseStock, err error)) *StockRepositoryMock {
+	if mmGetStocksByWarehouse.defaultExpectation != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("Default expectation is already set for the stockRepository.GetStocksByWarehouse method")
+	}
+
+	if len(mmGetStocksByWarehouse.expectations) > 0 {
+		mmGetStocksByWarehouse.mock.t.Fatalf("Some expectations are already set for the stockRepository.GetStocksByWarehouse method")
+	}
+
+	mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse = f
+	return mmGetStocksByWarehouse.mock
+}
+
+// When sets expectation for the stockRepository.GetStocksByWarehouse which will trigger the result defined by the following
+// Then helper
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) When(ctx context.Context, p1 model.ProductSku) *StockRepositoryMockGetStocksByWarehouseExpectation {
+	if mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.mock.t.Fatalf("StockRepositoryMock.GetStocksByWarehouse mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockGetStocksByWarehouseExpectation{
+		mock:   mmGetStocksByWarehouse.mock,
+		params: &StockRepositoryMockGetStocksByWarehouseParams{ctx, p1},
+	}
+	mmGetStocksByWarehouse.expectations = append(mmGetStocksByWarehouse.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.GetStocksByWarehouse return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockGetStocksByWarehouseExpectation) Then(wa1 []model.WarehouseStock, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockGetStocksByWarehouseResults{wa1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.GetStocksByWarehouse should be invoked
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Times(n uint64) *mStockRepositoryMockGetStocksByWarehouse {
+	if n == 0 {
+		mmGetStocksByWarehouse.mock.t.Fatalf("Times of StockRepositoryMock.GetStocksByWarehouse mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmGetStocksByWarehouse.expectedInvocations, n)
+	return mmGetStocksByWarehouse
+}
+
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) invocationsDone() bool {
+	if len(mmGetStocksByWarehouse.expectations) == 0 && mmGetStocksByWarehouse.defaultExpectation == nil && mmGetStocksByWarehouse.mock.funcGetStocksByWarehouse == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmGetStocksByWarehouse.mock.afterGetStocksByWarehouseCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmGetStocksByWarehouse.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// GetStocksByWarehouse implements service.stockRepository
+func (mmGetStocksByWarehouse *StockRepositoryMock) GetStocksByWarehouse(ctx context.Context, p1 model.ProductSku) (wa1 []model.WarehouseStock, err error) {
+	mm_atomic.AddUint64(&mmGetStocksByWarehouse.beforeGetStocksByWarehouseCounter, 1)
+	defer mm_atomic.AddUint64(&mmGetStocksByWarehouse.afterGetStocksByWarehouseCounter, 1)
+
+	if mmGetStocksByWarehouse.inspectFuncGetStocksByWarehouse != nil {
+		mmGetStocksByWarehouse.inspectFuncGetStocksByWarehouse(ctx, p1)
+	}
+
+	mm_params := StockRepositoryMockGetStocksByWarehouseParams{ctx, p1}
+
+	// Record call args
+	mmGetStocksByWarehouse.GetStocksByWarehouseMock.mutex.Lock()
+	mmGetStocksByWarehouse.GetStocksByWarehouseMock.callArgs = append(mmGetStocksByWarehouse.GetStocksByWarehouseMock.callArgs, &mm_params)
+	mmGetStocksByWarehouse.GetStocksByWarehouseMock.mutex.Unlock()
+
+	for _, e := range mmGetStocksByWarehouse.GetStocksByWarehouseMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.wa1, e.results.err
+		}
+	}
+
+	if mmGetStocksByWarehouse.GetStocksByWarehouseMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmGetStocksByWarehouse.GetStocksByWarehouseMock.defaultExpectation.Counter, 1)
+		mm_want := mmGetStocksByWarehouse.GetStocksByWarehouseMock.defaultExpectation.params
+		mm_want_ptrs := mmGetStocksByWarehouse.GetStocksByWarehouseMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockGetStocksByWarehouseParams{ctx, p1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmGetStocksByWarehouse.t.Errorf("StockRepositoryMock.GetStocksByWarehouse got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmGetStocksByWarehouse.t.Errorf("StockRepositoryMock.GetStocksByWarehouse got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmGetStocksByWarehouse.t.Errorf("StockRepositoryMock.GetStocksByWarehouse got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmGetStocksByWarehouse.GetStocksByWarehouseMock.defaultExpectation.results
+		if mm_results == nil {
+			mmGetStocksByWarehouse.t.Fatal("No results are set for the StockRepositoryMock.GetStocksByWarehouse")
+		}
+		return (*mm_results).wa1, (*mm_results).err
+	}
+	if mmGetStocksByWarehouse.funcGetStocksByWarehouse != nil {
+		return mmGetStocksByWarehouse.funcGetStocksByWarehouse(ctx, p1)
+	}
+	mmGetStocksByWarehouse.t.Fatalf("Unexpected call to StockRepositoryMock.GetStocksByWarehouse. %v %v", ctx, p1)
+	return
+}
+
+// GetStocksByWarehouseAfterCounter returns a count of finished StockRepositoryMock.GetStocksByWarehouse invocations
+func (mmGetStocksByWarehouse *StockRepositoryMock) GetStocksByWarehouseAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksByWarehouse.afterGetStocksByWarehouseCounter)
+}
+
+// GetStocksByWarehouseBeforeCounter returns a count of StockRepositoryMock.GetStocksByWarehouse invocations
+func (mmGetStocksByWarehouse *StockRepositoryMock) GetStocksByWarehouseBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmGetStocksByWarehouse.beforeGetStocksByWarehouseCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.GetStocksByWarehouse.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmGetStocksByWarehouse *mStockRepositoryMockGetStocksByWarehouse) Calls() []*StockRepositoryMockGetStocksByWarehouseParams {
+	mmGetStocksByWarehouse.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockGetStocksByWarehouseParams, len(mmGetStocksByWarehouse.callArgs))
+	copy(argCopy, mmGetStocksByWarehouse.callArgs)
+
+	mmGetStocksByWarehouse.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockGetStocksByWarehouseDone returns true if the count of the GetStocksByWarehouse invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockGetStocksByWarehouseDone() bool {
+	if m.GetStocksByWarehouseMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.GetStocksByWarehouseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.GetStocksByWarehouseMock.invocationsDone()
+}
+
+// MinimockGetStocksByWarehouseInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockGetStocksByWarehouseInspect() {
+	for _, e := range m.GetStocksByWarehouseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksByWarehouse with params: %#v", *e.params)
+		}
+	}
+
+	afterGetStocksByWarehouseCounter := mm_atomic.LoadUint64(&m.afterGetStocksByWarehouseCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.GetStocksByWarehouseMock.defaultExpectation != nil && afterGetStocksByWarehouseCounter < 1 {
+		if m.GetStocksByWarehouseMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.GetStocksByWarehouse")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.GetStocksByWarehouse with params: %#v", *m.GetStocksByWarehouseMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcGetStocksByWarehouse != nil && afterGetStocksByWarehouseCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.GetStocksByWarehouse")
+	}
+
+	if !m.GetStocksByWarehouseMock.invocationsDone() && afterGetStocksByWarehouseCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.GetStocksByWarehouse but found %d calls",
+			mm_atomic.LoadUint64(&m.GetStocksByWarehouseMock.expectedInvocations), afterGetStocksByWarehouseCounter)
+	}
+}
+
+type mStockRepositoryMockHoldCreate struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockHoldCreateExpectation
+	expectations       []*StockRepositoryMockHoldCreateExpectation
+
+	callArgs []*StockRepositoryMockHoldCreateParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockHoldCreateExpectation specifies expectation struct of the stockRepository.HoldCreate
+type StockRepositoryMockHoldCreateExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockHoldCreateParams
+	paramPtrs *StockRepositoryMockHoldCreateParamPtrs
+	results   *StockRepositoryMockHoldCreateResults
+	Counter   uint64
+}
+
+// StockRepositoryMockHoldCreateParams contains parameters of the stockRepository.HoldCreate
+type StockRepositoryMockHoldCreateParams struct {
+	ctx context.Context
+	u1  model.UserID
+	p1  model.ProductSku
+	u2  uint16
+	d1  time.Duration
+}
+
+// StockRepositoryMockHoldCreateParamPtrs contains pointers to parameters of the stockRepository.HoldCreate
+type StockRepositoryMockHoldCreateParamPtrs struct {
+	ctx *context.Context
+	u1  *model.UserID
+	p1  *model.ProductSku
+	u2  *uint16
+	d1  *time.Duration
+}
+
+// StockRepositoryMockHoldCreateResults contains results of the stockRepository.HoldCreate
+type StockRepositoryMockHoldCreateResults struct {
+	s1  model.StockHold
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Optional() *mStockRepositoryMockHoldCreate {
+	mmHoldCreate.optional = true
+	return mmHoldCreate
+}
+
+// Expect sets up expected params for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Expect(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by ExpectParams functions")
+	}
+
+	mmHoldCreate.defaultExpectation.params = &StockRepositoryMockHoldCreateParams{ctx, u1, p1, u2, d1}
+	for _, e := range mmHoldCreate.expectations {
+		if minimock.Equal(e.params, mmHoldCreate.defaultExpectation.params) {
+			mmHoldCreate.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHoldCreate.defaultExpectation.params)
+		}
+	}
+
+	return mmHoldCreate
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.params != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Expect")
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs == nil {
+		mmHoldCreate.defaultExpectation.paramPtrs = &StockRepositoryMockHoldCreateParamPtrs{}
+	}
+	mmHoldCreate.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHoldCreate
+}
+
+// ExpectU1Param2 sets up expected param u1 for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) ExpectU1Param2(u1 model.UserID) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.params != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Expect")
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs == nil {
+		mmHoldCreate.defaultExpectation.paramPtrs = &StockRepositoryMockHoldCreateParamPtrs{}
+	}
+	mmHoldCreate.defaultExpectation.paramPtrs.u1 = &u1
+
+	return mmHoldCreate
+}
+
+// ExpectP1Param3 sets up expected param p1 for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) ExpectP1Param3(p1 model.ProductSku) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.params != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Expect")
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs == nil {
+		mmHoldCreate.defaultExpectation.paramPtrs = &StockRepositoryMockHoldCreateParamPtrs{}
+	}
+	mmHoldCreate.defaultExpectation.paramPtrs.p1 = &p1
+
+	return mmHoldCreate
+}
+
+// ExpectU2Param4 sets up expected param u2 for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) ExpectU2Param4(u2 uint16) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.params != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Expect")
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs == nil {
+		mmHoldCreate.defaultExpectation.paramPtrs = &StockRepositoryMockHoldCreateParamPtrs{}
+	}
+	mmHoldCreate.defaultExpectation.paramPtrs.u2 = &u2
+
+	return mmHoldCreate
+}
+
+// ExpectD1Param5 sets up expected param d1 for stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) ExpectD1Param5(d1 time.Duration) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{}
+	}
+
+	if mmHoldCreate.defaultExpectation.params != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Expect")
+	}
+
+	if mmHoldCreate.defaultExpectation.paramPtrs == nil {
+		mmHoldCreate.defaultExpectation.paramPtrs = &StockRepositoryMockHoldCreateParamPtrs{}
+	}
+	mmHoldCreate.defaultExpectation.paramPtrs.d1 = &d1
+
+	return mmHoldCreate
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Inspect(f func(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration)) *mStockRepositoryMockHoldCreate {
+	if mmHoldCreate.mock.inspectFuncHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.HoldCreate")
+	}
+
+	mmHoldCreate.mock.inspectFuncHoldCreate = f
+
+	return mmHoldCreate
+}
+
+// Return sets up results that will be returned by stockRepository.HoldCreate
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Return(s1 model.StockHold, err error) *StockRepositoryMock {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	if mmHoldCreate.defaultExpectation == nil {
+		mmHoldCreate.defaultExpectation = &StockRepositoryMockHoldCreateExpectation{mock: mmHoldCreate.mock}
+	}
+	mmHoldCreate.defaultExpectation.results = &StockRepositoryMockHoldCreateResults{s1, err}
+	return mmHoldCreate.mock
+}
+
+// Set uses given function f to mock the stockRepository.HoldCreate method
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Set(f func(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration) (s1 model.StockHold, err error)) *StockRepositoryMock {
+	if mmHoldCreate.defaultExpectation != nil {
+		mmHoldCreate.mock.t.Fatalf("Default expectation is already set for the stockRepository.HoldCreate method")
+	}
+
+	if len(mmHoldCreate.expectations) > 0 {
+		mmHoldCreate.mock.t.Fatalf("Some expectations are already set for the stockRepository.HoldCreate method")
+	}
+
+	mmHoldCreate.mock.funcHoldCreate = f
+	return mmHoldCreate.mock
+}
+
+// When sets expectation for the stockRepository.HoldCreate which will trigger the result defined by the following
+// Then helper
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) When(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration) *StockRepositoryMockHoldCreateExpectation {
+	if mmHoldCreate.mock.funcHoldCreate != nil {
+		mmHoldCreate.mock.t.Fatalf("StockRepositoryMock.HoldCreate mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockHoldCreateExpectation{
+		mock:   mmHoldCreate.mock,
+		params: &StockRepositoryMockHoldCreateParams{ctx, u1, p1, u2, d1},
+	}
+	mmHoldCreate.expectations = append(mmHoldCreate.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.HoldCreate return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockHoldCreateExpectation) Then(s1 model.StockHold, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockHoldCreateResults{s1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.HoldCreate should be invoked
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Times(n uint64) *mStockRepositoryMockHoldCreate {
+	if n == 0 {
+		mmHoldCreate.mock.t.Fatalf("Times of StockRepositoryMock.HoldCreate mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHoldCreate.expectedInvocations, n)
+	return mmHoldCreate
+}
+
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) invocationsDone() bool {
+	if len(mmHoldCreate.expectations) == 0 && mmHoldCreate.defaultExpectation == nil && mmHoldCreate.mock.funcHoldCreate == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHoldCreate.mock.afterHoldCreateCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHoldCreate.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HoldCreate implements service.stockRepository
+func (mmHoldCreate *StockRepositoryMock) HoldCreate(ctx context.Context, u1 model.UserID, p1 model.ProductSku, u2 uint16, d1 time.Duration) (s1 model.StockHold, err error) {
+	mm_atomic.AddUint64(&mmHoldCreate.beforeHoldCreateCounter, 1)
+	defer mm_atomic.AddUint64(&mmHoldCreate.afterHoldCreateCounter, 1)
+
+	if mmHoldCreate.inspectFuncHoldCreate != nil {
+		mmHoldCreate.inspectFuncHoldCreate(ctx, u1, p1, u2, d1)
+	}
+
+	mm_params := StockRepositoryMockHoldCreateParams{ctx, u1, p1, u2, d1}
+
+	// Record call args
+	mmHoldCreate.HoldCreateMock.mutex.Lock()
+	mmHoldCreate.HoldCreateMock.callArgs = append(mmHoldCreate.HoldCreateMock.callArgs, &mm_params)
+	mmHoldCreate.HoldCreateMock.mutex.Unlock()
+
+	for _, e := range mmHoldCreate.HoldCreateMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.s1, e.results.err
+		}
+	}
+
+	if mmHoldCreate.HoldCreateMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHoldCreate.HoldCreateMock.defaultExpectation.Counter, 1)
+		mm_want := mmHoldCreate.HoldCreateMock.defaultExpectation.params
+		mm_want_ptrs := mmHoldCreate.HoldCreateMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockHoldCreateParams{ctx, u1, p1, u2, d1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
+				mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			}
+
+			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
+				mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
+			}
+
+			if mm_want_ptrs.u2 != nil && !minimock.Equal(*mm_want_ptrs.u2, mm_got.u2) {
+				mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameter u2, want: %#v, got: %#v%s\n", *mm_want_ptrs.u2, mm_got.u2, minimock.Diff(*mm_want_ptrs.u2, mm_got.u2))
+			}
+
+			if mm_want_ptrs.d1 != nil && !minimock.Equal(*mm_want_ptrs.d1, mm_got.d1) {
+				mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameter d1, want: %#v, got: %#v%s\n", *mm_want_ptrs.d1, mm_got.d1, minimock.Diff(*mm_want_ptrs.d1, mm_got.d1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHoldCreate.t.Errorf("StockRepositoryMock.HoldCreate got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHoldCreate.HoldCreateMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHoldCreate.t.Fatal("No results are set for the StockRepositoryMock.HoldCreate")
+		}
+		return (*mm_results).s1, (*mm_results).err
+	}
+	if mmHoldCreate.funcHoldCreate != nil {
+		return mmHoldCreate.funcHoldCreate(ctx, u1, p1, u2, d1)
+	}
+	mmHoldCreate.t.Fatalf("Unexpected call to StockRepositoryMock.HoldCreate. %v %v %v %v %v", ctx, u1, p1, u2, d1)
+	return
+}
+
+// HoldCreateAfterCounter returns a count of finished StockRepositoryMock.HoldCreate invocations
+func (mmHoldCreate *StockRepositoryMock) HoldCreateAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldCreate.afterHoldCreateCounter)
+}
+
+// HoldCreateBeforeCounter returns a count of StockRepositoryMock.HoldCreate invocations
+func (mmHoldCreate *StockRepositoryMock) HoldCreateBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldCreate.beforeHoldCreateCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.HoldCreate.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHoldCreate *mStockRepositoryMockHoldCreate) Calls() []*StockRepositoryMockHoldCreateParams {
+	mmHoldCreate.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockHoldCreateParams, len(mmHoldCreate.callArgs))
+	copy(argCopy, mmHoldCreate.callArgs)
+
+	mmHoldCreate.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHoldCreateDone returns true if the count of the HoldCreate invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockHoldCreateDone() bool {
+	if m.HoldCreateMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HoldCreateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HoldCreateMock.invocationsDone()
+}
+
+// MinimockHoldCreateInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockHoldCreateInspect() {
+	for _, e := range m.HoldCreateMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldCreate with params: %#v", *e.params)
+		}
+	}
+
+	afterHoldCreateCounter := mm_atomic.LoadUint64(&m.afterHoldCreateCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HoldCreateMock.defaultExpectation != nil && afterHoldCreateCounter < 1 {
+		if m.HoldCreateMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.HoldCreate")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldCreate with params: %#v", *m.HoldCreateMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHoldCreate != nil && afterHoldCreateCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.HoldCreate")
+	}
+
+	if !m.HoldCreateMock.invocationsDone() && afterHoldCreateCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.HoldCreate but found %d calls",
+			mm_atomic.LoadUint64(&m.HoldCreateMock.expectedInvocations), afterHoldCreateCounter)
+	}
+}
+
+type mStockRepositoryMockHoldExtend struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockHoldExtendExpectation
+	expectations       []*StockRepositoryMockHoldExtendExpectation
+
+	callArgs []*StockRepositoryMockHoldExtendParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockHoldExtendExpectation specifies expectation struct of the stockRepository.HoldExtend
+type StockRepositoryMockHoldExtendExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockHoldExtendParams
+	paramPtrs *StockRepositoryMockHoldExtendParamPtrs
+	results   *StockRepositoryMockHoldExtendResults
+	Counter   uint64
+}
+
+// StockRepositoryMockHoldExtendParams contains parameters of the stockRepository.HoldExtend
+type StockRepositoryMockHoldExtendParams struct {
+	ctx context.Context
+	u1  model.UserID
+	h1  model.HoldID
+	d1  time.Duration
+}
+
+// StockRepositoryMockHoldExtendParamPtrs contains pointers to parameters of the stockRepository.HoldExtend
+type StockRepositoryMockHoldExtendParamPtrs struct {
+	ctx *context.Context
+	u1  *model.UserID
+	h1  *model.HoldID
+	d1  *time.Duration
+}
+
+// StockRepositoryMockHoldExtendResults contains results of the stockRepository.HoldExtend
+type StockRepositoryMockHoldExtendResults struct {
+	t1  time.Time
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Optional() *mStockRepositoryMockHoldExtend {
+	mmHoldExtend.optional = true
+	return mmHoldExtend
+}
+
+// Expect sets up expected params for stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Expect(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{}
+	}
+
+	if mmHoldExtend.defaultExpectation.paramPtrs != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by ExpectParams functions")
+	}
+
+	mmHoldExtend.defaultExpectation.params = &StockRepositoryMockHoldExtendParams{ctx, u1, h1, d1}
+	for _, e := range mmHoldExtend.expectations {
+		if minimock.Equal(e.params, mmHoldExtend.defaultExpectation.params) {
+			mmHoldExtend.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHoldExtend.defaultExpectation.params)
+		}
+	}
+
+	return mmHoldExtend
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{}
+	}
+
+	if mmHoldExtend.defaultExpectation.params != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Expect")
+	}
+
+	if mmHoldExtend.defaultExpectation.paramPtrs == nil {
+		mmHoldExtend.defaultExpectation.paramPtrs = &StockRepositoryMockHoldExtendParamPtrs{}
+	}
+	mmHoldExtend.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHoldExtend
+}
+
+// ExpectU1Param2 sets up expected param u1 for stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) ExpectU1Param2(u1 model.UserID) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{}
+	}
+
+	if mmHoldExtend.defaultExpectation.params != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Expect")
+	}
+
+	if mmHoldExtend.defaultExpectation.paramPtrs == nil {
+		mmHoldExtend.defaultExpectation.paramPtrs = &StockRepositoryMockHoldExtendParamPtrs{}
+	}
+	mmHoldExtend.defaultExpectation.paramPtrs.u1 = &u1
+
+	return mmHoldExtend
+}
+
+// ExpectH1Param3 sets up expected param h1 for stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) ExpectH1Param3(h1 model.HoldID) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{}
+	}
+
+	if mmHoldExtend.defaultExpectation.params != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Expect")
+	}
+
+	if mmHoldExtend.defaultExpectation.paramPtrs == nil {
+		mmHoldExtend.defaultExpectation.paramPtrs = &StockRepositoryMockHoldExtendParamPtrs{}
+	}
+	mmHoldExtend.defaultExpectation.paramPtrs.h1 = &h1
+
+	return mmHoldExtend
+}
+
+// ExpectD1Param4 sets up expected param d1 for stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) ExpectD1Param4(d1 time.Duration) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{}
+	}
+
+	if mmHoldExtend.defaultExpectation.params != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Expect")
+	}
+
+	if mmHoldExtend.defaultExpectation.paramPtrs == nil {
+		mmHoldExtend.defaultExpectation.paramPtrs = &StockRepositoryMockHoldExtendParamPtrs{}
+	}
+	mmHoldExtend.defaultExpectation.paramPtrs.d1 = &d1
+
+	return mmHoldExtend
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Inspect(f func(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration)) *mStockRepositoryMockHoldExtend {
+	if mmHoldExtend.mock.inspectFuncHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.HoldExtend")
+	}
+
+	mmHoldExtend.mock.inspectFuncHoldExtend = f
+
+	return mmHoldExtend
+}
+
+// Return sets up results that will be returned by stockRepository.HoldExtend
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Return(t1 time.Time, err error) *StockRepositoryMock {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	if mmHoldExtend.defaultExpectation == nil {
+		mmHoldExtend.defaultExpectation = &StockRepositoryMockHoldExtendExpectation{mock: mmHoldExtend.mock}
+	}
+	mmHoldExtend.defaultExpectation.results = &StockRepositoryMockHoldExtendResults{t1, err}
+	return mmHoldExtend.mock
+}
+
+// Set uses given function f to mock the stockRepository.HoldExtend method
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Set(f func(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration) (t1 time.Time, err error)) *StockRepositoryMock {
+	if mmHoldExtend.defaultExpectation != nil {
+		mmHoldExtend.mock.t.Fatalf("Default expectation is already set for the stockRepository.HoldExtend method")
+	}
+
+	if len(mmHoldExtend.expectations) > 0 {
+		mmHoldExtend.mock.t.Fatalf("Some expectations are already set for the stockRepository.HoldExtend method")
+	}
+
+	mmHoldExtend.mock.funcHoldExtend = f
+	return mmHoldExtend.mock
+}
+
+// When sets expectation for the stockRepository.HoldExtend which will trigger the result defined by the following
+// Then helper
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) When(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration) *StockRepositoryMockHoldExtendExpectation {
+	if mmHoldExtend.mock.funcHoldExtend != nil {
+		mmHoldExtend.mock.t.Fatalf("StockRepositoryMock.HoldExtend mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockHoldExtendExpectation{
+		mock:   mmHoldExtend.mock,
+		params: &StockRepositoryMockHoldExtendParams{ctx, u1, h1, d1},
+	}
+	mmHoldExtend.expectations = append(mmHoldExtend.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.HoldExtend return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockHoldExtendExpectation) Then(t1 time.Time, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockHoldExtendResults{t1, err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.HoldExtend should be invoked
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Times(n uint64) *mStockRepositoryMockHoldExtend {
+	if n == 0 {
+		mmHoldExtend.mock.t.Fatalf("Times of StockRepositoryMock.HoldExtend mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHoldExtend.expectedInvocations, n)
+	return mmHoldExtend
+}
+
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) invocationsDone() bool {
+	if len(mmHoldExtend.expectations) == 0 && mmHoldExtend.defaultExpectation == nil && mmHoldExtend.mock.funcHoldExtend == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHoldExtend.mock.afterHoldExtendCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHoldExtend.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HoldExtend implements service.stockRepository
+func (mmHoldExtend *StockRepositoryMock) HoldExtend(ctx context.Context, u1 model.UserID, h1 model.HoldID, d1 time.Duration) (t1 time.Time, err error) {
+	mm_atomic.AddUint64(&mmHoldExtend.beforeHoldExtendCounter, 1)
+	defer mm_atomic.AddUint64(&mmHoldExtend.afterHoldExtendCounter, 1)
+
+	if mmHoldExtend.inspectFuncHoldExtend != nil {
+		mmHoldExtend.inspectFuncHoldExtend(ctx, u1, h1, d1)
+	}
+
+	mm_params := StockRepositoryMockHoldExtendParams{ctx, u1, h1, d1}
+
+	// Record call args
+	mmHoldExtend.HoldExtendMock.mutex.Lock()
+	mmHoldExtend.HoldExtendMock.callArgs = append(mmHoldExtend.HoldExtendMock.callArgs, &mm_params)
+	mmHoldExtend.HoldExtendMock.mutex.Unlock()
+
+	for _, e := range mmHoldExtend.HoldExtendMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.t1, e.results.err
+		}
+	}
+
+	if mmHoldExtend.HoldExtendMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHoldExtend.HoldExtendMock.defaultExpectation.Counter, 1)
+		mm_want := mmHoldExtend.HoldExtendMock.defaultExpectation.params
+		mm_want_ptrs := mmHoldExtend.HoldExtendMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockHoldExtendParams{ctx, u1, h1, d1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHoldExtend.t.Errorf("StockRepositoryMock.HoldExtend got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
+				mmHoldExtend.t.Errorf("StockRepositoryMock.HoldExtend got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			}
+
+			if mm_want_ptrs.h1 != nil && !minimock.Equal(*mm_want_ptrs.h1, mm_got.h1) {
+				mmHoldExtend.t.Errorf("StockRepositoryMock.HoldExtend got unexpected parameter h1, want: %#v, got: %#v%s\n", *mm_want_ptrs.h1, mm_got.h1, minimock.Diff(*mm_want_ptrs.h1, mm_got.h1))
+			}
+
+			if mm_want_ptrs.d1 != nil && !minimock.Equal(*mm_want_ptrs.d1, mm_got.d1) {
+				mmHoldExtend.t.Errorf("StockRepositoryMock.HoldExtend got unexpected parameter d1, want: %#v, got: %#v%s\n", *mm_want_ptrs.d1, mm_got.d1, minimock.Diff(*mm_want_ptrs.d1, mm_got.d1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHoldExtend.t.Errorf("StockRepositoryMock.HoldExtend got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHoldExtend.HoldExtendMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHoldExtend.t.Fatal("No results are set for the StockRepositoryMock.HoldExtend")
+		}
+		return (*mm_results).t1, (*mm_results).err
+	}
+	if mmHoldExtend.funcHoldExtend != nil {
+		return mmHoldExtend.funcHoldExtend(ctx, u1, h1, d1)
+	}
+	mmHoldExtend.t.Fatalf("Unexpected call to StockRepositoryMock.HoldExtend. %v %v %v %v", ctx, u1, h1, d1)
+	return
+}
+
+// HoldExtendAfterCounter returns a count of finished StockRepositoryMock.HoldExtend invocations
+func (mmHoldExtend *StockRepositoryMock) HoldExtendAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldExtend.afterHoldExtendCounter)
+}
+
+// HoldExtendBeforeCounter returns a count of StockRepositoryMock.HoldExtend invocations
+func (mmHoldExtend *StockRepositoryMock) HoldExtendBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldExtend.beforeHoldExtendCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.HoldExtend.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHoldExtend *mStockRepositoryMockHoldExtend) Calls() []*StockRepositoryMockHoldExtendParams {
+	mmHoldExtend.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockHoldExtendParams, len(mmHoldExtend.callArgs))
+	copy(argCopy, mmHoldExtend.callArgs)
+
+	mmHoldExtend.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHoldExtendDone returns true if the count of the HoldExtend invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockHoldExtendDone() bool {
+	if m.HoldExtendMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HoldExtendMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HoldExtendMock.invocationsDone()
+}
+
+// MinimockHoldExtendInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockHoldExtendInspect() {
+	for _, e := range m.HoldExtendMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldExtend with params: %#v", *e.params)
+		}
+	}
+
+	afterHoldExtendCounter := mm_atomic.LoadUint64(&m.afterHoldExtendCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HoldExtendMock.defaultExpectation != nil && afterHoldExtendCounter < 1 {
+		if m.HoldExtendMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.HoldExtend")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldExtend with params: %#v", *m.HoldExtendMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHoldExtend != nil && afterHoldExtendCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.HoldExtend")
+	}
+
+	if !m.HoldExtendMock.invocationsDone() && afterHoldExtendCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.HoldExtend but found %d calls",
+			mm_atomic.LoadUint64(&m.HoldExtendMock.expectedInvocations), afterHoldExtendCounter)
+	}
+}
+
+type mStockRepositoryMockHoldRelease struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockHoldReleaseExpectation
+	expectations       []*StockRepositoryMockHoldReleaseExpectation
+
+	callArgs []*StockRepositoryMockHoldReleaseParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockHoldReleaseExpectation specifies expectation struct of the stockRepository.HoldRelease
+type StockRepositoryMockHoldReleaseExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockHoldReleaseParams
+	paramPtrs *StockRepositoryMockHoldReleaseParamPtrs
+	results   *StockRepositoryMockHoldReleaseResults
+	Counter   uint64
+}
+
+// StockRepositoryMockHoldReleaseParams contains parameters of the stockRepository.HoldRelease
+type StockRepositoryMockHoldReleaseParams struct {
+	ctx context.Context
+	u1  model.UserID
+	h1  model.HoldID
+}
+
+// StockRepositoryMockHoldReleaseParamPtrs contains pointers to parameters of the stockRepository.HoldRelease
+type StockRepositoryMockHoldReleaseParamPtrs struct {
+	ctx *context.Context
+	u1  *model.UserID
+	h1  *model.HoldID
+}
+
+// StockRepositoryMockHoldReleaseResults contains results of the stockRepository.HoldRelease
+type StockRepositoryMockHoldReleaseResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Optional() *mStockRepositoryMockHoldRelease {
+	mmHoldRelease.optional = true
+	return mmHoldRelease
+}
+
+// Expect sets up expected params for stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Expect(ctx context.Context, u1 model.UserID, h1 model.HoldID) *mStockRepositoryMockHoldRelease {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	if mmHoldRelease.defaultExpectation == nil {
+		mmHoldRelease.defaultExpectation = &StockRepositoryMockHoldReleaseExpectation{}
+	}
+
+	if mmHoldRelease.defaultExpectation.paramPtrs != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by ExpectParams functions")
+	}
+
+	mmHoldRelease.defaultExpectation.params = &StockRepositoryMockHoldReleaseParams{ctx, u1, h1}
+	for _, e := range mmHoldRelease.expectations {
+		if minimock.Equal(e.params, mmHoldRelease.defaultExpectation.params) {
+			mmHoldRelease.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmHoldRelease.defaultExpectation.params)
+		}
+	}
+
+	return mmHoldRelease
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockHoldRelease {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	if mmHoldRelease.defaultExpectation == nil {
+		mmHoldRelease.defaultExpectation = &StockRepositoryMockHoldReleaseExpectation{}
+	}
+
+	if mmHoldRelease.defaultExpectation.params != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Expect")
+	}
+
+	if mmHoldRelease.defaultExpectation.paramPtrs == nil {
+		mmHoldRelease.defaultExpectation.paramPtrs = &StockRepositoryMockHoldReleaseParamPtrs{}
+	}
+	mmHoldRelease.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmHoldRelease
+}
+
+// ExpectU1Param2 sets up expected param u1 for stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) ExpectU1Param2(u1 model.UserID) *mStockRepositoryMockHoldRelease {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	if mmHoldRelease.defaultExpectation == nil {
+		mmHoldRelease.defaultExpectation = &StockRepositoryMockHoldReleaseExpectation{}
+	}
+
+	if mmHoldRelease.defaultExpectation.params != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Expect")
+	}
+
+	if mmHoldRelease.defaultExpectation.paramPtrs == nil {
+		mmHoldRelease.defaultExpectation.paramPtrs = &StockRepositoryMockHoldReleaseParamPtrs{}
+	}
+	mmHoldRelease.defaultExpectation.paramPtrs.u1 = &u1
+
+	return mmHoldRelease
+}
+
+// ExpectH1Param3 sets up expected param h1 for stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) ExpectH1Param3(h1 model.HoldID) *mStockRepositoryMockHoldRelease {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	if mmHoldRelease.defaultExpectation == nil {
+		mmHoldRelease.defaultExpectation = &StockRepositoryMockHoldReleaseExpectation{}
+	}
+
+	if mmHoldRelease.defaultExpectation.params != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Expect")
+	}
+
+	if mmHoldRelease.defaultExpectation.paramPtrs == nil {
+		mmHoldRelease.defaultExpectation.paramPtrs = &StockRepositoryMockHoldReleaseParamPtrs{}
+	}
+	mmHoldRelease.defaultExpectation.paramPtrs.h1 = &h1
+
+	return mmHoldRelease
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Inspect(f func(ctx context.Context, u1 model.UserID, h1 model.HoldID)) *mStockRepositoryMockHoldRelease {
+	if mmHoldRelease.mock.inspectFuncHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.HoldRelease")
+	}
+
+	mmHoldRelease.mock.inspectFuncHoldRelease = f
+
+	return mmHoldRelease
+}
+
+// Return sets up results that will be returned by stockRepository.HoldRelease
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Return(err error) *StockRepositoryMock {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	if mmHoldRelease.defaultExpectation == nil {
+		mmHoldRelease.defaultExpectation = &StockRepositoryMockHoldReleaseExpectation{mock: mmHoldRelease.mock}
+	}
+	mmHoldRelease.defaultExpectation.results = &StockRepositoryMockHoldReleaseResults{err}
+	return mmHoldRelease.mock
+}
+
+// Set uses given function f to mock the stockRepository.HoldRelease method
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Set(f func(ctx context.Context, u1 model.UserID, h1 model.HoldID) (err error)) *StockRepositoryMock {
+	if mmHoldRelease.defaultExpectation != nil {
+		mmHoldRelease.mock.t.Fatalf("Default expectation is already set for the stockRepository.HoldRelease method")
+	}
+
+	if len(mmHoldRelease.expectations) > 0 {
+		mmHoldRelease.mock.t.Fatalf("Some expectations are already set for the stockRepository.HoldRelease method")
+	}
+
+	mmHoldRelease.mock.funcHoldRelease = f
+	return mmHoldRelease.mock
+}
+
+// When sets expectation for the stockRepository.HoldRelease which will trigger the result defined by the following
+// Then helper
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) When(ctx context.Context, u1 model.UserID, h1 model.HoldID) *StockRepositoryMockHoldReleaseExpectation {
+	if mmHoldRelease.mock.funcHoldRelease != nil {
+		mmHoldRelease.mock.t.Fatalf("StockRepositoryMock.HoldRelease mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockHoldReleaseExpectation{
+		mock:   mmHoldRelease.mock,
+		params: &StockRepositoryMockHoldReleaseParams{ctx, u1, h1},
+	}
+	mmHoldRelease.expectations = append(mmHoldRelease.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.HoldRelease return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockHoldReleaseExpectation) Then(err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockHoldReleaseResults{err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.HoldRelease should be invoked
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Times(n uint64) *mStockRepositoryMockHoldRelease {
+	if n == 0 {
+		mmHoldRelease.mock.t.Fatalf("Times of StockRepositoryMock.HoldRelease mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmHoldRelease.expectedInvocations, n)
+	return mmHoldRelease
+}
+
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) invocationsDone() bool {
+	if len(mmHoldRelease.expectations) == 0 && mmHoldRelease.defaultExpectation == nil && mmHoldRelease.mock.funcHoldRelease == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmHoldRelease.mock.afterHoldReleaseCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmHoldRelease.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// HoldRelease implements service.stockRepository
+func (mmHoldRelease *StockRepositoryMock) HoldRelease(ctx context.Context, u1 model.UserID, h1 model.HoldID) (err error) {
+	mm_atomic.AddUint64(&mmHoldRelease.beforeHoldReleaseCounter, 1)
+	defer mm_atomic.AddUint64(&mmHoldRelease.afterHoldReleaseCounter, 1)
+
+	if mmHoldRelease.inspectFuncHoldRelease != nil {
+		mmHoldRelease.inspectFuncHoldRelease(ctx, u1, h1)
+	}
+
+	mm_params := StockRepositoryMockHoldReleaseParams{ctx, u1, h1}
+
+	// Record call args
+	mmHoldRelease.HoldReleaseMock.mutex.Lock()
+	mmHoldRelease.HoldReleaseMock.callArgs = append(mmHoldRelease.HoldReleaseMock.callArgs, &mm_params)
+	mmHoldRelease.HoldReleaseMock.mutex.Unlock()
+
+	for _, e := range mmHoldRelease.HoldReleaseMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmHoldRelease.HoldReleaseMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmHoldRelease.HoldReleaseMock.defaultExpectation.Counter, 1)
+		mm_want := mmHoldRelease.HoldReleaseMock.defaultExpectation.params
+		mm_want_ptrs := mmHoldRelease.HoldReleaseMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockHoldReleaseParams{ctx, u1, h1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmHoldRelease.t.Errorf("StockRepositoryMock.HoldRelease got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
+				mmHoldRelease.t.Errorf("StockRepositoryMock.HoldRelease got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			}
+
+			if mm_want_ptrs.h1 != nil && !minimock.Equal(*mm_want_ptrs.h1, mm_got.h1) {
+				mmHoldRelease.t.Errorf("StockRepositoryMock.HoldRelease got unexpected parameter h1, want: %#v, got: %#v%s\n", *mm_want_ptrs.h1, mm_got.h1, minimock.Diff(*mm_want_ptrs.h1, mm_got.h1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmHoldRelease.t.Errorf("StockRepositoryMock.HoldRelease got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmHoldRelease.HoldReleaseMock.defaultExpectation.results
+		if mm_results == nil {
+			mmHoldRelease.t.Fatal("No results are set for the StockRepositoryMock.HoldRelease")
+		}
+		return (*mm_results).err
+	}
+	if mmHoldRelease.funcHoldRelease != nil {
+		return mmHoldRelease.funcHoldRelease(ctx, u1, h1)
+	}
+	mmHoldRelease.t.Fatalf("Unexpected call to StockRepositoryMock.HoldRelease. %v %v %v", ctx, u1, h1)
+	return
+}
+
+// HoldReleaseAfterCounter returns a count of finished StockRepositoryMock.HoldRelease invocations
+func (mmHoldRelease *StockRepositoryMock) HoldReleaseAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldRelease.afterHoldReleaseCounter)
+}
+
+// HoldReleaseBeforeCounter returns a count of StockRepositoryMock.HoldRelease invocations
+func (mmHoldRelease *StockRepositoryMock) HoldReleaseBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmHoldRelease.beforeHoldReleaseCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.HoldRelease.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmHoldRelease *mStockRepositoryMockHoldRelease) Calls() []*StockRepositoryMockHoldReleaseParams {
+	mmHoldRelease.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockHoldReleaseParams, len(mmHoldRelease.callArgs))
+	copy(argCopy, mmHoldRelease.callArgs)
+
+	mmHoldRelease.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockHoldReleaseDone returns true if the count of the HoldRelease invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockHoldReleaseDone() bool {
+	if m.HoldReleaseMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.HoldReleaseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.HoldReleaseMock.invocationsDone()
+}
+
+// MinimockHoldReleaseInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockHoldReleaseInspect() {
+	for _, e := range m.HoldReleaseMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldRelease with params: %#v", *e.params)
+		}
+	}
+
+	afterHoldReleaseCounter := mm_atomic.LoadUint64(&m.afterHoldReleaseCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.HoldReleaseMock.defaultExpectation != nil && afterHoldReleaseCounter < 1 {
+		if m.HoldReleaseMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.HoldRelease")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.HoldRelease with params: %#v", *m.HoldReleaseMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcHoldRelease != nil && afterHoldReleaseCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.HoldRelease")
+	}
+
+	if !m.HoldReleaseMock.invocationsDone() && afterHoldReleaseCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.HoldRelease but found %d calls",
+			mm_atomic.LoadUint64(&m.HoldReleaseMock.expectedInvocations), afterHoldReleaseCounter)
+	}
+}
+
+type mStockRepositoryMockReserve struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockReserveExpectation
+	expectations       []*StockRepositoryMockReserveExpectation
+
+	callArgs []*StockRepositoryMockReserveParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockReserveExpectation specifies expectation struct of the stockRepository.Reserve
+type StockRepositoryMockReserveExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockReserveParams
+	paramPtrs *StockRepositoryMockReserveParamPtrs
+	results   *StockRepositoryMockReserveResults
+	Counter   uint64
+}
+
+// StockRepositoryMockReserveParams contains parameters of the stockRepository.Reserve
+type StockRepositoryMockReserveParams struct {
+	ctx context.Context
+	u1  model.UserID
+	oa1 []model.OrderItem
+	s1  allocation.Strategy
+	lp1 *model.Location
+}
+
+// StockRepositoryMockReserveParamPtrs contains pointers to parameters of the stockRepository.Reserve
+type StockRepositoryMockReserveParamPtrs struct {
+	ctx *context.Context
+	u1  *model.UserID
+	oa1 *[]model.OrderItem
+	s1  *allocation.Strategy
+	lp1 **model.Location
+}
+
+// StockRepositoryMockReserveResults contains results of the stockRepository.Reserve
+type StockRepositoryMockReserveResults struct {
+	oa2 []model.OrderItem
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReserve *mStockRepositoryMockReserve) Optional() *mStockRepositoryMockReserve {
+	mmReserve.optional = true
+	return mmReserve
+}
+
+// Expect sets up expected params for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location) *mStockRepositoryMockReserve {
+	if mmReserve.mock.funcReserve != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
+	}
+
+	if mmReserve.defaultExpectation == nil {
+		mmReserve.defaultExpectation = &StockRepositoryMockReserveExpectation{}
+	}
+
+	if mmReserve.defaultExpectation.paramPtrs != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by ExpectParams functions")
+	}
+
+	mmReserve.defaultExpectation.params = &StockRepositoryMockReserveParams{ctx, u1, oa1, s1, lp1}
+	for _, e := range mmReserve.expectations {
+		if minimock.Equal(e.params, mmReserve.defaultExpectation.params) {
+			mmReserve.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserve.defaultExpectation.params)
+		}
+	}
+
+	return mmReserve
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockReserve {
+	if mmReserve.mock.funcReserve != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
+	}
+
+	if mmReserve.defaultExpectation == nil {
+		mmReserve.defaultExpectation = &StockRepositoryMockReserveExpectation{}
+	}
+
+	if mmReserve.defaultExpectation.params != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Expect")
+	}
+
+	if mmReserve.defaultExpectation.paramPtrs == nil {
+		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
+	}
+	mmReserve.defaultExpectation.paramPtrs.ctx = &ctx
+
 	return mmReserve
 }
 
-// Expect sets up expected params for stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, p1 model.ProductSku, u1 uint16) *mStockRepositoryMockReserve {
+// ExpectU1Param2 sets up expected param u1 for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectU1Param2(u1 model.UserID) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -450,22 +3375,20 @@ func (mmReserve *mStockRepositoryMockReserve) Expect(ctx context.Context, p1 mod
 		mmReserve.defaultExpectation = &StockRepositoryMockReserveExpectation{}
 	}
 
-	if mmReserve.defaultExpectation.paramPtrs != nil {
-		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by ExpectParams functions")
+	if mmReserve.defaultExpectation.params != nil {
+		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Expect")
 	}
 
-	mmReserve.defaultExpectation.params = &StockRepositoryMockReserveParams{ctx, p1, u1}
-	for _, e := range mmReserve.expectations {
-		if minimock.Equal(e.params, mmReserve.defaultExpectation.params) {
-			mmReserve.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserve.defaultExpectation.params)
-		}
+	if mmReserve.defaultExpectation.paramPtrs == nil {
+		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
 	}
+	mmReserve.defaultExpectation.paramPtrs.u1 = &u1
 
 	return mmReserve
 }
 
-// ExpectCtxParam1 sets up expected param ctx for stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockReserve {
+// ExpectOa1Param3 sets up expected param oa1 for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectOa1Param3(oa1 []model.OrderItem) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -481,13 +3404,13 @@ func (mmReserve *mStockRepositoryMockReserve) ExpectCtxParam1(ctx context.Contex
 	if mmReserve.defaultExpectation.paramPtrs == nil {
 		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
 	}
-	mmReserve.defaultExpectation.paramPtrs.ctx = &ctx
+	mmReserve.defaultExpectation.paramPtrs.oa1 = &oa1
 
 	return mmReserve
 }
 
-// ExpectP1Param2 sets up expected param p1 for stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockReserve {
+// ExpectS1Param4 sets up expected param s1 for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectS1Param4(s1 allocation.Strategy) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -503,13 +3426,13 @@ func (mmReserve *mStockRepositoryMockReserve) ExpectP1Param2(p1 model.ProductSku
 	if mmReserve.defaultExpectation.paramPtrs == nil {
 		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
 	}
-	mmReserve.defaultExpectation.paramPtrs.p1 = &p1
+	mmReserve.defaultExpectation.paramPtrs.s1 = &s1
 
 	return mmReserve
 }
 
-// ExpectU1Param3 sets up expected param u1 for stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) ExpectU1Param3(u1 uint16) *mStockRepositoryMockReserve {
+// ExpectLp1Param5 sets up expected param lp1 for stockRepository.Reserve
+func (mmReserve *mStockRepositoryMockReserve) ExpectLp1Param5(lp1 *model.Location) *mStockRepositoryMockReserve {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -525,13 +3448,13 @@ func (mmReserve *mStockRepositoryMockReserve) ExpectU1Param3(u1 uint16) *mStockR
 	if mmReserve.defaultExpectation.paramPtrs == nil {
 		mmReserve.defaultExpectation.paramPtrs = &StockRepositoryMockReserveParamPtrs{}
 	}
-	mmReserve.defaultExpectation.paramPtrs.u1 = &u1
+	mmReserve.defaultExpectation.paramPtrs.lp1 = &lp1
 
 	return mmReserve
 }
 
 // Inspect accepts an inspector function that has same arguments as the stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) Inspect(f func(ctx context.Context, p1 model.ProductSku, u1 uint16)) *mStockRepositoryMockReserve {
+func (mmReserve *mStockRepositoryMockReserve) Inspect(f func(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location)) *mStockRepositoryMockReserve {
 	if mmReserve.mock.inspectFuncReserve != nil {
 		mmReserve.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.Reserve")
 	}
@@ -542,7 +3465,7 @@ func (mmReserve *mStockRepositoryMockReserve) Inspect(f func(ctx context.Context
 }
 
 // Return sets up results that will be returned by stockRepository.Reserve
-func (mmReserve *mStockRepositoryMockReserve) Return(err error) *StockRepositoryMock {
+func (mmReserve *mStockRepositoryMockReserve) Return(oa2 []model.OrderItem, err error) *StockRepositoryMock {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
@@ -550,12 +3473,12 @@ func (mmReserve *mStockRepositoryMockReserve) Return(err error) *StockRepository
 	if mmReserve.defaultExpectation == nil {
 		mmReserve.defaultExpectation = &StockRepositoryMockReserveExpectation{mock: mmReserve.mock}
 	}
-	mmReserve.defaultExpectation.results = &StockRepositoryMockReserveResults{err}
+	mmReserve.defaultExpectation.results = &StockRepositoryMockReserveResults{oa2, err}
 	return mmReserve.mock
 }
 
 // Set uses given function f to mock the stockRepository.Reserve method
-func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)) *StockRepositoryMock {
+func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location) (oa2 []model.OrderItem, err error)) *StockRepositoryMock {
 	if mmReserve.defaultExpectation != nil {
 		mmReserve.mock.t.Fatalf("Default expectation is already set for the stockRepository.Reserve method")
 	}
@@ -570,22 +3493,22 @@ func (mmReserve *mStockRepositoryMockReserve) Set(f func(ctx context.Context, p1
 
 // When sets expectation for the stockRepository.Reserve which will trigger the result defined by the following
 // Then helper
-func (mmReserve *mStockRepositoryMockReserve) When(ctx context.Context, p1 model.ProductSku, u1 uint16) *StockRepositoryMockReserveExpectation {
+func (mmReserve *mStockRepositoryMockReserve) When(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location) *StockRepositoryMockReserveExpectation {
 	if mmReserve.mock.funcReserve != nil {
 		mmReserve.mock.t.Fatalf("StockRepositoryMock.Reserve mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockReserveExpectation{
 		mock:   mmReserve.mock,
-		params: &StockRepositoryMockReserveParams{ctx, p1, u1},
+		params: &StockRepositoryMockReserveParams{ctx, u1, oa1, s1, lp1},
 	}
 	mmReserve.expectations = append(mmReserve.expectations, expectation)
 	return expectation
 }
 
 // Then sets up stockRepository.Reserve return parameters for the expectation previously defined by the When method
-func (e *StockRepositoryMockReserveExpectation) Then(err error) *StockRepositoryMock {
-	e.results = &StockRepositoryMockReserveResults{err}
+func (e *StockRepositoryMockReserveExpectation) Then(oa2 []model.OrderItem, err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockReserveResults{oa2, err}
 	return e.mock
 }
 
@@ -610,15 +3533,15 @@ func (mmReserve *mStockRepositoryMockReserve) invocationsDone() bool {
 }
 
 // Reserve implements service.stockRepository
-func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error) {
+func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, u1 model.UserID, oa1 []model.OrderItem, s1 allocation.Strategy, lp1 *model.Location) (oa2 []model.OrderItem, err error) {
 	mm_atomic.AddUint64(&mmReserve.beforeReserveCounter, 1)
 	defer mm_atomic.AddUint64(&mmReserve.afterReserveCounter, 1)
 
 	if mmReserve.inspectFuncReserve != nil {
-		mmReserve.inspectFuncReserve(ctx, p1, u1)
+		mmReserve.inspectFuncReserve(ctx, u1, oa1, s1, lp1)
 	}
 
-	mm_params := StockRepositoryMockReserveParams{ctx, p1, u1}
+	mm_params := StockRepositoryMockReserveParams{ctx, u1, oa1, s1, lp1}
 
 	// Record call args
 	mmReserve.ReserveMock.mutex.Lock()
@@ -628,7 +3551,7 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, p1 model.Prod
 	for _, e := range mmReserve.ReserveMock.expectations {
 		if minimock.Equal(*e.params, mm_params) {
 			mm_atomic.AddUint64(&e.Counter, 1)
-			return e.results.err
+			return e.results.oa2, e.results.err
 		}
 	}
 
@@ -637,7 +3560,7 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, p1 model.Prod
 		mm_want := mmReserve.ReserveMock.defaultExpectation.params
 		mm_want_ptrs := mmReserve.ReserveMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockReserveParams{ctx, p1, u1}
+		mm_got := StockRepositoryMockReserveParams{ctx, u1, oa1, s1, lp1}
 
 		if mm_want_ptrs != nil {
 
@@ -645,14 +3568,22 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, p1 model.Prod
 				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
-				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
-			}
-
 			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
 				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
 			}
 
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
+			}
+
+			if mm_want_ptrs.s1 != nil && !minimock.Equal(*mm_want_ptrs.s1, mm_got.s1) {
+				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter s1, want: %#v, got: %#v%s\n", *mm_want_ptrs.s1, mm_got.s1, minimock.Diff(*mm_want_ptrs.s1, mm_got.s1))
+			}
+
+			if mm_want_ptrs.lp1 != nil && !minimock.Equal(*mm_want_ptrs.lp1, mm_got.lp1) {
+				mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameter lp1, want: %#v, got: %#v%s\n", *mm_want_ptrs.lp1, mm_got.lp1, minimock.Diff(*mm_want_ptrs.lp1, mm_got.lp1))
+			}
+
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
 			mmReserve.t.Errorf("StockRepositoryMock.Reserve got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
 		}
@@ -661,12 +3592,12 @@ func (mmReserve *StockRepositoryMock) Reserve(ctx context.Context, p1 model.Prod
 		if mm_results == nil {
 			mmReserve.t.Fatal("No results are set for the StockRepositoryMock.Reserve")
 		}
-		return (*mm_results).err
+		return (*mm_results).oa2, (*mm_results).err
 	}
 	if mmReserve.funcReserve != nil {
-		return mmReserve.funcReserve(ctx, p1, u1)
+		return mmReserve.funcReserve(ctx, u1, oa1, s1, lp1)
 	}
-	mmReserve.t.Fatalf("Unexpected call to StockRepositoryMock.Reserve. %v %v %v", ctx, p1, u1)
+	mmReserve.t.Fatalf("Unexpected call to StockRepositoryMock.Reserve. %v %v %v %v %v", ctx, u1, oa1, s1, lp1)
 	return
 }
 
@@ -738,6 +3669,326 @@ func (m *StockRepositoryMock) MinimockReserveInspect() {
 	}
 }
 
+type mStockRepositoryMockReserveAt struct {
+	optional           bool
+	mock               *StockRepositoryMock
+	defaultExpectation *StockRepositoryMockReserveAtExpectation
+	expectations       []*StockRepositoryMockReserveAtExpectation
+
+	callArgs []*StockRepositoryMockReserveAtParams
+	mutex    sync.RWMutex
+
+	expectedInvocations uint64
+}
+
+// StockRepositoryMockReserveAtExpectation specifies expectation struct of the stockRepository.ReserveAt
+type StockRepositoryMockReserveAtExpectation struct {
+	mock      *StockRepositoryMock
+	params    *StockRepositoryMockReserveAtParams
+	paramPtrs *StockRepositoryMockReserveAtParamPtrs
+	results   *StockRepositoryMockReserveAtResults
+	Counter   uint64
+}
+
+// StockRepositoryMockReserveAtParams contains parameters of the stockRepository.ReserveAt
+type StockRepositoryMockReserveAtParams struct {
+	ctx context.Context
+	oa1 []model.OrderItem
+}
+
+// StockRepositoryMockReserveAtParamPtrs contains pointers to parameters of the stockRepository.ReserveAt
+type StockRepositoryMockReserveAtParamPtrs struct {
+	ctx *context.Context
+	oa1 *[]model.OrderItem
+}
+
+// StockRepositoryMockReserveAtResults contains results of the stockRepository.ReserveAt
+type StockRepositoryMockReserveAtResults struct {
+	err error
+}
+
+// Marks this method to be optional. The default behavior of any method with Return() is '1 or more', meaning
+// the test will fail minimock's automatic final call check if the mocked method was not called at least once.
+// Optional() makes method check to work in '0 or more' mode.
+// It is NOT RECOMMENDED to use this option by default unless you really need it, as it helps to
+// catch the problems when the expected method call is totally skipped during test run.
+func (mmReserveAt *mStockRepositoryMockReserveAt) Optional() *mStockRepositoryMockReserveAt {
+	mmReserveAt.optional = true
+	return mmReserveAt
+}
+
+// Expect sets up expected params for stockRepository.ReserveAt
+func (mmReserveAt *mStockRepositoryMockReserveAt) Expect(ctx context.Context, oa1 []model.OrderItem) *mStockRepositoryMockReserveAt {
+	if mmReserveAt.mock.funcReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Set")
+	}
+
+	if mmReserveAt.defaultExpectation == nil {
+		mmReserveAt.defaultExpectation = &StockRepositoryMockReserveAtExpectation{}
+	}
+
+	if mmReserveAt.defaultExpectation.paramPtrs != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by ExpectParams functions")
+	}
+
+	mmReserveAt.defaultExpectation.params = &StockRepositoryMockReserveAtParams{ctx, oa1}
+	for _, e := range mmReserveAt.expectations {
+		if minimock.Equal(e.params, mmReserveAt.defaultExpectation.params) {
+			mmReserveAt.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserveAt.defaultExpectation.params)
+		}
+	}
+
+	return mmReserveAt
+}
+
+// ExpectCtxParam1 sets up expected param ctx for stockRepository.ReserveAt
+func (mmReserveAt *mStockRepositoryMockReserveAt) ExpectCtxParam1(ctx context.Context) *mStockRepositoryMockReserveAt {
+	if mmReserveAt.mock.funcReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Set")
+	}
+
+	if mmReserveAt.defaultExpectation == nil {
+		mmReserveAt.defaultExpectation = &StockRepositoryMockReserveAtExpectation{}
+	}
+
+	if mmReserveAt.defaultExpectation.params != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Expect")
+	}
+
+	if mmReserveAt.defaultExpectation.paramPtrs == nil {
+		mmReserveAt.defaultExpectation.paramPtrs = &StockRepositoryMockReserveAtParamPtrs{}
+	}
+	mmReserveAt.defaultExpectation.paramPtrs.ctx = &ctx
+
+	return mmReserveAt
+}
+
+// ExpectOa1Param2 sets up expected param oa1 for stockRepository.ReserveAt
+func (mmReserveAt *mStockRepositoryMockReserveAt) ExpectOa1Param2(oa1 []model.OrderItem) *mStockRepositoryMockReserveAt {
+	if mmReserveAt.mock.funcReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Set")
+	}
+
+	if mmReserveAt.defaultExpectation == nil {
+		mmReserveAt.defaultExpectation = &StockRepositoryMockReserveAtExpectation{}
+	}
+
+	if mmReserveAt.defaultExpectation.params != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Expect")
+	}
+
+	if mmReserveAt.defaultExpectation.paramPtrs == nil {
+		mmReserveAt.defaultExpectation.paramPtrs = &StockRepositoryMockReserveAtParamPtrs{}
+	}
+	mmReserveAt.defaultExpectation.paramPtrs.oa1 = &oa1
+
+	return mmReserveAt
+}
+
+// Inspect accepts an inspector function that has same arguments as the stockRepository.ReserveAt
+func (mmReserveAt *mStockRepositoryMockReserveAt) Inspect(f func(ctx context.Context, oa1 []model.OrderItem)) *mStockRepositoryMockReserveAt {
+	if mmReserveAt.mock.inspectFuncReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ReserveAt")
+	}
+
+	mmReserveAt.mock.inspectFuncReserveAt = f
+
+	return mmReserveAt
+}
+
+// Return sets up results that will be returned by stockRepository.ReserveAt
+func (mmReserveAt *mStockRepositoryMockReserveAt) Return(err error) *StockRepositoryMock {
+	if mmReserveAt.mock.funcReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Set")
+	}
+
+	if mmReserveAt.defaultExpectation == nil {
+		mmReserveAt.defaultExpectation = &StockRepositoryMockReserveAtExpectation{mock: mmReserveAt.mock}
+	}
+	mmReserveAt.defaultExpectation.results = &StockRepositoryMockReserveAtResults{err}
+	return mmReserveAt.mock
+}
+
+// Set uses given function f to mock the stockRepository.ReserveAt method
+func (mmReserveAt *mStockRepositoryMockReserveAt) Set(f func(ctx context.Context, oa1 []model.OrderItem) (err error)) *StockRepositoryMock {
+	if mmReserveAt.defaultExpectation != nil {
+		mmReserveAt.mock.t.Fatalf("Default expectation is already set for the stockRepository.ReserveAt method")
+	}
+
+	if len(mmReserveAt.expectations) > 0 {
+		mmReserveAt.mock.t.Fatalf("Some expectations are already set for the stockRepository.ReserveAt method")
+	}
+
+	mmReserveAt.mock.funcReserveAt = f
+	return mmReserveAt.mock
+}
+
+// When sets expectation for the stockRepository.ReserveAt which will trigger the result defined by the following
+// Then helper
+func (mmReserveAt *mStockRepositoryMockReserveAt) When(ctx context.Context, oa1 []model.OrderItem) *StockRepositoryMockReserveAtExpectation {
+	if mmReserveAt.mock.funcReserveAt != nil {
+		mmReserveAt.mock.t.Fatalf("StockRepositoryMock.ReserveAt mock is already set by Set")
+	}
+
+	expectation := &StockRepositoryMockReserveAtExpectation{
+		mock:   mmReserveAt.mock,
+		params: &StockRepositoryMockReserveAtParams{ctx, oa1},
+	}
+	mmReserveAt.expectations = append(mmReserveAt.expectations, expectation)
+	return expectation
+}
+
+// Then sets up stockRepository.ReserveAt return parameters for the expectation previously defined by the When method
+func (e *StockRepositoryMockReserveAtExpectation) Then(err error) *StockRepositoryMock {
+	e.results = &StockRepositoryMockReserveAtResults{err}
+	return e.mock
+}
+
+// Times sets number of times stockRepository.ReserveAt should be invoked
+func (mmReserveAt *mStockRepositoryMockReserveAt) Times(n uint64) *mStockRepositoryMockReserveAt {
+	if n == 0 {
+		mmReserveAt.mock.t.Fatalf("Times of StockRepositoryMock.ReserveAt mock can not be zero")
+	}
+	mm_atomic.StoreUint64(&mmReserveAt.expectedInvocations, n)
+	return mmReserveAt
+}
+
+func (mmReserveAt *mStockRepositoryMockReserveAt) invocationsDone() bool {
+	if len(mmReserveAt.expectations) == 0 && mmReserveAt.defaultExpectation == nil && mmReserveAt.mock.funcReserveAt == nil {
+		return true
+	}
+
+	totalInvocations := mm_atomic.LoadUint64(&mmReserveAt.mock.afterReserveAtCounter)
+	expectedInvocations := mm_atomic.LoadUint64(&mmReserveAt.expectedInvocations)
+
+	return totalInvocations > 0 && (expectedInvocations == 0 || expectedInvocations == totalInvocations)
+}
+
+// ReserveAt implements service.stockRepository
+func (mmReserveAt *StockRepositoryMock) ReserveAt(ctx context.Context, oa1 []model.OrderItem) (err error) {
+	mm_atomic.AddUint64(&mmReserveAt.beforeReserveAtCounter, 1)
+	defer mm_atomic.AddUint64(&mmReserveAt.afterReserveAtCounter, 1)
+
+	if mmReserveAt.inspectFuncReserveAt != nil {
+		mmReserveAt.inspectFuncReserveAt(ctx, oa1)
+	}
+
+	mm_params := StockRepositoryMockReserveAtParams{ctx, oa1}
+
+	// Record call args
+	mmReserveAt.ReserveAtMock.mutex.Lock()
+	mmReserveAt.ReserveAtMock.callArgs = append(mmReserveAt.ReserveAtMock.callArgs, &mm_params)
+	mmReserveAt.ReserveAtMock.mutex.Unlock()
+
+	for _, e := range mmReserveAt.ReserveAtMock.expectations {
+		if minimock.Equal(*e.params, mm_params) {
+			mm_atomic.AddUint64(&e.Counter, 1)
+			return e.results.err
+		}
+	}
+
+	if mmReserveAt.ReserveAtMock.defaultExpectation != nil {
+		mm_atomic.AddUint64(&mmReserveAt.ReserveAtMock.defaultExpectation.Counter, 1)
+		mm_want := mmReserveAt.ReserveAtMock.defaultExpectation.params
+		mm_want_ptrs := mmReserveAt.ReserveAtMock.defaultExpectation.paramPtrs
+
+		mm_got := StockRepositoryMockReserveAtParams{ctx, oa1}
+
+		if mm_want_ptrs != nil {
+
+			if mm_want_ptrs.ctx != nil && !minimock.Equal(*mm_want_ptrs.ctx, mm_got.ctx) {
+				mmReserveAt.t.Errorf("StockRepositoryMock.ReserveAt got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
+			}
+
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmReserveAt.t.Errorf("StockRepositoryMock.ReserveAt got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
+			}
+
+		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
+			mmReserveAt.t.Errorf("StockRepositoryMock.ReserveAt got unexpected parameters, want: %#v, got: %#v%s\n", *mm_want, mm_got, minimock.Diff(*mm_want, mm_got))
+		}
+
+		mm_results := mmReserveAt.ReserveAtMock.defaultExpectation.results
+		if mm_results == nil {
+			mmReserveAt.t.Fatal("No results are set for the StockRepositoryMock.ReserveAt")
+		}
+		return (*mm_results).err
+	}
+	if mmReserveAt.funcReserveAt != nil {
+		return mmReserveAt.funcReserveAt(ctx, oa1)
+	}
+	mmReserveAt.t.Fatalf("Unexpected call to StockRepositoryMock.ReserveAt. %v %v", ctx, oa1)
+	return
+}
+
+// ReserveAtAfterCounter returns a count of finished StockRepositoryMock.ReserveAt invocations
+func (mmReserveAt *StockRepositoryMock) ReserveAtAfterCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReserveAt.afterReserveAtCounter)
+}
+
+// ReserveAtBeforeCounter returns a count of StockRepositoryMock.ReserveAt invocations
+func (mmReserveAt *StockRepositoryMock) ReserveAtBeforeCounter() uint64 {
+	return mm_atomic.LoadUint64(&mmReserveAt.beforeReserveAtCounter)
+}
+
+// Calls returns a list of arguments used in each call to StockRepositoryMock.ReserveAt.
+// The list is in the same order as the calls were made (i.e. recent calls have a higher index)
+func (mmReserveAt *mStockRepositoryMockReserveAt) Calls() []*StockRepositoryMockReserveAtParams {
+	mmReserveAt.mutex.RLock()
+
+	argCopy := make([]*StockRepositoryMockReserveAtParams, len(mmReserveAt.callArgs))
+	copy(argCopy, mmReserveAt.callArgs)
+
+	mmReserveAt.mutex.RUnlock()
+
+	return argCopy
+}
+
+// MinimockReserveAtDone returns true if the count of the ReserveAt invocations corresponds
+// the number of defined expectations
+func (m *StockRepositoryMock) MinimockReserveAtDone() bool {
+	if m.ReserveAtMock.optional {
+		// Optional methods provide '0 or more' call count restriction.
+		return true
+	}
+
+	for _, e := range m.ReserveAtMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			return false
+		}
+	}
+
+	return m.ReserveAtMock.invocationsDone()
+}
+
+// MinimockReserveAtInspect logs each unmet expectation
+func (m *StockRepositoryMock) MinimockReserveAtInspect() {
+	for _, e := range m.ReserveAtMock.expectations {
+		if mm_atomic.LoadUint64(&e.Counter) < 1 {
+			m.t.Errorf("Expected call to StockRepositoryMock.ReserveAt with params: %#v", *e.params)
+		}
+	}
+
+	afterReserveAtCounter := mm_atomic.LoadUint64(&m.afterReserveAtCounter)
+	// if default expectation was set then invocations count should be greater than zero
+	if m.ReserveAtMock.defaultExpectation != nil && afterReserveAtCounter < 1 {
+		if m.ReserveAtMock.defaultExpectation.params == nil {
+			m.t.Error("Expected call to StockRepositoryMock.ReserveAt")
+		} else {
+			m.t.Errorf("Expected call to StockRepositoryMock.ReserveAt with params: %#v", *m.ReserveAtMock.defaultExpectation.params)
+		}
+	}
+	// if func was set then invocations count should be greater than zero
+	if m.funcReserveAt != nil && afterReserveAtCounter < 1 {
+		m.t.Error("Expected call to StockRepositoryMock.ReserveAt")
+	}
+
+	if !m.ReserveAtMock.invocationsDone() && afterReserveAtCounter > 0 {
+		m.t.Errorf("Expected %d calls to StockRepositoryMock.ReserveAt but found %d calls",
+			mm_atomic.LoadUint64(&m.ReserveAtMock.expectedInvocations), afterReserveAtCounter)
+	}
+}
+
 type mStockRepositoryMockReserveCancel struct {
 	optional           bool
 	mock               *StockRepositoryMock
@@ -762,15 +4013,13 @@ type StockRepositoryMockReserveCancelExpectation struct {
 // StockRepositoryMockReserveCancelParams contains parameters of the stockRepository.ReserveCancel
 type StockRepositoryMockReserveCancelParams struct {
 	ctx context.Context
-	p1  model.ProductSku
-	u1  uint16
+	oa1 []model.OrderItem
 }
 
 // StockRepositoryMockReserveCancelParamPtrs contains pointers to parameters of the stockRepository.ReserveCancel
 type StockRepositoryMockReserveCancelParamPtrs struct {
 	ctx *context.Context
-	p1  *model.ProductSku
-	u1  *uint16
+	oa1 *[]model.OrderItem
 }
 
 // StockRepositoryMockReserveCancelResults contains results of the stockRepository.ReserveCancel
@@ -789,7 +4038,7 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) Optional() *mStockRepo
 }
 
 // Expect sets up expected params for stockRepository.ReserveCancel
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) Expect(ctx context.Context, p1 model.ProductSku, u1 uint16) *mStockRepositoryMockReserveCancel {
+func (mmReserveCancel *mStockRepositoryMockReserveCancel) Expect(ctx context.Context, oa1 []model.OrderItem) *mStockRepositoryMockReserveCancel {
 	if mmReserveCancel.mock.funcReserveCancel != nil {
 		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by Set")
 	}
@@ -802,7 +4051,7 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) Expect(ctx context.Con
 		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by ExpectParams functions")
 	}
 
-	mmReserveCancel.defaultExpectation.params = &StockRepositoryMockReserveCancelParams{ctx, p1, u1}
+	mmReserveCancel.defaultExpectation.params = &StockRepositoryMockReserveCancelParams{ctx, oa1}
 	for _, e := range mmReserveCancel.expectations {
 		if minimock.Equal(e.params, mmReserveCancel.defaultExpectation.params) {
 			mmReserveCancel.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserveCancel.defaultExpectation.params)
@@ -834,30 +4083,8 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) ExpectCtxParam1(ctx co
 	return mmReserveCancel
 }
 
-// ExpectP1Param2 sets up expected param p1 for stockRepository.ReserveCancel
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockReserveCancel {
-	if mmReserveCancel.mock.funcReserveCancel != nil {
-		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by Set")
-	}
-
-	if mmReserveCancel.defaultExpectation == nil {
-		mmReserveCancel.defaultExpectation = &StockRepositoryMockReserveCancelExpectation{}
-	}
-
-	if mmReserveCancel.defaultExpectation.params != nil {
-		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by Expect")
-	}
-
-	if mmReserveCancel.defaultExpectation.paramPtrs == nil {
-		mmReserveCancel.defaultExpectation.paramPtrs = &StockRepositoryMockReserveCancelParamPtrs{}
-	}
-	mmReserveCancel.defaultExpectation.paramPtrs.p1 = &p1
-
-	return mmReserveCancel
-}
-
-// ExpectU1Param3 sets up expected param u1 for stockRepository.ReserveCancel
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) ExpectU1Param3(u1 uint16) *mStockRepositoryMockReserveCancel {
+// ExpectOa1Param2 sets up expected param oa1 for stockRepository.ReserveCancel
+func (mmReserveCancel *mStockRepositoryMockReserveCancel) ExpectOa1Param2(oa1 []model.OrderItem) *mStockRepositoryMockReserveCancel {
 	if mmReserveCancel.mock.funcReserveCancel != nil {
 		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by Set")
 	}
@@ -873,13 +4100,13 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) ExpectU1Param3(u1 uint
 	if mmReserveCancel.defaultExpectation.paramPtrs == nil {
 		mmReserveCancel.defaultExpectation.paramPtrs = &StockRepositoryMockReserveCancelParamPtrs{}
 	}
-	mmReserveCancel.defaultExpectation.paramPtrs.u1 = &u1
+	mmReserveCancel.defaultExpectation.paramPtrs.oa1 = &oa1
 
 	return mmReserveCancel
 }
 
 // Inspect accepts an inspector function that has same arguments as the stockRepository.ReserveCancel
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) Inspect(f func(ctx context.Context, p1 model.ProductSku, u1 uint16)) *mStockRepositoryMockReserveCancel {
+func (mmReserveCancel *mStockRepositoryMockReserveCancel) Inspect(f func(ctx context.Context, oa1 []model.OrderItem)) *mStockRepositoryMockReserveCancel {
 	if mmReserveCancel.mock.inspectFuncReserveCancel != nil {
 		mmReserveCancel.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ReserveCancel")
 	}
@@ -903,7 +4130,7 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) Return(err error) *Sto
 }
 
 // Set uses given function f to mock the stockRepository.ReserveCancel method
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) Set(f func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)) *StockRepositoryMock {
+func (mmReserveCancel *mStockRepositoryMockReserveCancel) Set(f func(ctx context.Context, oa1 []model.OrderItem) (err error)) *StockRepositoryMock {
 	if mmReserveCancel.defaultExpectation != nil {
 		mmReserveCancel.mock.t.Fatalf("Default expectation is already set for the stockRepository.ReserveCancel method")
 	}
@@ -918,14 +4145,14 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) Set(f func(ctx context
 
 // When sets expectation for the stockRepository.ReserveCancel which will trigger the result defined by the following
 // Then helper
-func (mmReserveCancel *mStockRepositoryMockReserveCancel) When(ctx context.Context, p1 model.ProductSku, u1 uint16) *StockRepositoryMockReserveCancelExpectation {
+func (mmReserveCancel *mStockRepositoryMockReserveCancel) When(ctx context.Context, oa1 []model.OrderItem) *StockRepositoryMockReserveCancelExpectation {
 	if mmReserveCancel.mock.funcReserveCancel != nil {
 		mmReserveCancel.mock.t.Fatalf("StockRepositoryMock.ReserveCancel mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockReserveCancelExpectation{
 		mock:   mmReserveCancel.mock,
-		params: &StockRepositoryMockReserveCancelParams{ctx, p1, u1},
+		params: &StockRepositoryMockReserveCancelParams{ctx, oa1},
 	}
 	mmReserveCancel.expectations = append(mmReserveCancel.expectations, expectation)
 	return expectation
@@ -958,15 +4185,15 @@ func (mmReserveCancel *mStockRepositoryMockReserveCancel) invocationsDone() bool
 }
 
 // ReserveCancel implements service.stockRepository
-func (mmReserveCancel *StockRepositoryMock) ReserveCancel(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error) {
+func (mmReserveCancel *StockRepositoryMock) ReserveCancel(ctx context.Context, oa1 []model.OrderItem) (err error) {
 	mm_atomic.AddUint64(&mmReserveCancel.beforeReserveCancelCounter, 1)
 	defer mm_atomic.AddUint64(&mmReserveCancel.afterReserveCancelCounter, 1)
 
 	if mmReserveCancel.inspectFuncReserveCancel != nil {
-		mmReserveCancel.inspectFuncReserveCancel(ctx, p1, u1)
+		mmReserveCancel.inspectFuncReserveCancel(ctx, oa1)
 	}
 
-	mm_params := StockRepositoryMockReserveCancelParams{ctx, p1, u1}
+	mm_params := StockRepositoryMockReserveCancelParams{ctx, oa1}
 
 	// Record call args
 	mmReserveCancel.ReserveCancelMock.mutex.Lock()
@@ -985,7 +4212,7 @@ func (mmReserveCancel *StockRepositoryMock) ReserveCancel(ctx context.Context, p
 		mm_want := mmReserveCancel.ReserveCancelMock.defaultExpectation.params
 		mm_want_ptrs := mmReserveCancel.ReserveCancelMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockReserveCancelParams{ctx, p1, u1}
+		mm_got := StockRepositoryMockReserveCancelParams{ctx, oa1}
 
 		if mm_want_ptrs != nil {
 
@@ -993,12 +4220,8 @@ func (mmReserveCancel *StockRepositoryMock) ReserveCancel(ctx context.Context, p
 				mmReserveCancel.t.Errorf("StockRepositoryMock.ReserveCancel got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
-				mmReserveCancel.t.Errorf("StockRepositoryMock.ReserveCancel got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
-			}
-
-			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
-				mmReserveCancel.t.Errorf("StockRepositoryMock.ReserveCancel got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmReserveCancel.t.Errorf("StockRepositoryMock.ReserveCancel got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
@@ -1012,9 +4235,9 @@ func (mmReserveCancel *StockRepositoryMock) ReserveCancel(ctx context.Context, p
 		return (*mm_results).err
 	}
 	if mmReserveCancel.funcReserveCancel != nil {
-		return mmReserveCancel.funcReserveCancel(ctx, p1, u1)
+		return mmReserveCancel.funcReserveCancel(ctx, oa1)
 	}
-	mmReserveCancel.t.Fatalf("Unexpected call to StockRepositoryMock.ReserveCancel. %v %v %v", ctx, p1, u1)
+	mmReserveCancel.t.Fatalf("Unexpected call to StockRepositoryMock.ReserveCancel. %v %v", ctx, oa1)
 	return
 }
 
@@ -1110,15 +4333,13 @@ type StockRepositoryMockReserveRemoveExpectation struct {
 // StockRepositoryMockReserveRemoveParams contains parameters of the stockRepository.ReserveRemove
 type StockRepositoryMockReserveRemoveParams struct {
 	ctx context.Context
-	p1  model.ProductSku
-	u1  uint16
+	oa1 []model.OrderItem
 }
 
 // StockRepositoryMockReserveRemoveParamPtrs contains pointers to parameters of the stockRepository.ReserveRemove
 type StockRepositoryMockReserveRemoveParamPtrs struct {
 	ctx *context.Context
-	p1  *model.ProductSku
-	u1  *uint16
+	oa1 *[]model.OrderItem
 }
 
 // StockRepositoryMockReserveRemoveResults contains results of the stockRepository.ReserveRemove
@@ -1137,7 +4358,7 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) Optional() *mStockRepo
 }
 
 // Expect sets up expected params for stockRepository.ReserveRemove
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) Expect(ctx context.Context, p1 model.ProductSku, u1 uint16) *mStockRepositoryMockReserveRemove {
+func (mmReserveRemove *mStockRepositoryMockReserveRemove) Expect(ctx context.Context, oa1 []model.OrderItem) *mStockRepositoryMockReserveRemove {
 	if mmReserveRemove.mock.funcReserveRemove != nil {
 		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by Set")
 	}
@@ -1150,7 +4371,7 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) Expect(ctx context.Con
 		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by ExpectParams functions")
 	}
 
-	mmReserveRemove.defaultExpectation.params = &StockRepositoryMockReserveRemoveParams{ctx, p1, u1}
+	mmReserveRemove.defaultExpectation.params = &StockRepositoryMockReserveRemoveParams{ctx, oa1}
 	for _, e := range mmReserveRemove.expectations {
 		if minimock.Equal(e.params, mmReserveRemove.defaultExpectation.params) {
 			mmReserveRemove.mock.t.Fatalf("Expectation set by When has same params: %#v", *mmReserveRemove.defaultExpectation.params)
@@ -1182,8 +4403,8 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) ExpectCtxParam1(ctx co
 	return mmReserveRemove
 }
 
-// ExpectP1Param2 sets up expected param p1 for stockRepository.ReserveRemove
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) ExpectP1Param2(p1 model.ProductSku) *mStockRepositoryMockReserveRemove {
+// ExpectOa1Param2 sets up expected param oa1 for stockRepository.ReserveRemove
+func (mmReserveRemove *mStockRepositoryMockReserveRemove) ExpectOa1Param2(oa1 []model.OrderItem) *mStockRepositoryMockReserveRemove {
 	if mmReserveRemove.mock.funcReserveRemove != nil {
 		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by Set")
 	}
@@ -1199,35 +4420,13 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) ExpectP1Param2(p1 mode
 	if mmReserveRemove.defaultExpectation.paramPtrs == nil {
 		mmReserveRemove.defaultExpectation.paramPtrs = &StockRepositoryMockReserveRemoveParamPtrs{}
 	}
-	mmReserveRemove.defaultExpectation.paramPtrs.p1 = &p1
-
-	return mmReserveRemove
-}
-
-// ExpectU1Param3 sets up expected param u1 for stockRepository.ReserveRemove
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) ExpectU1Param3(u1 uint16) *mStockRepositoryMockReserveRemove {
-	if mmReserveRemove.mock.funcReserveRemove != nil {
-		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by Set")
-	}
-
-	if mmReserveRemove.defaultExpectation == nil {
-		mmReserveRemove.defaultExpectation = &StockRepositoryMockReserveRemoveExpectation{}
-	}
-
-	if mmReserveRemove.defaultExpectation.params != nil {
-		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by Expect")
-	}
-
-	if mmReserveRemove.defaultExpectation.paramPtrs == nil {
-		mmReserveRemove.defaultExpectation.paramPtrs = &StockRepositoryMockReserveRemoveParamPtrs{}
-	}
-	mmReserveRemove.defaultExpectation.paramPtrs.u1 = &u1
+	mmReserveRemove.defaultExpectation.paramPtrs.oa1 = &oa1
 
 	return mmReserveRemove
 }
 
 // Inspect accepts an inspector function that has same arguments as the stockRepository.ReserveRemove
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) Inspect(f func(ctx context.Context, p1 model.ProductSku, u1 uint16)) *mStockRepositoryMockReserveRemove {
+func (mmReserveRemove *mStockRepositoryMockReserveRemove) Inspect(f func(ctx context.Context, oa1 []model.OrderItem)) *mStockRepositoryMockReserveRemove {
 	if mmReserveRemove.mock.inspectFuncReserveRemove != nil {
 		mmReserveRemove.mock.t.Fatalf("Inspect function is already set for StockRepositoryMock.ReserveRemove")
 	}
@@ -1251,7 +4450,7 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) Return(err error) *Sto
 }
 
 // Set uses given function f to mock the stockRepository.ReserveRemove method
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) Set(f func(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error)) *StockRepositoryMock {
+func (mmReserveRemove *mStockRepositoryMockReserveRemove) Set(f func(ctx context.Context, oa1 []model.OrderItem) (err error)) *StockRepositoryMock {
 	if mmReserveRemove.defaultExpectation != nil {
 		mmReserveRemove.mock.t.Fatalf("Default expectation is already set for the stockRepository.ReserveRemove method")
 	}
@@ -1266,14 +4465,14 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) Set(f func(ctx context
 
 // When sets expectation for the stockRepository.ReserveRemove which will trigger the result defined by the following
 // Then helper
-func (mmReserveRemove *mStockRepositoryMockReserveRemove) When(ctx context.Context, p1 model.ProductSku, u1 uint16) *StockRepositoryMockReserveRemoveExpectation {
+func (mmReserveRemove *mStockRepositoryMockReserveRemove) When(ctx context.Context, oa1 []model.OrderItem) *StockRepositoryMockReserveRemoveExpectation {
 	if mmReserveRemove.mock.funcReserveRemove != nil {
 		mmReserveRemove.mock.t.Fatalf("StockRepositoryMock.ReserveRemove mock is already set by Set")
 	}
 
 	expectation := &StockRepositoryMockReserveRemoveExpectation{
 		mock:   mmReserveRemove.mock,
-		params: &StockRepositoryMockReserveRemoveParams{ctx, p1, u1},
+		params: &StockRepositoryMockReserveRemoveParams{ctx, oa1},
 	}
 	mmReserveRemove.expectations = append(mmReserveRemove.expectations, expectation)
 	return expectation
@@ -1306,15 +4505,15 @@ func (mmReserveRemove *mStockRepositoryMockReserveRemove) invocationsDone() bool
 }
 
 // ReserveRemove implements service.stockRepository
-func (mmReserveRemove *StockRepositoryMock) ReserveRemove(ctx context.Context, p1 model.ProductSku, u1 uint16) (err error) {
+func (mmReserveRemove *StockRepositoryMock) ReserveRemove(ctx context.Context, oa1 []model.OrderItem) (err error) {
 	mm_atomic.AddUint64(&mmReserveRemove.beforeReserveRemoveCounter, 1)
 	defer mm_atomic.AddUint64(&mmReserveRemove.afterReserveRemoveCounter, 1)
 
 	if mmReserveRemove.inspectFuncReserveRemove != nil {
-		mmReserveRemove.inspectFuncReserveRemove(ctx, p1, u1)
+		mmReserveRemove.inspectFuncReserveRemove(ctx, oa1)
 	}
 
-	mm_params := StockRepositoryMockReserveRemoveParams{ctx, p1, u1}
+	mm_params := StockRepositoryMockReserveRemoveParams{ctx, oa1}
 
 	// Record call args
 	mmReserveRemove.ReserveRemoveMock.mutex.Lock()
@@ -1333,7 +4532,7 @@ func (mmReserveRemove *StockRepositoryMock) ReserveRemove(ctx context.Context, p
 		mm_want := mmReserveRemove.ReserveRemoveMock.defaultExpectation.params
 		mm_want_ptrs := mmReserveRemove.ReserveRemoveMock.defaultExpectation.paramPtrs
 
-		mm_got := StockRepositoryMockReserveRemoveParams{ctx, p1, u1}
+		mm_got := StockRepositoryMockReserveRemoveParams{ctx, oa1}
 
 		if mm_want_ptrs != nil {
 
@@ -1341,12 +4540,8 @@ func (mmReserveRemove *StockRepositoryMock) ReserveRemove(ctx context.Context, p
 				mmReserveRemove.t.Errorf("StockRepositoryMock.ReserveRemove got unexpected parameter ctx, want: %#v, got: %#v%s\n", *mm_want_ptrs.ctx, mm_got.ctx, minimock.Diff(*mm_want_ptrs.ctx, mm_got.ctx))
 			}
 
-			if mm_want_ptrs.p1 != nil && !minimock.Equal(*mm_want_ptrs.p1, mm_got.p1) {
-				mmReserveRemove.t.Errorf("StockRepositoryMock.ReserveRemove got unexpected parameter p1, want: %#v, got: %#v%s\n", *mm_want_ptrs.p1, mm_got.p1, minimock.Diff(*mm_want_ptrs.p1, mm_got.p1))
-			}
-
-			if mm_want_ptrs.u1 != nil && !minimock.Equal(*mm_want_ptrs.u1, mm_got.u1) {
-				mmReserveRemove.t.Errorf("StockRepositoryMock.ReserveRemove got unexpected parameter u1, want: %#v, got: %#v%s\n", *mm_want_ptrs.u1, mm_got.u1, minimock.Diff(*mm_want_ptrs.u1, mm_got.u1))
+			if mm_want_ptrs.oa1 != nil && !minimock.Equal(*mm_want_ptrs.oa1, mm_got.oa1) {
+				mmReserveRemove.t.Errorf("StockRepositoryMock.ReserveRemove got unexpected parameter oa1, want: %#v, got: %#v%s\n", *mm_want_ptrs.oa1, mm_got.oa1, minimock.Diff(*mm_want_ptrs.oa1, mm_got.oa1))
 			}
 
 		} else if mm_want != nil && !minimock.Equal(*mm_want, mm_got) {
@@ -1360,9 +4555,9 @@ func (mmReserveRemove *StockRepositoryMock) ReserveRemove(ctx context.Context, p
 		return (*mm_results).err
 	}
 	if mmReserveRemove.funcReserveRemove != nil {
-		return mmReserveRemove.funcReserveRemove(ctx, p1, u1)
+		return mmReserveRemove.funcReserveRemove(ctx, oa1)
 	}
-	mmReserveRemove.t.Fatalf("Unexpected call to StockRepositoryMock.ReserveRemove. %v %v %v", ctx, p1, u1)
+	mmReserveRemove.t.Fatalf("Unexpected call to StockRepositoryMock.ReserveRemove. %v %v", ctx, oa1)
 	return
 }
 
@@ -1438,10 +4633,28 @@ func (m *StockRepositoryMock) MinimockReserveRemoveInspect() {
 func (m *StockRepositoryMock) MinimockFinish() {
 	m.finishOnce.Do(func() {
 		if !m.minimockDone() {
+			m.MinimockChangeStockInspect()
+
+			m.MinimockChangeStocksInspect()
+
+			m.MinimockGetMovementsInspect()
+
 			m.MinimockGetStocksBySkuInspect()
 
+			m.MinimockGetStocksBySkusInspect()
+
+			m.MinimockGetStocksByWarehouseInspect()
+
+			m.MinimockHoldCreateInspect()
+
+			m.MinimockHoldExtendInspect()
+
+			m.MinimockHoldReleaseInspect()
+
 			m.MinimockReserveInspect()
 
+			m.MinimockReserveAtInspect()
+
 			m.MinimockReserveCancelInspect()
 
 			m.MinimockReserveRemoveInspect()
@@ -1469,8 +4682,17 @@ func (m *StockRepositoryMock) MinimockWait(timeout mm_time.Duration) {
 func (m *StockRepositoryMock) minimockDone() bool {
 	done := true
 	return done &&
+		m.MinimockChangeStockDone() &&
+		m.MinimockChangeStocksDone() &&
+		m.MinimockGetMovementsDone() &&
 		m.MinimockGetStocksBySkuDone() &&
+		m.MinimockGetStocksBySkusDone() &&
+		m.MinimockGetStocksByWarehouseDone() &&
+		m.MinimockHoldCreateDone() &&
+		m.MinimockHoldExtendDone() &&
+		m.MinimockHoldReleaseDone() &&
 		m.MinimockReserveDone() &&
+		m.MinimockReserveAtDone() &&
 		m.MinimockReserveCancelDone() &&
 		m.MinimockReserveRemoveDone()
 }
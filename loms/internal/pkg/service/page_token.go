@@ -0,0 +1,45 @@
+package service
+
+import (
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"route256/loms/internal/pkg/model"
+	"time"
+)
+
+// pageToken - содержимое токена страницы. Клиент получает его в base64 и не должен разбирать.
+type pageToken struct {
+	CreatedAt int64 `json:"c"`
+	ID        int64 `json:"i"`
+}
+
+func encodePageToken(cursor model.OrderCursor) string {
+	data, _ := json.Marshal(pageToken{
+		CreatedAt: cursor.CreatedAt.UnixMicro(),
+		ID:        int64(cursor.ID),
+	})
+	return base64.RawURLEncoding.EncodeToString(data)
+}
+
+// decodePageToken возвращает nil для пустого токена - это первая страница.
+func decodePageToken(token string) (*model.OrderCursor, error) {
+	if token == "" {
+		return nil, nil
+	}
+	data, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidPageToken, err)
+	}
+	var t pageToken
+	if err := json.Unmarshal(data, &t); err != nil {
+		return nil, fmt.Errorf("%w: %w", model.ErrInvalidPageToken, err)
+	}
+	if t.ID <= 0 {
+		return nil, fmt.Errorf("%w: order id %d", model.ErrInvalidPageToken, t.ID)
+	}
+	return &model.OrderCursor{
+		CreatedAt: time.UnixMicro(t.CreatedAt).UTC(),
+		ID:        model.OrderID(t.ID),
+	}, nil
+}
@@ -0,0 +1,18 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS stock_holds (
+    id          BIGSERIAL PRIMARY KEY,
+    sku         BIGINT NOT NULL,
+    user_id     BIGINT NOT NULL,
+    count       INT NOT NULL,
+    expires_at  TIMESTAMPTZ NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW(),
+    UNIQUE (user_id, sku)
+);
+CREATE INDEX stock_holds_sku_expires_at_idx ON stock_holds (sku, expires_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE stock_holds;
+-- +goose StatementEnd
@@ -0,0 +1,23 @@
+-- +goose Up
+-- +goose StatementBegin
+ALTER TABLE stock ADD PRIMARY KEY (sku);
+
+CREATE TABLE IF NOT EXISTS stock_movements (
+    id          BIGSERIAL PRIMARY KEY,
+    sku         BIGINT NOT NULL,
+    operation   TEXT NOT NULL,
+    delta       INT NOT NULL,
+    total_count INT NOT NULL,
+    reason      TEXT NOT NULL,
+    actor       TEXT NOT NULL,
+    order_id    BIGINT,
+    created_at  TIMESTAMPTZ NOT NULL DEFAULT NOW()
+);
+CREATE INDEX stock_movements_sku_id_idx ON stock_movements (sku, id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE stock_movements;
+ALTER TABLE stock DROP CONSTRAINT stock_pkey;
+-- +goose StatementEnd
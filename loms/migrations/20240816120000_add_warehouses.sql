@@ -0,0 +1,39 @@
+-- +goose Up
+-- +goose StatementBegin
+CREATE TABLE IF NOT EXISTS warehouses (
+    id          BIGINT PRIMARY KEY,
+    name        TEXT NOT NULL,
+    latitude    DOUBLE PRECISION NOT NULL,
+    longitude   DOUBLE PRECISION NOT NULL,
+    created_at  TIMESTAMP NOT NULL DEFAULT NOW()
+);
+-- TEST DATA
+INSERT INTO warehouses (id, name, latitude, longitude)
+VALUES
+    (1, 'moscow', 55.7558, 37.6173),
+    (2, 'saint-petersburg', 59.9343, 30.3351),
+    (3, 'kazan', 55.7963, 49.1088);
+
+-- весь текущий остаток считаем лежащим на первом складе
+ALTER TABLE stock ADD COLUMN warehouse_id BIGINT NOT NULL DEFAULT 1 REFERENCES warehouses(id);
+ALTER TABLE stock ALTER COLUMN warehouse_id DROP DEFAULT;
+ALTER TABLE stock DROP CONSTRAINT stock_pkey;
+ALTER TABLE stock ADD PRIMARY KEY (warehouse_id, sku);
+CREATE INDEX stock_sku_idx ON stock (sku);
+
+ALTER TABLE stock_movements ADD COLUMN warehouse_id BIGINT NOT NULL DEFAULT 1;
+ALTER TABLE stock_movements ALTER COLUMN warehouse_id DROP DEFAULT;
+
+ALTER TABLE order_items ADD COLUMN warehouse_id BIGINT NOT NULL DEFAULT 1;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+ALTER TABLE order_items DROP COLUMN warehouse_id;
+ALTER TABLE stock_movements DROP COLUMN warehouse_id;
+DROP INDEX stock_sku_idx;
+ALTER TABLE stock DROP CONSTRAINT stock_pkey;
+ALTER TABLE stock DROP COLUMN warehouse_id;
+ALTER TABLE stock ADD PRIMARY KEY (sku);
+DROP TABLE warehouses;
+-- +goose StatementEnd
@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- поиск неоплаченных заказов с истекшим сроком оплаты
+CREATE INDEX IF NOT EXISTS orders_status_updated_at_idx ON orders (status, updated_at);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_status_updated_at_idx;
+-- +goose StatementEnd
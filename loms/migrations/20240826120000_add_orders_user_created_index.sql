@@ -0,0 +1,10 @@
+-- +goose Up
+-- +goose StatementBegin
+-- постраничный список заказов пользователя от новых к старым
+CREATE INDEX IF NOT EXISTS orders_user_id_created_at_idx ON orders (user_id, created_at DESC, id DESC);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_user_id_created_at_idx;
+-- +goose StatementEnd
@@ -0,0 +1,12 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключ идемпотентности OrderCreate, уникален в пределах пользователя
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS request_id TEXT;
+CREATE UNIQUE INDEX IF NOT EXISTS orders_user_id_request_id_idx ON orders (user_id, request_id) WHERE request_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_user_id_request_id_idx;
+ALTER TABLE orders DROP COLUMN IF EXISTS request_id;
+-- +goose StatementEnd
@@ -0,0 +1,26 @@
+-- +goose Up
+-- +goose StatementBegin
+-- возвраты оплаченных заказов, позиции возврата разложены по складам заказа
+CREATE TABLE IF NOT EXISTS order_returns (
+    id          BIGSERIAL PRIMARY KEY,
+    order_id    BIGINT NOT NULL REFERENCES orders(id) ON DELETE CASCADE,
+    created_at  TIMESTAMP NOT NULL DEFAULT now()
+);
+
+CREATE INDEX IF NOT EXISTS order_returns_order_id_idx ON order_returns (order_id);
+
+CREATE TABLE IF NOT EXISTS order_return_items (
+    return_id    BIGINT NOT NULL REFERENCES order_returns(id) ON DELETE CASCADE,
+    sku          BIGINT NOT NULL,
+    count        INT NOT NULL CHECK (count > 0),
+    warehouse_id BIGINT NOT NULL
+);
+
+CREATE INDEX IF NOT EXISTS order_return_items_return_id_idx ON order_return_items (return_id);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP TABLE IF EXISTS order_return_items;
+DROP TABLE IF EXISTS order_returns;
+-- +goose StatementEnd
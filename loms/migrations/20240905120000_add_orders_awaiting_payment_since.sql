@@ -0,0 +1,15 @@
+-- +goose Up
+-- +goose StatementBegin
+-- момент первого перехода в ожидание оплаты, от него отсчитывается срок оплаты
+ALTER TABLE orders ADD COLUMN IF NOT EXISTS awaiting_payment_since TIMESTAMP;
+UPDATE orders SET awaiting_payment_since = updated_at WHERE status = 'awaiting payment';
+DROP INDEX IF EXISTS orders_status_updated_at_idx;
+CREATE INDEX IF NOT EXISTS orders_status_awaiting_payment_since_idx ON orders (status, awaiting_payment_since);
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS orders_status_awaiting_payment_since_idx;
+CREATE INDEX IF NOT EXISTS orders_status_updated_at_idx ON orders (status, updated_at);
+ALTER TABLE orders DROP COLUMN IF EXISTS awaiting_payment_since;
+-- +goose StatementEnd
@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- возврат записывается в заказе раньше, чем пополняются остатки на складе;
+-- restocked_at ставится после пополнения, незавершенные возвраты дополняются фоном
+ALTER TABLE order_returns ADD COLUMN IF NOT EXISTS restocked_at TIMESTAMP;
+UPDATE order_returns SET restocked_at = created_at;
+CREATE INDEX IF NOT EXISTS order_returns_not_restocked_idx ON order_returns (id) WHERE restocked_at IS NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS order_returns_not_restocked_idx;
+ALTER TABLE order_returns DROP COLUMN IF EXISTS restocked_at;
+-- +goose StatementEnd
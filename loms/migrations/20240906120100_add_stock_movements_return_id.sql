@@ -0,0 +1,14 @@
+-- +goose Up
+-- +goose StatementBegin
+-- ключ идемпотентности пополнения по возврату: повтор того же возврата не меняет остатки второй раз.
+-- Один возврат дает движение на каждую пару склад-sku, поэтому они входят в ключ.
+ALTER TABLE stock_movements ADD COLUMN IF NOT EXISTS return_id BIGINT;
+CREATE UNIQUE INDEX IF NOT EXISTS stock_movements_order_id_return_id_idx
+    ON stock_movements (order_id, return_id, warehouse_id, sku) WHERE return_id IS NOT NULL;
+-- +goose StatementEnd
+
+-- +goose Down
+-- +goose StatementBegin
+DROP INDEX IF EXISTS stock_movements_order_id_return_id_idx;
+ALTER TABLE stock_movements DROP COLUMN IF EXISTS return_id;
+-- +goose StatementEnd
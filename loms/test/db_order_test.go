@@ -3,6 +3,7 @@ package test
 import (
 	"context"
 	"database/sql"
+	"errors"
 	"route256/loms/internal/pkg/config"
 	"route256/loms/internal/pkg/inrfa/shard_manager"
 	"route256/loms/internal/pkg/model"
@@ -19,7 +20,15 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
-const orderMigrationVersion = 20240620195339
+const (
+	orderMigrationVersion     = 20240620195339
+	orderStatusHistoryVersion = 20240822120000
+	ordersUserCreatedVersion  = 20240826120000
+	ordersRequestIDVersion    = 20240828120000
+	orderReturnsVersion       = 20240902120000
+	awaitingPaymentVersion    = 20240905120000
+	returnsRestockedVersion   = 20240906120000
+)
 
 type OrderSute struct {
 	suite.Suite
@@ -69,7 +78,7 @@ func (s *OrderSute) SetupSuite() {
 
 	s.migrationsDownTo = version
 
-	err = goose.UpTo(db, "../migrations", orderMigrationVersion)
+	err = goose.UpTo(db, "../migrations", returnsRestockedVersion)
 	require.NoError(s.T(), err)
 
 	// Waiting for data to reach replication
@@ -111,6 +120,8 @@ func (s *OrderSute) TestBGetOrder() {
 	ctx := context.Background()
 	order, err := s.orderRepository.GetById(ctx, 1)
 	require.NoError(s.T(), err)
+	require.NotZero(s.T(), order.CreatedAt)
+	order.CreatedAt = time.Time{}
 	require.Equal(s.T(), model.Order{
 		Status: model.OrderStatusNew,
 		User:   1,
@@ -125,8 +136,13 @@ func (s *OrderSute) TestBGetOrder() {
 
 func (s *OrderSute) TestCSetOrderStatus() {
 	ctx := context.Background()
-	err := s.orderRepository.SetStatus(ctx, 1, model.OrderStatusPaid)
+	items, err := s.orderRepository.SetStatus(ctx, 1, model.OrderStatusNew, model.OrderStatusPaid)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.OrderItem{{Sku: 1, Count: 1}}, items)
+
+	// статус уже не new - второй переход не проходит
+	_, err = s.orderRepository.SetStatus(ctx, 1, model.OrderStatusNew, model.OrderStatusCancelled)
+	require.ErrorIs(s.T(), err, model.ErrOrderStatusConflict)
 }
 
 func (s *OrderSute) TestDCheckOrderStatus() {
@@ -141,3 +157,247 @@ func (s *OrderSute) TestEGetOrderError() {
 	_, err := s.orderRepository.GetById(ctx, 2)
 	require.Error(s.T(), err)
 }
+
+func (s *OrderSute) TestFGetStatusHistory() {
+	ctx := context.Background()
+	history, err := s.orderRepository.GetStatusHistory(ctx, 1)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), history, 2)
+	require.Equal(s.T(), model.OrderStatusNone, history[0].From)
+	require.Equal(s.T(), model.OrderStatusNew, history[0].To)
+	require.Equal(s.T(), model.OrderStatusNew, history[1].From)
+	require.Equal(s.T(), model.OrderStatusPaid, history[1].To)
+
+	_, err = s.orderRepository.GetStatusHistory(ctx, 2)
+	require.ErrorIs(s.T(), err, model.ErrOrderNotFound)
+}
+
+func (s *OrderSute) TestGListByUser() {
+	ctx := context.Background()
+	for _, status := range []model.OrderStatus{model.OrderStatusNew, model.OrderStatusPaid, model.OrderStatusNew} {
+		_, err := s.orderRepository.Create(ctx, model.Order{
+			Status: status,
+			User:   7,
+			Items:  []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}},
+		})
+		require.NoError(s.T(), err)
+	}
+
+	orders, err := s.orderRepository.ListByUser(ctx, model.OrderFilter{User: 7}, nil, 2)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), orders, 2)
+	require.Len(s.T(), orders[0].Items, 1)
+	require.Greater(s.T(), orders[0].ID, orders[1].ID)
+
+	last := orders[1]
+	orders, err = s.orderRepository.ListByUser(ctx, model.OrderFilter{User: 7}, &model.OrderCursor{CreatedAt: last.CreatedAt, ID: last.ID}, 2)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), orders, 1)
+	require.Less(s.T(), orders[0].ID, last.ID)
+
+	orders, err = s.orderRepository.ListByUser(ctx, model.OrderFilter{User: 7, Statuses: []model.OrderStatus{model.OrderStatusPaid}}, nil, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), orders, 1)
+	require.Equal(s.T(), model.OrderStatusPaid, orders[0].Status)
+}
+
+func (s *OrderSute) TestHCreateByRequestID() {
+	ctx := context.Background()
+	order := model.Order{
+		Status:    model.OrderStatusNew,
+		User:      8,
+		Items:     []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}},
+		RequestID: "checkout-1",
+	}
+	orderID, err := s.orderRepository.Create(ctx, order)
+	require.NoError(s.T(), err)
+
+	_, err = s.orderRepository.Create(ctx, order)
+	require.ErrorIs(s.T(), err, model.ErrDuplicateOrderRequest)
+
+	created, err := s.orderRepository.GetByRequestID(ctx, 8, "checkout-1")
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), orderID, created.ID)
+	require.Equal(s.T(), "checkout-1", created.RequestID)
+
+	// ключ уникален только в пределах пользователя
+	order.User = 9
+	_, err = s.orderRepository.Create(ctx, order)
+	require.NoError(s.T(), err)
+
+	_, err = s.orderRepository.GetByRequestID(ctx, 8, "checkout-2")
+	require.ErrorIs(s.T(), err, model.ErrOrderNotFound)
+}
+
+func (s *OrderSute) TestIAddReturn() {
+	ctx := context.Background()
+	orderID, err := s.orderRepository.Create(ctx, model.Order{
+		Status: model.OrderStatusPaid,
+		User:   10,
+		Items:  []model.OrderItem{{Sku: 1, Count: 3, Warehouse: 1}},
+	})
+	require.NoError(s.T(), err)
+
+	orderReturn, err := s.orderRepository.AddReturn(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 2}})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), model.OrderStatusPartiallyReturned, orderReturn.Status)
+	require.Equal(s.T(), []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}}, orderReturn.Items)
+
+	_, err = s.orderRepository.AddReturn(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 2}})
+	require.ErrorIs(s.T(), err, model.ErrInvalidReturn)
+
+	// ошибка inTx откатывает возврат
+	_, err = s.orderRepository.AddReturn(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 1}}, func(context.Context, model.OrderReturn) error {
+		return errors.New("restock error")
+	})
+	require.Error(s.T(), err)
+
+	orderReturn, err = s.orderRepository.AddReturn(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 1}})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), model.OrderStatusReturned, orderReturn.Status)
+
+	order, err := s.orderRepository.GetById(ctx, orderID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), model.OrderStatusReturned, order.Status)
+}
+
+func (s *OrderSute) TestJUpdateItems() {
+	ctx := context.Background()
+	orderID, err := s.orderRepository.Create(ctx, model.Order{
+		Status: model.OrderStatusAwaitingPayment,
+		User:   11,
+		Items:  []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}, {Sku: 2, Count: 1, Warehouse: 1}},
+	})
+	require.NoError(s.T(), err)
+
+	reserve := func(_ context.Context, diff model.OrderItemsDiff) ([]model.OrderItem, error) {
+		require.Equal(s.T(), model.OrderItemsDiff{
+			Reserve: []model.OrderItem{{Sku: 1, Count: 1}},
+			Release: []model.OrderItem{{Sku: 2, Count: 1, Warehouse: 1}},
+		}, diff)
+		return []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 2}}, nil
+	}
+	order, err := s.orderRepository.UpdateItems(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 3}}, reserve)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}, {Sku: 1, Count: 1, Warehouse: 2}}, order.Items)
+
+	stored, err := s.orderRepository.GetById(ctx, orderID)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), order.Items, stored.Items)
+	require.Equal(s.T(), model.OrderStatusAwaitingPayment, stored.Status)
+
+	// ошибка резерва откатывает изменение позиций
+	_, err = s.orderRepository.UpdateItems(ctx, orderID, []model.OrderItem{{Sku: 3, Count: 1}}, func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error) {
+		return nil, model.ErrNotEnoughStock
+	})
+	require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+
+	_, err = s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusAwaitingPayment, model.OrderStatusPaid)
+	require.NoError(s.T(), err)
+	_, err = s.orderRepository.UpdateItems(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 1}}, reserve)
+	require.ErrorIs(s.T(), err, model.ErrOrderNotEditable)
+}
+
+func (s *OrderSute) TestKHandleUnpaidKeepsDeadline() {
+	ctx := context.Background()
+	orderID, err := s.orderRepository.Create(ctx, model.Order{
+		Status: model.OrderStatusNew,
+		User:   12,
+		Items:  []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}},
+	})
+	require.NoError(s.T(), err)
+	_, err = s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusNew, model.OrderStatusAwaitingPayment)
+	require.NoError(s.T(), err)
+	time.Sleep(1100 * time.Millisecond)
+
+	// откат статуса не перезапускает срок оплаты
+	_, err = s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusAwaitingPayment, model.OrderStatusPaid)
+	require.NoError(s.T(), err)
+	_, err = s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusPaid, model.OrderStatusAwaitingPayment)
+	require.NoError(s.T(), err)
+
+	var unpaid []model.OrderID
+	err = s.orderRepository.HandleUnpaid(ctx, time.Second, 100, func(_ context.Context, ids []model.OrderID) error {
+		unpaid = append(unpaid, ids...)
+		return nil
+	})
+	require.NoError(s.T(), err)
+	require.Contains(s.T(), unpaid, orderID)
+}
+
+func (s *OrderSute) TestLHandleNotRestocked() {
+	ctx := context.Background()
+	orderID, err := s.orderRepository.Create(ctx, model.Order{
+		Status: model.OrderStatusPaid,
+		User:   13,
+		Items:  []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 1}},
+	})
+	require.NoError(s.T(), err)
+	orderReturn, err := s.orderRepository.AddReturn(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 1}})
+	require.NoError(s.T(), err)
+
+	notRestocked := func() []model.OrderReturn {
+		var returns []model.OrderReturn
+		err := s.orderRepository.HandleNotRestocked(ctx, 0, 100, func(_ context.Context, all []model.OrderReturn) error {
+			// возвраты из других тестов тоже не пополнены
+			for _, r := range all {
+				if r.OrderID == orderID {
+					returns = append(returns, r)
+				}
+			}
+			return nil
+		})
+		require.NoError(s.T(), err)
+		return returns
+	}
+	returns := notRestocked()
+	require.Len(s.T(), returns, 1)
+	require.Equal(s.T(), orderReturn.ID, returns[0].ID)
+	require.Equal(s.T(), orderID, returns[0].OrderID)
+	require.Equal(s.T(), []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}}, returns[0].Items)
+
+	require.NoError(s.T(), s.orderRepository.SetReturnRestocked(ctx, orderID, orderReturn.ID))
+	require.Empty(s.T(), notRestocked())
+}
+
+func (s *OrderSute) TestMPayConcurrentUpdateItems() {
+	ctx := context.Background()
+	orderID, err := s.orderRepository.Create(ctx, model.Order{
+		Status: model.OrderStatusAwaitingPayment,
+		User:   14,
+		Items:  []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}},
+	})
+	require.NoError(s.T(), err)
+
+	// UpdateItems держит блокировку заказа, пока reserve не завершится
+	locked, unlock := make(chan struct{}), make(chan struct{})
+	updated := make(chan error, 1)
+	go func() {
+		_, err := s.orderRepository.UpdateItems(ctx, orderID, []model.OrderItem{{Sku: 1, Count: 3}}, func(context.Context, model.OrderItemsDiff) ([]model.OrderItem, error) {
+			close(locked)
+			<-unlock
+			return []model.OrderItem{{Sku: 1, Count: 2, Warehouse: 2}}, nil
+		})
+		updated <- err
+	}()
+	<-locked
+
+	type result struct {
+		items []model.OrderItem
+		err   error
+	}
+	paid := make(chan result, 1)
+	go func() {
+		items, err := s.orderRepository.SetStatus(ctx, orderID, model.OrderStatusAwaitingPayment, model.OrderStatusPaid)
+		paid <- result{items, err}
+	}()
+	// оплата ждет блокировку заказа и не возвращает позиции до изменения
+	time.Sleep(200 * time.Millisecond)
+	require.Empty(s.T(), paid)
+	close(unlock)
+
+	require.NoError(s.T(), <-updated)
+	res := <-paid
+	require.NoError(s.T(), res.err)
+	require.Equal(s.T(), []model.OrderItem{{Sku: 1, Count: 1, Warehouse: 1}, {Sku: 1, Count: 2, Warehouse: 2}}, res.items)
+}
@@ -3,9 +3,13 @@ package test
 import (
 	"context"
 	"database/sql"
+	"route256/loms/internal/pkg/allocation"
 	"route256/loms/internal/pkg/config"
 	"route256/loms/internal/pkg/middleware"
+	"route256/loms/internal/pkg/model"
 	"route256/loms/test/testconfig"
+	"sync"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -18,7 +22,13 @@ import (
 	"github.com/stretchr/testify/suite"
 )
 
-const stockMigrationVersion = 20240620195318
+const (
+	stockMigrationVersion      = 20240620195318
+	stockHoldsMigrationVersion = 20240805120000
+	stockMovementsVersion      = 20240812120000
+	warehousesVersion          = 20240816120000
+	movementsReturnIDVersion   = 20240906120100
+)
 
 type StockSute struct {
 	suite.Suite
@@ -63,7 +73,7 @@ func (s *StockSute) SetupSuite() {
 
 	s.migrationsDownTo = version
 
-	err = goose.UpTo(db, "../migrations", stockMigrationVersion)
+	err = goose.UpTo(db, "../migrations", movementsReturnIDVersion)
 	require.NoError(s.T(), err)
 
 	// Waiting for data to reach replication
@@ -94,8 +104,9 @@ func (s *StockSute) TestAGetStocksBySku() {
 
 func (s *StockSute) TestBReverse() {
 	ctx := context.Background()
-	err := s.stockRepository.Reserve(ctx, 1, 1)
+	allocated, err := s.stockRepository.Reserve(ctx, 0, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.OrderItem{{Warehouse: model.DefaultWarehouseID, Sku: 1, Count: 1}}, allocated)
 }
 
 func (s *StockSute) TestCGetStocksBySkuAfterReverse() {
@@ -107,7 +118,7 @@ func (s *StockSute) TestCGetStocksBySkuAfterReverse() {
 
 func (s *StockSute) TestDReverseCancel() {
 	ctx := context.Background()
-	err := s.stockRepository.ReserveCancel(ctx, 1, 1)
+	err := s.stockRepository.ReserveCancel(ctx, []model.OrderItem{{Warehouse: model.DefaultWarehouseID, Sku: 1, Count: 1}})
 	require.NoError(s.T(), err)
 }
 
@@ -120,8 +131,9 @@ func (s *StockSute) TestEGetStocksBySkuAfterReverseCancel() {
 
 func (s *StockSute) TestFReverse() {
 	ctx := context.Background()
-	err := s.stockRepository.Reserve(ctx, 1, 1)
+	allocated, err := s.stockRepository.Reserve(ctx, 0, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil)
 	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.OrderItem{{Warehouse: model.DefaultWarehouseID, Sku: 1, Count: 1}}, allocated)
 }
 
 func (s *StockSute) TestGGetStocksBySkuAfterReverse() {
@@ -133,7 +145,7 @@ func (s *StockSute) TestGGetStocksBySkuAfterReverse() {
 
 func (s *StockSute) TestHReverseRemove() {
 	ctx := context.Background()
-	err := s.stockRepository.ReserveRemove(ctx, 1, 1)
+	err := s.stockRepository.ReserveRemove(ctx, []model.OrderItem{{Warehouse: model.DefaultWarehouseID, Sku: 1, Count: 1}})
 	require.NoError(s.T(), err)
 }
 
@@ -149,3 +161,335 @@ func (s *StockSute) TestJGetStocksBySkuError() {
 	_, err := s.stockRepository.GetStocksBySku(ctx, 888888)
 	require.Error(s.T(), err)
 }
+
+func (s *StockSute) TestKGetStocksBySkus() {
+	ctx := context.Background()
+	stocks, err := s.stockRepository.GetStocksBySkus(ctx, []model.ProductSku{1, 2, 888888})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[model.ProductSku]uint64{1: 3, 2: 3}, stocks)
+}
+
+func (s *StockSute) TestLHoldCreate() {
+	ctx := context.Background()
+	hold, err := s.stockRepository.HoldCreate(ctx, 1, 1, 2, time.Minute)
+	require.NoError(s.T(), err)
+	require.NotZero(s.T(), hold.ID)
+	require.True(s.T(), hold.ExpiresAt.After(time.Now()))
+
+	time.Sleep(time.Second)
+	count, err := s.stockRepository.GetStocksBySku(ctx, 1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), uint64(1), count)
+}
+
+func (s *StockSute) TestMHoldCreateNotEnoughStock() {
+	ctx := context.Background()
+	_, err := s.stockRepository.HoldCreate(ctx, 2, 1, 2, time.Minute)
+	require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+}
+
+func (s *StockSute) TestNHoldCreateUpdatesOwnHold() {
+	ctx := context.Background()
+	first, err := s.stockRepository.HoldCreate(ctx, 1, 1, 2, time.Minute)
+	require.NoError(s.T(), err)
+	second, err := s.stockRepository.HoldCreate(ctx, 1, 1, 3, time.Minute)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), first.ID, second.ID)
+
+	_, err = s.stockRepository.HoldExtend(ctx, 1, second.ID, time.Hour)
+	require.NoError(s.T(), err)
+}
+
+func (s *StockSute) TestNReserveReleasesOwnHolds() {
+	ctx := context.Background()
+	// весь свободный остаток sku 1 в холде пользователя 1
+	_, err := s.stockRepository.Reserve(ctx, 2, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil)
+	require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+
+	allocated, err := s.stockRepository.Reserve(ctx, 1, []model.OrderItem{{Sku: 1, Count: 1}}, allocation.StrategySingleFirst, nil)
+	require.NoError(s.T(), err)
+
+	// холд снят в транзакции резерва
+	time.Sleep(time.Second)
+	count, err := s.stockRepository.GetStocksBySku(ctx, 1)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), uint64(2), count)
+
+	err = s.stockRepository.ReserveCancel(ctx, allocated)
+	require.NoError(s.T(), err)
+}
+
+func (s *StockSute) TestOHoldCreateConcurrent() {
+	ctx := context.Background()
+	const workers = 10
+
+	var wg sync.WaitGroup
+	// require нельзя вызывать вне горутины теста, результаты проверяются после wg.Wait
+	holds := make(chan model.StockHold, workers)
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		user := model.UserID(100 + i)
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			hold, err := s.stockRepository.HoldCreate(ctx, user, 1, 1, time.Minute)
+			if err != nil {
+				errs <- err
+				return
+			}
+			holds <- hold
+		}()
+	}
+	wg.Wait()
+	close(holds)
+	close(errs)
+	for err := range errs {
+		require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+	}
+
+	// у sku 1 свободно только 3 штуки
+	require.Len(s.T(), holds, 3)
+	for hold := range holds {
+		err := s.stockRepository.HoldRelease(ctx, hold.User, hold.ID)
+		require.NoError(s.T(), err)
+	}
+}
+
+func (s *StockSute) TestPHoldRelease() {
+	ctx := context.Background()
+	hold, err := s.stockRepository.HoldCreate(ctx, 2, 2, 1, time.Minute)
+	require.NoError(s.T(), err)
+	// чужой холд не продлевается и не снимается
+	_, err = s.stockRepository.HoldExtend(ctx, 1, hold.ID, time.Minute)
+	require.ErrorIs(s.T(), err, model.ErrHoldNotFound)
+	err = s.stockRepository.HoldRelease(ctx, 1, hold.ID)
+	require.NoError(s.T(), err)
+	_, err = s.stockRepository.HoldExtend(ctx, 2, hold.ID, time.Minute)
+	require.NoError(s.T(), err)
+
+	err = s.stockRepository.HoldRelease(ctx, 2, hold.ID)
+	require.NoError(s.T(), err)
+
+	_, err = s.stockRepository.HoldExtend(ctx, 2, hold.ID, time.Minute)
+	require.ErrorIs(s.T(), err, model.ErrHoldNotFound)
+}
+
+func (s *StockSute) TestQReserveAllOrNothing() {
+	ctx := context.Background()
+	_, err := s.stockRepository.Reserve(ctx, 0, []model.OrderItem{{Sku: 1, Count: 1}, {Sku: 2, Count: 100}}, allocation.StrategySingleFirst, nil)
+	require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+
+	time.Sleep(time.Second)
+	stocks, err := s.stockRepository.GetStocksBySkus(ctx, []model.ProductSku{1, 2})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), map[model.ProductSku]uint64{1: 3, 2: 3}, stocks)
+}
+
+func (s *StockSute) TestRReserveConcurrent() {
+	ctx := context.Background()
+	const workers = 20
+
+	var (
+		wg       sync.WaitGroup
+		reserved atomic.Int32
+	)
+	// require нельзя вызывать вне горутины теста, ошибки проверяются после wg.Wait
+	errs := make(chan error, workers)
+	for i := 0; i < workers; i++ {
+		// половина заказов перечисляет sku в обратном порядке - дедлока быть не должно
+		items := []model.OrderItem{{Sku: 3, Count: 1}, {Sku: 2, Count: 1}}
+		if i%2 == 1 {
+			items[0], items[1] = items[1], items[0]
+		}
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			_, err := s.stockRepository.Reserve(ctx, 0, items, allocation.StrategySplit, nil)
+			if err == nil {
+				reserved.Add(1)
+				return
+			}
+			errs <- err
+		}()
+	}
+	wg.Wait()
+	close(errs)
+	for err := range errs {
+		require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+	}
+
+	// у sku 3 свободно только 2 штуки
+	require.Equal(s.T(), int32(2), reserved.Load())
+	for sku, want := range map[int64]int{2: 4, 3: 3} {
+		var totalCount, reserved int
+		err := s.db.QueryRowContext(ctx, "SELECT total_count, reserved FROM stock WHERE sku = $1", sku).Scan(&totalCount, &reserved)
+		require.NoError(s.T(), err)
+		require.Equal(s.T(), want, reserved, "sku %d", sku)
+		require.LessOrEqual(s.T(), reserved, totalCount, "sku %d", sku)
+	}
+}
+
+func (s *StockSute) TestSChangeStock() {
+	ctx := context.Background()
+	movement, err := s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Sku:       1076963,
+		Warehouse: model.DefaultWarehouseID,
+		Operation: model.StockOperationAdd,
+		Count:     10,
+		Reason:    "supply",
+		Actor:     "test",
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(10), movement.Delta)
+	require.Equal(s.T(), uint64(14), movement.TotalCount)
+
+	movement, err = s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Sku:       1076963,
+		Warehouse: model.DefaultWarehouseID,
+		Operation: model.StockOperationSet,
+		Count:     5,
+		Reason:    "inventory",
+		Actor:     "test",
+		OrderID:   1000,
+	})
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), int64(-9), movement.Delta)
+
+	_, err = s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Sku:       1076963,
+		Warehouse: model.DefaultWarehouseID,
+		Operation: model.StockOperationAdjust,
+		Count:     -5,
+		Reason:    "damaged",
+		Actor:     "test",
+	})
+	require.ErrorIs(s.T(), err, model.ErrStockBelowReserved)
+
+	_, err = s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Sku:       888888,
+		Warehouse: model.DefaultWarehouseID,
+		Operation: model.StockOperationAdjust,
+		Count:     1,
+		Reason:    "damaged",
+		Actor:     "test",
+	})
+	require.ErrorIs(s.T(), err, model.ErrStockNotFound)
+
+	time.Sleep(time.Second)
+	movements, err := s.stockRepository.GetMovements(ctx, 1076963, 0, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), movements, 2)
+	require.Equal(s.T(), model.StockOperationSet, movements[0].Operation)
+	require.Equal(s.T(), model.OrderID(1000), movements[0].OrderID)
+
+	movements, err = s.stockRepository.GetMovements(ctx, 1076963, movements[0].ID, 10)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), movements, 1)
+	require.Equal(s.T(), model.StockOperationAdd, movements[0].Operation)
+}
+
+func (s *StockSute) TestTReserveSplitByWarehouse() {
+	ctx := context.Background()
+	_, err := s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Warehouse: 2,
+		Sku:       1076963,
+		Operation: model.StockOperationAdd,
+		Count:     3,
+		Reason:    "supply",
+		Actor:     "test",
+	})
+	require.NoError(s.T(), err)
+
+	_, err = s.stockRepository.ChangeStock(ctx, model.StockChange{
+		Warehouse: 99,
+		Sku:       1076963,
+		Operation: model.StockOperationAdd,
+		Count:     1,
+		Reason:    "supply",
+		Actor:     "test",
+	})
+	require.ErrorIs(s.T(), err, model.ErrWarehouseNotFound)
+
+	// на основном складе свободно 5 - 1 = 4 штуки, остальное берется со второго
+	allocated, err := s.stockRepository.Reserve(ctx, 0, []model.OrderItem{{Sku: 1076963, Count: 6}}, allocation.StrategySplit, nil)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.OrderItem{
+		{Warehouse: 1, Sku: 1076963, Count: 4},
+		{Warehouse: 2, Sku: 1076963, Count: 2},
+	}, allocated)
+
+	time.Sleep(time.Second)
+	stocks, err := s.stockRepository.GetStocksByWarehouse(ctx, 1076963)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.WarehouseStock{
+		{Warehouse: 1, Count: 0},
+		{Warehouse: 2, Count: 1},
+	}, stocks)
+}
+
+func (s *StockSute) TestUChangeStocksAllOrNothing() {
+	ctx := context.Background()
+	_, err := s.stockRepository.ChangeStocks(ctx, []model.StockChange{
+		{Warehouse: 2, Sku: 1076963, Operation: model.StockOperationReturn, Count: 2, Reason: "order return", Actor: "test", OrderID: 1},
+		{Warehouse: 99, Sku: 1076963, Operation: model.StockOperationReturn, Count: 1, Reason: "order return", Actor: "test", OrderID: 1},
+	})
+	require.ErrorIs(s.T(), err, model.ErrWarehouseNotFound)
+
+	movements, err := s.stockRepository.ChangeStocks(ctx, []model.StockChange{
+		{Warehouse: 1, Sku: 1076963, Operation: model.StockOperationReturn, Count: 1, Reason: "order return", Actor: "test", OrderID: 1},
+		{Warehouse: 2, Sku: 1076963, Operation: model.StockOperationReturn, Count: 2, Reason: "order return", Actor: "test", OrderID: 1},
+	})
+	require.NoError(s.T(), err)
+	require.Len(s.T(), movements, 2)
+
+	// первый пакет не применился: на втором складе 1 + 2 штуки
+	time.Sleep(time.Second)
+	stocks, err := s.stockRepository.GetStocksByWarehouse(ctx, 1076963)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.WarehouseStock{
+		{Warehouse: 1, Count: 1},
+		{Warehouse: 2, Count: 3},
+	}, stocks)
+}
+
+func (s *StockSute) TestVChangeStocksReturnOnce() {
+	ctx := context.Background()
+	changes := []model.StockChange{
+		{Warehouse: 1, Sku: 1076963, Operation: model.StockOperationReturn, Count: 1, Reason: "order return", Actor: "test", OrderID: 1, ReturnID: 1},
+		{Warehouse: 2, Sku: 1076963, Operation: model.StockOperationReturn, Count: 1, Reason: "order return", Actor: "test", OrderID: 1, ReturnID: 1},
+	}
+	movements, err := s.stockRepository.ChangeStocks(ctx, changes)
+	require.NoError(s.T(), err)
+	require.Len(s.T(), movements, 2)
+
+	// повтор того же возврата остатки не меняет
+	movements, err = s.stockRepository.ChangeStocks(ctx, changes)
+	require.NoError(s.T(), err)
+	require.Empty(s.T(), movements)
+
+	time.Sleep(time.Second)
+	stocks, err := s.stockRepository.GetStocksByWarehouse(ctx, 1076963)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.WarehouseStock{
+		{Warehouse: 1, Count: 2},
+		{Warehouse: 2, Count: 4},
+	}, stocks)
+}
+
+func (s *StockSute) TestWReserveAt() {
+	ctx := context.Background()
+	err := s.stockRepository.ReserveAt(ctx, []model.OrderItem{{Sku: 1076963, Count: 1, Warehouse: 1}})
+	require.NoError(s.T(), err)
+
+	// на складе меньше, чем просят: склад не подменяется другим
+	err = s.stockRepository.ReserveAt(ctx, []model.OrderItem{{Sku: 1076963, Count: 100, Warehouse: 1}})
+	require.ErrorIs(s.T(), err, model.ErrNotEnoughStock)
+
+	time.Sleep(time.Second)
+	stocks, err := s.stockRepository.GetStocksByWarehouse(ctx, 1076963)
+	require.NoError(s.T(), err)
+	require.Equal(s.T(), []model.WarehouseStock{
+		{Warehouse: 1, Count: 1},
+		{Warehouse: 2, Count: 4},
+	}, stocks)
+}
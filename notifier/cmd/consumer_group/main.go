@@ -2,6 +2,8 @@ package main
 
 import (
 	"context"
+	"flag"
+	"fmt"
 	"os"
 	"os/signal"
 	"route256/notifier/internal/app"
@@ -14,7 +16,26 @@ import (
 func main() {
 	ctx, close := context.WithCancel(context.Background())
 
-	config := config.NewConfig()
+	configFile := flag.String("config", os.Getenv("CONFIG_FILE"), "path to yaml config")
+	printConfig := flag.Bool("print-config", false, "print config and exit")
+	flag.Parse()
+
+	config, err := config.Load(*configFile)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+	if *printConfig {
+		if err := config.Print(os.Stdout); err != nil {
+			fmt.Fprintln(os.Stderr, err)
+			os.Exit(1)
+		}
+		return
+	}
+
+	if err := logger.SetLevel(config.LogLevel); err != nil {
+		logger.Panicw(ctx, "logger.SetLevel", "err", err)
+	}
 
 	app, err := app.NewApp(config)
 	if err != nil {
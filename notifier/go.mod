@@ -6,6 +6,7 @@ require (
 	github.com/IBM/sarama v1.43.2
 	go.opentelemetry.io/otel/trace v1.28.0
 	go.uber.org/zap v1.27.0
+	gopkg.in/yaml.v3 v3.0.1
 )
 
 require (
@@ -2,16 +2,25 @@ package app
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"route256/notifier/internal/pkg/config"
+	"route256/notifier/internal/pkg/health"
 	consumergroup "route256/notifier/internal/pkg/infra/kafka/consumer_group"
+	"route256/notifier/pkg/logger"
 	"sync"
+	"time"
 
 	"github.com/IBM/sarama"
 )
 
+const healthCheckTimeout = 2 * time.Second
+
 type App struct {
+	config        config.Config
 	consumergroup *consumergroup.ConsumerGroup
+	httpServer    *http.Server
 }
 
 func NewApp(config config.Config) (*App, error) {
@@ -27,8 +36,21 @@ func NewApp(config config.Config) (*App, error) {
 	if err != nil {
 		return nil, fmt.Errorf("consumergroup.NewConsumerGroup: %w", err)
 	}
+
+	checker := health.NewChecker(healthCheckTimeout)
+	checker.Add("kafka", health.KafkaCheck(config.Brokers))
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /healthz", checker.Liveness)
+	mux.HandleFunc("GET /readyz", checker.Readiness)
+
 	return &App{
+		config:        config,
 		consumergroup: cg,
+		httpServer: &http.Server{
+			Addr:    config.HttpUrl,
+			Handler: mux,
+		},
 	}, nil
 }
 
@@ -43,8 +65,20 @@ func (app *App) Run(ctx context.Context, wg *sync.WaitGroup) {
 		defer wg.Done()
 		app.consumergroup.RunErrorHandler(ctx)
 	}()
+	if app.config.File != "" {
+		go watchConfig(ctx, app.config)
+	}
+	go func() {
+		logger.Infow(ctx, "starting http server", "url", app.httpServer.Addr)
+		if err := app.httpServer.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+			logger.Errorw(ctx, "app.httpServer.ListenAndServe", "err", err)
+		}
+	}()
 }
 
 func (app *App) Stop() {
+	if err := app.httpServer.Shutdown(context.Background()); err != nil {
+		logger.Errorw(context.Background(), "app.httpServer.Shutdown", "err", err)
+	}
 	app.consumergroup.Close()
 }
@@ -0,0 +1,21 @@
+package app
+
+import (
+	"context"
+	"route256/notifier/internal/pkg/config"
+	"route256/notifier/pkg/logger"
+	"time"
+)
+
+const configReloadPeriod = 5 * time.Second
+
+// watchConfig применяет на лету уровень логирования из файла конфига.
+func watchConfig(ctx context.Context, conf config.Config) {
+	watcher := config.NewWatcher(conf.File, conf)
+	watcher.OnReload(func(conf config.Config) {
+		if err := logger.SetLevel(conf.LogLevel); err != nil {
+			logger.Errorw(ctx, "logger.SetLevel", "err", err)
+		}
+	})
+	watcher.Watch(ctx, configReloadPeriod)
+}
@@ -1,33 +1,103 @@
 package config
 
 import (
+	"errors"
+	"fmt"
+	"io"
 	"os"
-	"strings"
+
+	"go.uber.org/zap/zapcore"
+	"gopkg.in/yaml.v3"
 )
 
+// Config собирается слоями: значения по умолчанию, yaml-файл, переменные окружения.
 type Config struct {
-	Brokers           []string
-	ConsumerGroupName string
-	OrderEventsTopic  string
+	Brokers           []string `yaml:"kafka_brokers"`
+	ConsumerGroupName string   `yaml:"kafka_consumer_group_name"`
+	OrderEventsTopic  string   `yaml:"kafka_order_events_topic"`
+	HttpUrl           string   `yaml:"http_url"`
+	LogLevel          string   `yaml:"log_level" reload:"true"`
+	// File - путь к файлу, из которого прочитан конфиг
+	File string `yaml:"-"`
+}
+
+func Default() Config {
+	return Config{
+		Brokers:           []string{"localhost:9092"},
+		ConsumerGroupName: "notifier",
+		OrderEventsTopic:  "loms.order-events",
+		HttpUrl:           "localhost:8099",
+		LogLevel:          "info",
+	}
 }
 
-func NewConfig() Config {
-	brokers := []string{"localhost:9092"}
-	brokersRaw := os.Getenv("KAFKA_BROKERS")
-	if brokersRaw != "" {
-		brokers = strings.Split(brokersRaw, ",")
+// NewConfig читает конфиг из файла CONFIG_FILE (если задан) и переменных окружения.
+func NewConfig() (Config, error) {
+	return Load(os.Getenv("CONFIG_FILE"))
+}
+
+// Load читает конфиг из файла path и переменных окружения и проверяет его.
+// Переменные окружения имеют приоритет над файлом.
+func Load(path string) (Config, error) {
+	config := Default()
+	config.File = path
+	if path != "" {
+		if err := config.readFile(path); err != nil {
+			return Config{}, err
+		}
 	}
-	consumerGroupName := os.Getenv("KAFKA_CONSUMER_GROUP_NAME")
-	if consumerGroupName == "" {
-		consumerGroupName = "notifier"
+	config.readEnv()
+	if err := config.Validate(); err != nil {
+		return Config{}, err
 	}
-	orderEventsTopic := os.Getenv("KAFKA_ORDER_EVENTS_TOPIC")
-	if orderEventsTopic == "" {
-		orderEventsTopic = "loms.order-events"
+	return config, nil
+}
+
+func (c *Config) readFile(path string) error {
+	f, err := os.Open(path)
+	if err != nil {
+		return fmt.Errorf("config: %w", err)
 	}
-	return Config{
-		Brokers:           brokers,
-		ConsumerGroupName: consumerGroupName,
-		OrderEventsTopic:  orderEventsTopic,
+	defer f.Close()
+
+	decoder := yaml.NewDecoder(f)
+	decoder.KnownFields(true)
+	if err := decoder.Decode(c); err != nil && !errors.Is(err, io.EOF) {
+		return fmt.Errorf("config: %s: %w", path, err)
+	}
+	return nil
+}
+
+func (c *Config) readEnv() {
+	env := envReader{}
+	env.StringSlice("KAFKA_BROKERS", &c.Brokers)
+	env.String("KAFKA_CONSUMER_GROUP_NAME", &c.ConsumerGroupName)
+	env.String("KAFKA_ORDER_EVENTS_TOPIC", &c.OrderEventsTopic)
+	env.String("HTTP_URL", &c.HttpUrl)
+	env.String("LOG_LEVEL", &c.LogLevel)
+}
+
+// Validate возвращает все найденные ошибки конфига разом.
+func (c Config) Validate() error {
+	v := validator{}
+	v.Check(len(c.Brokers) > 0, "kafka_brokers must not be empty")
+	for _, broker := range c.Brokers {
+		v.Check(broker != "", "kafka_brokers must not contain empty values")
+	}
+	v.Check(c.ConsumerGroupName != "", "kafka_consumer_group_name must not be empty")
+	v.Check(c.OrderEventsTopic != "", "kafka_order_events_topic must not be empty")
+	v.Check(c.HttpUrl != "", "http_url must not be empty")
+	_, err := zapcore.ParseLevel(c.LogLevel)
+	v.Check(err == nil, "log_level must be one of debug, info, warn, error, got %q", c.LogLevel)
+	return v.Err()
+}
+
+// Print выводит итоговый конфиг в yaml. Секретов в конфиге нотификатора нет.
+func (c Config) Print(w io.Writer) error {
+	encoder := yaml.NewEncoder(w)
+	defer encoder.Close()
+	if err := encoder.Encode(c); err != nil {
+		return fmt.Errorf("encoder.Encode: %w", err)
 	}
+	return nil
 }
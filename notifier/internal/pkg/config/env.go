@@ -0,0 +1,46 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"strings"
+)
+
+// envReader переопределяет значения конфига переменными окружения.
+type envReader struct{}
+
+func (e envReader) lookup(name string) (string, bool) {
+	value, ok := os.LookupEnv(name)
+	if !ok || value == "" {
+		return "", false
+	}
+	return value, true
+}
+
+func (e envReader) String(name string, dst *string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = value
+	}
+}
+
+// StringSlice разбирает значения, перечисленные через запятую.
+func (e envReader) StringSlice(name string, dst *[]string) {
+	if value, ok := e.lookup(name); ok {
+		*dst = strings.Split(value, ",")
+	}
+}
+
+type validator struct {
+	errs []error
+}
+
+func (v *validator) Check(ok bool, format string, args ...any) {
+	if !ok {
+		v.errs = append(v.errs, fmt.Errorf("config: "+format, args...))
+	}
+}
+
+func (v *validator) Err() error {
+	return errors.Join(v.errs...)
+}
@@ -0,0 +1,125 @@
+package config
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"reflect"
+	"route256/notifier/pkg/logger"
+	"sync"
+	"time"
+)
+
+// Watcher перечитывает файл конфига и применяет на лету только безопасные
+// настройки (поля с тегом reload). Остальные изменения требуют перезапуска.
+type Watcher struct {
+	path     string
+	mx       sync.Mutex
+	current  Config
+	modTime  time.Time
+	size     int64
+	onReload []func(Config)
+}
+
+func NewWatcher(path string, config Config) *Watcher {
+	w := &Watcher{
+		path:    path,
+		current: config,
+	}
+	if info, err := os.Stat(path); err == nil {
+		w.modTime = info.ModTime()
+		w.size = info.Size()
+	}
+	return w
+}
+
+// OnReload регистрирует обработчик, который вызывается после применения новых настроек.
+func (w *Watcher) OnReload(fn func(Config)) {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	w.onReload = append(w.onReload, fn)
+}
+
+func (w *Watcher) Current() Config {
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	return w.current
+}
+
+// Reload применяет изменения файла. Возвращает имена полей, которые изменились,
+// но не могут быть применены без перезапуска.
+func (w *Watcher) Reload() (reloaded bool, ignored []string, err error) {
+	info, err := os.Stat(w.path)
+	if err != nil {
+		return false, nil, fmt.Errorf("os.Stat: %w", err)
+	}
+
+	w.mx.Lock()
+	defer w.mx.Unlock()
+	if info.ModTime().Equal(w.modTime) && info.Size() == w.size {
+		return false, nil, nil
+	}
+
+	config, err := Load(w.path)
+	if err != nil {
+		return false, nil, fmt.Errorf("Load: %w", err)
+	}
+	w.modTime = info.ModTime()
+	w.size = info.Size()
+
+	next, ignored := merge(w.current, config)
+	if reflect.DeepEqual(next, w.current) {
+		return false, ignored, nil
+	}
+	w.current = next
+	for _, fn := range w.onReload {
+		fn(next)
+	}
+	return true, ignored, nil
+}
+
+// Watch проверяет файл каждые interval, пока не отменен ctx.
+func (w *Watcher) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, ignored, err := w.Reload()
+			if err != nil {
+				logger.Errorw(ctx, "config reload failed", "path", w.path, "err", err)
+				continue
+			}
+			if len(ignored) > 0 {
+				logger.Infow(ctx, "config changes require restart", "path", w.path, "fields", ignored)
+			}
+			if reloaded {
+				logger.Infow(ctx, "config reloaded", "path", w.path)
+			}
+		}
+	}
+}
+
+// merge переносит из next в current поля с тегом reload и возвращает
+// yaml-имена остальных изменившихся полей. Поля сравниваются через DeepEqual:
+// в конфиге есть срезы.
+func merge(current, next Config) (Config, []string) {
+	var ignored []string
+	cv := reflect.ValueOf(&current).Elem()
+	nv := reflect.ValueOf(next)
+	t := cv.Type()
+	for i := 0; i < t.NumField(); i++ {
+		if reflect.DeepEqual(cv.Field(i).Interface(), nv.Field(i).Interface()) {
+			continue
+		}
+		if t.Field(i).Tag.Get("reload") != "true" {
+			ignored = append(ignored, t.Field(i).Tag.Get("yaml"))
+			continue
+		}
+		cv.Field(i).Set(nv.Field(i))
+	}
+	return current, ignored
+}
@@ -0,0 +1,31 @@
+package health
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/IBM/sarama"
+)
+
+// KafkaCheck проверяет, что хотя бы один брокер отвечает на запрос метаданных.
+func KafkaCheck(brokers []string) CheckFn {
+	return func(ctx context.Context) error {
+		config := sarama.NewConfig()
+		config.Net.DialTimeout = time.Second
+		config.Metadata.Retry.Max = 0
+		if deadline, ok := ctx.Deadline(); ok && time.Until(deadline) > 0 {
+			config.Net.DialTimeout = time.Until(deadline)
+		}
+		client, err := sarama.NewClient(brokers, config)
+		if err != nil {
+			return fmt.Errorf("sarama.NewClient: %w", err)
+		}
+		defer client.Close()
+		if len(client.Brokers()) == 0 {
+			return errors.New("no available kafka brokers")
+		}
+		return nil
+	}
+}
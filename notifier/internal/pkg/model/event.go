@@ -6,4 +6,14 @@ type Event struct {
 	OrderID int64
 	Status  string
 	Time    time.Time
+	Reason  string
+	// ReturnID и Items заполнены для событий о возврате позиций заказа
+	ReturnID int64
+	Items    []EventItem
+}
+
+type EventItem struct {
+	Sku       uint32
+	Count     uint16
+	Warehouse int64
 }